@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	osexec "os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
 )
 
 // Executor handles Bazel command execution.
@@ -69,7 +71,7 @@ func (e *Executor) Run(ctx context.Context, target string) error {
 
 // Query executes a Bazel query.
 func (e *Executor) Query(ctx context.Context, query string) ([]string, error) {
-	cmd := exec.CommandContext(ctx, e.bazelPath, "query", query)
+	cmd := osexec.CommandContext(ctx, e.bazelPath, "query", query)
 	cmd.Dir = e.workspaceRoot
 
 	output, err := cmd.Output()
@@ -83,19 +85,7 @@ func (e *Executor) Query(ctx context.Context, query string) ([]string, error) {
 
 // execute runs a Bazel command with proper output handling.
 func (e *Executor) execute(ctx context.Context, args []string) error {
-	cmd := exec.CommandContext(ctx, e.bazelPath, args...)
-	cmd.Dir = e.workspaceRoot
-
-	if e.verbose {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		// Capture output for progress parsing
-	} else {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-	}
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{Name: e.bazelPath, Args: args, Dir: e.workspaceRoot}); err != nil {
 		return fmt.Errorf("bazel command failed: %w", err)
 	}
 
@@ -105,12 +95,12 @@ func (e *Executor) execute(ctx context.Context, args []string) error {
 // findBazel locates bazelisk or bazel binary.
 func findBazel() (string, error) {
 	// Try bazelisk first (recommended)
-	if path, err := exec.LookPath("bazelisk"); err == nil {
+	if path, err := osexec.LookPath("bazelisk"); err == nil {
 		return path, nil
 	}
 
 	// Fall back to bazel
-	if path, err := exec.LookPath("bazel"); err == nil {
+	if path, err := osexec.LookPath("bazel"); err == nil {
 		return path, nil
 	}
 
@@ -126,7 +116,7 @@ func findBazel() (string, error) {
 
 // Version returns the Bazel version.
 func (e *Executor) Version(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, e.bazelPath, "version")
+	cmd := osexec.CommandContext(ctx, e.bazelPath, "version")
 
 	output, err := cmd.Output()
 	if err != nil {
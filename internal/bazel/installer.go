@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -31,9 +32,15 @@ func NewInstaller(verbose bool) *Installer {
 	}
 }
 
-// Install downloads and installs Bazelisk.
+// BinDir returns the directory forge installs Bazelisk into.
+func (i *Installer) BinDir() string {
+	return filepath.Join(i.forgeHome, "bazel", "bin")
+}
+
+// Install downloads and installs Bazelisk, along with a bazel link to it so
+// commands that run bazel by name use it.
 func (i *Installer) Install(ctx context.Context) error {
-	bazelDir := filepath.Join(i.forgeHome, "bazel", "bin")
+	bazelDir := i.BinDir()
 	if err := os.MkdirAll(bazelDir, 0755); err != nil {
 		return fmt.Errorf("failed to create bazel directory: %w", err)
 	}
@@ -53,6 +60,12 @@ func (i *Installer) Install(ctx context.Context) error {
 		return fmt.Errorf("failed to make bazelisk executable: %w", err)
 	}
 
+	linkPath := filepath.Join(bazelDir, "bazel")
+	os.Remove(linkPath)
+	if err := os.Symlink("bazelisk", linkPath); err != nil {
+		return fmt.Errorf("failed to link bazel to bazelisk: %w", err)
+	}
+
 	fmt.Println("✅ Bazelisk installed successfully!")
 	fmt.Printf("   Location: %s\n", targetPath)
 	fmt.Println("   Bazel will be automatically downloaded on first use.")
@@ -90,13 +103,23 @@ func (i *Installer) getBazeliskURL() (string, string) {
 	return url, filename
 }
 
-// downloadWithProgress downloads a file with a progress bar.
+// downloadWithProgress downloads a file with a progress bar. The file is
+// written next to targetPath and renamed once complete.
 func (i *Installer) downloadWithProgress(ctx context.Context, url, targetPath string) error {
-	// This is a placeholder - in real implementation, use HTTP client
-	// For now, using curl/wget as a quick solution
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
 
-	// Create progress bar
-	_ = progressbar.NewOptions(-1,
+	bar := progressbar.NewOptions64(resp.ContentLength,
 		progressbar.OptionSetDescription("Downloading"),
 		progressbar.OptionSetWriter(os.Stderr),
 		progressbar.OptionShowBytes(true),
@@ -110,9 +133,21 @@ func (i *Installer) downloadWithProgress(ctx context.Context, url, targetPath st
 		progressbar.OptionFullWidth(),
 	)
 
-	// TODO: Implement actual HTTP download with progress
-	// For now, this is a simplified version
-	return fmt.Errorf("download not yet implemented - please install bazelisk manually")
+	tmpPath := targetPath + ".download"
+	out, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(out, bar), resp.Body); err != nil {
+		out.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, targetPath)
 }
 
 // GetVersion returns the installed Bazel version.
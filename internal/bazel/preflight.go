@@ -0,0 +1,36 @@
+package bazel
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// InstallHint is the guidance shown when bazel is missing. Bazelisk is
+// preferred as it runs the Bazel version pinned in the workspace .bazelversion.
+const InstallHint = `Forge builds and generates BUILD files with Bazel. Install Bazelisk, which
+downloads the Bazel version pinned in .bazelversion:
+
+  macOS:   brew install bazelisk
+  Linux:   npm install -g @bazel/bazelisk
+  Windows: choco install bazelisk
+
+Or download it from https://github.com/bazelbuild/bazelisk/releases and put it
+on PATH as 'bazel'. See https://github.com/dosanma1/forge-cli#prerequisites`
+
+// Available reports whether bazel can be run by name. When it is not on PATH
+// but Bazelisk was installed by forge, the forge bin directory is added to
+// PATH for this process so every bazel invocation finds it.
+func Available() bool {
+	if _, err := exec.LookPath("bazel"); err == nil {
+		return true
+	}
+
+	binDir := NewInstaller(false).BinDir()
+	if _, err := os.Stat(filepath.Join(binDir, "bazel")); err != nil {
+		return false
+	}
+	os.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	_, err := exec.LookPath("bazel")
+	return err == nil
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
 // AngularBuilder implements the Builder interface for Angular projects
@@ -32,15 +34,9 @@ func (b *AngularBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildA
 	outputPath := getStringOption(opts.Options, "outputPath", "dist")
 	optimization := getBoolOption(opts.Options, "optimization", false)
 	sourceMap := getBoolOption(opts.Options, "sourceMap", true)
-	envMapper := getMapOption(opts.Options, "environmentMapper", nil)
 
 	// Map forge configuration to Angular configuration
-	angularConfig := opts.Configuration
-	if envMapper != nil {
-		if mapped, ok := envMapper[opts.Configuration].(string); ok {
-			angularConfig = mapped
-		}
-	}
+	angularConfig := workspace.MapEnvironment(opts.Options, opts.Configuration)
 
 	// Merge configuration-specific options
 	if opts.ConfigurationOptions != nil {
@@ -64,7 +60,7 @@ func (b *AngularBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildA
 	}
 
 	// Construct the absolute path to the build output
-	angularJSONDir := b.findAngularJSONDir(opts.ProjectRoot)
+	angularJSONDir := findAngularJSONDir(opts.WorkspaceRoot, opts.ProjectRoot)
 	absoluteOutputPath := filepath.Join(angularJSONDir, outputPath)
 
 	// Angular builds produce static files (HTML, CSS, JS)
@@ -96,15 +92,8 @@ func (b *AngularBuilder) Validate(opts *BuildOptions) error {
 		return fmt.Errorf("project root does not exist: %s", opts.ProjectRoot)
 	}
 
-	// Check for angular.json
-	angularJSON := filepath.Join(opts.ProjectRoot, "angular.json")
-	if _, err := os.Stat(angularJSON); os.IsNotExist(err) {
-		// Try parent directories for workspace setup
-		parent := filepath.Dir(opts.ProjectRoot)
-		angularJSON = filepath.Join(parent, "angular.json")
-		if _, err := os.Stat(angularJSON); os.IsNotExist(err) {
-			return fmt.Errorf("angular.json not found in project root or parent")
-		}
+	if findAngularJSONDir(opts.WorkspaceRoot, opts.ProjectRoot) == "" {
+		return fmt.Errorf("angular.json not found in %s or the directories above it", opts.ProjectRoot)
 	}
 
 	return nil
@@ -137,15 +126,14 @@ func (b *AngularBuilder) buildWithNg(ctx context.Context, opts *BuildOptions, an
 		args = append(args, "--source-map=false")
 	}
 
-	cmd := exec.CommandContext(ctx, "ng", args...)
-
 	// Run from the directory containing angular.json
-	angularJSONDir := b.findAngularJSONDir(opts.ProjectRoot)
-	cmd.Dir = angularJSONDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "ng",
+		Args:   args,
+		Dir:    findAngularJSONDir(opts.WorkspaceRoot, opts.ProjectRoot),
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return fmt.Errorf("ng build failed: %w", err)
 	}
 
@@ -156,29 +144,21 @@ func (b *AngularBuilder) buildWithNg(ctx context.Context, opts *BuildOptions, an
 	return nil
 }
 
-// findAngularJSONDir finds the directory containing angular.json
-func (b *AngularBuilder) findAngularJSONDir(projectRoot string) string {
-	angularJSON := filepath.Join(projectRoot, "angular.json")
-	if _, err := os.Stat(angularJSON); err == nil {
-		return projectRoot
-	}
-
-	// Try parent directory
-	parent := filepath.Dir(projectRoot)
-	angularJSON = filepath.Join(parent, "angular.json")
-	if _, err := os.Stat(angularJSON); err == nil {
-		return parent
-	}
-
-	// Default to project root
-	return projectRoot
-}
-
-func getMapOption(opts map[string]interface{}, key string, defaultValue map[string]interface{}) map[string]interface{} {
-	if v, ok := opts[key].(map[string]interface{}); ok {
-		return v
+// findAngularJSONDir returns the directory of the angular.json declaring a
+// project: the project root of a standalone app, or a parent directory up to
+// the workspace root for a project of a shared Angular workspace (e.g.
+// projects/<app>). It returns "" when there is none.
+func findAngularJSONDir(workspaceRoot, projectRoot string) string {
+	dir := projectRoot
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "angular.json")); err == nil {
+			return dir
+		}
+		if dir == workspaceRoot || dir == filepath.Dir(dir) {
+			return ""
+		}
+		dir = filepath.Dir(dir)
 	}
-	return defaultValue
 }
 
 func init() {
@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindAngularJSONDir(t *testing.T) {
+	workspaceRoot := t.TempDir()
+	standalone := filepath.Join(workspaceRoot, "frontend", "apps", "web")
+	shared := filepath.Join(workspaceRoot, "frontend", "portal")
+	sharedProject := filepath.Join(shared, "projects", "admin")
+	missing := filepath.Join(workspaceRoot, "frontend", "apps", "broken")
+	for _, dir := range []string{standalone, sharedProject, missing} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, dir := range []string{standalone, shared} {
+		if err := os.WriteFile(filepath.Join(dir, "angular.json"), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		projectRoot string
+		want        string
+	}{
+		{name: "standalone workspace", projectRoot: standalone, want: standalone},
+		{name: "project of a shared workspace", projectRoot: sharedProject, want: shared},
+		{name: "none up to the workspace root", projectRoot: missing, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findAngularJSONDir(workspaceRoot, tt.projectRoot); got != tt.want {
+				t.Errorf("findAngularJSONDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package builder
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
 	"path/filepath"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
 )
 
 // BazelBuilder implements direct Bazel builds
@@ -27,6 +29,17 @@ func (b *BazelBuilder) Validate(opts *BuildOptions) error {
 	return nil
 }
 
+// UsesBazel returns true as every project is built with bazel
+func (b *BazelBuilder) UsesBazel(projectRoot string) bool {
+	return true
+}
+
+// SupportsPlatform reports whether Bazel has a platform mapping for the target
+func (b *BazelBuilder) SupportsPlatform(platform string) bool {
+	_, ok := skaffold.BazelPlatformTarget(platform)
+	return ok
+}
+
 // Build executes a Bazel build
 func (b *BazelBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildArtifact, error) {
 	// Determine target from options or use default
@@ -49,7 +62,7 @@ func (b *BazelBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildArt
 	}
 
 	// Build Bazel command
-	args := []string{"build", bazelTarget}
+	args := append([]string{"build", bazelTarget}, opts.bazelJobsArgs()...)
 
 	// Add platform flags if specified
 	if opts.Platform != "" {
@@ -63,15 +76,13 @@ func (b *BazelBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildArt
 		args = append(args, "--compilation_mode=opt")
 	}
 
-	cmd := exec.CommandContext(ctx, "bazel", args...)
-	cmd.Dir = opts.WorkspaceRoot
-
+	cmdOpts := exec.Options{Name: "bazel", Args: args, Dir: opts.WorkspaceRoot, Stdout: io.Discard, Stderr: io.Discard}
 	if opts.Verbose {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		cmdOpts.Stdout = opts.stdout()
+		cmdOpts.Stderr = opts.stderr()
 	}
 
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, cmdOpts); err != nil {
 		return nil, fmt.Errorf("bazel build failed: %w", err)
 	}
 
@@ -119,19 +130,13 @@ func determineArtifactType(target string) ArtifactType {
 
 // getPlatformArgs returns Bazel platform arguments for the given platform
 func getPlatformArgs(platform string) []string {
-	switch platform {
-	case "linux/amd64":
-		return []string{"--platforms=@rules_go//go/toolchain:linux_amd64"}
-	case "linux/arm64":
-		return []string{"--platforms=@rules_go//go/toolchain:linux_arm64"}
-	case "darwin/amd64":
-		return []string{"--platforms=@rules_go//go/toolchain:darwin_amd64"}
-	case "darwin/arm64":
-		return []string{"--platforms=@rules_go//go/toolchain:darwin_arm64"}
-	case "":
+	if platform == "" {
 		// Empty platform means use native/host platform
 		return []string{"--platforms=@local_config_platform//:host"}
-	default:
-		return []string{"--platforms=@rules_go//go/toolchain:linux_amd64"}
 	}
+	if target, ok := skaffold.BazelPlatformTarget(platform); ok {
+		return []string{"--platforms=" + target}
+	}
+	target, _ := skaffold.BazelPlatformTarget("linux/amd64")
+	return []string{"--platforms=" + target}
 }
@@ -4,6 +4,8 @@ package builder
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 )
 
 // Builder is the interface that all language/framework-specific builders must implement.
@@ -19,6 +21,45 @@ type Builder interface {
 	Validate(opts *BuildOptions) error
 }
 
+// CrossPlatformBuilder is implemented by builders that can produce artifacts for
+// platforms other than the host. Builders that don't implement it are native-only,
+// and multi-platform builds are rejected for them before anything is built.
+type CrossPlatformBuilder interface {
+	// SupportsPlatform reports whether the builder can target the given platform (e.g., "linux/arm64")
+	SupportsPlatform(platform string) bool
+}
+
+// SupportsPlatforms reports whether a builder can build for every given platform.
+// A single empty platform (native build) is supported by all builders.
+func SupportsPlatforms(b Builder, platforms []string) bool {
+	if len(platforms) == 0 || (len(platforms) == 1 && platforms[0] == "") {
+		return true
+	}
+	cp, ok := b.(CrossPlatformBuilder)
+	if !ok {
+		return false
+	}
+	for _, platform := range platforms {
+		if !cp.SupportsPlatform(platform) {
+			return false
+		}
+	}
+	return true
+}
+
+// BazelDependent is implemented by builders that run bazel, for every project
+// or only some, so commands can check that bazel is installed before building.
+type BazelDependent interface {
+	// UsesBazel reports whether building the project at projectRoot runs bazel
+	UsesBazel(projectRoot string) bool
+}
+
+// UsesBazel reports whether b runs bazel to build the project at projectRoot.
+func UsesBazel(b Builder, projectRoot string) bool {
+	bd, ok := b.(BazelDependent)
+	return ok && bd.UsesBazel(projectRoot)
+}
+
 // BuildOptions contains the options for a build operation
 type BuildOptions struct {
 	// ProjectRoot is the absolute path to the project root
@@ -39,11 +80,55 @@ type BuildOptions struct {
 	// Verbose output
 	Verbose bool
 
-	// Platform is the target platform (e.g., "linux/amd64")
+	// Platform is the target platform (e.g., "linux/amd64").
+	// Multi-platform builds call Build once per platform.
 	Platform string
 
 	// WorkspaceRoot is the absolute path to the workspace root
 	WorkspaceRoot string
+
+	// Jobs is passed to Bazel as --jobs (0 leaves Bazel's default)
+	Jobs int
+
+	// ImageTag is the tag of the built container images (default: the configuration name)
+	ImageTag string
+
+	// Stdout and Stderr receive the output of build tools (default: os.Stdout, os.Stderr).
+	// Parallel builds set them to prefix each line with the project name.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// stdout returns the writer for build tool output.
+func (o *BuildOptions) stdout() io.Writer {
+	if o.Stdout != nil {
+		return o.Stdout
+	}
+	return os.Stdout
+}
+
+// stderr returns the writer for build tool errors.
+func (o *BuildOptions) stderr() io.Writer {
+	if o.Stderr != nil {
+		return o.Stderr
+	}
+	return os.Stderr
+}
+
+// bazelJobsArgs returns the --jobs flag for Bazel commands, if any.
+func (o *BuildOptions) bazelJobsArgs() []string {
+	if o.Jobs <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("--jobs=%d", o.Jobs)}
+}
+
+// imageTag returns the tag of the built container images.
+func (o *BuildOptions) imageTag() string {
+	if o.ImageTag != "" {
+		return o.ImageTag
+	}
+	return o.Configuration
 }
 
 // Registry holds all registered builders
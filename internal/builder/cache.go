@@ -0,0 +1,218 @@
+package builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+// cacheSkipDirs are directories whose content is never a build input.
+var cacheSkipDirs = map[string]bool{
+	"node_modules": true,
+	"dist":         true,
+	"vendor":       true,
+}
+
+// BuildCache keeps the artifact of the last build of each project under
+// .forge/cache/build, keyed by a hash of the project's source files and build
+// options, so a project whose inputs have not changed is not rebuilt.
+type BuildCache struct {
+	dir string
+}
+
+// cacheEntry is the last build of a project for one platform.
+type cacheEntry struct {
+	Key      string         `json:"key"`
+	Artifact *BuildArtifact `json:"artifact"`
+}
+
+// NewBuildCache creates a build cache for the workspace.
+func NewBuildCache(workspaceRoot string) *BuildCache {
+	return &BuildCache{dir: filepath.Join(workspaceRoot, ".forge", "cache", "build")}
+}
+
+// Build returns the artifact of the last build when the project's inputs have
+// not changed since, and builds it with b otherwise. inputs are directories the
+// project is built from besides its root, such as the roots of the projects it
+// depends on. It reports whether the artifact came from the cache.
+//
+// Builds that run bazel are never cached: bazel has its own cache and tracks
+// inputs outside the project root.
+func (c *BuildCache) Build(ctx context.Context, b Builder, builderName string, opts *BuildOptions, inputs []string) (*BuildArtifact, bool, error) {
+	if UsesBazel(b, opts.ProjectRoot) {
+		artifact, err := b.Build(ctx, opts)
+		return artifact, false, err
+	}
+
+	entryPath := c.entryPath(opts)
+	previous := c.load(entryPath)
+
+	var exclude string
+	if previous != nil && previous.Artifact != nil {
+		exclude = previous.Artifact.Path
+	}
+	key, err := buildInputHash(builderName, opts, inputs, exclude)
+	if err != nil {
+		return nil, false, err
+	}
+	if previous != nil && previous.Key == key && artifactExists(ctx, previous.Artifact) {
+		return previous.Artifact, true, nil
+	}
+
+	artifact, err := b.Build(ctx, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Hash again without the new output, which may be inside the project root
+	if artifact != nil && artifact.Path != exclude {
+		if key, err = buildInputHash(builderName, opts, inputs, artifact.Path); err != nil {
+			return artifact, false, nil
+		}
+	}
+	// Failing to save only costs a rebuild next time
+	_ = c.save(entryPath, &cacheEntry{Key: key, Artifact: artifact})
+
+	return artifact, false, nil
+}
+
+// entryPath returns the file holding the last build of the project at
+// opts.ProjectRoot for opts.Platform.
+func (c *BuildCache) entryPath(opts *BuildOptions) string {
+	project, err := filepath.Rel(opts.WorkspaceRoot, opts.ProjectRoot)
+	if err != nil {
+		project = opts.ProjectRoot
+	}
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(filepath.ToSlash(project))
+	if opts.Platform != "" {
+		name += "@" + strings.ReplaceAll(opts.Platform, "/", "_")
+	}
+	return filepath.Join(c.dir, name+".json")
+}
+
+func (c *BuildCache) load(path string) *cacheEntry {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+func (c *BuildCache) save(path string, entry *cacheEntry) error {
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// buildInputHash hashes everything a build depends on: the builder, the
+// configuration, platform and options, and the files under the project root
+// and inputs, except generated directories and the exclude path.
+func buildInputHash(builderName string, opts *BuildOptions, inputs []string, exclude string) (string, error) {
+	hash := sha256.New()
+
+	settings, err := json.Marshal(struct {
+		Builder              string
+		Configuration        string
+		Platform             string
+		Options              map[string]interface{}
+		ConfigurationOptions map[string]interface{}
+		Env                  map[string]string
+	}{builderName, opts.Configuration, opts.Platform, opts.Options, opts.ConfigurationOptions, opts.Env})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash build options: %w", err)
+	}
+	hash.Write(settings)
+
+	// go.work decides which local modules a Go build uses
+	if content, err := os.ReadFile(filepath.Join(opts.WorkspaceRoot, "go.work")); err == nil {
+		fmt.Fprintf(hash, "\x00go.work\x00%d\x00", len(content))
+		hash.Write(content)
+	}
+
+	for _, root := range append([]string{opts.ProjectRoot}, inputs...) {
+		if err := hashDir(hash, opts.WorkspaceRoot, root, exclude); err != nil {
+			return "", fmt.Errorf("failed to hash sources of %s: %w", root, err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashDir writes the path and content of every source file under root to w.
+func hashDir(w io.Writer, workspaceRoot, root, exclude string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && path == exclude {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (cacheSkipDirs[name] || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "bazel-")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(workspaceRoot, path)
+		if err != nil {
+			rel = path
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\x00%s\x00%d\x00", filepath.ToSlash(rel), info.Size())
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
+
+// artifactExists reports whether a cached artifact is still there: its path on
+// disk, or its image in the local Docker daemon.
+func artifactExists(ctx context.Context, artifact *BuildArtifact) bool {
+	if artifact == nil {
+		return false
+	}
+	if artifact.Path != "" {
+		if _, err := os.Stat(artifact.Path); err != nil {
+			return false
+		}
+	}
+	if artifact.Type == ArtifactTypeImage && artifact.ImageName != "" {
+		if _, err := exec.Capture(ctx, exec.Options{Name: "docker", Args: []string{"image", "inspect", artifact.ImageName}}); err != nil {
+			return false
+		}
+	}
+	return true
+}
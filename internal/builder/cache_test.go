@@ -0,0 +1,98 @@
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countingBuilder writes its output inside the project root, like the Angular
+// builder, and counts its builds.
+type countingBuilder struct {
+	builds int
+}
+
+func (b *countingBuilder) Name() string                      { return "@test/counting:build" }
+func (b *countingBuilder) Validate(opts *BuildOptions) error { return nil }
+
+func (b *countingBuilder) Build(ctx context.Context, opts *BuildOptions) (*BuildArtifact, error) {
+	b.builds++
+	out := filepath.Join(opts.ProjectRoot, "out")
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(out, "main.js"), []byte{byte(b.builds)}, 0644); err != nil {
+		return nil, err
+	}
+	return &BuildArtifact{Type: ArtifactTypeStatic, Path: out}, nil
+}
+
+func TestBuildCache(t *testing.T) {
+	root := t.TempDir()
+	projectRoot := filepath.Join(root, "web")
+	libRoot := filepath.Join(root, "lib")
+	for path, content := range map[string]string{
+		"web/src/main.ts":     "console.log(1)",
+		"web/node_modules/x":  "ignored",
+		"lib/index.ts":        "export {}",
+		"web/.angular/cache1": "ignored",
+	} {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache := NewBuildCache(root)
+	b := &countingBuilder{}
+	opts := &BuildOptions{
+		ProjectRoot:   projectRoot,
+		WorkspaceRoot: root,
+		Configuration: "production",
+		Options:       map[string]interface{}{"outputPath": "out"},
+	}
+	build := func(wantCached bool) {
+		t.Helper()
+		_, cached, err := cache.Build(context.Background(), b, "@test/counting:build", opts, []string{libRoot})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cached != wantCached {
+			t.Fatalf("cached = %v, want %v (builds: %d)", cached, wantCached, b.builds)
+		}
+	}
+
+	build(false)
+	build(true)
+
+	// Ignored directories are not inputs
+	if err := os.WriteFile(filepath.Join(projectRoot, "node_modules", "x"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	build(true)
+
+	if err := os.WriteFile(filepath.Join(projectRoot, "src", "main.ts"), []byte("console.log(2)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	build(false)
+	build(true)
+
+	if err := os.WriteFile(filepath.Join(libRoot, "index.ts"), []byte("export const x = 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	build(false)
+
+	opts.Configuration = "development"
+	build(false)
+	build(true)
+
+	// A removed artifact is rebuilt
+	if err := os.RemoveAll(filepath.Join(projectRoot, "out")); err != nil {
+		t.Fatal(err)
+	}
+	build(false)
+}
@@ -5,8 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
 )
 
 // GoBuilder implements the Builder interface for Go projects
@@ -72,6 +73,11 @@ func (b *GoBuilder) Validate(opts *BuildOptions) error {
 	return nil
 }
 
+// UsesBazel reports whether the project is built with Bazel rather than Docker
+func (b *GoBuilder) UsesBazel(projectRoot string) bool {
+	return b.useBazel(projectRoot)
+}
+
 // useBazel checks if the project uses Bazel
 func (b *GoBuilder) useBazel(projectRoot string) bool {
 	buildFile := filepath.Join(projectRoot, "BUILD.bazel")
@@ -81,12 +87,13 @@ func (b *GoBuilder) useBazel(projectRoot string) bool {
 
 // buildWithBazel builds using Bazel
 func (b *GoBuilder) buildWithBazel(ctx context.Context, opts *BuildOptions) (*BuildArtifact, error) {
-	cmd := exec.CommandContext(ctx, "bazel", "build", "//...")
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "bazel",
+		Args:   append([]string{"build", "//..."}, opts.bazelJobsArgs()...),
+		Dir:    opts.ProjectRoot,
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return nil, fmt.Errorf("bazel build failed: %w", err)
 	}
 
@@ -108,7 +115,7 @@ func (b *GoBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions, reg
 	// Get the project name from the directory
 	projectName := filepath.Base(opts.ProjectRoot)
 	imageName := fmt.Sprintf("%s/%s", registry, projectName)
-	imageTag := fmt.Sprintf("%s:%s", imageName, opts.Configuration)
+	imageTag := fmt.Sprintf("%s:%s", imageName, opts.imageTag())
 
 	// Build Docker image
 	args := []string{"build", "-t", imageTag}
@@ -117,12 +124,13 @@ func (b *GoBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions, reg
 	}
 	args = append(args, ".")
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "docker",
+		Args:   args,
+		Dir:    opts.ProjectRoot,
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return nil, fmt.Errorf("docker build failed: %w", err)
 	}
 
@@ -133,7 +141,7 @@ func (b *GoBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions, reg
 	artifact := &BuildArtifact{
 		Type:      ArtifactTypeImage,
 		Path:      "", // Docker image doesn't have a file path
-		Tag:       opts.Configuration,
+		Tag:       opts.imageTag(),
 		ImageName: imageTag,
 		Metadata: map[string]interface{}{
 			"builder":    "docker",
@@ -5,8 +5,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
 // NestJSBuilder implements the Builder interface for NestJS projects
@@ -71,8 +73,12 @@ func (b *NestJSBuilder) Validate(opts *BuildOptions) error {
 		return fmt.Errorf("project root does not exist: %s", opts.ProjectRoot)
 	}
 
-	// Check for package.json
-	packageJSON := filepath.Join(opts.ProjectRoot, "package.json")
+	// Check for package.json, at the monorepo root for apps of a Nest monorepo
+	packageDir := opts.ProjectRoot
+	if nestDir, _ := workspace.FindNestMonorepo(opts.WorkspaceRoot, opts.ProjectRoot); nestDir != "" {
+		packageDir = nestDir
+	}
+	packageJSON := filepath.Join(packageDir, "package.json")
 	if _, err := os.Stat(packageJSON); os.IsNotExist(err) {
 		return fmt.Errorf("package.json not found in project root")
 	}
@@ -80,6 +86,11 @@ func (b *NestJSBuilder) Validate(opts *BuildOptions) error {
 	return nil
 }
 
+// UsesBazel reports whether the project is built with Bazel rather than npm or Docker
+func (b *NestJSBuilder) UsesBazel(projectRoot string) bool {
+	return b.useBazel(projectRoot)
+}
+
 // useBazel checks if the project uses Bazel
 func (b *NestJSBuilder) useBazel(projectRoot string) bool {
 	buildFile := filepath.Join(projectRoot, "BUILD.bazel")
@@ -89,12 +100,13 @@ func (b *NestJSBuilder) useBazel(projectRoot string) bool {
 
 // buildWithBazel builds using Bazel
 func (b *NestJSBuilder) buildWithBazel(ctx context.Context, opts *BuildOptions) (*BuildArtifact, error) {
-	cmd := exec.CommandContext(ctx, "bazel", "build", "//...")
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "bazel",
+		Args:   append([]string{"build", "//..."}, opts.bazelJobsArgs()...),
+		Dir:    opts.ProjectRoot,
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return nil, fmt.Errorf("bazel build failed: %w", err)
 	}
 
@@ -114,7 +126,22 @@ func (b *NestJSBuilder) buildWithBazel(ctx context.Context, opts *BuildOptions)
 func (b *NestJSBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions, registry, dockerfile string) (*BuildArtifact, error) {
 	projectName := filepath.Base(opts.ProjectRoot)
 	imageName := fmt.Sprintf("%s/%s", registry, projectName)
-	imageTag := fmt.Sprintf("%s:%s", imageName, opts.Configuration)
+	imageTag := fmt.Sprintf("%s:%s", imageName, opts.imageTag())
+
+	// Apps of a Nest monorepo are built from its root, which holds the
+	// package.json they share
+	contextDir := opts.ProjectRoot
+	if nestDir, _ := workspace.FindNestMonorepo(opts.WorkspaceRoot, opts.ProjectRoot); nestDir != "" {
+		contextDir = nestDir
+		if dockerfile == "" {
+			dockerfile = "Dockerfile"
+		}
+		appPath, err := filepath.Rel(nestDir, opts.ProjectRoot)
+		if err != nil {
+			return nil, err
+		}
+		dockerfile = filepath.Join(appPath, dockerfile)
+	}
 
 	args := []string{"build", "-t", imageTag}
 	if dockerfile != "" {
@@ -122,12 +149,13 @@ func (b *NestJSBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions,
 	}
 	args = append(args, ".")
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "docker",
+		Args:   args,
+		Dir:    contextDir,
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return nil, fmt.Errorf("docker build failed: %w", err)
 	}
 
@@ -138,7 +166,7 @@ func (b *NestJSBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions,
 	artifact := &BuildArtifact{
 		Type:      ArtifactTypeImage,
 		Path:      "",
-		Tag:       opts.Configuration,
+		Tag:       opts.imageTag(),
 		ImageName: imageTag,
 		Metadata: map[string]interface{}{
 			"builder":    "docker",
@@ -153,13 +181,25 @@ func (b *NestJSBuilder) buildWithDocker(ctx context.Context, opts *BuildOptions,
 func (b *NestJSBuilder) buildWithNest(ctx context.Context, opts *BuildOptions, tsconfig string) (*BuildArtifact, error) {
 	// Run nest build
 	args := []string{"run", "build"}
+	dir := opts.ProjectRoot
+
+	// NestJS builds to dist/ by default
+	outputPath := filepath.Join(opts.ProjectRoot, "dist")
 
-	cmd := exec.CommandContext(ctx, "npm", args...)
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Apps of a Nest monorepo are built by name from its root, into dist/apps/<app>
+	if nestDir, app := workspace.FindNestMonorepo(opts.WorkspaceRoot, opts.ProjectRoot); nestDir != "" {
+		args = append(args, "--", app)
+		dir = nestDir
+		outputPath = filepath.Join(nestDir, "dist", "apps", app)
+	}
 
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "npm",
+		Args:   args,
+		Dir:    dir,
+		Stdout: opts.stdout(),
+		Stderr: opts.stderr(),
+	}); err != nil {
 		return nil, fmt.Errorf("nest build failed: %w", err)
 	}
 
@@ -167,9 +207,6 @@ func (b *NestJSBuilder) buildWithNest(ctx context.Context, opts *BuildOptions, t
 		fmt.Printf("Successfully built NestJS project\n")
 	}
 
-	// NestJS builds to dist/ by default
-	outputPath := filepath.Join(opts.ProjectRoot, "dist")
-
 	artifact := &BuildArtifact{
 		Type: ArtifactTypeStatic,
 		Path: outputPath,
@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// ResolveImageTag returns the image tag of a tag strategy, computed like
+// Skaffold's matching tagger so direct and Skaffold builds tag alike.
+// Timestamp tags use buildTime, which callers share across the projects of a
+// run.
+func ResolveImageTag(ctx context.Context, workspaceRoot string, strategy workspace.TagStrategy, buildTime time.Time) (string, error) {
+	switch strategy {
+	case workspace.TagStrategyGitSha:
+		return gitOutput(ctx, workspaceRoot, "rev-parse", "--short", "HEAD")
+	case workspace.TagStrategySemver:
+		return gitOutput(ctx, workspaceRoot, "describe", "--tags", "--always")
+	case workspace.TagStrategyTimestamp:
+		return buildTime.UTC().Format(workspace.TimestampTagFormat), nil
+	case workspace.TagStrategyLatest:
+		return "latest", nil
+	default:
+		return "", fmt.Errorf("unknown tag strategy %q", strategy)
+	}
+}
+
+// gitOutput runs git in dir and returns its trimmed output.
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := exec.Capture(ctx, exec.Options{Name: "git", Args: args, Dir: dir})
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
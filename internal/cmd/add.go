@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/generator"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var addHandlerMethod string
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add components to existing projects",
+	Long: `Add components such as HTTP handlers to projects that already exist in forge.json.
+
+Examples:
+  forge add handler user-service /users/{id}
+  forge add handler api-gateway /orders --method=POST`,
+}
+
+var addHandlerCmd = &cobra.Command{
+	Use:   "handler <service> <path>",
+	Short: "Add an HTTP handler to a service",
+	Long: `Add an HTTP handler to an existing service.
+
+For Go services this creates internal/handler_<name>.go with a handler stub and
+request/response structs, and registers the route in the REST controller's
+Endpoints() list.
+
+For NestJS services this adds a controller method to src/app.controller.ts.`,
+	Example: `  forge add handler user-service /users/{id}
+  forge add handler user-service /users --method=POST`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAddHandler,
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.AddCommand(addHandlerCmd)
+
+	addHandlerCmd.Flags().StringVarP(&addHandlerMethod, "method", "m", "GET", "HTTP method (GET, POST, PUT, PATCH, DELETE)")
+}
+
+func runAddHandler(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	gen := generator.NewHandlerGenerator()
+	opts := generator.GeneratorOptions{
+		OutputDir: workspaceRoot,
+		Name:      args[0],
+		Data: map[string]interface{}{
+			"path":   args[1],
+			"method": addHandlerMethod,
+		},
+	}
+
+	ctx := cmd.Context()
+	if err := gen.Generate(ctx, opts); err != nil {
+		return fmt.Errorf("failed to add handler: %w", err)
+	}
+
+	return nil
+}
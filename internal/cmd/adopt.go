@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/bazel"
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/sync"
+	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	adoptName     string
+	adoptDeployer string
+	adoptConfig   map[string]string
+)
+
+var adoptCmd = &cobra.Command{
+	Use:   "adopt <path>",
+	Short: "Register an existing project directory in forge.json",
+	Long: `Register a hand-written project that lives in the workspace but not in
+forge.json, so forge can build, serve and deploy it.
+
+The language is detected from the directory:
+  - go.mod: a Go service, or a Go library when it has no main package
+  - angular.json: an Angular application
+  - package.json: a NestJS service or a Vue or Angular application,
+    depending on its dependencies
+
+The project gets the same build and serve targets as a generated one. Go
+modules are added to go.work. Services and applications are asked for a
+deployer and get its deployment files, unless the deployment folder already
+exists. Finally the project's BUILD.bazel files are generated, without
+syncing the rest of the workspace.
+
+Examples:
+  forge adopt backend/services/legacy-api
+  forge adopt backend/services/legacy-api --name orders --deployer helm
+  forge adopt frontend/projects/admin --deployer firebase --config projectId=acme-admin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdopt,
+}
+
+func init() {
+	rootCmd.AddCommand(adoptCmd)
+	adoptCmd.Flags().StringVar(&adoptName, "name", "", "Project name (default: the directory name)")
+	adoptCmd.Flags().StringVarP(&adoptDeployer, "deployer", "d", "", "Deployment target (default: ask)")
+	adoptCmd.Flags().StringToStringVar(&adoptConfig, "config", nil, "Deployer-specific configuration (key=value pairs)")
+}
+
+// adoptedProject is what forge adopt detected about a directory.
+type adoptedProject struct {
+	Language    string
+	ProjectType string
+	// Main is the package of a Go service's server, relative to its root
+	Main string
+}
+
+func runAdopt(cmd *cobra.Command, args []string) error {
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", args[0])
+	}
+
+	workspaceRoot, err := workspace.FindRootFrom(dir)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.Rel(workspaceRoot, dir)
+	if err != nil || root == "." {
+		return fmt.Errorf("%s is the workspace root, not a project directory", args[0])
+	}
+	root = filepath.ToSlash(root)
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+	for name, project := range config.Projects {
+		if filepath.ToSlash(filepath.Clean(project.Root)) == root {
+			return fmt.Errorf("%s is already registered as project %q", root, name)
+		}
+	}
+
+	detected, err := detectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	name := adoptName
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	if name, err = checkName("project", name); err != nil {
+		return err
+	}
+	if config.GetProject(name) != nil {
+		return fmt.Errorf("project %q already exists in forge.json; choose another name with --name", name)
+	}
+
+	fmt.Printf("Detected %s %s in %s\n", detected.Language, detected.ProjectType, root)
+
+	// Libraries are not deployed
+	var deployerName string
+	if detected.ProjectType != "library" {
+		if adoptDeployer != "" {
+			deployerName, err = checkDeployer(adoptDeployer, detected.Language)
+		} else {
+			deployerName, err = askDeployer(askDeployerTarget, "Select deployment target:", detected.Language)
+		}
+		if err != nil {
+			return err
+		}
+	} else if adoptDeployer != "" {
+		return fmt.Errorf("--deployer is not supported for libraries")
+	}
+
+	project, err := newAdoptedProject(config, name, root, detected)
+	if err != nil {
+		return err
+	}
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		return config.AddProject(name, project)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register project in forge.json: %w", err)
+	}
+	fmt.Printf("✔ Registered %s in forge.json\n", name)
+
+	if detected.Language == "go" {
+		if workPath, err := findGoWorkspace(workspaceRoot); err == nil {
+			if err := addToGoWorkspace(workPath, dir); err != nil {
+				fmt.Printf("⚠️  Could not add to go.work: %v\n", err)
+			} else {
+				fmt.Println("✔ Added to go.work")
+			}
+		}
+	}
+
+	if deployerName != "" {
+		if err := adoptDeployerConfig(cmd.Context(), config, workspaceRoot, name, deployerName, detected.Language); err != nil {
+			return err
+		}
+	}
+
+	if err := syncAdoptedProject(cmd.Context(), workspaceRoot, name, detected.Language); err != nil {
+		fmt.Printf("⚠️  Could not generate Bazel files: %v\n", err)
+		fmt.Println("   Run 'forge sync' to generate them")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  $ forge build %s\n", name)
+	if deployerName != "" {
+		fmt.Printf("  $ forge deploy %s\n", name)
+	}
+	return nil
+}
+
+// adoptDeployerConfig points the adopted project at its deployer, generating
+// the deployment files unless the project already has them.
+func adoptDeployerConfig(ctx context.Context, config *workspace.Config, workspaceRoot, name, deployerName, language string) error {
+	prompter, err := ui.NewPrompter()
+	if err != nil {
+		return fmt.Errorf("failed to create prompter: %w", err)
+	}
+
+	deployerConfig := adoptConfig
+	if len(deployerConfig) == 0 {
+		deployerConfig, err = promptForDeployerConfig(prompter.WithFlag("--config"), deployerName, language)
+		if err != nil {
+			return fmt.Errorf("failed to get deployer configuration: %w", err)
+		}
+	}
+	if deployerConfig["configPath"] == "" {
+		deployerConfig["configPath"] = fmt.Sprintf("deploy/%s", deployerName)
+	}
+
+	switcher := deployer.NewSwitcher(&deployer.SwitcherOptions{
+		Config:            config,
+		ProjectName:       name,
+		Project:           config.GetProject(name),
+		TargetDeployer:    deployerName,
+		DeployerConfig:    deployer.DeployerConfig{Options: deployerConfig},
+		Force:             true,
+		WorkspaceRoot:     workspaceRoot,
+		KeepExistingFiles: true,
+	})
+	return switcher.Switch(ctx, prompter)
+}
+
+// syncAdoptedProject generates the BUILD.bazel files of the adopted project.
+// Go projects need bazel to run gazelle.
+func syncAdoptedProject(ctx context.Context, workspaceRoot, name, language string) error {
+	if language == "go" && !bazel.Available() {
+		return fmt.Errorf("bazel is not installed")
+	}
+
+	syncer, err := sync.NewSyncer(workspaceRoot, false)
+	if err != nil {
+		return err
+	}
+	report, err := syncer.SyncProject(ctx, name)
+	if err != nil {
+		return err
+	}
+	for _, file := range report.CreatedFiles {
+		fmt.Printf("✔ Generated %s\n", file)
+	}
+	return nil
+}
+
+// detectProject detects the language and type of the project in dir.
+func detectProject(dir string) (*adoptedProject, error) {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		main, err := findGoMain(dir)
+		if err != nil {
+			return nil, err
+		}
+		if main == "" {
+			return &adoptedProject{Language: "go", ProjectType: "library"}, nil
+		}
+		return &adoptedProject{Language: "go", ProjectType: "service", Main: main}, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "angular.json")); err == nil {
+		return &adoptedProject{Language: "angular", ProjectType: "application"}, nil
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal(content, &pkg); err != nil {
+			return nil, fmt.Errorf("failed to parse package.json: %w", err)
+		}
+		has := func(dep string) bool {
+			_, ok := pkg.Dependencies[dep]
+			if !ok {
+				_, ok = pkg.DevDependencies[dep]
+			}
+			return ok
+		}
+		switch {
+		case has("@nestjs/core"):
+			return &adoptedProject{Language: "nestjs", ProjectType: "service"}, nil
+		case has("@angular/core"):
+			return &adoptedProject{Language: "angular", ProjectType: "application"}, nil
+		case has("vue"):
+			return &adoptedProject{Language: "vue", ProjectType: "application"}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not detect the project in %s: expected a go.mod, an angular.json, or a package.json depending on NestJS, Angular or Vue", dir)
+}
+
+// findGoMain returns the main package of a Go module, preferring cmd/server,
+// then the module root, then the first cmd/<name>. It is empty for libraries.
+func findGoMain(dir string) (string, error) {
+	candidates := []string{"cmd/server", "."}
+	entries, err := os.ReadDir(filepath.Join(dir, "cmd"))
+	if err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && entry.Name() != "server" {
+				candidates = append(candidates, "cmd/"+entry.Name())
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if isGoMainPackage(filepath.Join(dir, candidate)) {
+			if candidate == "." {
+				return ".", nil
+			}
+			return "./" + candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// isGoMainPackage reports whether dir holds a Go package main.
+func isGoMainPackage(dir string) bool {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if goPackageName(file) == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+// goPackageName returns the package name declared in a Go file.
+func goPackageName(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if name, ok := strings.CutPrefix(line, "package "); ok {
+			return strings.TrimSpace(name)
+		}
+	}
+	return ""
+}
+
+// newAdoptedProject builds the forge.json entry of an adopted project with
+// the build and serve targets its generator would create. The deploy target
+// is added by the deployer switch.
+func newAdoptedProject(config *workspace.Config, name, root string, detected *adoptedProject) (*workspace.Project, error) {
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return nil, err
+	}
+	configurations := func() map[string]interface{} {
+		return map[string]interface{}{
+			"production":  map[string]interface{}{},
+			"development": map[string]interface{}{},
+			"local":       map[string]interface{}{},
+		}
+	}
+	registry := ""
+	if config.Workspace.Docker != nil {
+		registry = config.Workspace.Docker.Registry
+	}
+	usedPorts := config.UsedPorts()
+
+	project := &workspace.Project{
+		ProjectType: detected.ProjectType,
+		Language:    detected.Language,
+		Root:        root,
+		Architect: &workspace.Architect{
+			Build: &workspace.ArchitectTarget{
+				Builder:              "@forge/bazel:build",
+				Options:              map[string]interface{}{},
+				Configurations:       configurations(),
+				DefaultConfiguration: "production",
+			},
+		},
+	}
+	build := project.Architect.Build.Options
+
+	switch {
+	case detected.Language == "go" && detected.ProjectType == "library":
+		project.Tags = []string{"library", "shared"}
+		build["target"] = "/..."
+	case detected.Language == "go":
+		project.Tags = []string{"backend", "service"}
+		build["target"] = "/..."
+		build["goVersion"] = toolVersions.Go
+		build["dockerfile"] = "Dockerfile"
+		if registry != "" {
+			build["registry"] = registry
+		}
+		project.Architect.Serve = &workspace.ArchitectTarget{
+			Builder: "@forge/go:serve",
+			Options: map[string]interface{}{
+				"main": detected.Main,
+				"port": workspace.NextFreePort(8080, usedPorts),
+			},
+		}
+	case detected.Language == "nestjs":
+		project.Tags = []string{"backend", "nestjs", "service"}
+		build["target"] = ":image_tarball.tar"
+		build["nodeVersion"] = toolVersions.Node
+		build["dockerfile"] = "Dockerfile"
+		if registry != "" {
+			build["registry"] = registry
+		}
+		project.Architect.Serve = &workspace.ArchitectTarget{
+			Builder: "@forge/nestjs:serve",
+			Options: map[string]interface{}{
+				"port": workspace.NextFreePort(3000, usedPorts),
+			},
+		}
+	case detected.Language == "angular":
+		project.Tags = []string{"frontend", "angular"}
+		build["target"] = ":build"
+		build["outputPath"] = fmt.Sprintf("dist/%s", name)
+		project.Architect.Serve = &workspace.ArchitectTarget{
+			Builder: "@forge/angular:serve",
+			Options: map[string]interface{}{
+				"port": 4200,
+				"host": "localhost",
+			},
+		}
+	case detected.Language == "vue":
+		project.Tags = []string{"frontend", "vue"}
+		build["target"] = ":build"
+		build["outputPath"] = "dist"
+		project.Architect.Serve = &workspace.ArchitectTarget{
+			Builder: "@forge/vue:serve",
+			Options: map[string]interface{}{
+				"port": 5173,
+				"host": "localhost",
+			},
+		}
+	}
+
+	return project, nil
+}
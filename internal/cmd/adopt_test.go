@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectProject(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  adoptedProject
+	}{
+		{
+			name: "go service",
+			files: map[string]string{
+				"go.mod":              "module example.com/api\n",
+				"cmd/worker/main.go":  "package main\n",
+				"cmd/server/main.go":  "// Server entrypoint\npackage main\n",
+				"internal/handler.go": "package internal\n",
+			},
+			want: adoptedProject{Language: "go", ProjectType: "service", Main: "./cmd/server"},
+		},
+		{
+			name: "go library",
+			files: map[string]string{
+				"go.mod":  "module example.com/lib\n",
+				"lib.go":  "package lib\n",
+				"main.go": "package lib\n",
+			},
+			want: adoptedProject{Language: "go", ProjectType: "library"},
+		},
+		{
+			name:  "nestjs",
+			files: map[string]string{"package.json": `{"dependencies": {"@nestjs/core": "^10.0.0"}}`},
+			want:  adoptedProject{Language: "nestjs", ProjectType: "service"},
+		},
+		{
+			name:  "vue",
+			files: map[string]string{"package.json": `{"dependencies": {"vue": "^3.5.0"}}`},
+			want:  adoptedProject{Language: "vue", ProjectType: "application"},
+		},
+		{
+			name:  "angular",
+			files: map[string]string{"angular.json": `{}`, "package.json": `{}`},
+			want:  adoptedProject{Language: "angular", ProjectType: "application"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				write(t, dir, name, content)
+			}
+			got, err := detectProject(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("detectProject() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	if _, err := detectProject(t.TempDir()); err == nil {
+		t.Error("detectProject() of an empty directory succeeded")
+	}
+}
@@ -3,21 +3,32 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	buildVerbose  bool
-	buildEnv      string
-	buildPush     bool
-	buildPlatform string
+	buildVerbose     bool
+	buildEnv         string
+	buildPush        bool
+	buildPlatform    string
+	buildJobs        int
+	buildTags        []string
+	buildNoCache     bool
+	buildInteractive bool
+	buildOutput      string
 )
 
 var buildCmd = &cobra.Command{
@@ -31,33 +42,63 @@ with environment-specific build settings.
 
 Use --push to build and push Docker images to the registry.
 
+--platform accepts a comma-separated list (e.g. linux/amd64,linux/arm64) to
+build one image per architecture. With --push, the per-arch images are
+combined into a manifest list; image tarballs built by Bazel are loaded into
+Docker as <registry>/<project>:<tag> first. Builders that cannot cross-compile are
+rejected before any build starts.
+
+A project is not rebuilt when its source files, the projects it depends on and
+its build options are unchanged since its last build: the previous artifact is
+reused from .forge/cache/build. Use --no-cache to build anyway. Bazel builds
+always run, as Bazel keeps its own cache.
+
+Without project arguments or --tag, forge build lets you pick the projects
+from a list when stdin is a terminal (or with --interactive); picking none
+builds them all.
+
+--output copies what each project produced into <dir>/<project>: the binary,
+the static files or the image tarball. Images built into the local Docker
+daemon are exported with 'docker save'. A manifest.json in <dir> lists the
+collected artifacts, to ship the builds to an offline environment.
+
 Examples:
   forge build                            # Build all services using default config
+  forge build -i                         # Pick the services to build from a list
   forge build --env=production           # Build all for production
   forge build --push                     # Build and push Docker images
   forge build api-server                 # Build specific service
   forge build api-server worker          # Build multiple services
+  forge build 'api-*'                    # Build every project matching a glob
+  forge build --tag=backend              # Build every project tagged backend
   forge build --env=development --verbose # Dev build with details
-  forge build --platform=linux/arm64     # Build for specific platform`,
+  forge build --platform=linux/arm64     # Build for specific platform
+  forge build --platform=linux/amd64,linux/arm64 --push # Multi-arch images
+  forge build --no-cache                 # Rebuild even if nothing changed
+  forge build --env=production --output=dist # Collect the artifacts in dist/`,
 	RunE: runBuild,
 }
 
 func init() {
 	rootCmd.AddCommand(buildCmd)
 	buildCmd.Flags().BoolVarP(&buildVerbose, "verbose", "v", false, "Show detailed build output")
-	buildCmd.Flags().StringVarP(&buildEnv, "env", "e", "", "Build environment/profile (local, development, production)")
+	buildCmd.Flags().StringVarP(&buildEnv, "env", "e", "", "Build environment/profile (local, development, production); defaults to 'forge env use'")
 	buildCmd.Flags().BoolVar(&buildPush, "push", false, "Build and push Docker images to registry")
-	buildCmd.Flags().StringVar(&buildPlatform, "platform", "", "Target platform for builds (empty = native platform)")
+	buildCmd.Flags().IntVarP(&buildJobs, "jobs", "j", 0, "Number of projects to build in parallel, also passed to Bazel (default: workspace.build.parallel.workers, or the number of CPUs; at most 64)")
+	buildCmd.Flags().StringVar(&buildPlatform, "platform", "", "Target platform(s) for builds, comma-separated (empty = native platform)")
+	buildCmd.Flags().StringSliceVar(&buildTags, "tag", nil, "Only build projects with this tag (repeatable; projects must have every tag)")
+	buildCmd.Flags().BoolVar(&buildNoCache, "no-cache", false, "Build every project even if its sources and options are unchanged since its last build")
+	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "", "Copy the built artifacts and a manifest.json into this directory")
+	buildCmd.Flags().BoolVarP(&buildInteractive, "interactive", "i", false, "Pick the projects to build from a list when none is given (default when stdin is a terminal)")
 }
 
 func runBuild(cmd *cobra.Command, args []string) error {
-	fmt.Println("🚀 Using direct builder (not Skaffold)")
-	ctx := context.Background()
+	log.Info("🚀 Using direct builder (not Skaffold)")
+	ctx := cmd.Context()
 
-	// Get workspace root
-	workspaceRoot, err := os.Getwd()
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	// Load forge.json (with validation)
@@ -67,12 +108,21 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine which projects to build
-	projectNames := args
+	projectNames, err := expandProjectPatterns(config, args, nil)
+	if err != nil {
+		return err
+	}
 	if len(projectNames) == 0 {
-		// Build all projects
+		// Build all projects, or the ones picked from a list
 		for name := range config.Projects {
 			projectNames = append(projectNames, name)
 		}
+		sort.Strings(projectNames)
+		if len(buildTags) == 0 {
+			if projectNames, err = promptProjects("build", projectNames, buildInteractive); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Validate that all specified projects exist
@@ -81,112 +131,99 @@ func runBuild(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("project %q not found in forge.json", projectName)
 		}
 	}
+	projectNames, err = filterProjectsByTags(config, projectNames, buildTags)
+	if err != nil {
+		return err
+	}
 
-	// Track build results for summary
-	type buildResult struct {
-		project  string
-		duration time.Duration
-		success  bool
-		err      error
+	// Reject multi-arch builds for builders that can't cross-compile before building anything
+	platforms := skaffold.ParsePlatforms(buildPlatform)
+	if len(platforms) > 1 {
+		for _, projectName := range projectNames {
+			project := config.Projects[projectName]
+			if project.Architect == nil || project.Architect.Build == nil {
+				continue
+			}
+			projectBuilder, err := builder.GetBuilder(project.Architect.Build.Builder)
+			if err != nil {
+				continue
+			}
+			if !builder.SupportsPlatforms(projectBuilder, platforms) {
+				return fmt.Errorf("project %q uses builder %s, which cannot build for %s", projectName, project.Architect.Build.Builder, strings.Join(platforms, ", "))
+			}
+		}
+	}
+	if len(platforms) == 0 {
+		platforms = []string{buildPlatform}
 	}
-	var results []buildResult
-	totalStart := time.Now()
 
-	fmt.Printf("\n🔨 Building %d project(s)...\n\n", len(projectNames))
+	// Dependencies are built before the projects that need them
+	deps, err := config.Dependencies(projectNames)
+	if err != nil {
+		return err
+	}
+	if _, err := config.SortByDependencies(projectNames); err != nil {
+		return err
+	}
 
-	// Build all projects using their configured builders
-	// Build command ALWAYS uses direct builders (never Skaffold)
+	// Check for bazel up front rather than failing every Bazel build
 	for _, projectName := range projectNames {
 		project := config.Projects[projectName]
-		buildStart := time.Now()
-
 		if project.Architect == nil || project.Architect.Build == nil {
-			results = append(results, buildResult{
-				project:  projectName,
-				duration: time.Since(buildStart),
-				success:  false,
-				err:      fmt.Errorf("project %s has no build configuration", projectName),
-			})
 			continue
 		}
-
-		// Determine configuration
-		buildConfig := buildEnv
-		if buildConfig == "" && project.Architect.Build.DefaultConfiguration != "" {
-			buildConfig = project.Architect.Build.DefaultConfiguration
-		}
-		if buildConfig == "" {
-			buildConfig = "production"
-		}
-
-		// Get builder
-		builderName := project.Architect.Build.Builder
-		projectBuilder, err := builder.GetBuilder(builderName)
+		projectBuilder, err := builder.GetBuilder(project.Architect.Build.Builder)
 		if err != nil {
-			results = append(results, buildResult{
-				project:  projectName,
-				duration: time.Since(buildStart),
-				success:  false,
-				err:      fmt.Errorf("failed to get builder: %w", err),
-			})
 			continue
 		}
-
-		fmt.Printf("  🔨 Building %s with %s (configuration: %s)\n", projectName, builderName, buildConfig)
-
-		// Get project absolute path
-		projectAbsPath := filepath.Join(workspaceRoot, project.Root)
-
-		// Get build options and configuration options
-		buildOpts := project.Architect.Build.Options
-		var configOpts map[string]interface{}
-		if project.Architect.Build.Configurations != nil {
-			if cfg, ok := project.Architect.Build.Configurations[buildConfig]; ok {
-				if typedCfg, ok := cfg.(map[string]interface{}); ok {
-					configOpts = typedCfg
-				}
+		if builder.UsesBazel(projectBuilder, filepath.Join(workspaceRoot, project.Root)) {
+			if err := ensureBazel(ctx); err != nil {
+				return err
 			}
+			break
 		}
+	}
 
-		// Build using the configured builder
-		opts := &builder.BuildOptions{
-			ProjectRoot:          projectAbsPath,
-			Configuration:        buildConfig,
-			Options:              buildOpts,
-			ConfigurationOptions: configOpts,
-			Verbose:              buildVerbose,
-			Platform:             buildPlatform,
-			WorkspaceRoot:        workspaceRoot,
-		}
+	var cache *builder.BuildCache
+	if !buildNoCache {
+		cache = builder.NewBuildCache(workspaceRoot)
+	}
 
-		artifact, err := projectBuilder.Build(ctx, opts)
-		buildDuration := time.Since(buildStart)
+	jobs := config.ParallelJobs(buildJobs)
+	workers := max(min(jobs, len(projectNames)), 1)
+	totalStart := time.Now()
 
-		if err != nil {
-			fmt.Printf("  ❌ Failed %s (%.1fs)\n", projectName, buildDuration.Seconds())
-			results = append(results, buildResult{
-				project:  projectName,
-				duration: buildDuration,
-				success:  false,
-				err:      err,
-			})
-			continue
+	if workers > 1 {
+		log.Info("\n🔨 Building %d project(s) with %d workers...\n", len(projectNames), workers)
+	} else {
+		log.Info("\n🔨 Building %d project(s)...\n", len(projectNames))
+	}
+
+	// Build all projects using their configured builders
+	// Build command ALWAYS uses direct builders (never Skaffold)
+	results := runBuildPool(projectNames, deps, workers, func(projectName string) buildResult {
+		if workers == 1 {
+			return buildProject(ctx, config, workspaceRoot, projectName, platforms, jobs, totalStart, cache, os.Stdout, os.Stderr)
 		}
 
-		fmt.Printf("  ✅ Built %s (%.1fs)\n", projectName, buildDuration.Seconds())
-		if buildVerbose && artifact != nil {
-			fmt.Printf("     %s at %s\n", artifact.Type, artifact.Path)
+		// Prefix tool output so concurrent builds stay readable
+		prefix := fmt.Sprintf("[%s] ", projectName)
+		stdout := newPrefixWriter(os.Stdout, prefix)
+		stderr := newPrefixWriter(os.Stderr, prefix)
+		defer stdout.Flush()
+		defer stderr.Flush()
+		return buildProject(ctx, config, workspaceRoot, projectName, platforms, jobs, totalStart, cache, stdout, stderr)
+	})
+
+	if buildOutput != "" {
+		if err := collectArtifacts(ctx, buildOutput, results, platforms, totalStart); err != nil {
+			return fmt.Errorf("failed to collect artifacts: %w", err)
 		}
-		results = append(results, buildResult{
-			project:  projectName,
-			duration: buildDuration,
-			success:  true,
-		})
 	}
 
 	// Print summary
 	totalDuration := time.Since(totalStart)
-	fmt.Printf("\n" + strings.Repeat("─", 50) + "\n")
+	log.Info("\n%s", strings.Repeat("─", 50))
 
 	successCount := 0
 	failCount := 0
@@ -199,57 +236,382 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	}
 
 	if failCount == 0 {
-		fmt.Printf("✅ All builds completed successfully!\n")
-		fmt.Printf("   Total time: %.1fs\n\n", totalDuration.Seconds())
+		log.Info("✅ All builds completed successfully!")
+		log.Info("   Total time: %.1fs\n", totalDuration.Seconds())
 		return nil
 	}
 
 	// Print failure summary
-	fmt.Printf("❌ Build Summary: %d succeeded, %d failed\n", successCount, failCount)
-	fmt.Printf("   Total time: %.1fs\n\n", totalDuration.Seconds())
+	log.Error("❌ Build Summary: %d succeeded, %d failed", successCount, failCount)
+	log.Error("   Total time: %.1fs\n", totalDuration.Seconds())
 
-	fmt.Println("Failed builds:")
+	log.Error("Failed builds:")
 	for _, result := range results {
 		if !result.success {
-			fmt.Printf("  • %s: %v\n", result.project, result.err)
+			log.Error("  • %s: %v", result.project, result.err)
 
 			// Suggest fixes based on error patterns
 			errMsg := result.err.Error()
 			if strings.Contains(errMsg, "no such target") || strings.Contains(errMsg, "no such package") {
-				fmt.Println("    💡 Try running: forge sync")
+				log.Info("    💡 Try running: forge sync")
 			} else if strings.Contains(errMsg, "missing dependencies") || strings.Contains(errMsg, "cannot load") {
-				fmt.Println("    💡 Try running: forge sync")
+				log.Info("    💡 Try running: forge sync")
 			} else if strings.Contains(errMsg, "BUILD") && strings.Contains(errMsg, "file") {
-				fmt.Println("    💡 BUILD files may be out of sync. Try: forge sync")
+				log.Info("    💡 BUILD files may be out of sync. Try: forge sync")
 			}
 		}
 	}
-	fmt.Println()
+	log.Info("")
 
 	return fmt.Errorf("%d build(s) failed", failCount)
 }
 
-// findAngularWorkspaceRoot finds the directory containing angular.json
-// by walking up from the project root
-func findAngularWorkspaceRoot(workspaceRoot, projectRoot string) string {
-	// Start from the project's absolute path
-	projectAbsPath := filepath.Join(workspaceRoot, projectRoot)
+// buildResult is the outcome of building one project.
+type buildResult struct {
+	project   string
+	duration  time.Duration
+	success   bool
+	err       error
+	artifacts []*builder.BuildArtifact // one per platform
+}
+
+// buildProject builds one project for every platform with its configured builder.
+// Bazel runs with up to jobs parallel actions and timestamp image tags use
+// buildTime. Build tool output goes to stdout and stderr.
+func buildProject(ctx context.Context, config *workspace.Config, workspaceRoot, projectName string, platforms []string, jobs int, buildTime time.Time, cache *builder.BuildCache, stdout, stderr io.Writer) buildResult {
+	project := config.Projects[projectName]
+	buildStart := time.Now()
+
+	if project.Architect == nil || project.Architect.Build == nil {
+		return buildResult{
+			project:  projectName,
+			duration: time.Since(buildStart),
+			success:  false,
+			err:      fmt.Errorf("project %s has no build configuration", projectName),
+		}
+	}
+
+	// Determine configuration
+	buildConfig := config.BuildConfiguration(&project, buildEnv)
+
+	// Get builder
+	builderName := project.Architect.Build.Builder
+	projectBuilder, err := builder.GetBuilder(builderName)
+	if err != nil {
+		return buildResult{
+			project:  projectName,
+			duration: time.Since(buildStart),
+			success:  false,
+			err:      fmt.Errorf("failed to get builder: %w", err),
+		}
+	}
+
+	imageTag, err := resolveImageTag(ctx, workspaceRoot, project.Architect.Build, buildConfig, buildTime)
+	if err != nil {
+		return buildResult{
+			project:  projectName,
+			duration: time.Since(buildStart),
+			success:  false,
+			err:      err,
+		}
+	}
+
+	log.Info("  🔨 Building %s with %s (configuration: %s)", projectName, builderName, buildConfig)
+
+	// Get project absolute path
+	projectAbsPath := filepath.Join(workspaceRoot, project.Root)
 
-	// Walk up directories looking for angular.json
-	currentPath := projectAbsPath
-	for {
-		angularJsonPath := filepath.Join(currentPath, "angular.json")
-		if _, err := os.Stat(angularJsonPath); err == nil {
-			return currentPath
+	// Get build options and configuration options
+	buildOpts := project.Architect.Build.Options
+	configOpts := project.Architect.Build.ConfigurationOptions(buildConfig)
+
+	// Build using the configured builder, once per target platform
+	var artifacts []*builder.BuildArtifact
+	cached := true
+	for _, platform := range platforms {
+		opts := &builder.BuildOptions{
+			ProjectRoot:          projectAbsPath,
+			Configuration:        buildConfig,
+			Options:              buildOpts,
+			ConfigurationOptions: configOpts,
+			Verbose:              buildVerbose,
+			Platform:             platform,
+			WorkspaceRoot:        workspaceRoot,
+			Jobs:                 jobs,
+			ImageTag:             imageTag,
+			Stdout:               stdout,
+			Stderr:               stderr,
 		}
 
-		// Move up one directory
-		parentPath := filepath.Dir(currentPath)
-		if parentPath == currentPath || parentPath == "/" {
-			// Reached root without finding angular.json
-			// Return workspace root as fallback
-			return workspaceRoot
+		var artifact *builder.BuildArtifact
+		var fromCache bool
+		artifact, fromCache, err = buildWithCache(ctx, cache, config, workspaceRoot, projectName, projectBuilder, opts)
+		cached = cached && fromCache
+		if err != nil {
+			if len(platforms) > 1 {
+				err = fmt.Errorf("%s: %w", platform, err)
+			}
+			break
 		}
-		currentPath = parentPath
+		artifacts = append(artifacts, artifact)
+
+		// Bazel images are tarballs, loaded into Docker so they can be pushed
+		if buildPush && len(platforms) > 1 && artifact != nil && artifact.ImageName == "" && artifact.Type == builder.ArtifactTypeImage && artifact.Path != "" {
+			registry := config.Registry(project.Architect.Build, buildConfig)
+			if registry == "" {
+				err = fmt.Errorf("no registry to push %s to: set workspace.docker.registry or the registry build option", projectName)
+				break
+			}
+			if err = loadImageTarball(ctx, artifact, fmt.Sprintf("%s/%s:%s", registry, projectName, imageTag)); err != nil {
+				break
+			}
+		}
+
+		// Each platform build overwrites the local image tag, so keep a per-arch tag
+		if buildPush && len(platforms) > 1 && artifact != nil && artifact.ImageName != "" {
+			if err = runDocker(ctx, "tag", artifact.ImageName, archImageName(artifact.ImageName, platform)); err != nil {
+				break
+			}
+		}
+	}
+
+	if err == nil && buildPush && len(platforms) > 1 {
+		err = pushManifestList(ctx, projectName, platforms, artifacts)
+	}
+	buildDuration := time.Since(buildStart)
+
+	if err != nil {
+		log.Error("  ❌ Failed %s (%.1fs)", projectName, buildDuration.Seconds())
+		return buildResult{
+			project:  projectName,
+			duration: buildDuration,
+			success:  false,
+			err:      err,
+		}
+	}
+
+	if cached {
+		log.Info("  ✅ %s is up to date (cached)", projectName)
+	} else {
+		log.Info("  ✅ Built %s (%.1fs)", projectName, buildDuration.Seconds())
+	}
+	for i, artifact := range artifacts {
+		if artifact == nil {
+			continue
+		}
+		if len(platforms) > 1 {
+			log.Debug("     [%s] %s at %s", platforms[i], artifact.Type, artifact.Path)
+		} else {
+			log.Debug("     %s at %s", artifact.Type, artifact.Path)
+		}
+	}
+	return buildResult{
+		project:   projectName,
+		duration:  buildDuration,
+		success:   true,
+		artifacts: artifacts,
+	}
+}
+
+// buildWithCache builds a project, reusing its last artifact when cache is
+// set and nothing the build depends on has changed. It reports whether the
+// artifact came from the cache.
+func buildWithCache(ctx context.Context, cache *builder.BuildCache, config *workspace.Config, workspaceRoot, projectName string, projectBuilder builder.Builder, opts *builder.BuildOptions) (*builder.BuildArtifact, bool, error) {
+	if cache == nil {
+		artifact, err := projectBuilder.Build(ctx, opts)
+		return artifact, false, err
+	}
+
+	var inputs []string
+	for _, dep := range dependencyClosure(config, projectName) {
+		inputs = append(inputs, filepath.Join(workspaceRoot, config.Projects[dep].Root))
+	}
+	project := config.Projects[projectName]
+	return cache.Build(ctx, projectBuilder, project.Architect.Build.Builder, opts, inputs)
+}
+
+// resolveImageTag returns the tag of the images built from target in
+// configuration, following its tag strategy.
+func resolveImageTag(ctx context.Context, workspaceRoot string, target *workspace.ArchitectTarget, configuration string, buildTime time.Time) (string, error) {
+	strategy, err := workspace.ImageTagStrategy(target, configuration)
+	if err != nil {
+		return "", err
+	}
+	tag, err := builder.ResolveImageTag(ctx, workspaceRoot, strategy, buildTime)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the %s image tag: %w", strategy, err)
+	}
+	return tag, nil
+}
+
+// dependencyClosure returns the projects projectName depends on through
+// metadata.dependsOn, directly or not, in sorted order.
+func dependencyClosure(config *workspace.Config, projectName string) []string {
+	seen := map[string]bool{projectName: true}
+	var deps []string
+	var visit func(name string)
+	visit = func(name string) {
+		project, ok := config.Projects[name]
+		if !ok {
+			return
+		}
+		dependsOn, _ := project.DependsOn()
+		for _, dep := range dependsOn {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if _, ok := config.Projects[dep]; ok {
+				deps = append(deps, dep)
+			}
+			visit(dep)
+		}
+	}
+	visit(projectName)
+	sort.Strings(deps)
+	return deps
+}
+
+// runBuildPool builds projectNames with up to workers builds at a time. A
+// project starts once all its dependencies in deps have been built; if one of
+// them failed, it is reported as failed without being built. Builds already
+// running always complete. Results are returned in projectNames order.
+func runBuildPool(projectNames []string, deps map[string][]string, workers int, build func(projectName string) buildResult) []buildResult {
+	waiting := make(map[string]int, len(projectNames))
+	dependents := make(map[string][]string)
+	for _, name := range projectNames {
+		waiting[name] = len(deps[name])
+		for _, dep := range deps[name] {
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	// Both channels hold every project, so scheduling never blocks
+	jobs := make(chan string, len(projectNames))
+	done := make(chan buildResult, len(projectNames))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				done <- build(name)
+			}
+		}()
+	}
+
+	for _, name := range projectNames {
+		if waiting[name] == 0 {
+			jobs <- name
+		}
+	}
+
+	resultsByProject := make(map[string]buildResult, len(projectNames))
+	failedDeps := make(map[string]string)
+	for len(resultsByProject) < len(projectNames) {
+		result := <-done
+		resultsByProject[result.project] = result
+
+		for _, dependent := range dependents[result.project] {
+			if !result.success {
+				failedDeps[dependent] = result.project
+			}
+			waiting[dependent]--
+			if waiting[dependent] > 0 {
+				continue
+			}
+			if dep, failed := failedDeps[dependent]; failed {
+				// Its own dependents are released the same way when this result is read
+				done <- buildResult{
+					project: dependent,
+					success: false,
+					err:     fmt.Errorf("not built: dependency %s failed", dep),
+				}
+				continue
+			}
+			jobs <- dependent
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	results := make([]buildResult, 0, len(projectNames))
+	for _, name := range projectNames {
+		results = append(results, resultsByProject[name])
+	}
+	return results
+}
+
+// archImageName returns the per-architecture tag for an image, e.g. "app:prod-linux-arm64"
+func archImageName(image, platform string) string {
+	return fmt.Sprintf("%s-%s", image, strings.ReplaceAll(platform, "/", "-"))
+}
+
+// pushManifestList pushes the per-arch images tagged during the build and combines them
+// into a manifest list under the artifact's image name. It fails for builds
+// that produced no image, like binaries or static files.
+func pushManifestList(ctx context.Context, projectName string, platforms []string, artifacts []*builder.BuildArtifact) error {
+	var manifest string
+	var archImages []string
+
+	for i, artifact := range artifacts {
+		if artifact == nil || artifact.ImageName == "" {
+			return fmt.Errorf("the %s build of %s produced no image to push", platforms[i], projectName)
+		}
+		manifest = artifact.ImageName
+
+		archImage := archImageName(artifact.ImageName, platforms[i])
+		if err := runDocker(ctx, "push", archImage); err != nil {
+			return err
+		}
+		archImages = append(archImages, archImage)
+	}
+
+	createArgs := append([]string{"manifest", "create", "--amend", manifest}, archImages...)
+	if err := runDocker(ctx, createArgs...); err != nil {
+		return err
+	}
+	if err := runDocker(ctx, "manifest", "push", manifest); err != nil {
+		return err
+	}
+
+	log.Info("  📦 Pushed manifest list %s (%s)", manifest, strings.Join(platforms, ", "))
+	return nil
+}
+
+// loadImageTarball loads an image tarball built by Bazel into Docker and tags
+// it as image, which becomes the artifact's image name.
+func loadImageTarball(ctx context.Context, artifact *builder.BuildArtifact, image string) error {
+	output, err := exec.Capture(ctx, exec.Options{Name: "docker", Args: []string{"load", "-i", artifact.Path}})
+	if err != nil {
+		return fmt.Errorf("docker load failed: %w", err)
+	}
+
+	// "Loaded image: <ref>" for tagged images, "Loaded image ID: <id>" otherwise
+	var loaded string
+	for _, line := range strings.Split(string(output), "\n") {
+		if _, ref, ok := strings.Cut(line, "Loaded image"); ok {
+			if _, ref, ok = strings.Cut(ref, ": "); ok {
+				loaded = strings.TrimSpace(ref)
+			}
+		}
+	}
+	if loaded == "" {
+		return fmt.Errorf("docker load did not report the image loaded from %s", artifact.Path)
+	}
+
+	if err := runDocker(ctx, "tag", loaded, image); err != nil {
+		return err
+	}
+	artifact.ImageName = image
+	return nil
+}
+
+// runDocker runs a docker CLI command, streaming its output
+func runDocker(ctx context.Context, args ...string) error {
+	if err := exec.Run(ctx, exec.Options{Name: "docker", Args: args}); err != nil {
+		return fmt.Errorf("docker %s failed: %w", args[0], err)
 	}
+	return nil
 }
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/pkg/xos"
+)
+
+// buildManifestFileName is the manifest written to the --output directory
+const buildManifestFileName = "manifest.json"
+
+// buildManifest lists the artifacts collected by 'forge build --output'.
+type buildManifest struct {
+	BuiltAt   string              `json:"builtAt"`
+	Artifacts []collectedArtifact `json:"artifacts"`
+}
+
+// collectedArtifact is one artifact copied to the output directory. Path is
+// relative to the output directory.
+type collectedArtifact struct {
+	Project   string               `json:"project"`
+	Type      builder.ArtifactType `json:"type"`
+	Platform  string               `json:"platform,omitempty"`
+	Path      string               `json:"path"`
+	Tag       string               `json:"tag,omitempty"`
+	ImageName string               `json:"imageName,omitempty"`
+}
+
+// collectArtifacts copies the artifacts of the successful builds to
+// <outputDir>/<project>, in a directory per platform when there are several,
+// and writes a manifest of them. Images without a file on disk are saved from
+// the Docker daemon as image.tar.
+func collectArtifacts(ctx context.Context, outputDir string, results []buildResult, platforms []string, builtAt time.Time) error {
+	manifest := buildManifest{
+		BuiltAt:   builtAt.UTC().Format(time.RFC3339),
+		Artifacts: []collectedArtifact{},
+	}
+
+	for _, result := range results {
+		if !result.success {
+			continue
+		}
+
+		// Don't leave artifacts of an earlier build next to the new ones
+		projectDir := filepath.Join(outputDir, result.project)
+		if err := os.RemoveAll(projectDir); err != nil {
+			return fmt.Errorf("failed to clean %s: %w", projectDir, err)
+		}
+
+		for i, artifact := range result.artifacts {
+			if artifact == nil {
+				continue
+			}
+			platform := ""
+			if i < len(platforms) {
+				platform = platforms[i]
+			}
+			dir := projectDir
+			if len(platforms) > 1 {
+				dir = filepath.Join(projectDir, strings.ReplaceAll(platform, "/", "-"))
+			}
+
+			path, err := copyArtifact(ctx, artifact, dir)
+			if err != nil {
+				return fmt.Errorf("%s: %w", result.project, err)
+			}
+			if path == "" {
+				log.Warn("  ⚠️  %s: the %s artifact has no file or image to collect", result.project, artifact.Type)
+				continue
+			}
+
+			rel, err := filepath.Rel(outputDir, path)
+			if err != nil {
+				return err
+			}
+			manifest.Artifacts = append(manifest.Artifacts, collectedArtifact{
+				Project:   result.project,
+				Type:      artifact.Type,
+				Platform:  platform,
+				Path:      filepath.ToSlash(rel),
+				Tag:       artifact.Tag,
+				ImageName: artifact.ImageName,
+			})
+		}
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := xos.WriteFile(filepath.Join(outputDir, buildManifestFileName), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", buildManifestFileName, err)
+	}
+
+	log.Info("📦 Collected %d artifact(s) in %s", len(manifest.Artifacts), outputDir)
+	return nil
+}
+
+// copyArtifact copies an artifact into dir and returns where it was put, or ""
+// when there is nothing to copy.
+func copyArtifact(ctx context.Context, artifact *builder.BuildArtifact, dir string) (string, error) {
+	if artifact.Path == "" {
+		if artifact.Type != builder.ArtifactTypeImage || artifact.ImageName == "" {
+			return "", nil
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+		path := filepath.Join(dir, "image.tar")
+		if err := runDocker(ctx, "save", "-o", path, artifact.ImageName); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	info, err := os.Stat(artifact.Path)
+	if err != nil {
+		return "", fmt.Errorf("%s artifact not found: %w", artifact.Type, err)
+	}
+	path := filepath.Join(dir, filepath.Base(artifact.Path))
+	if info.IsDir() {
+		return path, copyTree(artifact.Path, path)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return path, xos.CopyFile(artifact.Path, path, info.Mode().Perm())
+}
+
+// copyTree copies the files of src into dst, keeping their permissions.
+// Symlinked files are copied as regular files, as Bazel outputs usually are
+// symlinks into its cache.
+func copyTree(src, dst string) error {
+	// Walk the target when src itself is a symlink, like bazel-bin
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		// Follow symlinks, skipping directories so a link cycle can't recurse
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if entry.IsDir() {
+				return os.MkdirAll(target, 0755)
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return xos.CopyFile(path, target, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+func TestRunBuildPoolBuildsInParallel(t *testing.T) {
+	projects := []string{"api", "web", "worker", "gateway"}
+
+	// Every build waits until all of them have started, which only happens
+	// when they run at the same time
+	var started sync.WaitGroup
+	started.Add(len(projects))
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	results := runBuildPool(projects, nil, len(projects), func(name string) buildResult {
+		started.Done()
+		select {
+		case <-allStarted:
+			return buildResult{project: name, success: true}
+		case <-time.After(5 * time.Second):
+			return buildResult{project: name, err: fmt.Errorf("builds did not run in parallel")}
+		}
+	})
+
+	for i, result := range results {
+		if result.project != projects[i] {
+			t.Errorf("result %d is for %s, want %s", i, result.project, projects[i])
+		}
+		if !result.success {
+			t.Errorf("%s failed: %v", result.project, result.err)
+		}
+	}
+}
+
+func TestRunBuildPoolFailureKeepsInFlightBuilds(t *testing.T) {
+	projects := []string{"broken", "api", "web"}
+	failed := make(chan struct{})
+
+	results := runBuildPool(projects, nil, len(projects), func(name string) buildResult {
+		if name == "broken" {
+			defer close(failed)
+			return buildResult{project: name, err: fmt.Errorf("compile error")}
+		}
+		// Still running when the failure comes in
+		<-failed
+		time.Sleep(10 * time.Millisecond)
+		return buildResult{project: name, success: true}
+	})
+
+	want := map[string]bool{"broken": false, "api": true, "web": true}
+	for _, result := range results {
+		if result.success != want[result.project] {
+			t.Errorf("%s success = %v, want %v (err: %v)", result.project, result.success, want[result.project], result.err)
+		}
+	}
+}
+
+func TestRunBuildPoolDependencyFailure(t *testing.T) {
+	projects := []string{"shared", "api", "gateway", "web"}
+	deps := map[string][]string{
+		"api":     {"shared"},
+		"gateway": {"api"},
+	}
+
+	var mu sync.Mutex
+	built := make(map[string]bool)
+	results := runBuildPool(projects, deps, 2, func(name string) buildResult {
+		mu.Lock()
+		built[name] = true
+		mu.Unlock()
+
+		if name == "shared" {
+			return buildResult{project: name, err: fmt.Errorf("compile error")}
+		}
+		return buildResult{project: name, success: true}
+	})
+
+	if len(results) != len(projects) {
+		t.Fatalf("got %d results, want %d", len(results), len(projects))
+	}
+	for _, name := range []string{"api", "gateway"} {
+		if built[name] {
+			t.Errorf("%s was built although its dependency failed", name)
+		}
+	}
+	if !built["web"] {
+		t.Error("web was not built although it does not depend on shared")
+	}
+
+	for _, result := range results {
+		switch result.project {
+		case "web":
+			if !result.success {
+				t.Errorf("web failed: %v", result.err)
+			}
+		case "shared":
+			if result.success {
+				t.Error("shared succeeded, want failure")
+			}
+		default:
+			if result.success || result.err == nil {
+				t.Errorf("%s = success %v, err %v, want a dependency failure", result.project, result.success, result.err)
+			}
+		}
+	}
+}
+
+func TestRunBuildPoolWaitsForDependencies(t *testing.T) {
+	projects := []string{"gateway", "api", "shared"}
+	deps := map[string][]string{
+		"api":     {"shared"},
+		"gateway": {"api", "shared"},
+	}
+
+	var mu sync.Mutex
+	var order []string
+	runBuildPool(projects, deps, len(projects), func(name string) buildResult {
+		mu.Lock()
+		order = append(order, name)
+		mu.Unlock()
+		return buildResult{project: name, success: true}
+	})
+
+	want := []string{"shared", "api", "gateway"}
+	if fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("build order = %v, want %v", order, want)
+	}
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	src := t.TempDir()
+	write := func(path, content string, perm os.FileMode) {
+		t.Helper()
+		path = filepath.Join(src, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), perm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("web/dist/browser/index.html", "<html></html>", 0644)
+	write("cache/main.js", "console.log()", 0644)
+	write("api/bin/api", "binary", 0755)
+	// Bazel outputs are symlinks into its cache
+	if err := os.Symlink(filepath.Join(src, "cache/main.js"), filepath.Join(src, "web/dist/browser/main.js")); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	if err := os.MkdirAll(filepath.Join(out, "web", "stale"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	results := []buildResult{
+		{project: "web", success: true, artifacts: []*builder.BuildArtifact{
+			{Type: builder.ArtifactTypeStatic, Path: filepath.Join(src, "web/dist"), Tag: "production"},
+		}},
+		{project: "api", success: true, artifacts: []*builder.BuildArtifact{
+			{Type: builder.ArtifactTypeBinary, Path: filepath.Join(src, "api/bin/api"), Tag: "production"},
+		}},
+		{project: "broken", err: fmt.Errorf("compile error")},
+	}
+	builtAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := collectArtifacts(context.Background(), out, results, []string{""}, builtAt); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"web/dist/browser/index.html": "<html></html>",
+		"web/dist/browser/main.js":    "console.log()",
+		"api/api":                     "binary",
+	} {
+		content, err := os.ReadFile(filepath.Join(out, path))
+		if err != nil || string(content) != want {
+			t.Errorf("%s = %q, %v, want %q", path, content, err, want)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(out, "api/api")); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("the binary is not executable: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "web", "stale")); !os.IsNotExist(err) {
+		t.Error("artifacts of an earlier build were kept")
+	}
+	if _, err := os.Stat(filepath.Join(out, "broken")); !os.IsNotExist(err) {
+		t.Error("a failed build was collected")
+	}
+
+	content, err := os.ReadFile(filepath.Join(out, buildManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest buildManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	want := []collectedArtifact{
+		{Project: "web", Type: builder.ArtifactTypeStatic, Path: "web/dist", Tag: "production"},
+		{Project: "api", Type: builder.ArtifactTypeBinary, Path: "api/api", Tag: "production"},
+	}
+	if manifest.BuiltAt != "2026-03-04T05:06:07Z" || fmt.Sprint(manifest.Artifacts) != fmt.Sprint(want) {
+		t.Errorf("manifest = %+v, want %+v", manifest, want)
+	}
+}
+
+func TestCollectArtifactsPerPlatform(t *testing.T) {
+	src := t.TempDir()
+	for _, arch := range []string{"amd64", "arm64"} {
+		if err := os.WriteFile(filepath.Join(src, arch), []byte(arch), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := t.TempDir()
+	results := []buildResult{{project: "api", success: true, artifacts: []*builder.BuildArtifact{
+		{Type: builder.ArtifactTypeBinary, Path: filepath.Join(src, "amd64")},
+		{Type: builder.ArtifactTypeBinary, Path: filepath.Join(src, "arm64")},
+	}}}
+	if err := collectArtifacts(context.Background(), out, results, []string{"linux/amd64", "linux/arm64"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"api/linux-amd64/amd64", "api/linux-arm64/arm64"} {
+		if _, err := os.Stat(filepath.Join(out, path)); err != nil {
+			t.Errorf("%s was not collected: %v", path, err)
+		}
+	}
+}
+
+func TestCollectArtifactsMissingPath(t *testing.T) {
+	results := []buildResult{{project: "api", success: true, artifacts: []*builder.BuildArtifact{
+		{Type: builder.ArtifactTypeBinary, Path: filepath.Join(t.TempDir(), "bazel-bin")},
+	}}}
+	if err := collectArtifacts(context.Background(), t.TempDir(), results, []string{""}, time.Now()); err == nil {
+		t.Error("collectArtifacts() succeeded for an artifact that does not exist")
+	}
+}
+
+func TestLoadImageTarball(t *testing.T) {
+	var commands []string
+	restore := exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		commands = append(commands, opts.String())
+		if opts.Args[0] == "load" {
+			fmt.Fprintln(opts.Stdout, "Loaded image: bazel/backend/services/api/cmd/server:image")
+		}
+		return nil
+	}))
+	defer restore()
+
+	artifact := &builder.BuildArtifact{Type: builder.ArtifactTypeImage, Path: "bazel-bin/api/image_tarball.tar"}
+	if err := loadImageTarball(context.Background(), artifact, "gcr.io/shop/api:prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"docker load -i bazel-bin/api/image_tarball.tar",
+		"docker tag bazel/backend/services/api/cmd/server:image gcr.io/shop/api:prod",
+	}
+	if fmt.Sprint(commands) != fmt.Sprint(want) {
+		t.Errorf("commands = %q, want %q", commands, want)
+	}
+	if artifact.ImageName != "gcr.io/shop/api:prod" {
+		t.Errorf("ImageName = %q, want gcr.io/shop/api:prod", artifact.ImageName)
+	}
+}
+
+func TestPushManifestListWithoutImage(t *testing.T) {
+	restore := exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		t.Errorf("unexpected command %s", opts)
+		return nil
+	}))
+	defer restore()
+
+	artifacts := []*builder.BuildArtifact{
+		{Type: builder.ArtifactTypeBinary, Path: "bazel-bin/api/api"},
+		{Type: builder.ArtifactTypeBinary, Path: "bazel-bin/api/api"},
+	}
+	if err := pushManifestList(context.Background(), "api", []string{"linux/amd64", "linux/arm64"}, artifacts); err == nil {
+		t.Error("pushManifestList() succeeded without images to push")
+	}
+}
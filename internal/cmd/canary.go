@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
+	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var canaryEnv string
+
+var promoteCanaryCmd = &cobra.Command{
+	Use:   "promote-canary <project...>",
+	Short: "Send all traffic to a running canary",
+	Long: `Finish a canary rollout started with 'forge deploy --canary'.
+
+For Cloud Run all traffic is routed to the latest revision. For Helm the stable
+release is redeployed with the canary build and the canary release is removed.`,
+	Example: `  forge promote-canary api --env=prod`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runPromoteCanary,
+}
+
+var abortCanaryCmd = &cobra.Command{
+	Use:   "abort-canary <project...>",
+	Short: "Roll back a running canary",
+	Long: `Abort a canary rollout started with 'forge deploy --canary'.
+
+For Cloud Run all traffic is routed back to the stable revision. For Helm the
+canary release is removed, leaving the stable release serving all traffic.`,
+	Example: `  forge abort-canary api --env=prod`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runAbortCanary,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{promoteCanaryCmd, abortCanaryCmd} {
+		cmd.Flags().StringVarP(&canaryEnv, "env", "e", "production", "Environment/profile the canary was deployed to")
+		rootCmd.AddCommand(cmd)
+	}
+}
+
+func runPromoteCanary(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	workspaceRoot, config, targets, err := loadCanaryTargets(args)
+	if err != nil {
+		return err
+	}
+
+	return promoteCanaries(ctx, workspaceRoot, config, canaryEnv, targets)
+}
+
+// promoteCanaries sends all traffic of each target to its canary.
+func promoteCanaries(ctx context.Context, workspaceRoot string, config *workspace.Config, env string, targets []*deployer.CanaryTarget) error {
+	for _, target := range targets {
+		fmt.Printf("🚀 Promoting canary for %s...\n", target.Project)
+
+		// Helm canaries run in their own release: ship the same build to the stable release first
+		if target.Deployer == "@forge/helm:deploy" {
+			skaffoldConfig, err := skaffold.GenerateConfig(config, []string{target.Project}, workspaceRoot, "")
+			if err != nil {
+				return fmt.Errorf("failed to generate Skaffold config: %w", err)
+			}
+			executor := skaffold.NewExecutor(skaffoldConfig, workspaceRoot)
+			if err := executor.Deploy(ctx, skaffold.DeployOptions{Profile: env}); err != nil {
+				return fmt.Errorf("❌ Failed to deploy %s: %w", target.Project, err)
+			}
+		}
+
+		if err := deployer.PromoteCanary(ctx, target); err != nil {
+			return fmt.Errorf("❌ Failed to promote canary for %s: %w", target.Project, err)
+		}
+		fmt.Printf("✅ %s now serves all traffic from the new version\n", target.Project)
+	}
+
+	return nil
+}
+
+func runAbortCanary(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	_, _, targets, err := loadCanaryTargets(args)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		fmt.Printf("⏪ Aborting canary for %s...\n", target.Project)
+		if err := deployer.AbortCanary(ctx, target); err != nil {
+			return fmt.Errorf("❌ Failed to abort canary for %s: %w", target.Project, err)
+		}
+		fmt.Printf("✅ %s is back on the stable version\n", target.Project)
+	}
+
+	return nil
+}
+
+// loadCanaryTargets loads forge.json and resolves the canary target of each project.
+func loadCanaryTargets(projects []string) (string, *workspace.Config, []*deployer.CanaryTarget, error) {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	targets := make([]*deployer.CanaryTarget, 0, len(projects))
+	for _, project := range projects {
+		target, err := deployer.ResolveCanaryTarget(config, project, canaryEnv)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return workspaceRoot, config, targets, nil
+}
+
+// canaryRollout tracks a canary deploy between configuring it and routing traffic.
+type canaryRollout struct {
+	percent        int
+	env            string
+	targets        []*deployer.CanaryTarget
+	stableRevision map[string]string // Cloud Run service -> revision serving traffic before the deploy
+	cleanups       []func()
+}
+
+// prepareCanary resolves the canary targets, pins Cloud Run traffic to the
+// revisions that stay stable and turns the Helm releases into canary releases
+// before deploying.
+func prepareCanary(ctx context.Context, workspaceRoot string, config *workspace.Config, projects []string, env string, executor *skaffold.Executor, percent int) (*canaryRollout, error) {
+	if _, _, err := deployer.TrafficSplit(percent); err != nil {
+		return nil, err
+	}
+
+	rollout := &canaryRollout{
+		percent:        percent,
+		env:            env,
+		stableRevision: make(map[string]string),
+	}
+
+	hasHelm := false
+	pinned := make(map[string]string) // Cloud Run manifest -> stable revision
+	for _, project := range projects {
+		target, err := deployer.ResolveCanaryTarget(config, project, env)
+		if err != nil {
+			return nil, err
+		}
+		rollout.targets = append(rollout.targets, target)
+
+		switch target.Deployer {
+		case "@forge/cloudrun:deploy":
+			revision, err := deployer.CloudRunServingRevision(ctx, target)
+			if err != nil {
+				return nil, err
+			}
+			rollout.stableRevision[target.Service] = revision
+			manifest := filepath.Join(workspaceRoot, config.Projects[project].Root, "deploy", "cloudrun", "service.yaml")
+			pinned[manifest] = revision
+		case "@forge/helm:deploy":
+			hasHelm = true
+		}
+	}
+
+	if executor == nil {
+		return rollout, nil
+	}
+
+	// The new Cloud Run revisions are deployed without traffic and only get
+	// their share once start splits it
+	if len(pinned) > 0 {
+		cleanup, err := executor.PinCloudRunTraffic(pinned)
+		if err != nil {
+			return nil, err
+		}
+		rollout.cleanups = append(rollout.cleanups, cleanup)
+	}
+
+	if hasHelm {
+		cleanup, err := executor.EnableCanary(percent)
+		if err != nil {
+			rollout.cleanup()
+			return nil, err
+		}
+		rollout.cleanups = append(rollout.cleanups, cleanup)
+	}
+
+	return rollout, nil
+}
+
+// start splits Cloud Run traffic once the new revisions exist and prints how to
+// finish the rollout. A failed split sends all traffic back to the stable revision.
+func (r *canaryRollout) start(ctx context.Context) error {
+	for _, target := range r.targets {
+		if revision, ok := r.stableRevision[target.Service]; ok {
+			if err := deployer.SplitCloudRunTraffic(ctx, target, revision, r.percent); err != nil {
+				if restoreErr := deployer.RestoreCloudRunTraffic(ctx, target, revision); restoreErr != nil {
+					return fmt.Errorf("%w (restoring %s to 100%% also failed: %v)", err, revision, restoreErr)
+				}
+				return err
+			}
+		}
+		fmt.Printf("🐤 %s: %d%% of traffic goes to the new version\n", target.Project, r.percent)
+	}
+	return nil
+}
+
+// finish promotes the canary once wait has passed or, without a wait, once the
+// user confirms. Declining leaves the canary running so it can be promoted or
+// aborted later; a prompt that cannot be answered, e.g. without a terminal in
+// CI, fails the deploy instead of reporting success.
+func (r *canaryRollout) finish(ctx context.Context, workspaceRoot string, config *workspace.Config, wait time.Duration) error {
+	if wait > 0 {
+		fmt.Printf("\n⏳ Promoting in %s (interrupt to keep the canary running)\n", wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	} else {
+		promote, err := ui.WithFlag("--canary-wait").AskConfirm("Promote the new version to 100% of traffic?", false)
+		if err != nil {
+			return fmt.Errorf("failed to confirm the canary promotion (use --canary-wait when not running interactively), the canary is still running: %w", err)
+		}
+		if !promote {
+			fmt.Printf("\nWhen ready: forge promote-canary <project> --env=%s\n", r.env)
+			fmt.Printf("To roll back: forge abort-canary <project> --env=%s\n", r.env)
+			return nil
+		}
+	}
+
+	return promoteCanaries(ctx, workspaceRoot, config, r.env, r.targets)
+}
+
+// cleanup removes temporary files created for the rollout.
+func (r *canaryRollout) cleanup() {
+	for _, cleanup := range r.cleanups {
+		cleanup()
+	}
+}
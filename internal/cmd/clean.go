@@ -1,19 +1,23 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cleanCache bool
-	cleanDeep  bool
+	cleanCache   bool
+	cleanDeep    bool
+	cleanExpunge bool
+	cleanDryRun  bool
 )
 
 var cleanCmd = &cobra.Command{
@@ -21,127 +25,196 @@ var cleanCmd = &cobra.Command{
 	Short: "Clean build artifacts and caches",
 	Long: `Clean build artifacts and caches in the workspace.
 
+By default removes the bazel-* output symlinks, the .forge/ directory and the
+dist/ and .angular/ directories of the workspace, the frontend and every project.
+Nothing outside the workspace root is touched, and only these generated
+directories are removed.
+
+Use --deep to also remove the node_modules of the workspace, the frontend and
+every project.
+Use --expunge to run bazel clean --expunge when Bazel is installed.
 Use --cache to remove project-local caches (.forge/cache, .angular/cache) and run bazel clean --expunge.
-Use --deep to additionally remove global caches (~/.cache/bazel, ~/go/pkg/mod/cache, ~/.npm) with confirmation.`,
+Use --dry-run to list what would be removed.
+
+Examples:
+  forge clean                # Remove build outputs
+  forge clean --dry-run      # Show what would be removed
+  forge clean --deep         # Also remove node_modules
+  forge clean --expunge      # Also wipe the Bazel output base`,
 	RunE: runClean,
 }
 
 func init() {
 	cleanCmd.Flags().BoolVar(&cleanCache, "cache", false, "Remove all caches (project-local and Bazel)")
-	cleanCmd.Flags().BoolVar(&cleanDeep, "deep", false, "Remove global caches (requires confirmation)")
+	cleanCmd.Flags().BoolVar(&cleanDeep, "deep", false, "Also remove node_modules")
+	cleanCmd.Flags().BoolVar(&cleanExpunge, "expunge", false, "Run bazel clean --expunge when Bazel is installed")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without removing anything")
 	rootCmd.AddCommand(cleanCmd)
 }
 
 func runClean(cmd *cobra.Command, args []string) error {
-	workspaceRoot, err := findWorkspaceRoot()
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("not in a forge workspace: %w", err)
+		return err
 	}
 
-	if !cleanCache && !cleanDeep {
-		return fmt.Errorf("no cleaning operation specified. Use --cache or --deep")
+	targets, err := findCleanTargets(workspaceRoot, cleanDeep)
+	if err != nil {
+		return err
 	}
 
-	if cleanCache {
-		if err := cleanProjectCaches(workspaceRoot); err != nil {
-			return err
+	if len(targets) == 0 {
+		fmt.Println("✓ No build artifacts to remove")
+	}
+	for _, target := range targets {
+		rel, _ := filepath.Rel(workspaceRoot, target)
+		if cleanDryRun {
+			fmt.Printf("Would remove %s\n", rel)
+			continue
 		}
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", rel, err)
+		}
+		fmt.Printf("🗑️  Removed %s\n", rel)
+	}
 
-		if err := cleanBazelCache(workspaceRoot); err != nil {
+	if cleanExpunge && !cleanCache {
+		if _, err := exec.LookPath("bazel"); err != nil {
+			fmt.Println("⚠️  Bazel is not installed, skipping bazel clean --expunge")
+		} else if cleanDryRun {
+			fmt.Println("Would run bazel clean --expunge")
+		} else if err := cleanBazelCache(cmd.Context(), workspaceRoot); err != nil {
 			return err
 		}
 	}
 
-	if cleanDeep {
-		if err := cleanGlobalCaches(); err != nil {
-			return err
+	if cleanCache {
+		if cleanDryRun {
+			fmt.Println("Would remove project caches and run bazel clean --expunge")
+		} else {
+			if err := cleanProjectCaches(workspaceRoot); err != nil {
+				return err
+			}
+
+			if err := cleanBazelCache(cmd.Context(), workspaceRoot); err != nil {
+				return err
+			}
 		}
 	}
 
+	if cleanDryRun {
+		return nil
+	}
+
 	fmt.Println("✅ Clean completed successfully")
 	return nil
 }
 
-func cleanProjectCaches(workspaceRoot string) error {
-	caches := []string{
-		filepath.Join(workspaceRoot, ".forge", "cache"),
-		filepath.Join(workspaceRoot, "frontend", ".angular", "cache"),
+// cleanOutputDirs are the generated directories removed from the workspace,
+// the frontend and every project root.
+var cleanOutputDirs = []string{"dist", ".angular"}
+
+// findCleanTargets lists the existing build artifacts in the workspace. With
+// deep, node_modules directories are included.
+func findCleanTargets(workspaceRoot string, deep bool) ([]string, error) {
+	var targets []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if seen[path] || !isInsideDir(workspaceRoot, path) {
+			return
+		}
+		if _, err := os.Lstat(path); err != nil {
+			return
+		}
+		seen[path] = true
+		targets = append(targets, path)
 	}
 
-	for _, cache := range caches {
-		if _, err := os.Stat(cache); err == nil {
-			fmt.Printf("🗑️  Removing %s...\n", cache)
-			if err := os.RemoveAll(cache); err != nil {
-				return fmt.Errorf("failed to remove %s: %w", cache, err)
-			}
-			fmt.Printf("   ✓ Removed %s\n", cache)
+	// Bazel output symlinks (bazel-bin, bazel-out, bazel-<workspace>, ...)
+	entries, err := os.ReadDir(workspaceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace root: %w", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "bazel-") && entry.Type()&os.ModeSymlink != 0 {
+			add(filepath.Join(workspaceRoot, entry.Name()))
 		}
 	}
 
-	return nil
-}
-
-func cleanBazelCache(workspaceRoot string) error {
-	fmt.Println("🗑️  Running bazel clean --expunge...")
+	add(filepath.Join(workspaceRoot, ".forge"))
 
-	cmd := exec.Command("bazel", "clean", "--expunge")
-	cmd.Dir = workspaceRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	names := cleanOutputDirs
+	if deep {
+		names = append(append([]string{}, cleanOutputDirs...), "node_modules")
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("bazel clean failed: %w", err)
+	for _, dir := range cleanBaseDirs(workspaceRoot) {
+		for _, name := range names {
+			add(filepath.Join(dir, name))
+		}
 	}
 
-	fmt.Println("   ✓ Bazel cache cleaned")
-	return nil
+	return targets, nil
 }
 
-func cleanGlobalCaches() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
+// cleanBaseDirs returns the workspace root, the frontend directory and the root
+// of every project in forge.json.
+func cleanBaseDirs(workspaceRoot string) []string {
+	dirs := []string{workspaceRoot, filepath.Join(workspaceRoot, "frontend")}
 
-	globalCaches := []string{
-		filepath.Join(homeDir, ".cache", "bazel"),
-		filepath.Join(homeDir, "go", "pkg", "mod", "cache"),
-		filepath.Join(homeDir, ".npm"),
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return dirs
 	}
 
-	// Show what will be deleted
-	fmt.Println("\n⚠️  Deep clean will remove the following global caches:")
-	for _, cache := range globalCaches {
-		if info, err := os.Stat(cache); err == nil && info.IsDir() {
-			fmt.Printf("   - %s\n", cache)
+	roots := make([]string, 0, len(config.Projects))
+	for _, project := range config.Projects {
+		if project.Root != "" {
+			roots = append(roots, project.Root)
 		}
 	}
+	sort.Strings(roots)
+	for _, root := range roots {
+		dirs = append(dirs, filepath.Join(workspaceRoot, root))
+	}
+	return dirs
+}
 
-	// Confirm with user
-	fmt.Print("\nThis will free disk space but may slow down future builds. Continue? (y/N): ")
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+// isInsideDir reports whether path is strictly inside dir.
+func isInsideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		return false
 	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	if response != "y" && response != "yes" {
-		fmt.Println("Cancelled.")
-		return nil
+func cleanProjectCaches(workspaceRoot string) error {
+	caches := []string{
+		filepath.Join(workspaceRoot, ".forge", "cache"),
+		filepath.Join(workspaceRoot, "frontend", ".angular", "cache"),
 	}
 
-	// Remove global caches
-	for _, cache := range globalCaches {
+	for _, cache := range caches {
 		if _, err := os.Stat(cache); err == nil {
 			fmt.Printf("🗑️  Removing %s...\n", cache)
 			if err := os.RemoveAll(cache); err != nil {
-				fmt.Printf("   ⚠️  Failed to remove %s: %v\n", cache, err)
-			} else {
-				fmt.Printf("   ✓ Removed %s\n", cache)
+				return fmt.Errorf("failed to remove %s: %w", cache, err)
 			}
+			fmt.Printf("   ✓ Removed %s\n", cache)
 		}
 	}
 
 	return nil
 }
+
+func cleanBazelCache(ctx context.Context, workspaceRoot string) error {
+	fmt.Println("🗑️  Running bazel clean --expunge...")
+
+	if err := exec.Run(ctx, exec.Options{Name: "bazel", Args: []string{"clean", "--expunge"}, Dir: workspaceRoot}); err != nil {
+		return fmt.Errorf("bazel clean failed: %w", err)
+	}
+
+	fmt.Println("   ✓ Bazel cache cleaned")
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and change forge.json settings",
+	Long: `Read and change forge.json settings by dotted key path.
+
+Keys follow the JSON names of forge.json. Projects and environments are
+addressed by name, e.g. projects.api.root or workspace.environments.prod.registry.
+
+Examples:
+  forge config get workspace.docker.registry
+  forge config set workspace.gcp.projectId my-project
+  forge config set cli.defaultBuildEnvironment development
+  forge config set workspace.build.parallel.workers 4`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a forge.json setting",
+	Long: `Print the value of a forge.json setting. Objects and lists are printed as
+JSON; nothing is printed for a setting that is not set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a forge.json setting",
+	Long: `Change a forge.json setting. The key must exist in forge.json and the value
+must match its type: numbers and booleans must parse, lists take a JSON array
+or comma-separated items, and objects take a JSON object. Projects and
+environments must already exist; a change that leaves forge.json invalid is
+rejected.
+
+forge.json is locked while it is updated and replaced atomically.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	value, err := config.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case string, bool, int, float64:
+		fmt.Println(v)
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", args[0], err)
+		}
+		fmt.Println(string(data))
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config := &workspace.Config{}
+	if err := config.Update(workspaceRoot, func(c *workspace.Config) error {
+		// A workspace that is not valid yet, e.g. without projects, can still
+		// be configured, but a change must not introduce a new problem
+		before := c.Validate()
+		if err := c.Set(key, value); err != nil {
+			return err
+		}
+		if err := c.Validate(); err != nil && (before == nil || err.Error() != before.Error()) {
+			return fmt.Errorf("setting %s would make %s invalid: %w", key, workspace.ConfigFileName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Set %s to %s\n", key, value)
+	return nil
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/daemon"
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonDetach    bool
+	daemonWorkspace string
+	daemonForce     bool
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Manage the Forge daemon",
+	Long: `Manage the Forge daemon, a background process that watches the workspace
+and serves the Forge API over a Unix socket.
+
+The socket, PID file and log of the daemon live in ~/.forge.`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the daemon",
+	Long: `Start the daemon for the current workspace.
+
+The daemon runs in the foreground until interrupted. Use --detach to run it
+in the background, with its output written to ~/.forge/daemon.log.
+
+Examples:
+  forge daemon start            # Run in the foreground
+  forge daemon start --detach   # Run in the background`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonStart,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the daemon",
+	Long: `Ask the running daemon to stop gracefully, waiting for in-flight requests.
+Use --force to stop it without waiting.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonStop,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the daemon status",
+	Long: `Show whether the daemon is running, with its version, uptime, workspace
+and active watchers. A socket left behind by a daemon that died is removed.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonStatus,
+}
+
+func init() {
+	daemonStartCmd.Flags().BoolVarP(&daemonDetach, "detach", "d", false, "Run the daemon in the background")
+	daemonStartCmd.Flags().StringVar(&daemonWorkspace, "workspace", "", "Workspace directory to serve (default: current workspace)")
+	daemonStopCmd.Flags().BoolVar(&daemonForce, "force", false, "Stop without waiting for in-flight requests")
+
+	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
+
+func runDaemonStart(cmd *cobra.Command, args []string) error {
+	config := daemon.DefaultConfig()
+	config.Version = rootCmd.Version
+
+	workspaceDir, err := daemonWorkspaceDir()
+	if err != nil {
+		return err
+	}
+	config.WorkspaceDir = workspaceDir
+
+	if status, err := daemon.QueryStatus(cmd.Context(), config.SocketPath); err == nil {
+		return fmt.Errorf("daemon is already running for %s (pid %d)", status.WorkspaceDir, daemon.ReadPID(config.PIDPath))
+	} else if !errors.Is(err, daemon.ErrNotRunning) {
+		return err
+	}
+	if err := daemon.Cleanup(config); err != nil {
+		return err
+	}
+
+	if daemonDetach {
+		return startDetachedDaemon(cmd.Context(), config)
+	}
+
+	ctx := cmd.Context()
+
+	d := daemon.New(config)
+	if err := d.Start(ctx); err != nil {
+		d.Stop()
+		return fmt.Errorf("failed to start daemon: %w", err)
+	}
+
+	fmt.Printf("🚀 Daemon started for %s\n", config.WorkspaceDir)
+	fmt.Printf("   Socket: %s\n", config.SocketPath)
+	fmt.Println("   Press Ctrl+C to stop")
+
+	select {
+	case <-ctx.Done():
+	case <-d.Done():
+	}
+	// After a Shutdown request this waits for the stop in progress to finish
+	// removing the socket and PID files
+	if err := d.Stop(); err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Daemon stopped")
+	return nil
+}
+
+// startDetachedDaemon starts forge daemon start in the background and waits
+// until the daemon answers on its socket.
+func startDetachedDaemon(ctx context.Context, config *daemon.Config) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate forge executable: %w", err)
+	}
+
+	logPath := filepath.Join(filepath.Dir(config.SocketPath), "daemon.log")
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(logPath), err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open daemon log: %w", err)
+	}
+	defer logFile.Close()
+
+	pid, err := exec.Start(ctx, exec.Options{
+		Name:        executable,
+		Args:        []string{"daemon", "start", "--workspace", config.WorkspaceDir},
+		Stdout:      logFile,
+		Stderr:      logFile,
+		SysProcAttr: daemon.DetachedProcAttr(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start daemon: %w", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, err := daemon.QueryStatus(ctx, config.SocketPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("daemon did not start within 10s, see %s", logPath)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	fmt.Printf("🚀 Daemon started in the background (pid %d)\n", pid)
+	fmt.Printf("   Workspace: %s\n", config.WorkspaceDir)
+	fmt.Printf("   Log: %s\n", logPath)
+	return nil
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	config := daemon.DefaultConfig()
+
+	err := daemon.RequestShutdown(cmd.Context(), config.SocketPath, daemonForce)
+	if errors.Is(err, daemon.ErrNotRunning) {
+		if err := daemon.Cleanup(config); err != nil {
+			return err
+		}
+		fmt.Println("Daemon is not running")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The daemon removes its socket once it has stopped
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, err := os.Stat(config.SocketPath); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("daemon did not stop within 10s, use --force")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	fmt.Println("✓ Daemon stopped")
+	return nil
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	config := daemon.DefaultConfig()
+
+	status, err := daemon.QueryStatus(cmd.Context(), config.SocketPath)
+	if errors.Is(err, daemon.ErrNotRunning) {
+		_, socketErr := os.Stat(config.SocketPath)
+		if err := daemon.Cleanup(config); err != nil {
+			return err
+		}
+		fmt.Println("Daemon is not running")
+		if socketErr == nil {
+			fmt.Printf("🧹 Removed stale socket %s\n", config.SocketPath)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Daemon is running")
+	if pid := daemon.ReadPID(config.PIDPath); pid != 0 {
+		fmt.Printf("  PID:       %d\n", pid)
+	}
+	fmt.Printf("  Version:   %s\n", status.Version)
+	fmt.Printf("  Uptime:    %s\n", time.Duration(status.UptimeSeconds)*time.Second)
+	fmt.Printf("  Workspace: %s\n", status.WorkspaceDir)
+	fmt.Printf("  Watchers:  %d\n", status.ActiveWatchers)
+	fmt.Printf("  Socket:    %s\n", config.SocketPath)
+	return nil
+}
+
+// daemonWorkspaceDir returns the absolute workspace directory the daemon
+// serves: --workspace, else the current workspace, else the current directory.
+func daemonWorkspaceDir() (string, error) {
+	dir := daemonWorkspace
+	if dir == "" {
+		root, err := workspace.FindRoot()
+		if err != nil {
+			root = "."
+		}
+		dir = root
+	}
+	return filepath.Abs(dir)
+}
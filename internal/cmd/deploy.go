@@ -3,24 +3,41 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dosanma1/forge-cli/internal/builder"
 	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/skaffold"
+	"github.com/dosanma1/forge-cli/internal/ui"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	deployEnv       string
-	deployVerbose   bool
-	deployDebug     bool
-	deployTail      bool
-	deploySkipBuild bool
-	deployPlatform  string
+	deployEnv         string
+	deployVerbose     bool
+	deployDebug       bool
+	deployTail        bool
+	deploySkipBuild   bool
+	deployNoCache     bool
+	deployPlatform    string
+	deployDiff        bool
+	deployYes         bool
+	deployCanary      int
+	deployCanaryWait  time.Duration
+	deployNoOrder     bool
+	deployDryRun      bool
+	deployTimeout     time.Duration
+	deployWait        bool
+	deployTags        []string
+	deploySecretsFrom string
+	deployRollback    bool
+	deployInteractive bool
+	deployLenient     bool
 )
 
 var deployCmd = &cobra.Command{
@@ -34,31 +51,105 @@ becomes a Skaffold profile with environment-specific settings.
 
 Examples:
   forge deploy                           # Deploy all services using default config
+  forge deploy -i                        # Pick the services to deploy from a list
   forge deploy --env=production          # Deploy all to production
   forge deploy api-server --env=local    # Deploy specific service locally
+  forge deploy 'api-*' --env=production  # Deploy every project matching a glob
+  forge deploy --tag=backend             # Deploy every project tagged backend
   forge deploy --skip-build              # Deploy without rebuilding images
-  forge deploy --tail                    # Stream logs after deployment`,
+  forge deploy --tail                    # Stream logs after deployment
+  forge deploy --diff                    # Show changes against the live state before applying
+  forge deploy --diff --yes              # Show changes and apply without prompting
+  forge deploy api --env=prod --canary=10  # Send 10% of traffic to the new version, then confirm promotion
+  forge deploy api --env=prod --canary=10 --canary-wait=15m  # Promote automatically after 15 minutes
+  forge deploy --env=production --dry-run  # Print what would be deployed without applying it
+  forge deploy --secrets-from=.env.prod    # Apply secrets from a dotenv file (helm)
+  forge deploy --secrets-from=gsm://my-project  # Use Secret Manager secrets (helm, cloudrun)
+  forge deploy api --env=prod --rollback   # Return to the previous release (helm, cloudrun)
+  forge deploy --env=prod --wait --timeout=5m  # Fail unless the rollout completes within 5 minutes
+
+Projects are deployed after the projects listed in their metadata.dependsOn,
+and Skaffold artifacts and releases follow the same order. A dependency cycle
+fails the deploy. Use --no-order to deploy in the order given on the command
+line instead, or alphabetically when no projects are given.
+
+Without project arguments or --tag, forge deploy lets you pick the projects
+from a list when stdin is a terminal (or with --interactive); picking none
+deploys them all.
+
+--env must name a configuration of a project or an environment of
+workspace.environments; anything else, like a mistyped name, is rejected with
+the valid names, even with --lenient. Shell completion offers them.
+
+Every deployed project must define the configuration in both its build and
+deploy targets, or the deploy fails naming the projects that do not; with
+--lenient they are deployed with their base options and a warning.
+
+Helm and Cloud Run projects with a healthPath deploy option are polled after
+the deploy (through kubectl port-forward or the Cloud Run URL) until the path
+answers 200. The deploy fails with the last response when --timeout expires;
+--timeout=0 skips the health checks.
+
+With --wait the deploy only succeeds once the rollout has completed within
+--timeout: Helm releases are installed with --wait, Skaffold's status check
+waits for Kubernetes deployments to be Available, and Cloud Run services are
+polled until their latest revision is Ready. A failed or stuck rollout fails
+the command, so it can gate CI. Other deployers do not wait.
+
+The secrets deploy option lists the environment variables a project reads from
+secrets, either as names or as an object mapping names to keys in the source.
+With --secrets-from, Helm projects get a "<project>-secrets" Kubernetes Secret,
+created or updated before the deploy and loaded through envFrom, and Cloud Run
+services get their Secret Manager references mapped into their environment.
+Secret values are never logged nor written to forge.json.`,
 	RunE: runDeploy,
 }
 
 func init() {
 	rootCmd.AddCommand(deployCmd)
-	deployCmd.Flags().StringVarP(&deployEnv, "env", "e", "", "Environment/profile to deploy (local, development, production)")
+	deployCmd.Flags().StringVarP(&deployEnv, "env", "e", "", "Environment/profile to deploy (local, development, production); defaults to 'forge env use'")
 	deployCmd.Flags().BoolVarP(&deployVerbose, "verbose", "v", false, "Show verbose output")
 	deployCmd.Flags().BoolVarP(&deployDebug, "debug", "d", false, "Show debug output including generated Skaffold config")
 	deployCmd.Flags().BoolVarP(&deployTail, "tail", "t", false, "Stream logs after deployment")
 	deployCmd.Flags().BoolVar(&deploySkipBuild, "skip-build", false, "Skip build phase")
+	deployCmd.Flags().BoolVar(&deployNoCache, "no-cache", false, "Rebuild projects even if their sources and options are unchanged since their last build")
 	deployCmd.Flags().StringVar(&deployPlatform, "platform", "", "Target platform for builds (empty = native platform)")
+	deployCmd.Flags().BoolVar(&deployDiff, "diff", false, "Show what changed since the last deploy before applying")
+	deployCmd.Flags().BoolVarP(&deployYes, "yes", "y", false, "Apply without confirmation when using --diff")
+	deployCmd.Flags().IntVar(&deployCanary, "canary", 0, "Route this percentage of traffic to the new version (helm, cloudrun)")
+	deployCmd.Flags().DurationVar(&deployCanaryWait, "canary-wait", 0, "Promote the canary to 100% after this long instead of asking for confirmation")
+	deployCmd.Flags().BoolVar(&deployNoOrder, "no-order", false, "Ignore metadata.dependsOn and deploy projects in the order given (alphabetical by default)")
+	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Render manifests and run deployer dry runs without building, pushing or applying anything")
+	deployCmd.Flags().StringSliceVar(&deployTags, "tag", nil, "Only deploy projects with this tag (repeatable; projects must have every tag)")
+	deployCmd.Flags().StringVar(&deploySecretsFrom, "secrets-from", "", "Read the secrets deploy option from a dotenv file or gsm://[project] (Secret Manager)")
+	deployCmd.Flags().BoolVar(&deployRollback, "rollback", false, "Return the selected projects to their previous release instead of deploying (see 'forge rollback')")
+	deployCmd.Flags().DurationVar(&deployTimeout, "timeout", 2*time.Minute, "How long to wait for rollouts with --wait and for deployed projects to answer their healthPath (0 = skip health checks)")
+	deployCmd.Flags().BoolVar(&deployWait, "wait", false, "Wait for the rollout to complete and fail if it does not within --timeout (helm, cloudrun)")
+	deployCmd.Flags().BoolVarP(&deployInteractive, "interactive", "i", false, "Pick the projects to deploy from a list when none is given (default when stdin is a terminal)")
+	deployCmd.Flags().BoolVar(&deployLenient, "lenient", false, "Warn instead of failing when a project's build or deploy target lacks the configuration")
+	_ = deployCmd.RegisterFlagCompletionFunc("env", completeEnvironments)
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
-	fmt.Println("🚀 Using Skaffold-first deployment architecture")
-	ctx := context.Background()
+	log.Info("🚀 Using Skaffold-first deployment architecture")
+	ctx := cmd.Context()
 
-	// Get workspace root
-	workspaceRoot, err := os.Getwd()
+	if deployDryRun && (deployDiff || deployCanary != 0 || deployWait) {
+		return fmt.Errorf("--dry-run cannot be combined with --diff, --canary or --wait")
+	}
+	if deployWait && deployTimeout <= 0 {
+		return fmt.Errorf("--wait requires a positive --timeout")
+	}
+	if deployCanaryWait != 0 && deployCanary == 0 {
+		return fmt.Errorf("--canary-wait requires --canary")
+	}
+	if deployRollback && (deployDryRun || deployDiff || deployCanary != 0) {
+		return fmt.Errorf("--rollback cannot be combined with --dry-run, --diff or --canary")
+	}
+
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	// Load forge.json (with validation)
@@ -67,20 +158,35 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load forge.json: %w", err)
 	}
 
+	// A mistyped --env would otherwise deploy with no matching configuration
+	if deployEnv != "" {
+		if err := validateEnvironment(config, deployEnv); err != nil {
+			return err
+		}
+	}
+
+	// Only services/applications with deploy configuration are deployed by default
+	deployable := func(project workspace.Project) bool {
+		return project.ProjectType != "library" && project.Architect != nil && project.Architect.Deploy != nil
+	}
+
 	// Determine which projects to deploy
-	projectNames := args
+	projectNames, err := expandProjectPatterns(config, args, deployable)
+	if err != nil {
+		return err
+	}
 	if len(projectNames) == 0 {
-		// Deploy all deployable projects (skip libraries)
 		for name, project := range config.Projects {
-			// Skip libraries - only deploy services/applications
-			if project.ProjectType == "library" {
-				continue
-			}
-			// Only include projects with deploy configuration
-			if project.Architect != nil && project.Architect.Deploy != nil {
+			if deployable(project) {
 				projectNames = append(projectNames, name)
 			}
 		}
+		sort.Strings(projectNames)
+		if len(deployTags) == 0 {
+			if projectNames, err = promptProjects("deploy", projectNames, deployInteractive); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Validate that all specified projects exist and are deployable
@@ -93,16 +199,49 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("project %q is a library and cannot be deployed", projectName)
 		}
 	}
+	projectNames, err = filterProjectsByTags(config, projectNames, deployTags)
+	if err != nil {
+		return err
+	}
+
+	// Deploy dependencies before the projects that need them
+	if !deployNoOrder {
+		projectNames, err = config.SortByDependencies(projectNames)
+		if err != nil {
+			return fmt.Errorf("failed to order projects: %w", err)
+		}
+		log.Debug("ℹ️  Deploy order: %s", strings.Join(projectNames, ", "))
+	}
 
 	// Determine configuration/environment
-	deployConfig := deployEnv
-	if deployConfig == "" {
-		deployConfig = "production"
-		if deployVerbose {
-			fmt.Printf("ℹ️  Using default configuration: %s\n", deployConfig)
+	deployConfig := config.DeployConfiguration(deployEnv)
+	if deployEnv == "" {
+		log.Debug("ℹ️  Using default configuration: %s", deployConfig)
+	}
+
+	// Projects missing the configuration would silently deploy with their base options
+	if mismatches := config.ConfigurationMismatches(projectNames, deployConfig); len(mismatches) > 0 {
+		if !deployLenient {
+			for _, mismatch := range mismatches {
+				log.Error("✗ %s", mismatch)
+			}
+			return fmt.Errorf("%d project%s lack a matching build and deploy configuration %q (use --lenient to deploy with their base options)",
+				len(mismatches), pluralize(len(mismatches), "", "s"), deployConfig)
+		}
+		for _, mismatch := range mismatches {
+			log.Warn("⚠️  %s; using its base options", mismatch)
 		}
 	}
 
+	// Roll dependents back before the projects they depend on
+	if deployRollback {
+		rollback := make([]string, 0, len(projectNames))
+		for i := len(projectNames) - 1; i >= 0; i-- {
+			rollback = append(rollback, projectNames[i])
+		}
+		return rollbackProjects(ctx, config, rollback, deployConfig, "")
+	}
+
 	// Partition projects into Skaffold-compatible vs direct deployment
 	skaffoldProjects := []string{}
 	directProjects := []string{}
@@ -125,20 +264,76 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Deploy Skaffold-compatible projects first (batch orchestration)
-	if len(skaffoldProjects) > 0 {
-		if deployVerbose {
-			fmt.Printf("🔧 Deploying with Skaffold orchestration: %s\n", strings.Join(skaffoldProjects, ", "))
-		}
+	// Skaffold projects are deployed as one batch before the direct ones
+	if !deployNoOrder {
+		warnCrossDeployerDependencies(config, skaffoldProjects, directProjects)
+	}
 
-		// Generate Skaffold configuration
+	// Generate Skaffold configuration once so the diff and the deploy use the same manifests
+	var executor *skaffold.Executor
+	if len(skaffoldProjects) > 0 {
 		skaffoldConfig, err := skaffold.GenerateConfig(config, skaffoldProjects, workspaceRoot, deployPlatform)
 		if err != nil {
 			return fmt.Errorf("failed to generate Skaffold config: %w", err)
 		}
+		executor = skaffold.NewExecutor(skaffoldConfig, workspaceRoot)
+
+		if !deployDryRun && skaffoldBuildsWithBazel(config, skaffoldProjects) {
+			if err := ensureBazel(ctx); err != nil {
+				return err
+			}
+		}
+	}
 
-		// Create Skaffold executor
-		executor := skaffold.NewExecutor(skaffoldConfig, workspaceRoot)
+	if deployWait {
+		if executor != nil {
+			executor.WaitForRollout(deployTimeout)
+		}
+		if len(directProjects) > 0 {
+			log.Warn("⚠️  --wait is not supported for %s; they will not be waited for", strings.Join(directProjects, ", "))
+		}
+	}
+
+	if deployDryRun {
+		return runDeployDryRun(ctx, config, workspaceRoot, executor, deployConfig, directProjects)
+	}
+
+	var secrets *deploySecrets
+	if deploySecretsFrom != "" {
+		if executor == nil {
+			return fmt.Errorf("--secrets-from is only supported for helm and cloudrun projects")
+		}
+		secrets, err = prepareDeploySecrets(ctx, config, skaffoldProjects, deployConfig, executor, deploySecretsFrom)
+		if err != nil {
+			return err
+		}
+	}
+
+	var canary *canaryRollout
+	if deployCanary != 0 {
+		if len(directProjects) > 0 {
+			return fmt.Errorf("--canary is not supported for %s", strings.Join(directProjects, ", "))
+		}
+		canary, err = prepareCanary(ctx, workspaceRoot, config, skaffoldProjects, deployConfig, executor, deployCanary)
+		if err != nil {
+			return err
+		}
+		defer canary.cleanup()
+	}
+
+	if deployDiff {
+		proceed, err := showDeployDiff(ctx, executor, deployConfig, directProjects)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+	}
+
+	// Deploy Skaffold-compatible projects first (batch orchestration)
+	if len(skaffoldProjects) > 0 {
+		log.Debug("🔧 Deploying with Skaffold orchestration: %s", strings.Join(skaffoldProjects, ", "))
 
 		// Deploy using Skaffold (builds + deploys)
 		deployOpts := skaffold.DeployOptions{
@@ -149,23 +344,48 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			Tail:      deployTail,
 		}
 
+		if secrets != nil {
+			if err := secrets.applyHelm(ctx); err != nil {
+				return err
+			}
+		}
+
 		if err := executor.Deploy(ctx, deployOpts); err != nil {
 			return fmt.Errorf("❌ Skaffold deploy failed: %w", err)
 		}
+
+		if secrets != nil {
+			if err := secrets.applyCloudRun(ctx); err != nil {
+				return err
+			}
+		}
+
+		if deployWait {
+			if err := waitForCloudRunRollouts(ctx, config, skaffoldProjects, deployConfig); err != nil {
+				return err
+			}
+		}
+
+		if canary != nil {
+			if err := canary.start(ctx); err != nil {
+				return err
+			}
+			if err := canary.finish(ctx, workspaceRoot, config, deployCanaryWait); err != nil {
+				return err
+			}
+		} else if err := waitForHealthChecks(ctx, config, skaffoldProjects, deployConfig); err != nil {
+			return err
+		}
 	}
 
 	// Deploy direct projects sequentially (build then deploy each)
 	if len(directProjects) > 0 {
-		if deployVerbose {
-			fmt.Printf("🔧 Deploying with direct deployers: %s\n", strings.Join(directProjects, ", "))
-		}
+		log.Debug("🔧 Deploying with direct deployers: %s", strings.Join(directProjects, ", "))
 
 		for _, projectName := range directProjects {
 			project := config.Projects[projectName]
 
-			if deployVerbose {
-				fmt.Printf("\n📦 Deploying %s (configuration: %s)\n", projectName, deployConfig)
-			}
+			log.Debug("\n📦 Deploying %s (configuration: %s)", projectName, deployConfig)
 
 			// Step 1: Build the project (unless skip-build is set)
 			var artifact *builder.BuildArtifact
@@ -182,13 +402,11 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 				// Get build options and configuration options
 				buildOpts := project.Architect.Build.Options
-				var configOpts map[string]interface{}
-				if project.Architect.Build.Configurations != nil {
-					if cfg, ok := project.Architect.Build.Configurations[deployConfig]; ok {
-						if typedCfg, ok := cfg.(map[string]interface{}); ok {
-							configOpts = typedCfg
-						}
-					}
+				configOpts := project.Architect.Build.ConfigurationOptions(deployConfig)
+
+				imageTag, err := resolveImageTag(ctx, workspaceRoot, project.Architect.Build, deployConfig, time.Now())
+				if err != nil {
+					return fmt.Errorf("failed to tag %s: %w", projectName, err)
 				}
 
 				// Build the project
@@ -200,19 +418,26 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 					Verbose:              deployVerbose,
 					Platform:             deployPlatform,
 					WorkspaceRoot:        workspaceRoot,
+					Jobs:                 config.ParallelJobs(0),
+					ImageTag:             imageTag,
 				}
 
-				if deployVerbose {
-					fmt.Printf("🔨 Building %s with %s\n", projectName, builderName)
-				}
+				log.Debug("🔨 Building %s with %s", projectName, builderName)
 
-				artifact, err = projectBuilder.Build(ctx, opts)
+				var cache *builder.BuildCache
+				if !deployNoCache {
+					cache = builder.NewBuildCache(workspaceRoot)
+				}
+				var cached bool
+				artifact, cached, err = buildWithCache(ctx, cache, config, workspaceRoot, projectName, projectBuilder, opts)
 				if err != nil {
 					return fmt.Errorf("❌ Build failed for %s: %w", projectName, err)
 				}
 
-				if deployVerbose {
-					fmt.Printf("✅ Built %s: %s\n", projectName, artifact.Type)
+				if cached {
+					log.Debug("✅ %s is up to date, reusing its last build", projectName)
+				} else {
+					log.Debug("✅ Built %s: %s", projectName, artifact.Type)
 				}
 			}
 
@@ -223,25 +448,11 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to get deployer for %s: %w", projectName, err)
 			}
 
-			// Get deployment options
-			deployOpts := project.Architect.Deploy.Options
-			if deployOpts == nil {
-				deployOpts = make(map[string]interface{})
-			}
-
-			// Merge configuration-specific options
-			if project.Architect.Deploy.Configurations != nil {
-				if cfg, ok := project.Architect.Deploy.Configurations[deployConfig].(map[string]interface{}); ok {
-					for k, v := range cfg {
-						deployOpts[k] = v
-					}
-				}
-			}
+			// Get deployment options, with configuration-specific overrides
+			deployOpts := project.Architect.Deploy.ResolveOptions(deployConfig)
 
 			// Deploy
-			if deployVerbose {
-				fmt.Printf("🚀 Deploying %s with %s\n", projectName, deployerName)
-			}
+			log.Debug("🚀 Deploying %s with %s", projectName, deployerName)
 
 			deployOptions := &deployer.DeployOptions{
 				Project:       projectName,
@@ -258,12 +469,188 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("❌ Deploy failed for %s: %w", projectName, err)
 			}
 
-			if deployVerbose {
-				fmt.Printf("✅ Deployed %s successfully\n", projectName)
+			if err := waitForHealthChecks(ctx, config, []string{projectName}, deployConfig); err != nil {
+				return err
 			}
+
+			log.Debug("✅ Deployed %s successfully", projectName)
+		}
+	}
+
+	log.Info("\n✅ All deployments completed successfully!")
+	return nil
+}
+
+// runDeployDryRun prints what a deploy would apply without building, pushing
+// or changing anything: the manifests Skaffold renders for its projects and the
+// dry run of each direct deployer.
+func runDeployDryRun(ctx context.Context, config *workspace.Config, workspaceRoot string, executor *skaffold.Executor, deployConfig string, directProjects []string) error {
+	if executor != nil {
+		log.Info("📄 Rendering Skaffold manifests (profile: %s)", deployConfig)
+		manifests, err := executor.Render(ctx, skaffold.RenderOptions{Profile: deployConfig, Verbose: deployVerbose})
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(manifests))
+	}
+
+	for _, projectName := range directProjects {
+		project := config.Projects[projectName]
+		deployerName := project.Architect.Deploy.Deployer
+
+		projectDeployer, err := deployer.GetDeployer(deployerName)
+		if err != nil {
+			return fmt.Errorf("failed to get deployer for %s: %w", projectName, err)
 		}
+
+		log.Info("\n📄 Dry run of %s with %s", projectName, deployerName)
+		if err := projectDeployer.Deploy(ctx, &deployer.DeployOptions{
+			Project:       projectName,
+			Builder:       project.Architect.Build.Builder,
+			Configuration: deployConfig,
+			Options:       project.Architect.Deploy.ResolveOptions(deployConfig),
+			Verbose:       deployVerbose,
+			WorkspaceRoot: workspaceRoot,
+			ProjectRoot:   filepath.Join(workspaceRoot, project.Root),
+			DryRun:        true,
+		}); err != nil {
+			return fmt.Errorf("❌ Dry run failed for %s: %w", projectName, err)
+		}
+	}
+
+	log.Info("\n✅ Dry run complete, nothing was deployed")
+	return nil
+}
+
+// waitForHealthChecks waits for the deployed projects that have a healthPath
+// to answer 200, failing on the first one that does not within --timeout.
+func waitForHealthChecks(ctx context.Context, config *workspace.Config, projectNames []string, deployConfig string) error {
+	if deployTimeout <= 0 {
+		return nil
+	}
+
+	for _, projectName := range projectNames {
+		check := deployer.ResolveHealthCheck(config, projectName, deployConfig)
+		if check == nil {
+			continue
+		}
+
+		log.Info("🩺 Waiting for %s to answer %s (timeout: %s)", projectName, check.Path, deployTimeout)
+		if err := deployer.WaitHealthy(ctx, check, deployTimeout); err != nil {
+			return fmt.Errorf("❌ Health check failed: %w", err)
+		}
+		log.Debug("✅ %s is healthy", projectName)
 	}
 
-	fmt.Printf("\n✅ All deployments completed successfully!\n")
 	return nil
 }
+
+// waitForCloudRunRollouts waits for the latest revision of each deployed Cloud
+// Run service to be Ready, failing on the first one that is not within --timeout.
+func waitForCloudRunRollouts(ctx context.Context, config *workspace.Config, projectNames []string, deployConfig string) error {
+	for _, projectName := range projectNames {
+		if config.Projects[projectName].Architect.Deploy.Deployer != "@forge/cloudrun:deploy" {
+			continue
+		}
+		target, err := deployer.ResolveRolloutTarget(config, projectName, deployConfig)
+		if err != nil {
+			return err
+		}
+
+		log.Info("⏳ Waiting for %s to roll out (timeout: %s)", projectName, deployTimeout)
+		if err := deployer.WaitCloudRunRollout(ctx, target, deployTimeout); err != nil {
+			return fmt.Errorf("❌ Rollout failed: %w", err)
+		}
+		log.Debug("✅ %s rolled out", projectName)
+	}
+
+	return nil
+}
+
+// warnCrossDeployerDependencies warns about Skaffold projects depending on a
+// project deployed directly, since the Skaffold batch always runs first.
+func warnCrossDeployerDependencies(config *workspace.Config, skaffoldProjects, directProjects []string) {
+	direct := make(map[string]bool, len(directProjects))
+	for _, name := range directProjects {
+		direct[name] = true
+	}
+
+	for _, name := range skaffoldProjects {
+		project := config.Projects[name]
+		dependsOn, _ := project.DependsOn()
+		for _, dep := range dependsOn {
+			if direct[dep] {
+				log.Warn("⚠️  %s depends on %s, but Skaffold projects are deployed before direct deployments", name, dep)
+			}
+		}
+	}
+}
+
+// showDeployDiff prints the differences between the rendered manifests and the live
+// state, then asks for confirmation unless --yes was given. It returns whether the
+// deploy should proceed.
+func showDeployDiff(ctx context.Context, executor *skaffold.Executor, profile string, directProjects []string) (bool, error) {
+	log.Info("🔍 Comparing with live state...")
+
+	hasChanges := false
+	if executor != nil {
+		result, err := executor.Diff(ctx, skaffold.DiffOptions{
+			Profile: profile,
+			Verbose: deployVerbose,
+		})
+		if err != nil {
+			return false, fmt.Errorf("❌ Diff failed: %w", err)
+		}
+
+		if result.Kubernetes != "" {
+			fmt.Println(result.Kubernetes)
+		}
+
+		services := make([]string, 0, len(result.CloudRun))
+		for name := range result.CloudRun {
+			services = append(services, name)
+		}
+		sort.Strings(services)
+		for _, name := range services {
+			fmt.Println(result.CloudRun[name])
+		}
+
+		hasChanges = result.HasChanges()
+	}
+
+	if len(directProjects) > 0 {
+		log.Warn("⚠️  Diff is not supported for %s; they will be deployed as-is", strings.Join(directProjects, ", "))
+	}
+
+	if !hasChanges && len(directProjects) == 0 {
+		log.Info("✅ No changes since last deploy")
+		return false, nil
+	}
+
+	if deployYes {
+		return true, nil
+	}
+
+	apply, err := ui.WithFlag("--yes").AskConfirm("Apply these changes?", false)
+	if err != nil {
+		return false, err
+	}
+	if !apply {
+		log.Info("❌ Deploy cancelled")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// skaffoldBuildsWithBazel reports whether Skaffold builds the image of any of
+// the projects with bazel.
+func skaffoldBuildsWithBazel(config *workspace.Config, projects []string) bool {
+	for _, projectName := range projects {
+		project := config.Projects[projectName]
+		if project.Architect != nil && project.Architect.Build != nil && project.Architect.Build.Builder == "@forge/bazel:build" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// deploySecrets holds the secrets resolved for a deploy. Helm secrets are
+// applied as Kubernetes Secrets before Skaffold deploys, Cloud Run secrets are
+// mapped into the services' environment afterwards.
+type deploySecrets struct {
+	helm     []helmSecret
+	cloudRun []cloudRunSecrets
+}
+
+type helmSecret struct {
+	project   string
+	name      string
+	namespace string
+	values    map[string]string
+}
+
+type cloudRunSecrets struct {
+	target *deployer.CanaryTarget
+	refs   string
+}
+
+// prepareDeploySecrets resolves the secrets deploy option of every Skaffold
+// project from source and points their Helm releases at the Secret holding
+// them. Nothing is changed in the cluster or in Cloud Run yet.
+func prepareDeploySecrets(ctx context.Context, config *workspace.Config, projects []string, env string, executor *skaffold.Executor, spec string) (*deploySecrets, error) {
+	source, err := deployer.ParseSecretSource(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := &deploySecrets{}
+	for _, projectName := range projects {
+		deploy := config.Projects[projectName].Architect.Deploy
+		options := deploy.ResolveOptions(env)
+
+		projectSecrets, err := deployer.ProjectSecrets(options)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secrets for %s: %w", projectName, err)
+		}
+		if len(projectSecrets) == 0 {
+			continue
+		}
+
+		switch deploy.Deployer {
+		case "@forge/helm:deploy":
+			values, err := source.Values(ctx, projectSecrets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve secrets for %s: %w", projectName, err)
+			}
+			name := deployer.SecretName(projectName)
+			namespace := "default"
+			if ns, ok := options["namespace"].(string); ok && ns != "" {
+				namespace = ns
+			}
+			secrets.helm = append(secrets.helm, helmSecret{
+				project:   projectName,
+				name:      name,
+				namespace: namespace,
+				values:    values,
+			})
+			executor.ReferenceSecret(deployer.HelmReleaseNames(projectName, options), name)
+
+		case "@forge/cloudrun:deploy":
+			refs, err := source.CloudRunSecrets(projectSecrets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map secrets for %s: %w", projectName, err)
+			}
+			target, err := deployer.ResolveCanaryTarget(config, projectName, env)
+			if err != nil {
+				return nil, err
+			}
+			secrets.cloudRun = append(secrets.cloudRun, cloudRunSecrets{target: target, refs: refs})
+
+		default:
+			log.Warn("⚠️  %s uses %s, which does not support secrets; skipping them", projectName, deploy.Deployer)
+			continue
+		}
+
+		log.Debug("🔐 %s: %s from %s", projectName, strings.Join(sortedKeys(projectSecrets), ", "), source)
+	}
+
+	return secrets, nil
+}
+
+// applyHelm creates or updates the Kubernetes Secrets of the Helm projects.
+func (s *deploySecrets) applyHelm(ctx context.Context) error {
+	for _, secret := range s.helm {
+		if err := deployer.ApplyKubernetesSecret(ctx, secret.name, secret.namespace, secret.values); err != nil {
+			return fmt.Errorf("failed to apply secrets for %s: %w", secret.project, err)
+		}
+		log.Info("🔐 Applied %d secret(s) to %s/%s", len(secret.values), secret.namespace, secret.name)
+	}
+	return nil
+}
+
+// applyCloudRun maps the Secret Manager references into the environment of
+// the deployed Cloud Run services.
+func (s *deploySecrets) applyCloudRun(ctx context.Context) error {
+	for _, secret := range s.cloudRun {
+		if err := deployer.UpdateCloudRunSecrets(ctx, secret.target, secret.refs); err != nil {
+			return err
+		}
+		log.Info("🔐 Mapped secrets into cloud run service %s", secret.target.Service)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
+	"github.com/dosanma1/forge-cli/internal/ui"
+)
+
+// exitError is a command exiting with a code, as a fake runner reports it.
+type exitError int
+
+func (e exitError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitError) ExitCode() int { return int(e) }
+
+func TestShowDeployDiff(t *testing.T) {
+	const manifest = "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n"
+	const diff = "-  replicas: 1\n+  replicas: 3\n"
+
+	var commands []string
+	var diffInput string
+	restore := exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		commands = append(commands, opts.Name+" "+opts.Args[0])
+		switch {
+		case opts.Name == "skaffold" && opts.Args[0] == "render":
+			fmt.Fprint(opts.Stdout, manifest)
+			return nil
+		case opts.Name == "kubectl" && opts.Args[0] == "diff":
+			input, _ := io.ReadAll(opts.Stdin)
+			diffInput = string(input)
+			fmt.Fprint(opts.Stdout, diff)
+			return exitError(1) // kubectl diff exits 1 when there are differences
+		}
+		return errors.New("unexpected command")
+	}))
+	defer restore()
+
+	ui.SetNonInteractive(true)
+	defer ui.SetNonInteractive(false)
+
+	executor := skaffold.NewExecutor(&latest.SkaffoldConfig{}, t.TempDir())
+	proceed, err := showDeployDiff(context.Background(), executor, "production", nil)
+
+	// Without --yes the changes must be confirmed, which fails without a terminal
+	var notInteractive *ui.NotInteractiveError
+	if !errors.As(err, &notInteractive) || notInteractive.Flag != "--yes" {
+		t.Errorf("showDeployDiff() error = %v, want a prompt for --yes", err)
+	}
+	if proceed {
+		t.Error("showDeployDiff() proceeds without confirmation")
+	}
+
+	want := []string{"skaffold render", "kubectl diff"}
+	if strings.Join(commands, ",") != strings.Join(want, ",") {
+		t.Errorf("commands = %v, want %v and nothing applied", commands, want)
+	}
+	if !strings.Contains(diffInput, "kind: Deployment") || !strings.Contains(diffInput, "name: api") {
+		t.Errorf("kubectl diff input = %q, want the rendered manifests", diffInput)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List environments and show the default one",
+	Long: `List the environments (build and deploy configurations) defined by the
+projects in forge.json and show the default environment.
+
+forge build and forge deploy use the default environment when --env is omitted.
+It is set with 'forge env use' and stored in forge.json under
+cli.defaultBuildEnvironment, falling back to workspace.defaults.buildEnvironment.
+
+Examples:
+  forge env                 # List environments
+  forge env use development # Build and deploy development by default`,
+	Args: cobra.NoArgs,
+	RunE: runEnv,
+}
+
+var envUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default build environment",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEnvUse,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+	envCmd.AddCommand(envUseCmd)
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	environments := config.Environments()
+	if len(environments) == 0 {
+		fmt.Println("No environments configured in forge.json")
+		return nil
+	}
+
+	current := config.DefaultEnvironment()
+	fmt.Println("Environments:")
+	for _, env := range environments {
+		marker := " "
+		if env == current {
+			marker = "*"
+		}
+		fmt.Printf("  %s %s\n", marker, env)
+	}
+
+	fmt.Println()
+	if current == "" {
+		fmt.Println("No default environment set")
+		fmt.Println("Run 'forge env use <name>' to set one")
+	} else {
+		fmt.Printf("Default: %s\n", current)
+	}
+
+	return nil
+}
+
+func runEnvUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	if !config.HasEnvironment(name) {
+		environments := config.Environments()
+		if len(environments) == 0 {
+			return fmt.Errorf("environment %q not found: no project defines build or deploy configurations", name)
+		}
+		return fmt.Errorf("environment %q not found (available: %s)", name, strings.Join(environments, ", "))
+	}
+
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		if config.CLI == nil {
+			config.CLI = &workspace.CLIConfig{}
+		}
+		config.CLI.DefaultBuildEnvironment = name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Default environment set to %s\n", name)
+	return nil
+}
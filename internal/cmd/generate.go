@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/internal/generator"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/builder"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +25,7 @@ var generateCmd = &cobra.Command{
 
 Available types:
   service     Generate a new microservice (Go, NestJS)
-  app         Generate a new application (Angular, React)
+  app         Generate a new application (Angular, Vue)
   library     Generate a shared library
 
 Examples:
@@ -31,14 +34,34 @@ Examples:
   forge g service payment-service
   forge generate app admin-portal --lang=angular
   forge g app web-app
-  forge g library shared/auth`,
+  forge g library shared/auth
+  forge g service billing --lang=go -C ~/src/my-workspace`,
 }
 
 var (
 	serviceLanguage string
 	serviceDeployer string
+	serviceOpenAPI  string
+	serviceMigrate  bool
+	serviceRateLim  float64
+	serviceAuth     string
+	serviceResume   bool
+	serviceNestMono bool
+	servicePort     int
+	serviceGRPC     bool
+	serviceHTTP     bool
 	appLanguage     string
 	appDeployer     string
+	appConfig       map[string]string
+	appSSR          bool
+	libLanguage     string
+	libModulePath   string
+	libPackageName  string
+	libProto        bool
+
+	generateKeepOnFailure bool
+	generateDryRun        bool
+	generateOutputDir     string
 )
 
 var generateServiceCmd = &cobra.Command{
@@ -47,9 +70,13 @@ var generateServiceCmd = &cobra.Command{
 	Long: `Generate a new microservice with Forge patterns.
 
 Supports multiple languages:
-- Go: Standard Go microservice with HTTP server
+- Go: Standard Go microservice with HTTP server, or a gRPC server with --grpc
 - NestJS: TypeScript microservice with NestJS framework
 
+With --nestjs-monorepo a NestJS service is added as an app of the shared Nest
+monorepo in <services>/nest ('nest generate app'), created on first use, so
+all NestJS services share one package.json and node_modules.
+
 The service will include:
 - Main application with HTTP server
 - Logging and observability setup
@@ -62,7 +89,16 @@ The service will include:
 Examples:
   forge generate service user-service --lang=go
   forge generate service api-gateway --lang=nestjs
-  forge g service payment-service`,
+  forge g service payment-service
+  forge generate service orders --lang=go --openapi-from api.yaml
+  forge generate service billing --lang=go --sql-migrations
+  forge generate service public-api --lang=go --rate-limit=10
+  forge generate service accounts --lang=go --auth=oidc
+  forge generate service search --lang=go --port=8085
+  forge generate service orders --lang=go --grpc         # gRPC server with reflection and health service
+  forge generate service orders --lang=go --grpc --http  # gRPC server plus a JSON/HTTP gRPC-gateway
+  forge generate service api-gateway --lang=nestjs --deployer=helm --dry-run
+  forge generate service notifications --lang=nestjs --nestjs-monorepo`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGenerateService,
 }
@@ -73,8 +109,9 @@ var generateAppCmd = &cobra.Command{
 	Long: `Generate a new frontend application with Forge patterns.
 
 Supports multiple frameworks:
-- Angular: Standalone Angular application with Tailwind CSS
-- React: React application (coming soon)
+- Angular: Standalone Angular application with Tailwind CSS, generated as its
+  own Angular workspace (angular.json, package.json) in frontend/apps/<name>
+- Vue: Vite + Vue 3 + TypeScript application with Tailwind CSS
 
 The application will include:
 - Framework-specific configuration
@@ -82,11 +119,25 @@ The application will include:
 - TypeScript configuration
 - Package.json with dependencies
 - Deployment configurations
+- Environment files with the API URL of each environment
+
+API URLs default to the first backend service locally and to the ingress
+domain (workspace.kubernetes.domain) or the GCP project for dev and prod.
+Override them with --config apiUrl.<local|dev|prod>=<url>.
+
+With --ssr, Angular applications are generated with server-side rendering
+(Angular SSR): the build produces a Node server next to the browser bundle,
+and the image runs that server instead of serving static files with nginx.
+SSR applications need a server, so they deploy with cloudrun or helm.
 
 Examples:
   forge generate app web-app --lang=angular
   forge generate app admin-portal --lang=angular
-  forge g app dashboard`,
+  forge generate app storefront --lang=vue --deployer=firebase
+  forge generate app web-app --config apiUrl.prod=https://api.acme.com/api,apiUrl.dev=https://api.dev.acme.com/api
+  forge g app dashboard
+  forge g app dashboard --lang=angular --deployer=firebase --dry-run
+  forge g app marketing --lang=angular --ssr --deployer=cloudrun`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGenerateApp,
 }
@@ -96,18 +147,53 @@ var generateLibraryCmd = &cobra.Command{
 	Short: "Generate a shared library",
 	Long: `Generate a shared library at the specified path.
 
+The library type, Go module path and npm package name are prompted for unless
+passed as flags. After adding files to a Go library, run 'forge lib sync <path>'
+to update its BUILD.bazel.
+
+--proto generates a contract library instead: a Go module with a proto/
+directory holding a starter .proto, buf.yaml, buf.gen.yaml and a BUILD.bazel
+with proto_library and go_proto_library rules. It is registered in forge.json
+with the proto tag and its proto directory, so 'forge proto' compiles it, and
+services share its messages by importing the Go package <module-path>/proto or
+depending on its Bazel targets.
+
 Examples:
   forge g library shared/auth
-  forge g library shared/utils/logging`,
+  forge g library shared/utils/logging
+  forge g library shared/auth --lang=go --module-path=github.com/org/ws/shared/auth
+  forge g library shared/ui-kit --lang=ts --package-name=ui-kit
+  forge g library shared/contracts --proto --module-path=github.com/org/ws/shared/contracts`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGenerateLibrary,
 }
 
 func init() {
 	generateServiceCmd.Flags().StringVarP(&serviceLanguage, "lang", "l", "", "Service language (go, nestjs)")
-	generateServiceCmd.Flags().StringVarP(&serviceDeployer, "deployer", "d", "", "Deployment target (helm, cloudrun)")
-	generateAppCmd.Flags().StringVarP(&appLanguage, "lang", "l", "", "Application language (angular, react)")
+	generateServiceCmd.Flags().StringVarP(&serviceDeployer, "deployer", "d", "", "Deployment target (helm, cloudrun, ecs, aca)")
+	generateServiceCmd.Flags().StringVar(&serviceOpenAPI, "openapi-from", "", "OpenAPI 3 spec to scaffold handlers, routes and types from (Go only)")
+	generateServiceCmd.Flags().BoolVar(&serviceMigrate, "sql-migrations", false, "Scaffold SQL migrations with a golang-migrate runner and migration Job (Go only)")
+	generateServiceCmd.Flags().Float64Var(&serviceRateLim, "rate-limit", 0, "Add per-client-IP rate limiting with this many requests per second (Go only)")
+	generateServiceCmd.Flags().StringVar(&serviceAuth, "auth", "", "Protect /api routes with bearer token auth: jwt or oidc (Go only)")
+	generateServiceCmd.Flags().BoolVar(&serviceResume, "resume", false, "Finish a service whose generation was interrupted, skipping completed steps (NestJS only)")
+	generateServiceCmd.Flags().BoolVar(&serviceNestMono, "nestjs-monorepo", false, "Add the service as an app of the shared Nest monorepo workspace instead of a standalone project (NestJS only)")
+	generateServiceCmd.Flags().BoolVar(&serviceGRPC, "grpc", false, "Scaffold a gRPC server with a proto/ definition instead of the HTTP server (Go only)")
+	generateServiceCmd.Flags().BoolVar(&serviceHTTP, "http", false, "With --grpc, also serve the API over HTTP through a gRPC-gateway")
+	generateServiceCmd.Flags().IntVar(&servicePort, "port", 0, "Local serve port (default: the first free port from 8080 for Go, 3000 for NestJS)")
+	generateAppCmd.Flags().StringVarP(&appLanguage, "lang", "l", "", "Application language (angular, vue)")
 	generateAppCmd.Flags().StringVarP(&appDeployer, "deployer", "d", "", "Deployment target (firebase, helm, cloudrun)")
+	generateAppCmd.Flags().StringToStringVar(&appConfig, "config", nil, "App configuration (key=value pairs): apiUrl.local, apiUrl.dev, apiUrl.prod")
+	generateAppCmd.Flags().BoolVar(&appSSR, "ssr", false, "Render pages on the server with a Node server (Angular only; deploys with cloudrun or helm)")
+	generateLibraryCmd.Flags().StringVarP(&libLanguage, "lang", "l", "", "Library language (go, ts)")
+	generateLibraryCmd.Flags().StringVar(&libModulePath, "module-path", "", "Go module path of the library (Go only)")
+	generateLibraryCmd.Flags().StringVar(&libPackageName, "package-name", "", "Package name, published as @shared/<name> (TypeScript only)")
+	generateLibraryCmd.Flags().BoolVar(&libProto, "proto", false, "Generate a proto contract library shared by services, compiled by 'forge proto'")
+
+	generateCmd.PersistentFlags().StringVarP(&generateOutputDir, "output-dir", "C", "", "Generate into the workspace containing this directory instead of the current one (library paths are relative to it)")
+	generateCmd.PersistentFlags().BoolVar(&generateKeepOnFailure, "keep-on-failure", false, "Keep partially generated files when generation fails instead of rolling back")
+	for _, c := range []*cobra.Command{generateServiceCmd, generateAppCmd, generateNestJSCmd, generateFrontendCmd} {
+		c.Flags().BoolVar(&generateDryRun, "dry-run", false, "Print the files that would be written and the commands that would run, without generating anything")
+	}
 
 	generateCmd.AddCommand(generateServiceCmd)
 	generateCmd.AddCommand(generateAppCmd)
@@ -144,18 +230,26 @@ Examples:
 func runGenerateNestJS(cmd *cobra.Command, args []string) error {
 	serviceName := args[0]
 
+	workspaceDir, err := generateWorkspaceDir()
+	if err != nil {
+		return err
+	}
+
 	// Create generator
 	gen := generator.NewNestJSServiceGenerator()
 
 	// Prepare options
 	opts := generator.GeneratorOptions{
-		OutputDir: ".",
+		OutputDir: workspaceDir,
 		Name:      serviceName,
-		DryRun:    false,
+		DryRun:    generateDryRun,
+		Data: map[string]interface{}{
+			"keepOnFailure": generateKeepOnFailure,
+		},
 	}
 
 	// Generate service
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to generate NestJS service: %w", err)
 	}
@@ -185,18 +279,26 @@ Examples:
 func runGenerateFrontend(cmd *cobra.Command, args []string) error {
 	appName := args[0]
 
+	workspaceDir, err := generateWorkspaceDir()
+	if err != nil {
+		return err
+	}
+
 	// Create generator
 	gen := generator.NewFrontendGenerator()
 
 	// Prepare options
 	opts := generator.GeneratorOptions{
-		OutputDir: ".",
+		OutputDir: workspaceDir,
 		Name:      appName,
-		DryRun:    false,
+		DryRun:    generateDryRun,
+		Data: map[string]interface{}{
+			"keepOnFailure": generateKeepOnFailure,
+		},
 	}
 
 	// Generate frontend
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to generate frontend: %w", err)
 	}
@@ -205,11 +307,16 @@ func runGenerateFrontend(cmd *cobra.Command, args []string) error {
 }
 
 func runGenerateService(cmd *cobra.Command, args []string) error {
+	workspaceDir, err := generateWorkspaceDir()
+	if err != nil {
+		return err
+	}
+
 	var serviceName string
 
 	// Prompt for name if not provided
 	if len(args) == 0 {
-		name, err := ui.AskText("Service name:", "")
+		name, err := ui.WithFlag("a service name argument").AskText("Service name:", "")
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
@@ -217,75 +324,109 @@ func runGenerateService(cmd *cobra.Command, args []string) error {
 	} else {
 		serviceName = args[0]
 	}
+	serviceName, err = checkName("service", serviceName)
+	if err != nil {
+		return err
+	}
 
 	// Prompt for language if not provided
 	if serviceLanguage == "" {
-		_, lang, err := ui.AskSelect("Select service language:", []string{"Go", "NestJS"})
+		lang, err := askGeneratorLanguage("Select service language:", workspace.ProjectKindService)
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
-		serviceLanguage = strings.ToLower(lang)
+		serviceLanguage = lang
 	}
 
 	// Normalize language
 	serviceLanguage = strings.ToLower(serviceLanguage)
 
+	if serviceOpenAPI != "" && serviceLanguage != "go" {
+		return fmt.Errorf("--openapi-from is only supported for Go services")
+	}
+	if serviceMigrate && serviceLanguage != "go" {
+		return fmt.Errorf("--sql-migrations is only supported for Go services")
+	}
+	if serviceRateLim != 0 && serviceLanguage != "go" {
+		return fmt.Errorf("--rate-limit is only supported for Go services")
+	}
+	if serviceGRPC && serviceLanguage != "go" {
+		return fmt.Errorf("--grpc is only supported for Go services")
+	}
+	if serviceHTTP && !serviceGRPC {
+		return fmt.Errorf("--http requires --grpc")
+	}
+	if serviceGRPC && (serviceOpenAPI != "" || serviceRateLim != 0 || serviceAuth != "") {
+		return fmt.Errorf("--grpc cannot be combined with --openapi-from, --rate-limit or --auth")
+	}
+	if serviceResume && serviceLanguage != "nestjs" {
+		return fmt.Errorf("--resume is only supported for NestJS services")
+	}
+	if serviceNestMono && serviceLanguage != "nestjs" {
+		return fmt.Errorf("--nestjs-monorepo is only supported for NestJS services")
+	}
+	serviceAuth = strings.ToLower(serviceAuth)
+	if serviceAuth != "" {
+		if serviceLanguage != "go" {
+			return fmt.Errorf("--auth is only supported for Go services")
+		}
+		if serviceAuth != "jwt" && serviceAuth != "oidc" {
+			return fmt.Errorf("unsupported auth mode: %s (supported: jwt, oidc)", serviceAuth)
+		}
+	}
+
 	// Prompt for deployer selection
 	var deployer string
 	if serviceDeployer != "" {
-		deployer = strings.ToLower(serviceDeployer)
-		// Validate deployer
-		if deployer != "helm" && deployer != "cloudrun" {
-			return fmt.Errorf("unsupported deployer: %s (supported: helm, cloudrun)", deployer)
+		deployer, err = checkDeployer(serviceDeployer, serviceLanguage)
+		if err != nil {
+			return err
 		}
 	} else {
-		_, deployerChoice, err := ui.AskSelect("Select deployment target:", []string{"Helm (Kubernetes)", "CloudRun"})
+		deployer, err = askDeployer(askDeployerTarget, "Select deployment target:", serviceLanguage)
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
-
-		// Map display names to internal names
-		switch deployerChoice {
-		case "Helm (Kubernetes)":
-			deployer = "helm"
-		case "CloudRun":
-			deployer = "cloudrun"
-		default:
-			deployer = "helm"
-		}
 	}
 
-	// Create appropriate generator
-	var gen generator.Generator
-	switch serviceLanguage {
-	case "go":
-		gen = generator.NewServiceGenerator()
-	case "nestjs":
-		gen = generator.NewNestJSServiceGenerator()
-	default:
-		return fmt.Errorf("unsupported service language: %s (supported: go, nestjs)", serviceLanguage)
+	// Resolve generator for the selected language
+	gen, err := generator.Resolve(workspace.ProjectKindService, workspace.LanguageType(serviceLanguage))
+	if err != nil {
+		return err
 	}
 
 	// Prepare options with deployer data
 	opts := generator.GeneratorOptions{
-		OutputDir: ".",
+		OutputDir: workspaceDir,
 		Name:      serviceName,
-		DryRun:    false,
+		DryRun:    generateDryRun,
 		Data: map[string]interface{}{
-			"deployer": deployer,
+			"deployer":      deployer,
+			"openapiSpec":   serviceOpenAPI,
+			"sqlMigrations": serviceMigrate,
+			"rateLimit":     serviceRateLim,
+			"auth":          serviceAuth,
+			"grpc":          serviceGRPC,
+			"http":          serviceHTTP,
+			"resume":        serviceResume,
+			"monorepo":      serviceNestMono,
+			"keepOnFailure": generateKeepOnFailure,
 		},
 	}
+	if servicePort != 0 {
+		opts.Data["port"] = strconv.Itoa(servicePort)
+	}
 
 	// Generate service
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to generate %s service: %w", serviceLanguage, err)
 	}
 
 	// Auto-sync workspace for Go services (consolidates go.mod)
-	if serviceLanguage == "go" {
+	if serviceLanguage == "go" && !generateDryRun {
 		fmt.Println("\n🔄 Running forge sync to consolidate dependencies...")
-		if err := runSync(cmd, []string{"--yes"}); err != nil {
+		if err := runSyncIn(ctx, workspaceDir, os.Stdout); err != nil {
 			fmt.Printf("⚠️  Warning: Auto-sync failed: %v\n", err)
 			fmt.Println("   Run 'forge sync' manually to complete setup")
 		}
@@ -295,11 +436,21 @@ func runGenerateService(cmd *cobra.Command, args []string) error {
 }
 
 func runGenerateApp(cmd *cobra.Command, args []string) error {
+	workspaceDir, err := generateWorkspaceDir()
+	if err != nil {
+		return err
+	}
+
+	apiURLs, err := parseAppAPIURLs(appConfig)
+	if err != nil {
+		return err
+	}
+
 	var appName string
 
 	// Prompt for name if not provided
 	if len(args) == 0 {
-		name, err := ui.AskText("Application name:", "")
+		name, err := ui.WithFlag("an application name argument").AskText("Application name:", "")
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
@@ -307,69 +458,64 @@ func runGenerateApp(cmd *cobra.Command, args []string) error {
 	} else {
 		appName = args[0]
 	}
+	appName, err = checkName("application", appName)
+	if err != nil {
+		return err
+	}
 
 	// Prompt for language if not provided
 	if appLanguage == "" {
-		_, lang, err := ui.AskSelect("Select application framework:", []string{"Angular", "React"})
+		lang, err := askGeneratorLanguage("Select application framework:", workspace.ProjectKindApplication)
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
-		appLanguage = strings.ToLower(lang)
+		appLanguage = lang
 	}
 
 	// Normalize language
 	appLanguage = strings.ToLower(appLanguage)
+	if appSSR && appLanguage != string(workspace.LanguageAngular) {
+		return fmt.Errorf("--ssr is only supported for Angular applications")
+	}
 
 	// Prompt for deployer selection if not provided
 	var deployer string
 	if appDeployer != "" {
-		deployer = strings.ToLower(appDeployer)
-		// Validate deployer
-		if deployer != "firebase" && deployer != "helm" && deployer != "cloudrun" {
-			return fmt.Errorf("unsupported deployer: %s (supported: firebase, helm, cloudrun)", deployer)
+		deployer, err = checkDeployer(appDeployer, appLanguage)
+		if err != nil {
+			return err
 		}
 	} else {
-		_, deployerChoice, err := ui.AskSelect("Select deployment target:", []string{"Firebase", "Helm (Kubernetes)", "CloudRun"})
+		deployer, err = askDeployer(askDeployerTarget, "Select deployment target:", appLanguage)
 		if err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
-
-		// Map display names to internal names
-		switch deployerChoice {
-		case "Firebase":
-			deployer = "firebase"
-		case "Helm (Kubernetes)":
-			deployer = "helm"
-		case "CloudRun":
-			deployer = "cloudrun"
-		default:
-			deployer = "firebase"
-		}
+	}
+	if appSSR && deployer == "firebase" {
+		return fmt.Errorf("--ssr needs a server to run on: use --deployer=cloudrun or --deployer=helm")
 	}
 
-	// Create appropriate generator
-	var gen generator.Generator
-	switch appLanguage {
-	case "angular":
-		gen = generator.NewFrontendGenerator()
-	case "react":
-		return fmt.Errorf("React support coming soon")
-	default:
-		return fmt.Errorf("unsupported app framework: %s (supported: angular, react)", appLanguage)
+	// Resolve generator for the selected framework
+	gen, err := generator.Resolve(workspace.ProjectKindApplication, workspace.LanguageType(appLanguage))
+	if err != nil {
+		return err
 	}
 
 	// Prepare options with deployer data
 	opts := generator.GeneratorOptions{
-		OutputDir: ".",
+		OutputDir: workspaceDir,
 		Name:      appName,
-		DryRun:    false,
+		DryRun:    generateDryRun,
 		Data: map[string]interface{}{
-			"deployer": deployer,
+			"deployer":      deployer,
+			"apiUrls":       apiURLs,
+			"keepOnFailure": generateKeepOnFailure,
+			"ssr":           appSSR,
 		},
 	}
 
 	// Generate app
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to generate %s app: %w", appLanguage, err)
 	}
@@ -377,15 +523,95 @@ func runGenerateApp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// generateWorkspaceDir returns the workspace root generators write into: the
+// workspace containing --output-dir, or else the current directory.
+func generateWorkspaceDir() (string, error) {
+	if generateOutputDir == "" {
+		return workspace.FindRoot()
+	}
+	info, err := os.Stat(generateOutputDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid --output-dir: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("invalid --output-dir: %s is not a directory", generateOutputDir)
+	}
+	return workspace.FindRootFrom(generateOutputDir)
+}
+
+// parseAppAPIURLs reads the apiUrl.<env> keys of --config. development and
+// production are accepted for dev and prod.
+func parseAppAPIURLs(config map[string]string) (map[string]string, error) {
+	urls := make(map[string]string)
+	for key, value := range config {
+		env, ok := strings.CutPrefix(key, "apiUrl.")
+		if !ok {
+			return nil, fmt.Errorf("unknown --config key %q (supported: apiUrl.local, apiUrl.dev, apiUrl.prod)", key)
+		}
+
+		switch strings.ToLower(env) {
+		case "local":
+			env = "local"
+		case "dev", "development":
+			env = "dev"
+		case "prod", "production":
+			env = "prod"
+		default:
+			return nil, fmt.Errorf("unknown environment %q in --config %s (supported: local, dev, prod)", env, key)
+		}
+
+		if value == "" {
+			return nil, fmt.Errorf("--config %s requires a URL", key)
+		}
+		urls[env] = value
+	}
+	return urls, nil
+}
+
 func runGenerateLibrary(cmd *cobra.Command, args []string) error {
 	libPath := args[0]
 
 	// Determine library type
-	_, libType, err := ui.AskSelect("Select library type:", []string{"Go", "TypeScript"})
-	if err != nil {
-		return fmt.Errorf("cancelled: %w", err)
+	var libType string
+	switch strings.ToLower(libLanguage) {
+	case "go":
+		libType = "Go"
+	case "ts", "typescript":
+		libType = "TypeScript"
+	case "":
+		if libProto {
+			libType = "Proto"
+		} else if libModulePath != "" {
+			libType = "Go"
+		} else if libPackageName != "" {
+			libType = "TypeScript"
+		} else {
+			var err error
+			_, libType, err = ui.WithFlag("--lang").AskSelectIndex("Select library type:", []string{"Go", "TypeScript", "Proto"})
+			if err != nil {
+				return fmt.Errorf("cancelled: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported library language: %s (supported: go, ts)", libLanguage)
+	}
+
+	if libProto && libType != "Proto" {
+		if libType != "Go" {
+			return fmt.Errorf("--proto cannot be combined with --lang=%s", libLanguage)
+		}
+		libType = "Proto"
+	}
+	if libModulePath != "" && libType != "Go" && libType != "Proto" {
+		return fmt.Errorf("--module-path is only supported for Go and proto libraries")
+	}
+	if libPackageName != "" && libType != "TypeScript" {
+		return fmt.Errorf("--package-name is only supported for TypeScript libraries")
 	}
 
+	if generateOutputDir != "" && !filepath.IsAbs(libPath) {
+		libPath = filepath.Join(generateOutputDir, libPath)
+	}
 	absPath, err := filepath.Abs(libPath)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
@@ -400,11 +626,15 @@ func runGenerateLibrary(cmd *cobra.Command, args []string) error {
 
 	switch libType {
 	case "Go":
-		if err := generateGoLibrary(absPath); err != nil {
+		if err := generateGoLibrary(absPath, libModulePath); err != nil {
 			return err
 		}
 	case "TypeScript":
-		if err := generateTypeScriptLibrary(absPath); err != nil {
+		if err := generateTypeScriptLibrary(absPath, libPackageName); err != nil {
+			return err
+		}
+	case "Proto":
+		if err := generateProtoLibrary(cmd.Context(), absPath, libModulePath); err != nil {
 			return err
 		}
 	}
@@ -413,16 +643,21 @@ func runGenerateLibrary(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func generateGoLibrary(path string) error {
+// generateGoLibrary creates a Go library at path. The module path is prompted
+// for when empty.
+func generateGoLibrary(path, modulePath string) error {
 	// Create directory
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Get module path from user
-	modulePath, err := ui.AskText("Go module path (e.g., github.com/org/lib):", "")
-	if err != nil {
-		return err
+	if modulePath == "" {
+		var err error
+		modulePath, err = ui.WithFlag("--module-path").AskText("Go module path (e.g., github.com/org/lib):", "")
+		if err != nil {
+			return err
+		}
 	}
 
 	// Create go.mod
@@ -481,12 +716,12 @@ import "%s"
 	}
 
 	// Register library in forge.json
-	if err := registerLibraryInForgeConfig(path, modulePath); err != nil {
+	if err := registerLibraryInForgeConfig(path, modulePath, ""); err != nil {
 		return fmt.Errorf("failed to register library: %w", err)
 	}
 
 	// Try to add to go.work if it exists
-	workspacePath, err := findGoWorkspace()
+	workspacePath, err := findGoWorkspace(filepath.Dir(path))
 	if err == nil {
 		if err := addToGoWorkspace(workspacePath, path); err != nil {
 			fmt.Printf("⚠️  Could not add to go.work: %v\n", err)
@@ -498,17 +733,21 @@ import "%s"
 	return nil
 }
 
-func generateTypeScriptLibrary(path string) error {
+// generateTypeScriptLibrary creates a TypeScript library at path. The package
+// name is prompted for when empty.
+func generateTypeScriptLibrary(path, packageName string) error {
 	// Create directory
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Get package name
-	defaultName := filepath.Base(path)
-	packageName, err := ui.AskText("Package name:", defaultName)
-	if err != nil {
-		return err
+	if packageName == "" {
+		var err error
+		packageName, err = ui.WithFlag("--package-name").AskText("Package name:", filepath.Base(path))
+		if err != nil {
+			return err
+		}
 	}
 
 	// Create package.json
@@ -598,13 +837,114 @@ import { version } from '@shared/%s';
 	return nil
 }
 
-func findGoWorkspace() (string, error) {
-	// Look for go.work in current directory and parents
-	dir, err := os.Getwd()
+// generateProtoLibrary creates a proto contract library at path: a Go module
+// whose proto/ directory holds the shared messages. The module path is
+// prompted for when empty.
+func generateProtoLibrary(ctx context.Context, path, modulePath string) error {
+	protoDir := filepath.Join(path, "proto")
+	if err := os.MkdirAll(protoDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if modulePath == "" {
+		var err error
+		modulePath, err = ui.WithFlag("--module-path").AskText("Go module path (e.g., github.com/org/contracts):", "")
+		if err != nil {
+			return err
+		}
+	}
+
+	goModContent := fmt.Sprintf(`module %s
+
+go 1.23
+`, modulePath)
+
+	if err := os.WriteFile(filepath.Join(path, "go.mod"), []byte(goModContent), 0644); err != nil {
+		return fmt.Errorf("failed to create go.mod: %w", err)
+	}
+
+	// Proto identifiers cannot contain dashes
+	protoName := strings.ReplaceAll(filepath.Base(path), "-", "_")
+	data := map[string]interface{}{
+		"ModulePath":     modulePath,
+		"ProtoFile":      protoName + ".proto",
+		"ProtoPackage":   protoName + ".v1",
+		"ProtoTarget":    protoName,
+		"GoProtoPackage": strings.ReplaceAll(protoName, "_", "") + "pb",
+	}
+
+	engine := template.NewEngine()
+	files := map[string]string{
+		protoName + ".proto": "library/proto/contracts.proto.tmpl",
+		"BUILD.bazel":        "library/proto/BUILD.bazel.tmpl",
+		"buf.yaml":           "library/proto/buf.yaml.tmpl",
+		"buf.gen.yaml":       "library/proto/buf.gen.yaml.tmpl",
+	}
+	for name, tmpl := range files {
+		content, err := engine.RenderTemplate(tmpl, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(protoDir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", name, err)
+		}
+	}
+
+	libName := filepath.Base(path)
+	readmeContent := fmt.Sprintf(`# %s
+
+Proto contracts shared by the services of the workspace.
+
+Add messages to proto/%s.proto and run `+"`forge proto`"+` to compile them.
+
+## Usage
+
+Go services import the generated package:
+
+`+"```"+`go
+import "%s/proto"
+`+"```"+`
+
+Bazel targets depend on the %s_proto (proto_library) or %s_go_proto
+(go_proto_library) targets of proto/BUILD.bazel.
+`, libName, protoName, modulePath, protoName, protoName)
+
+	if err := os.WriteFile(filepath.Join(path, "README.md"), []byte(readmeContent), 0644); err != nil {
+		return fmt.Errorf("failed to create README.md: %w", err)
+	}
+	fmt.Println("✔ Generated proto/")
+
+	if err := registerLibraryInForgeConfig(path, modulePath, "proto"); err != nil {
+		return fmt.Errorf("failed to register library: %w", err)
+	}
+
+	// Compile the stubs so go mod tidy finds the protobuf dependencies
+	compiled, err := builder.CompileGoProtos(ctx, protoDir)
 	if err != nil {
-		return "", err
+		fmt.Printf("⚠️  Proto compilation failed: %v\n", err)
+	} else if !compiled {
+		fmt.Println("⚠️  Protobuf compiler not found, run 'forge proto' and 'go mod tidy' to generate the Go stubs")
+	} else if err := exec.Run(ctx, exec.Options{Name: "go", Args: []string{"mod", "tidy"}, Dir: path}); err != nil {
+		fmt.Printf("⚠️  go mod tidy failed: %v\n", err)
+	} else {
+		fmt.Println("✔ Compiled proto/")
+	}
+
+	workspacePath, err := findGoWorkspace(filepath.Dir(path))
+	if err == nil {
+		if err := addToGoWorkspace(workspacePath, path); err != nil {
+			fmt.Printf("⚠️  Could not add to go.work: %v\n", err)
+		} else {
+			fmt.Println("✔ Added to go.work")
+		}
 	}
 
+	fmt.Println("Run 'forge sync' to add the proto rules to MODULE.bazel.")
+	return nil
+}
+
+// findGoWorkspace looks for go.work in dir and its parents.
+func findGoWorkspace(dir string) (string, error) {
 	for {
 		workPath := filepath.Join(dir, "go.work")
 		if _, err := os.Stat(workPath); err == nil {
@@ -688,10 +1028,22 @@ func addToGoWorkspace(workspacePath, modulePath string) error {
 
 // generateLibraryBuildFile creates BUILD.bazel for a library
 func generateLibraryBuildFile(libPath, importPath, packageName string) error {
+	// Use package name (no dashes) for filename
+	if _, err := writeLibraryBuildFile(libPath, importPath, []string{packageName + ".go"}, nil); err != nil {
+		return err
+	}
+
+	fmt.Println("✔ Generated BUILD.bazel")
+	return nil
+}
+
+// writeLibraryBuildFile renders the BUILD.bazel of a Go library with the given
+// sources and tests. It reports whether the file changed.
+func writeLibraryBuildFile(libPath, importPath string, files, testFiles []string) (bool, error) {
 	// Read template
-	templateContent, err := template.TemplatesFS.ReadFile("templates/library/BUILD.bazel.tmpl")
+	templateContent, err := template.NewEngine().ReadEmbeddedFile("library/BUILD.bazel.tmpl")
 	if err != nil {
-		return fmt.Errorf("failed to read BUILD template: %w", err)
+		return false, fmt.Errorf("failed to read BUILD template: %w", err)
 	}
 
 	// Get library name from path (with dashes) for Bazel target
@@ -707,32 +1059,35 @@ func generateLibraryBuildFile(libPath, importPath, packageName string) error {
 	}{
 		PackageName: libName, // Use library name (with dashes) for Bazel target
 		ImportPath:  importPath,
-		Files:       []string{packageName + ".go"}, // Use package name (no dashes) for filename
-		TestFiles:   []string{},
-		HasTests:    false,
+		Files:       files,
+		TestFiles:   testFiles,
+		HasTests:    len(testFiles) > 0,
 	}
 
 	// Render template
 	engine := template.NewEngine()
 	rendered, err := engine.Render(string(templateContent), data)
 	if err != nil {
-		return fmt.Errorf("failed to render BUILD template: %w", err)
+		return false, fmt.Errorf("failed to render BUILD template: %w", err)
 	}
 
 	// Write BUILD.bazel
 	buildPath := filepath.Join(libPath, "BUILD.bazel")
+	if current, err := os.ReadFile(buildPath); err == nil && string(current) == rendered {
+		return false, nil
+	}
 	if err := os.WriteFile(buildPath, []byte(rendered), 0644); err != nil {
-		return fmt.Errorf("failed to write BUILD.bazel: %w", err)
+		return false, fmt.Errorf("failed to write BUILD.bazel: %w", err)
 	}
 
-	fmt.Println("✔ Generated BUILD.bazel")
-	return nil
+	return true, nil
 }
 
-// registerLibraryInForgeConfig adds the library to forge.json
-func registerLibraryInForgeConfig(libPath, importPath string) error {
-	// Find workspace root
-	workspaceRoot, err := findWorkspaceRoot()
+// registerLibraryInForgeConfig adds the library to forge.json. A non-empty
+// protoDir registers the library's proto directory for 'forge proto'.
+func registerLibraryInForgeConfig(libPath, importPath, protoDir string) error {
+	// Find the workspace the library is in
+	workspaceRoot, err := workspace.FindRootFrom(filepath.Dir(libPath))
 	if err != nil {
 		return fmt.Errorf("failed to find workspace root: %w", err)
 	}
@@ -771,14 +1126,45 @@ func registerLibraryInForgeConfig(libPath, importPath string) error {
 			},
 		},
 	}
+	if protoDir != "" {
+		project.Tags = append(project.Tags, "proto")
+		project.Metadata = map[string]interface{}{
+			"grpc": map[string]interface{}{"protoDir": protoDir},
+		}
+	}
 
-	config.AddProject(libName, project)
-
-	// Save config
-	if err := config.SaveToDir(workspaceRoot); err != nil {
-		return fmt.Errorf("failed to save forge.json: %w", err)
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		return config.AddProject(libName, project)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register library in forge.json: %w", err)
 	}
 
 	fmt.Printf("✔ Registered library in forge.json\n")
 	return nil
 }
+
+// askGeneratorLanguage prompts for one of the languages registered for a project kind.
+func askGeneratorLanguage(label string, kind workspace.ProjectKind) (string, error) {
+	entries := generator.List(kind)
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no generators registered for %s", kind)
+	}
+
+	labels := make([]string, len(entries))
+	for i, entry := range entries {
+		labels[i] = entry.Label
+	}
+
+	idx, _, err := ui.WithFlag("--lang").AskSelectIndex(label, labels)
+	if err != nil {
+		return "", err
+	}
+	return string(entries[idx].Language), nil
+}
+
+// askDeployerTarget prompts for one of the deployer items, answered by
+// --deployer without a prompt.
+func askDeployerTarget(label string, items []string) (string, error) {
+	return ui.WithFlag("--deployer").AskSelect(label, items)
+}
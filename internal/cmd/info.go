@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/skaffold"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	infoEnv  string
+	infoJSON bool
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info <project>",
+	Short: "Show the resolved configuration of a project",
+	Long: `Show a project's type, language, root and tags, and the build and deploy
+configuration for an environment.
+
+Options are resolved the same way forge build and forge deploy resolve them:
+the target options overridden by the environment's configuration. The image
+is the one Skaffold builds for the project.
+
+Examples:
+  forge info api-server                  # Default environment
+  forge info api-server --env=production # Production configuration
+  forge info api-server --json           # Machine-readable output`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().StringVarP(&infoEnv, "env", "e", "", "Environment to resolve (defaults to 'forge env use')")
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Print the project information as JSON")
+}
+
+// projectInfo is the resolved view of a project printed by forge info.
+type projectInfo struct {
+	Name        string      `json:"name"`
+	ProjectType string      `json:"projectType"`
+	Language    string      `json:"language"`
+	Root        string      `json:"root"`
+	Tags        []string    `json:"tags,omitempty"`
+	DependsOn   []string    `json:"dependsOn,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	Build       *targetInfo `json:"build,omitempty"`
+	Deploy      *targetInfo `json:"deploy,omitempty"`
+}
+
+// targetInfo is an architect target resolved for one configuration.
+type targetInfo struct {
+	Name          string                 `json:"name"`
+	Configuration string                 `json:"configuration"`
+	Skaffold      bool                   `json:"skaffold,omitempty"`
+	Options       map[string]interface{} `json:"options,omitempty"`
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	name := args[0]
+	project := config.GetProject(name)
+	if project == nil {
+		return fmt.Errorf("project %q not found in forge.json", name)
+	}
+
+	if infoEnv != "" && !config.HasEnvironment(infoEnv) {
+		return fmt.Errorf("environment %q not found (available: %s)", infoEnv, strings.Join(config.Environments(), ", "))
+	}
+
+	info, err := resolveProjectInfo(cmd.Context(), config, workspaceRoot, name, project, infoEnv)
+	if err != nil {
+		return err
+	}
+
+	if infoJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+
+	printProjectInfo(info)
+	return nil
+}
+
+// resolveProjectInfo resolves the build and deploy targets of project for env
+// the way forge build and forge deploy do.
+func resolveProjectInfo(ctx context.Context, config *workspace.Config, workspaceRoot, name string, project *workspace.Project, env string) (*projectInfo, error) {
+	dependsOn, err := project.DependsOn()
+	if err != nil {
+		return nil, fmt.Errorf("project %q: %w", name, err)
+	}
+
+	info := &projectInfo{
+		Name:        name,
+		ProjectType: project.ProjectType,
+		Language:    project.Language,
+		Root:        project.Root,
+		Tags:        project.Tags,
+		DependsOn:   dependsOn,
+	}
+	if project.Architect == nil {
+		return info, nil
+	}
+
+	if build := project.Architect.Build; build != nil {
+		configuration := config.BuildConfiguration(project, env)
+		info.Build = &targetInfo{
+			Name:          build.Builder,
+			Configuration: configuration,
+			Options:       build.ResolveOptions(configuration),
+		}
+	}
+
+	if deploy := project.Architect.Deploy; deploy != nil {
+		configuration := config.DeployConfiguration(env)
+		info.Deploy = &targetInfo{
+			Name:          deploy.Deployer,
+			Configuration: configuration,
+			Options:       deploy.ResolveOptions(configuration),
+		}
+
+		// Skaffold builds and tags the image of the projects it deploys
+		if build := project.Architect.Build; build != nil && deployer.CanUseSkaffold(deploy.Deployer, build.Builder) {
+			info.Deploy.Skaffold = true
+			if build.Builder == "@forge/bazel:build" {
+				registry := skaffold.ResolveRegistry(config, build, configuration)
+				info.Image = fmt.Sprintf("%s/%s:%s", registry, name, gitShortSHA(ctx, workspaceRoot))
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// gitShortSHA returns the abbreviated commit Skaffold tags images with.
+func gitShortSHA(ctx context.Context, dir string) string {
+	out, err := exec.Capture(ctx, exec.Options{Name: "git", Args: []string{"rev-parse", "--short", "HEAD"}, Dir: dir})
+	if err != nil {
+		return "<commit>"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func printProjectInfo(info *projectInfo) {
+	fmt.Printf("📦 %s (%s, %s)\n", info.Name, info.ProjectType, info.Language)
+	fmt.Printf("  Root:       %s\n", info.Root)
+	if len(info.Tags) > 0 {
+		fmt.Printf("  Tags:       %s\n", strings.Join(info.Tags, ", "))
+	}
+	if len(info.DependsOn) > 0 {
+		fmt.Printf("  Depends on: %s\n", strings.Join(info.DependsOn, ", "))
+	}
+
+	if info.Build != nil {
+		fmt.Printf("\n🔨 Build (configuration: %s)\n", info.Build.Configuration)
+		fmt.Printf("  Builder:    %s\n", info.Build.Name)
+		if info.Image != "" {
+			fmt.Printf("  Image:      %s\n", info.Image)
+		}
+		printInfoOptions(info.Build.Options)
+	}
+
+	if info.Deploy != nil {
+		fmt.Printf("\n🚀 Deploy (configuration: %s)\n", info.Deploy.Configuration)
+		if info.Deploy.Skaffold {
+			fmt.Printf("  Deployer:   %s (via Skaffold)\n", info.Deploy.Name)
+		} else {
+			fmt.Printf("  Deployer:   %s\n", info.Deploy.Name)
+		}
+		printInfoOptions(info.Deploy.Options)
+	}
+
+	if info.Build == nil && info.Deploy == nil {
+		fmt.Println("\nNo build or deploy configuration")
+	}
+}
+
+// printInfoOptions prints options sorted by key, with nested values as JSON.
+func printInfoOptions(options map[string]interface{}) {
+	if len(options) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("  Options:")
+	for _, key := range keys {
+		value := options[key]
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, map[string]string, []string:
+			if data, err := json.Marshal(value); err == nil {
+				value = string(data)
+			}
+		}
+		fmt.Printf("    %s: %v\n", key, value)
+	}
+}
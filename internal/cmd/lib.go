@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var libCmd = &cobra.Command{
+	Use:   "lib",
+	Short: "Manage shared libraries",
+}
+
+var libSyncCmd = &cobra.Command{
+	Use:   "sync <path|name>",
+	Short: "Regenerate the BUILD.bazel of a Go library",
+	Long: `Rescan a Go library generated with 'forge generate library' and rewrite
+its BUILD.bazel so srcs lists every .go file and a go_test target covers the
+_test.go files.
+
+Only the library directory itself is scanned and only its BUILD.bazel is
+rewritten, which is much faster than a full 'forge sync' after adding a file.
+
+Examples:
+  forge lib sync shared/auth
+  forge lib sync auth        # Library name in forge.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLibSync,
+}
+
+func init() {
+	rootCmd.AddCommand(libCmd)
+	libCmd.AddCommand(libSyncCmd)
+}
+
+func runLibSync(cmd *cobra.Command, args []string) error {
+	libPath, err := resolveLibraryPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	importPath := readGoModulePath(filepath.Join(libPath, "go.mod"))
+	if importPath == "" {
+		return fmt.Errorf("%s is not a Go library: no module path in go.mod", libPath)
+	}
+
+	files, testFiles, err := scanLibraryFiles(libPath)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no Go source files found in %s", libPath)
+	}
+
+	changed, err := writeLibraryBuildFile(libPath, importPath, files, testFiles)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		fmt.Printf("✔ %s is up to date (%d sources, %d tests)\n", filepath.Join(libPath, "BUILD.bazel"), len(files), len(testFiles))
+		return nil
+	}
+	fmt.Printf("✔ Updated %s (%d sources, %d tests)\n", filepath.Join(libPath, "BUILD.bazel"), len(files), len(testFiles))
+	return nil
+}
+
+// resolveLibraryPath returns the directory of a library given as a path or as
+// the name of a library project in forge.json.
+func resolveLibraryPath(arg string) (string, error) {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		return filepath.Clean(arg), nil
+	}
+
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return "", fmt.Errorf("library %s not found", arg)
+	}
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	project := config.GetProject(arg)
+	if project == nil {
+		return "", fmt.Errorf("library %s not found: no such directory or project", arg)
+	}
+	if project.ProjectType != "library" {
+		return "", fmt.Errorf("project %s is a %s, not a library", arg, project.ProjectType)
+	}
+	return filepath.Join(workspaceRoot, project.Root), nil
+}
+
+// scanLibraryFiles lists the Go sources and tests of a library directory,
+// sorted, without descending into subpackages.
+func scanLibraryFiles(libPath string) (files, testFiles []string, err error) {
+	entries, err := os.ReadDir(libPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", libPath, err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.go") {
+			testFiles = append(testFiles, name)
+		} else {
+			files = append(files, name)
+		}
+	}
+
+	sort.Strings(files)
+	sort.Strings(testFiles)
+	return files, testFiles, nil
+}
+
+// readGoModulePath reads the module path from a go.mod file, or returns "".
+func readGoModulePath(goModPath string) string {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+		}
+	}
+	return ""
+}
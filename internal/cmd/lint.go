@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/sync"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the workspace against its architectural rules",
+	Long: `Check the workspace against its architectural rules.
+
+Use 'forge proto lint' to lint protocol buffers.`,
+}
+
+var lintDepsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Check project dependencies against the forge.json constraints",
+	Long: `Check that projects only depend on the projects the constraints section of
+forge.json allows, based on project tags (like Nx's depConstraints).
+
+The dependencies of Go projects are read from their go.mod requirements and
+the imports of their packages. Every constraint applies to the projects
+tagged with its sourceTag ("*" for all projects): their dependencies must
+have one of the onlyDependOnTags, and none of the notDependOnTags.
+
+  "constraints": [
+    {"sourceTag": "service", "onlyDependOnTags": ["shared"]},
+    {"sourceTag": "shared", "notDependOnTags": ["service"]}
+  ]
+
+The command lists every violation and fails if there is any, so it can gate CI.
+
+Examples:
+  forge lint deps`,
+	Args: cobra.NoArgs,
+	RunE: runLintDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.AddCommand(lintDepsCmd)
+}
+
+func runLintDeps(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+	if len(config.Constraints) == 0 {
+		fmt.Println("No constraints in forge.json, nothing to check")
+		return nil
+	}
+	if err := config.ValidateConstraints(); err != nil {
+		return fmt.Errorf("invalid forge.json: %w", err)
+	}
+
+	deps, err := sync.GoProjectDependencies(workspaceRoot, config)
+	if err != nil {
+		return err
+	}
+
+	violations := config.CheckDependencyConstraints(deps)
+	if len(violations) > 0 {
+		for _, violation := range violations {
+			fmt.Printf("✗ %s\n", violation.Error())
+		}
+		return fmt.Errorf("%d dependenc%s break%s the forge.json constraints", len(violations),
+			pluralize(len(violations), "y", "ies"), pluralize(len(violations), "s", ""))
+	}
+
+	fmt.Printf("✔ The dependencies of %d Go project%s follow the constraints\n", len(deps), pluralize(len(deps), "", "s"))
+	return nil
+}
@@ -3,11 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os/exec"
+	"os"
+	"strconv"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/internal/generator"
 	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +23,8 @@ var (
 	newGKERegion      string
 	newGKECluster     string
 	newYes            bool // Skip all prompts
+	newForce          bool // Add missing files to an existing workspace
+	newTemplate       string
 )
 
 var newCmd = &cobra.Command{
@@ -31,7 +37,13 @@ Examples:
   forge new my-project
   forge new my-project --github-org=mycompany
   forge new my-project --docker-registry=gcr.io/mycompany
-  forge new my-project --gcp-project=my-gcp-project`,
+  forge new my-project --gcp-project=my-gcp-project
+  forge new my-project --template fullstack-angular
+  forge new my-project --force --yes     # Restore missing scaffolding in an existing workspace
+
+Templates create the workspace with a preset set of services and
+applications instead of prompting for them:
+` + presetsHelp(),
 	Args: cobra.MaximumNArgs(1),
 	RunE: runNew,
 }
@@ -46,6 +58,8 @@ func init() {
 	newCmd.Flags().StringVar(&newGKERegion, "gke-region", "us-central1", "GKE cluster region")
 	newCmd.Flags().StringVar(&newGKECluster, "gke-cluster", "", "GKE cluster name (defaults to <workspace>-cluster)")
 	newCmd.Flags().BoolVarP(&newYes, "yes", "y", false, "Skip all prompts and use defaults (non-interactive mode)")
+	newCmd.Flags().BoolVar(&newForce, "force", false, "Add missing files to an existing workspace without overwriting existing ones")
+	newCmd.Flags().StringVar(&newTemplate, "template", "", "Create the workspace from a preset ("+strings.Join(generator.ListPresets(), ", ")+")")
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
@@ -57,12 +71,16 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("workspace name is required in non-interactive mode")
 	}
 
+	if newTemplate != "" {
+		return runNewFromTemplate(cmd.Context(), name, newTemplate)
+	}
+
 	// Collect initial values from flags
 	githubOrg := newGitHubOrg
 
 	// If github-org not provided, try to get it from git config
 	if githubOrg == "" {
-		if org, err := getOrgFromGit(); err == nil && org != "" {
+		if org, err := getOrgFromGit(cmd.Context()); err == nil && org != "" {
 			githubOrg = org
 		}
 	}
@@ -73,10 +91,16 @@ func runNew(cmd *cobra.Command, args []string) error {
 		if githubOrg == "" {
 			githubOrg = "example"
 		}
-		return runNewNonInteractive(name, githubOrg)
+		if err := workspace.ValidateName(name); err != nil {
+			return fmt.Errorf("invalid workspace name: %w", err)
+		}
+		return runNewNonInteractive(cmd.Context(), name, githubOrg, []interface{}{}, []interface{}{})
 	}
 
 	// Interactive mode
+	if !ui.Interactive() {
+		return fmt.Errorf("forge new prompts for services and applications; provide --yes and a workspace name when prompts are disabled by --no-interactive or CI")
+	}
 	var err error
 
 	// Create prompter
@@ -93,6 +117,9 @@ func runNew(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 	}
+	if name, err = checkName("workspace", name); err != nil {
+		return err
+	}
 
 	// If still not set, prompt for it
 	if githubOrg == "" {
@@ -109,8 +136,10 @@ func runNew(cmd *cobra.Command, args []string) error {
 	gkeRegion := newGKERegion
 	gkeCluster := newGKECluster
 
-	// Build services list
+	// Build services list. Each service gets its own local serve port, so the
+	// port prompts default to the next one that is still free.
 	var servicesData []interface{}
+	usedPorts := make(map[int]bool)
 
 	// Ask for services in a loop
 	for {
@@ -129,6 +158,9 @@ func runNew(cmd *cobra.Command, args []string) error {
 			fmt.Println("Workspace creation cancelled.")
 			return nil
 		}
+		if serviceName, err = checkName("service", serviceName); err != nil {
+			return err
+		}
 
 		serviceType, err := prompter.AskSelect("Which backend framework would you like to use?", []string{"Go", "NestJS"})
 		if err != nil {
@@ -137,23 +169,12 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 
 		// Prompt for deployer selection
-		deployerChoice, err := prompter.AskSelect("Which deployment target would you like to use?", []string{"Helm (Kubernetes)", "CloudRun"})
+		deployer, err := askDeployer(prompter.AskSelect, "Which deployment target would you like to use?", strings.ToLower(serviceType))
 		if err != nil {
 			fmt.Println("Workspace creation cancelled.")
 			return nil
 		}
 
-		// Map display names to internal names
-		var deployer string
-		switch deployerChoice {
-		case "Helm (Kubernetes)":
-			deployer = "helm"
-		case "CloudRun":
-			deployer = "cloudrun"
-		default:
-			deployer = "helm"
-		}
-
 		// Prompt for deployer-specific configuration
 		deployerConfig := make(map[string]string)
 		switch deployer {
@@ -165,11 +186,17 @@ func runNew(cmd *cobra.Command, args []string) error {
 			}
 			deployerConfig["namespace"] = namespace
 
-			port, err := prompter.AskText("Service port", "8080")
+			defaultPort := strconv.Itoa(workspace.NextFreePort(servicePortBase(serviceType), usedPorts))
+			port, err := prompter.AskText("Service port", defaultPort)
 			if err != nil {
 				fmt.Println("Workspace creation cancelled.")
 				return nil
 			}
+			portNumber, err := workspace.ParsePort(port)
+			if err != nil {
+				return err
+			}
+			usedPorts[portNumber] = true
 			deployerConfig["port"] = port
 
 			healthPath, err := prompter.AskText("Health check path", "/health")
@@ -193,6 +220,48 @@ func runNew(cmd *cobra.Command, args []string) error {
 				return nil
 			}
 			deployerConfig["memory"] = memory
+
+		case "ecs":
+			region, err := prompter.AskText("AWS region", "us-east-1")
+			if err != nil {
+				fmt.Println("Workspace creation cancelled.")
+				return nil
+			}
+			deployerConfig["region"] = region
+
+			cluster, err := prompter.AskText("ECS cluster", "default")
+			if err != nil {
+				fmt.Println("Workspace creation cancelled.")
+				return nil
+			}
+			deployerConfig["cluster"] = cluster
+
+		case "aca":
+			resourceGroup, err := prompter.AskText("Azure resource group", "")
+			if err != nil {
+				fmt.Println("Workspace creation cancelled.")
+				return nil
+			}
+			deployerConfig["resourceGroup"] = resourceGroup
+
+			location, err := prompter.AskText("Azure location", "eastus")
+			if err != nil {
+				fmt.Println("Workspace creation cancelled.")
+				return nil
+			}
+			deployerConfig["location"] = location
+
+			environment, err := prompter.AskText("Container Apps environment", "")
+			if err != nil {
+				fmt.Println("Workspace creation cancelled.")
+				return nil
+			}
+			deployerConfig["environment"] = environment
+		}
+
+		if _, ok := deployerConfig["port"]; !ok {
+			// Reserve the port the generator will pick for this service
+			usedPorts[workspace.NextFreePort(servicePortBase(serviceType), usedPorts)] = true
 		}
 
 		service := map[string]interface{}{
@@ -224,32 +293,23 @@ func runNew(cmd *cobra.Command, args []string) error {
 			fmt.Println("Workspace creation cancelled.")
 			return nil
 		}
+		if appName, err = checkName("application", appName); err != nil {
+			return err
+		}
 
-		appType, err := prompter.AskSelect("Which frontend framework would you like to use?", []string{"Angular", "Next.js"})
+		appType, err := prompter.AskSelect("Which frontend framework would you like to use?", []string{"Angular", "Vue", "Next.js"})
 		if err != nil {
 			fmt.Println("Workspace creation cancelled.")
 			return nil
 		}
 
-		deployerChoice, err := prompter.AskSelect("Which deployment target would you like to use?", []string{"Firebase", "Helm (Kubernetes)", "CloudRun"})
+		framework := strings.ToLower(strings.ReplaceAll(appType, ".", ""))
+		deployer, err := askDeployer(prompter.AskSelect, "Which deployment target would you like to use?", framework)
 		if err != nil {
 			fmt.Println("Workspace creation cancelled.")
 			return nil
 		}
 
-		// Map display names to internal names
-		var deployer string
-		switch deployerChoice {
-		case "Firebase":
-			deployer = "firebase"
-		case "Helm (Kubernetes)":
-			deployer = "helm"
-		case "CloudRun":
-			deployer = "cloudrun"
-		default:
-			deployer = "firebase"
-		}
-
 		// Prompt for deployer-specific configuration
 		deployerConfig := make(map[string]string)
 		switch deployer {
@@ -360,16 +420,25 @@ func runNew(cmd *cobra.Command, args []string) error {
 			"gke_cluster":     gkeCluster,
 			"services":        servicesData,
 			"frontends":       frontendsData,
+			"force":           newForce,
 		},
 		DryRun: false,
 	}
 
 	// Generate workspace
-	ctx := context.Background()
+	_, statErr := os.Stat(name)
+	completing := newForce && statErr == nil
+
+	ctx := cmd.Context()
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to create workspace: %w", err)
 	}
 
+	// Completing an existing workspace reports its own progress
+	if completing {
+		return nil
+	}
+
 	fmt.Printf("CREATE %s\n", name)
 	fmt.Println("✔ Workspace created successfully.")
 	fmt.Printf("\nNext steps:\n")
@@ -379,14 +448,114 @@ func runNew(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// runNewNonInteractive creates a workspace without any prompts
-func runNewNonInteractive(name, githubOrg string) error {
+// runNewFromTemplate creates a workspace with the services and applications
+// of a preset, prompting only for a missing workspace name or organization.
+func runNewFromTemplate(ctx context.Context, name, templateName string) error {
+	preset, err := generator.LoadPreset(templateName)
+	if err != nil {
+		return err
+	}
+	if err := validatePreset(preset); err != nil {
+		return err
+	}
+
+	githubOrg := newGitHubOrg
+	if githubOrg == "" {
+		if org, err := getOrgFromGit(ctx); err == nil && org != "" {
+			githubOrg = org
+		}
+	}
+
+	if name == "" || githubOrg == "" {
+		if ui.Interactive() && !newYes {
+			prompter, err := ui.NewPrompter()
+			if err != nil {
+				return fmt.Errorf("failed to create prompter: %w", err)
+			}
+			if name == "" {
+				if name, err = prompter.AskText("What name would you like to use for the workspace?", ""); err != nil {
+					fmt.Println("Workspace creation cancelled.")
+					return nil
+				}
+			}
+			if githubOrg == "" {
+				if githubOrg, err = prompter.AskText("Organization/username (e.g., mycompany, myuser)", ""); err != nil {
+					fmt.Println("Workspace creation cancelled.")
+					return nil
+				}
+			}
+		} else if name == "" {
+			return fmt.Errorf("workspace name is required in non-interactive mode")
+		}
+	}
+	if githubOrg == "" {
+		githubOrg = "example"
+	}
+	if name, err = checkName("workspace", name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Using template %s: %s\n", preset.Name, preset.Description)
+	for _, svc := range preset.Services {
+		fmt.Printf("  - service %s (%s, %s)\n", svc.Name, svc.Type, svc.Deployer)
+	}
+	for _, app := range preset.Frontends {
+		fmt.Printf("  - application %s (%s, %s)\n", app.Name, app.Type, app.Deployer)
+	}
+
+	return runNewNonInteractive(ctx, name, githubOrg, preset.ServicesData(), preset.FrontendsData())
+}
+
+// validatePreset checks that a preset's project names are valid and that each
+// project uses a deployer offered for its framework.
+func validatePreset(preset *generator.Preset) error {
+	check := func(kind, name, framework, deployerName string) error {
+		if err := workspace.ValidateName(name); err != nil {
+			return fmt.Errorf("template %s: invalid %s name: %w", preset.Name, kind, err)
+		}
+		language := strings.ToLower(strings.ReplaceAll(framework, ".", ""))
+		for _, supported := range deployer.Names(language) {
+			if supported == deployerName {
+				return nil
+			}
+		}
+		return fmt.Errorf("template %s: %s %s uses deployer %q, which does not support %s projects", preset.Name, kind, name, deployerName, framework)
+	}
+
+	for _, svc := range preset.Services {
+		if err := check("service", svc.Name, svc.Type, svc.Deployer); err != nil {
+			return err
+		}
+	}
+	for _, app := range preset.Frontends {
+		if err := check("application", app.Name, app.Type, app.Deployer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// presetsHelp lists the workspace presets for the command help.
+func presetsHelp() string {
+	var b strings.Builder
+	for _, name := range generator.ListPresets() {
+		preset, err := generator.LoadPreset(name)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "  - %s: %s\n", preset.Name, preset.Description)
+	}
+	return b.String()
+}
+
+// runNewNonInteractive creates a workspace with the given services and
+// frontends without any prompts
+func runNewNonInteractive(ctx context.Context, name, githubOrg string, services, frontends []interface{}) error {
 	fmt.Printf("CREATE Creating workspace '%s'...\n", name)
 
 	// Create generator
 	gen := generator.NewWorkspaceGenerator()
 
-	// Prepare options with empty services/frontends (they will be added via forge generate)
 	opts := generator.GeneratorOptions{
 		OutputDir: ".",
 		Name:      name,
@@ -397,18 +566,26 @@ func runNewNonInteractive(name, githubOrg string) error {
 			"k8s_namespace":   newK8sNamespace,
 			"gke_region":      newGKERegion,
 			"gke_cluster":     newGKECluster,
-			"services":        []interface{}{},
-			"frontends":       []interface{}{},
+			"services":        services,
+			"frontends":       frontends,
+			"force":           newForce,
 		},
 		DryRun: false,
 	}
 
 	// Generate workspace
-	ctx := context.Background()
+	_, statErr := os.Stat(name)
+	completing := newForce && statErr == nil
+
 	if err := gen.Generate(ctx, opts); err != nil {
 		return fmt.Errorf("failed to create workspace: %w", err)
 	}
 
+	// Completing an existing workspace reports its own progress
+	if completing {
+		return nil
+	}
+
 	fmt.Printf("CREATE %s\n", name)
 	fmt.Println("✔ Workspace created successfully.")
 
@@ -417,34 +594,28 @@ func runNewNonInteractive(name, githubOrg string) error {
 
 // getOrgFromGit tries to get the organization/username from git config.
 // This could be for GitHub, GitLab, Bitbucket, or any git hosting provider.
-func getOrgFromGit() (string, error) {
-	// Try github.user first (common convention)
-	cmd := exec.Command("git", "config", "--get", "github.user")
-	if output, err := cmd.Output(); err == nil {
-		org := strings.TrimSpace(string(output))
-		if org != "" && !strings.Contains(org, " ") {
-			return org, nil
+func getOrgFromGit(ctx context.Context) (string, error) {
+	// Try github.user first (common convention), then gitlab.user, and fall
+	// back to user.name, but only if it's username-like (no spaces, likely a
+	// username rather than a full name)
+	for _, key := range []string{"github.user", "gitlab.user", "user.name"} {
+		output, err := exec.Capture(ctx, exec.Options{Name: "git", Args: []string{"config", "--get", key}})
+		if err != nil {
+			continue
 		}
-	}
-
-	// Try gitlab.user
-	cmd = exec.Command("git", "config", "--get", "gitlab.user")
-	if output, err := cmd.Output(); err == nil {
-		org := strings.TrimSpace(string(output))
-		if org != "" && !strings.Contains(org, " ") {
+		if org := strings.TrimSpace(string(output)); org != "" && !strings.Contains(org, " ") {
 			return org, nil
 		}
 	}
 
-	// Fallback to user.name, but only if it's username-like (no spaces)
-	cmd = exec.Command("git", "config", "--get", "user.name")
-	if output, err := cmd.Output(); err == nil {
-		username := strings.TrimSpace(string(output))
-		// Only return if it doesn't contain spaces (likely a username, not a full name)
-		if username != "" && !strings.Contains(username, " ") {
-			return username, nil
-		}
-	}
-
 	return "", fmt.Errorf("no git config found")
 }
+
+// servicePortBase returns the port local serve ports of a backend framework
+// are counted up from.
+func servicePortBase(serviceType string) int {
+	if serviceType == "NestJS" {
+		return 3000
+	}
+	return 8080
+}
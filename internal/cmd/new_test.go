@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/generator"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	names := generator.ListPresets()
+	for _, want := range []string{"go-api", "fullstack-angular", "grpc-mesh"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("preset %s is missing from %v", want, names)
+		}
+	}
+
+	for _, name := range names {
+		preset, err := generator.LoadPreset(name)
+		if err != nil {
+			t.Fatalf("LoadPreset(%s): %v", name, err)
+		}
+		if preset.Description == "" {
+			t.Errorf("preset %s has no description", name)
+		}
+		if len(preset.Services)+len(preset.Frontends) == 0 {
+			t.Errorf("preset %s has no projects", name)
+		}
+		if err := validatePreset(preset); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, err := generator.LoadPreset("missing"); err == nil {
+		t.Error("LoadPreset(missing) succeeded")
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openEnv       string
+	openDashboard bool
+	openPrint     bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <project>",
+	Short: "Open the URL or console of a deployed project",
+	Long: `Print a deployed project's URL and open it in the browser.
+
+The URL depends on the project's deployer:
+  cloudrun   the service URL, from 'gcloud run services describe'
+  firebase   the hosting site of the app's target in .firebaserc (https://<site>.web.app)
+  helm       the ingress host of the chart values for the environment
+
+With --dashboard the project's console is opened instead: the Cloud Run
+service or the GKE workloads in the Google Cloud console, or Firebase Hosting
+in the Firebase console.
+
+The browser is started with xdg-open, open (macOS) or the URL handler of
+Windows. Use --print to only print the URL.
+
+Examples:
+  forge open api --env=production
+  forge open web --dashboard
+  forge open api --print`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOpen,
+}
+
+func init() {
+	openCmd.Flags().StringVarP(&openEnv, "env", "e", "", "Environment/profile the project is deployed to (defaults to 'forge env use')")
+	openCmd.Flags().BoolVar(&openDashboard, "dashboard", false, "Open the project's GCP or Firebase console instead of its URL")
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URL without opening it")
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	configuration := config.DeployConfiguration(openEnv)
+	var url string
+	if openDashboard {
+		url, err = deployer.DashboardURL(config, workspaceRoot, args[0], configuration)
+	} else {
+		url, err = deployer.ProjectURL(cmd.Context(), config, workspaceRoot, args[0], configuration)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(url)
+	if openPrint {
+		return nil
+	}
+	if err := ui.OpenBrowser(cmd.Context(), url); err != nil {
+		log.Warn("Could not open the browser: %v", err)
+	}
+	return nil
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/dosanma1/forge-cli/internal/daemon"
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -17,31 +22,59 @@ var protoCmd = &cobra.Command{
 	Long: `Compile protocol buffers using buf or protoc.
 
 This command will:
-- Scan for proto/ directories in services
+- Scan for proto/ directories in services, including the proto directories
+  registered in forge.json by 'forge generate service --grpc' and
+  'forge generate library --proto'
 - Detect buf.yaml or use protoc
 - Compile .proto files to Go/TypeScript
 - Generate gRPC stubs
 
+--lang selects the protoc outputs: go (Go and gRPC stubs next to the .proto
+files), ts (TypeScript stubs written to src/gen of every Angular and Vue
+project in forge.json) or both. Without --lang the
+languages are asked interactively. buf uses its buf.gen.yaml instead.
+
+If a buf.yaml exists but buf is not installed, protoc is used instead.
+
+--watch keeps running and recompiles a proto directory whenever one of its
+.proto files changes.
+
+'forge proto lint' and 'forge proto breaking --against <git-ref>' check the
+same directories with buf.
+
 Examples:
   forge proto
   forge proto --tool=buf
-  forge proto --tool=protoc`,
+  forge proto --tool=protoc
+  forge proto --lang=both
+  forge proto --watch --lang=go
+  forge proto lint
+  forge proto breaking --against main`,
 	RunE: runProto,
 }
 
-var protoTool string
+var (
+	protoTool  string
+	protoLang  string
+	protoWatch bool
+)
+
+// protoWatchDebounce groups the writes of an editor save into one recompilation.
+const protoWatchDebounce = 300 * time.Millisecond
 
 func init() {
 	rootCmd.AddCommand(protoCmd)
 	protoCmd.Flags().StringVar(&protoTool, "tool", "auto", "Protobuf tool to use: auto, buf, or protoc")
+	protoCmd.Flags().StringVar(&protoLang, "lang", "", "Languages to generate with protoc: go, ts, or both (default: ask)")
+	protoCmd.Flags().BoolVarP(&protoWatch, "watch", "w", false, "Recompile when .proto files change")
 }
 
 func runProto(cmd *cobra.Command, args []string) error {
 
 	// Find proto directories
-	protoDirs, err := findProtoDirs(".")
+	protoDirs, err := discoverProtoDirs()
 	if err != nil {
-		return fmt.Errorf("failed to scan for proto directories: %w", err)
+		return err
 	}
 
 	if len(protoDirs) == 0 {
@@ -58,40 +91,206 @@ func runProto(cmd *cobra.Command, args []string) error {
 
 	// Determine tool to use
 	tool := protoTool
-	if tool == "auto" {
+	switch tool {
+	case "auto":
 		tool, err = detectProtoTool(protoDirs)
 		if err != nil {
 			return err
 		}
+	case "buf":
+		if _, err := exec.LookPath("buf"); err != nil {
+			if _, err := exec.LookPath("protoc"); err != nil {
+				return fmt.Errorf("buf is not installed and protoc was not found")
+			}
+			fmt.Println("⚠️  buf is not installed, falling back to protoc")
+			tool = "protoc"
+		}
+	case "protoc":
+	default:
+		return fmt.Errorf("unknown tool: %s", tool)
 	}
 
 	fmt.Printf("Using tool: %s\n\n", tool)
 
+	var languages []string
+	if tool == "protoc" {
+		languages, err = protoLanguages()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Compile each directory
 	for _, dir := range protoDirs {
-		fmt.Printf("Compiling %s...\n", dir)
+		if err := compileProtoDir(cmd.Context(), tool, dir, languages); err != nil {
+			if protoWatch {
+				continue
+			}
+			return err
+		}
+	}
+
+	if protoWatch {
+		return watchProto(cmd.Context(), protoDirs, tool, languages)
+	}
+
+	fmt.Println("✔ All proto files compiled successfully.")
+	return nil
+}
+
+// compileProtoDir compiles a single proto directory with tool.
+func compileProtoDir(ctx context.Context, tool, dir string, languages []string) error {
+	fmt.Printf("Compiling %s...\n", dir)
+
+	var compileErr error
+	switch tool {
+	case "buf":
+		compileErr = compileBuf(ctx, dir)
+	case "protoc":
+		compileErr = compileProtoc(ctx, dir, languages)
+	}
+
+	if compileErr != nil {
+		fmt.Printf("✗ Failed: %v\n", compileErr)
+		return compileErr
+	}
+
+	fmt.Println("✔ Success")
+	fmt.Println()
+	return nil
+}
+
+// watchProto recompiles the proto directory of every changed .proto file until
+// ctx is cancelled (Ctrl-C).
+func watchProto(ctx context.Context, protoDirs []string, tool string, languages []string) error {
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	config := daemon.DefaultWatcherConfig(root)
+	config.Patterns = []string{"*.proto"}
+	config.IgnorePatterns = []string{".git", "node_modules", "vendor", "dist", "bazel-*", ".idea", ".vscode"}
+
+	watcher, err := daemon.NewWatcher(config)
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	if err := watcher.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer watcher.Stop()
+
+	fmt.Println("👀 Watching .proto files (Ctrl-C to stop)")
+
+	changed := make(map[string]bool)
+	timer := time.NewTimer(protoWatchDebounce)
+	timer.Stop()
 
-		var compileErr error
-		switch tool {
-		case "buf":
-			compileErr = compileBuf(dir)
-		case "protoc":
-			compileErr = compileProtoc(dir)
-		default:
-			return fmt.Errorf("unknown tool: %s", tool)
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n👋 Stopped watching")
+			return nil
+
+		case err := <-watcher.Errors():
+			fmt.Printf("⚠️  Watcher error: %v\n", err)
+
+		case event := <-watcher.Events():
+			if dir := owningProtoDir(root, event.Path, protoDirs); dir != "" {
+				changed[dir] = true
+				timer.Reset(protoWatchDebounce)
+			}
+
+		case <-timer.C:
+			dirs := make([]string, 0, len(changed))
+			for dir := range changed {
+				dirs = append(dirs, dir)
+			}
+			sort.Strings(dirs)
+			changed = make(map[string]bool)
+
+			fmt.Printf("\n🔄 Proto files changed in %s\n", strings.Join(dirs, ", "))
+			for _, dir := range dirs {
+				// Errors are printed and the watch goes on until the file is fixed
+				_ = compileProtoDir(ctx, tool, dir, languages)
+			}
 		}
+	}
+}
 
-		if compileErr != nil {
-			fmt.Printf("✗ Failed: %v\n", compileErr)
-			return compileErr
+// owningProtoDir returns the proto directory (relative to root) containing path.
+func owningProtoDir(root, path string, protoDirs []string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return ""
+	}
+	for _, dir := range protoDirs {
+		if rel == dir || strings.HasPrefix(rel, dir+string(filepath.Separator)) {
+			return dir
 		}
+	}
+	return ""
+}
 
-		fmt.Println("✔ Success")
-		fmt.Println()
+// protoLanguages returns the protoc output languages from --lang, or asks for them.
+func protoLanguages() ([]string, error) {
+	switch strings.ToLower(protoLang) {
+	case "go":
+		return []string{"Go"}, nil
+	case "ts", "typescript":
+		return []string{"TypeScript"}, nil
+	case "both":
+		return []string{"Go", "TypeScript"}, nil
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown language %q (supported: go, ts, both)", protoLang)
 	}
 
-	fmt.Println("✔ All proto files compiled successfully.")
-	return nil
+	// Determine output languages
+	fmt.Println("Select output languages:")
+
+	var languages []string
+	genGo, err := ui.WithFlag("--lang").AskConfirm("Generate Go code?", true)
+	if err != nil {
+		return nil, err
+	}
+	if genGo {
+		languages = append(languages, "Go")
+	}
+
+	genTS, err := ui.WithFlag("--lang").AskConfirm("Generate TypeScript code?", false)
+	if err != nil {
+		return nil, err
+	}
+	if genTS {
+		languages = append(languages, "TypeScript")
+	}
+
+	genPython, err := ui.WithFlag("--lang").AskConfirm("Generate Python code?", false)
+	if err != nil {
+		return nil, err
+	}
+	if genPython {
+		languages = append(languages, "Python")
+	}
+
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("no languages selected")
+	}
+	fmt.Println()
+
+	return languages, nil
+}
+
+// discoverProtoDirs returns the proto directories under the working directory
+// and those registered in forge.json.
+func discoverProtoDirs() ([]string, error) {
+	protoDirs, err := findProtoDirs(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for proto directories: %w", err)
+	}
+	return mergeProtoDirs(protoDirs, registeredProtoDirs()), nil
 }
 
 func findProtoDirs(root string) ([]string, error) {
@@ -103,7 +302,7 @@ func findProtoDirs(root string) ([]string, error) {
 		}
 
 		// Skip hidden directories, node_modules, vendor, etc.
-		if info.IsDir() {
+		if info.IsDir() && path != root {
 			name := info.Name()
 			if strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" || name == "dist" || name == "bazel-" {
 				return filepath.SkipDir
@@ -122,6 +321,56 @@ func findProtoDirs(root string) ([]string, error) {
 	return protoDirs, err
 }
 
+// registeredProtoDirs returns the proto directories registered in forge.json
+// by gRPC projects and proto libraries under the working directory, relative
+// to it.
+func registeredProtoDirs() []string {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return nil
+	}
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, project := range config.Projects {
+		protoDir := project.ProtoDir()
+		if protoDir == "" {
+			continue
+		}
+		rel, err := filepath.Rel(cwd, filepath.Join(workspaceRoot, project.Root, protoDir))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if _, err := os.Stat(rel); err == nil {
+			dirs = append(dirs, rel)
+		}
+	}
+	return dirs
+}
+
+// mergeProtoDirs adds the registered directories missing from the scanned ones.
+func mergeProtoDirs(scanned, registered []string) []string {
+	seen := make(map[string]bool, len(scanned))
+	for _, dir := range scanned {
+		seen[filepath.Clean(dir)] = true
+	}
+	for _, dir := range registered {
+		if !seen[filepath.Clean(dir)] {
+			seen[filepath.Clean(dir)] = true
+			scanned = append(scanned, dir)
+		}
+	}
+	sort.Strings(scanned)
+	return scanned
+}
+
 func detectProtoTool(protoDirs []string) (string, error) {
 	// Check if buf is installed and buf.yaml exists
 	if _, err := exec.LookPath("buf"); err == nil {
@@ -135,13 +384,26 @@ func detectProtoTool(protoDirs []string) (string, error) {
 
 	// Check if protoc is installed
 	if _, err := exec.LookPath("protoc"); err == nil {
+		if hasBufConfig(protoDirs) {
+			fmt.Println("⚠️  buf.yaml found but buf is not installed, falling back to protoc")
+		}
 		return "protoc", nil
 	}
 
 	return "", fmt.Errorf("no protobuf compiler found. Install buf (https://buf.build) or protoc (https://grpc.io/docs/protoc-installation/)")
 }
 
-func compileBuf(protoDir string) error {
+// hasBufConfig reports whether any proto directory has a buf.yaml.
+func hasBufConfig(protoDirs []string) bool {
+	for _, dir := range protoDirs {
+		if _, err := os.Stat(filepath.Join(dir, "buf.yaml")); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func compileBuf(ctx context.Context, protoDir string) error {
 	// Check for buf.yaml
 	bufYaml := filepath.Join(protoDir, "buf.yaml")
 	if _, err := os.Stat(bufYaml); os.IsNotExist(err) {
@@ -149,15 +411,10 @@ func compileBuf(protoDir string) error {
 	}
 
 	// Run buf generate
-	cmd := exec.Command("buf", "generate")
-	cmd.Dir = protoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
+	return exec.Run(ctx, exec.Options{Name: "buf", Args: []string{"generate"}, Dir: protoDir})
 }
 
-func compileProtoc(protoDir string) error {
+func compileProtoc(ctx context.Context, protoDir string, languages []string) error {
 	// Find all .proto files
 	var protoFiles []string
 	err := filepath.Walk(protoDir, func(path string, info os.FileInfo, err error) error {
@@ -179,60 +436,105 @@ func compileProtoc(protoDir string) error {
 		return fmt.Errorf("no .proto files found in %s", protoDir)
 	}
 
-	// Determine output languages
-	fmt.Println("\nSelect output languages:")
+	// Build protoc command
+	args := []string{"--proto_path=."}
 
-	var languages []string
-	genGo, err := ui.AskConfirm("Generate Go code?", true)
-	if err != nil {
-		return err
+	generateTS := false
+	for _, lang := range languages {
+		switch lang {
+		case "Go":
+			args = append(args, "--go_out=paths=source_relative:.", "--go-grpc_out=paths=source_relative:.")
+		case "TypeScript":
+			generateTS = true
+		case "Python":
+			args = append(args, "--python_out=.", "--grpc_python_out=.")
+		}
 	}
-	if genGo {
-		languages = append(languages, "Go")
+
+	if len(args) > 1 {
+		if err := runProtoc(ctx, protoDir, append(args, protoFiles...)); err != nil {
+			return err
+		}
 	}
 
-	genTS, err := ui.AskConfirm("Generate TypeScript code?", false)
+	if generateTS {
+		return compileProtocTS(ctx, protoDir, protoFiles)
+	}
+	return nil
+}
+
+// compileProtocTS runs protoc-gen-ts once per Angular and Vue project in
+// forge.json, writing the stubs to src/gen of the project.
+func compileProtocTS(ctx context.Context, protoDir string, protoFiles []string) error {
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
 		return err
 	}
-	if genTS {
-		languages = append(languages, "TypeScript")
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	appRoots := frontendProjectRoots(workspaceRoot, config)
+	if len(appRoots) == 0 {
+		return fmt.Errorf("no Angular or Vue projects in forge.json for TypeScript output")
 	}
 
-	genPython, err := ui.AskConfirm("Generate Python code?", false)
+	plugin, err := findProtocGenTS(appRoots)
 	if err != nil {
 		return err
 	}
-	if genPython {
-		languages = append(languages, "Python")
-	}
 
-	if len(languages) == 0 {
-		return fmt.Errorf("no languages selected")
+	for _, appRoot := range appRoots {
+		outDir := filepath.Join(appRoot, "src", "gen")
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", outDir, err)
+		}
+
+		args := []string{"--proto_path=.", "--plugin=protoc-gen-ts=" + plugin, "--ts_out=" + outDir}
+		if err := runProtoc(ctx, protoDir, append(args, protoFiles...)); err != nil {
+			return fmt.Errorf("TypeScript generation for %s failed: %w", appRoot, err)
+		}
+		fmt.Printf("  ✓ TypeScript stubs written to %s\n", outDir)
 	}
 
-	// Build protoc command
-	args := []string{"--proto_path=" + protoDir}
+	return nil
+}
 
-	for _, lang := range languages {
-		switch lang {
-		case "Go":
-			args = append(args, "--go_out=.", "--go-grpc_out=.")
-		case "TypeScript":
-			args = append(args, "--ts_out=.")
-		case "Python":
-			args = append(args, "--python_out=.", "--grpc_python_out=.")
+// frontendProjectRoots returns the absolute roots of the Angular and Vue
+// projects in forge.json, sorted.
+func frontendProjectRoots(workspaceRoot string, config *workspace.Config) []string {
+	var roots []string
+	for _, project := range config.Projects {
+		language := workspace.LanguageType(project.Language)
+		if language != workspace.LanguageAngular && language != workspace.LanguageVue {
+			continue
 		}
+		roots = append(roots, filepath.Join(workspaceRoot, filepath.FromSlash(project.Root)))
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// findProtocGenTS locates the protoc-gen-ts plugin on PATH or in the
+// node_modules of one of the frontend projects.
+func findProtocGenTS(appRoots []string) (string, error) {
+	if path, err := exec.LookPath("protoc-gen-ts"); err == nil {
+		return path, nil
 	}
 
-	args = append(args, protoFiles...)
+	for _, appRoot := range appRoots {
+		local := filepath.Join(appRoot, "node_modules", ".bin", "protoc-gen-ts")
+		if _, err := os.Stat(local); err == nil {
+			return local, nil
+		}
+	}
 
-	cmd := exec.Command("protoc", args...)
-	cmd.Dir = protoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	return "", fmt.Errorf("protoc-gen-ts not found. Install it in a frontend project with: npm install --save-dev ts-protoc-gen")
+}
 
-	return cmd.Run()
+func runProtoc(ctx context.Context, protoDir string, args []string) error {
+	return exec.Run(ctx, exec.Options{Name: "protoc", Args: args, Dir: protoDir})
 }
 
 func pluralize(count int, singular, plural string) string {
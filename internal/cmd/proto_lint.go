@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/spf13/cobra"
+)
+
+var protoLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint protocol buffers with buf",
+	Long: `Run 'buf lint' in every proto directory of the workspace.
+
+Every directory is linted, and the command fails if any of them has lint
+errors. Directories without a buf.yaml use buf's default rules.
+
+Linting requires buf (https://buf.build/docs/installation): protoc cannot lint.
+
+Examples:
+  forge proto lint`,
+	Args: cobra.NoArgs,
+	RunE: runProtoLint,
+}
+
+var protoBreakingCmd = &cobra.Command{
+	Use:   "breaking --against <git-ref>",
+	Short: "Detect breaking changes in protocol buffers with buf",
+	Long: `Run 'buf breaking' in every proto directory of the workspace, comparing
+it to the same directory at a git reference.
+
+Proto directories that do not exist at the reference are new and skipped.
+The command fails if any directory has a breaking change, so it can gate
+CI alongside 'forge proto'.
+
+Requires buf (https://buf.build/docs/installation).
+
+Examples:
+  forge proto breaking --against main
+  forge proto breaking --against origin/main
+  forge proto breaking --against v1.2.0`,
+	Args: cobra.NoArgs,
+	RunE: runProtoBreaking,
+}
+
+var protoAgainst string
+
+func init() {
+	protoCmd.AddCommand(protoLintCmd)
+	protoCmd.AddCommand(protoBreakingCmd)
+	protoBreakingCmd.Flags().StringVar(&protoAgainst, "against", "", "Git reference to compare to (branch, tag or commit)")
+	protoBreakingCmd.MarkFlagRequired("against")
+}
+
+func runProtoLint(cmd *cobra.Command, args []string) error {
+	protoDirs, err := bufProtoDirs()
+	if err != nil || len(protoDirs) == 0 {
+		return err
+	}
+
+	ctx := cmd.Context()
+	return forEachProtoDir(protoDirs, "lint", func(dir string) (bool, error) {
+		fmt.Printf("Linting %s...\n", dir)
+		return true, exec.Run(ctx, exec.Options{Name: "buf", Args: []string{"lint"}, Dir: dir})
+	})
+}
+
+func runProtoBreaking(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	gitRoot, err := exec.Capture(ctx, exec.Options{Name: "git", Args: []string{"rev-parse", "--show-toplevel"}})
+	if err != nil {
+		return fmt.Errorf("forge proto breaking compares to a git reference and must run in a git repository: %w", err)
+	}
+	repo := strings.TrimSpace(string(gitRoot))
+	if _, err := exec.Capture(ctx, exec.Options{Name: "git", Args: []string{"rev-parse", "--verify", "--quiet", protoAgainst + "^{commit}"}}); err != nil {
+		return fmt.Errorf("unknown git reference %q", protoAgainst)
+	}
+
+	protoDirs, err := bufProtoDirs()
+	if err != nil || len(protoDirs) == 0 {
+		return err
+	}
+
+	return forEachProtoDir(protoDirs, "breaking change check", func(dir string) (bool, error) {
+		subdir, err := repoRelPath(repo, dir)
+		if err != nil {
+			return false, err
+		}
+		// A directory missing at the reference has nothing to break
+		if _, err := exec.Capture(ctx, exec.Options{Name: "git", Args: []string{"cat-file", "-e", protoAgainst + ":" + subdir}}); err != nil {
+			fmt.Printf("Skipping %s (not in %s)\n\n", dir, protoAgainst)
+			return false, nil
+		}
+
+		fmt.Printf("Checking %s against %s...\n", dir, protoAgainst)
+		return true, exec.Run(ctx, exec.Options{
+			Name: "buf",
+			Args: []string{"breaking", dir, "--against", bufGitInput(repo, protoAgainst, subdir)},
+		})
+	})
+}
+
+// bufProtoDirs returns the proto directories of the workspace, after checking
+// that buf is installed.
+func bufProtoDirs() ([]string, error) {
+	if _, err := exec.LookPath("buf"); err != nil {
+		return nil, fmt.Errorf("buf is not installed; install it from https://buf.build/docs/installation (protoc cannot lint or detect breaking changes)")
+	}
+
+	protoDirs, err := discoverProtoDirs()
+	if err != nil {
+		return nil, err
+	}
+	if len(protoDirs) == 0 {
+		fmt.Println("No proto/ directories found")
+	}
+	return protoDirs, nil
+}
+
+// forEachProtoDir runs check in every proto directory, going on after
+// failures, and fails when any of them failed. check reports whether it ran.
+func forEachProtoDir(protoDirs []string, what string, check func(dir string) (bool, error)) error {
+	var failed []string
+	checked := 0
+	for _, dir := range protoDirs {
+		ran, err := check(dir)
+		if err != nil {
+			fmt.Printf("✗ Failed: %v\n\n", err)
+			failed = append(failed, dir)
+			continue
+		}
+		if ran {
+			checked++
+			fmt.Println("✔ Success")
+			fmt.Println()
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("proto %s failed in %d of %d director%s: %s", what, len(failed), len(protoDirs), pluralize(len(protoDirs), "y", "ies"), strings.Join(failed, ", "))
+	}
+	fmt.Printf("✔ Proto %s passed in %d director%s.\n", what, checked, pluralize(checked, "y", "ies"))
+	return nil
+}
+
+// repoRelPath returns dir relative to the root of the git repository.
+func repoRelPath(repo, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	// The repository root is reported with symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(repo); err == nil {
+		repo = resolved
+	}
+	rel, err := filepath.Rel(repo, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", fmt.Errorf("%s is outside the git repository", dir)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// bufGitInput returns the buf input for subdir of the repository at ref.
+func bufGitInput(repo, ref, subdir string) string {
+	return fmt.Sprintf("%s#ref=%s,subdir=%s", filepath.Join(repo, ".git"), ref, subdir)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestForEachProtoDirAggregatesFailures(t *testing.T) {
+	var visited []string
+	err := forEachProtoDir([]string{"a/proto", "b/proto", "c/proto"}, "lint", func(dir string) (bool, error) {
+		visited = append(visited, dir)
+		if dir != "b/proto" {
+			return true, fmt.Errorf("lint errors")
+		}
+		return true, nil
+	})
+
+	if len(visited) != 3 {
+		t.Errorf("visited %v, want every directory", visited)
+	}
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "2 of 3") || !strings.Contains(err.Error(), "a/proto, c/proto") {
+		t.Errorf("error %q does not list the failed directories", err)
+	}
+
+	if err := forEachProtoDir([]string{"a/proto"}, "lint", func(string) (bool, error) { return false, nil }); err != nil {
+		t.Errorf("skipped directories failed: %v", err)
+	}
+}
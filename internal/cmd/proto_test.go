@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestFrontendProjectRoots(t *testing.T) {
+	root := t.TempDir()
+	config := workspace.NewConfig("shop")
+	config.Projects = map[string]workspace.Project{
+		"web":    {ProjectType: "application", Language: "angular", Root: workspace.DefaultFrontendAppsPath + "/web"},
+		"admin":  {ProjectType: "application", Language: "vue", Root: workspace.DefaultFrontendAppsPath + "/admin"},
+		"orders": {ProjectType: "service", Language: "go", Root: "backend/services/orders"},
+	}
+
+	// Roots come from forge.json, not from the working directory
+	got := frontendProjectRoots(root, config)
+	want := []string{
+		filepath.Join(root, "frontend", "apps", "admin"),
+		filepath.Join(root, "frontend", "apps", "web"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frontendProjectRoots() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackEnv      string
+	rollbackRevision string
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <project...>",
+	Short: "Return projects to their previous release",
+	Long: `Revert a bad deploy.
+
+For Helm the release is rolled back with 'helm rollback' in the project's
+namespace. For Cloud Run all traffic is routed back to an earlier revision with
+'gcloud run services update-traffic'. Namespace, region and GCP project come
+from the deploy options of the environment.
+
+Without --to-revision each project returns to its last good release before the
+current one. 'forge deploy --rollback' does the same for the projects it
+selects.`,
+	Example: `  forge rollback api --env=production
+  forge rollback api --to-revision=4                 # Helm release revision
+  forge rollback web --to-revision=web-00012-abc     # Cloud Run revision`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRollback,
+}
+
+func init() {
+	rollbackCmd.Flags().StringVarP(&rollbackEnv, "env", "e", "", "Environment/profile to roll back (defaults to 'forge env use')")
+	rollbackCmd.Flags().StringVar(&rollbackRevision, "to-revision", "", "Helm revision number or Cloud Run revision name to return to (default: the previous one)")
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	projects, err := expandProjectPatterns(config, args, nil)
+	if err != nil {
+		return err
+	}
+	if rollbackRevision != "" && len(projects) > 1 {
+		return fmt.Errorf("--to-revision applies to a single project")
+	}
+
+	return rollbackProjects(cmd.Context(), config, projects, config.DeployConfiguration(rollbackEnv), rollbackRevision)
+}
+
+// rollbackProjects rolls each project back to revision, or to its previous
+// release when revision is empty. Every target is resolved before anything is
+// rolled back.
+func rollbackProjects(ctx context.Context, config *workspace.Config, projects []string, configuration, revision string) error {
+	targets := make([]*deployer.CanaryTarget, 0, len(projects))
+	for _, project := range projects {
+		target, err := deployer.ResolveRollbackTarget(config, project, configuration)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	for _, target := range targets {
+		fmt.Printf("⏪ Rolling back %s (%s)...\n", target.Project, configuration)
+		rolledBackTo, err := deployer.Rollback(ctx, target, revision)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to roll back %s: %w", target.Project, err)
+		}
+		fmt.Printf("✅ %s rolled back to revision %s\n", target.Project, rolledBackTo)
+	}
+
+	return nil
+}
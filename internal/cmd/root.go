@@ -1,9 +1,41 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/internal/update"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
+var (
+	logQuiet   bool
+	logVerbose bool
+	logFormat  string
+
+	strictConfig bool
+
+	noInteractive bool
+
+	templatesDir string
+
+	noUpdateCheck bool
+	updateCheck   *update.Check
+)
+
+// version is the CLI version, set at build time with
+// -ldflags "-X github.com/dosanma1/forge-cli/internal/cmd.version=1.2.3"
+var version = "1.0.0"
+
 var rootCmd = &cobra.Command{
 	Use:   "forge",
 	Short: "Forge CLI - Production-ready microservice scaffolding",
@@ -11,11 +43,127 @@ var rootCmd = &cobra.Command{
 It provides standardized patterns for Go services with built-in observability, authentication, and more.
 
 Built with ❤️ following industry best practices.`,
-	Version: "1.0.0",
+	Version: version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// forge validate has its own --strict, which shadows the global one
+		strict := strictConfig
+		if flag := cmd.Flags().Lookup("strict"); flag != nil {
+			strict = flag.Value.String() == "true"
+		}
+		template.SetOverrideDir(templatesDir)
+		ui.SetNonInteractive(noInteractive)
+
+		if err := configureLogging(cmd); err != nil {
+			return err
+		}
+		if err := checkUnknownFields(cmd, strict); err != nil {
+			return err
+		}
+
+		startUpdateCheck(cmd)
+		return nil
+	},
 }
 
+// Execute runs the forge command line. Commands get a context cancelled on
+// Ctrl-C or SIGTERM, which interrupts the tools they run (bazel, skaffold,
+// docker...) instead of leaving them running after forge exits.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	printUpdateNotice()
+	return err
+}
+
+// startUpdateCheck looks for a newer forge release in the background unless
+// disabled with --no-update-check or FORGE_NO_UPDATE_CHECK. The long-running
+// daemon and shell completion never check.
+func startUpdateCheck(cmd *cobra.Command) {
+	if noUpdateCheck || update.Disabled() {
+		return
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "daemon", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+			return
+		}
+	}
+	updateCheck = update.Start(version)
+}
+
+// checkUnknownFields warns about keys the workspace's forge.json does not
+// define, or fails with --strict. A typo such as "porjects" would otherwise
+// load as a workspace without projects. Outside a workspace there is nothing
+// to check.
+func checkUnknownFields(cmd *cobra.Command, strict bool) error {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return nil
+	}
+
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(workspaceRoot, workspace.ConfigFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	// Syntax errors are reported by the command when it loads forge.json
+	unknown, err := workspace.UnknownFields(data)
+	if err != nil || len(unknown) == 0 {
+		return nil
+	}
+	if strict {
+		return fmt.Errorf("unknown fields in %s: %s", path, strings.Join(unknown, ", "))
+	}
+	log.Warn("⚠️  Ignoring unknown fields in %s: %s (check for typos)", path, strings.Join(unknown, ", "))
+	return nil
+}
+
+// printUpdateNotice prints the update notice on stderr after the command so
+// it never mixes with command output. It is hidden with --quiet and in JSON
+// mode.
+func printUpdateNotice() {
+	notice := updateCheck.Notice()
+	if notice == "" || !log.Enabled(log.LevelInfo) || log.JSON() {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n%s\n", notice)
+}
+
+// configureLogging applies --quiet, --verbose and --log-format. Commands with
+// their own --verbose flag shadow the global one, so the value is read from
+// the command being run.
+func configureLogging(cmd *cobra.Command) error {
+	format, err := log.ParseFormat(logFormat)
+	if err != nil {
+		return err
+	}
+
+	verbose := logVerbose
+	if flag := cmd.Flags().Lookup("verbose"); flag != nil {
+		verbose = flag.Value.String() == "true"
+	}
+
+	if logQuiet && verbose {
+		return fmt.Errorf("--quiet and --verbose cannot be used together")
+	}
+
+	level := log.LevelInfo
+	switch {
+	case logQuiet:
+		level = log.LevelWarn
+	case verbose:
+		level = log.LevelDebug
+	}
+
+	log.Configure(level, format)
+	return nil
 }
 
 func init() {
@@ -23,4 +171,12 @@ func init() {
 	// This avoids duplicate command registration
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(validateCmd)
+
+	rootCmd.PersistentFlags().BoolVarP(&logQuiet, "quiet", "q", false, "Only print warnings and errors")
+	rootCmd.PersistentFlags().BoolVarP(&logVerbose, "verbose", "v", false, "Print debug output")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log output format (text, json)")
+	rootCmd.PersistentFlags().BoolVar(&strictConfig, "strict", false, "Fail on unknown keys in forge.json instead of warning")
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "Fail instead of prompting when an input is missing (implied by CI=true)")
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "Do not check GitHub for a newer forge release (or set "+update.DisableEnv+")")
+	rootCmd.PersistentFlags().StringVar(&templatesDir, "templates-dir", "", "Directory of template overrides (takes precedence over $FORGE_TEMPLATES and ~/.forge/templates)")
 }
@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/dosanma1/forge-cli/internal/bazel"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -30,13 +30,13 @@ func init() {
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 	service := args[0]
 
 	// Get workspace root
-	workspaceRoot, err := findWorkspaceRoot()
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("not in a forge workspace: %w", err)
+		return err
 	}
 
 	// Create Bazel executor
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/generator"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaffoldOverwrite          bool
+	scaffoldDomain             string
+	scaffoldTLSIssuer          string
+	scaffoldIngressClass       string
+	scaffoldIngressAnnotations map[string]string
+)
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold",
+	Short: "Add workspace scaffolding that is missing",
+}
+
+var scaffoldInfraCmd = &cobra.Command{
+	Use:   "infra",
+	Short: "Regenerate missing infrastructure files",
+	Long: `Write the infrastructure files of the current workspace: the root
+skaffold.yaml, infra/kind-config.yaml, the generic Helm service chart in
+infra/helm, infra/cloudrun and the API gateway chart in infra/api-gateway.
+
+Workspaces created with an older forge can adopt new scaffolding this way.
+Projects are not touched, and files that already exist are kept unless
+--overwrite is given.
+
+The API gateway ingress is rendered from workspace.apiGateway in forge.json:
+its domain (default: workspace.kubernetes.domain), the cert-manager
+ClusterIssuer enabling TLS, the ingress class (default: nginx) and annotations
+added to the default CORS ones. --domain, --tls-issuer, --ingress-class and
+--ingress-annotation save these settings to forge.json first; combine them
+with --overwrite to re-render an existing chart.
+
+Examples:
+  forge scaffold infra              # Add missing files
+  forge scaffold infra --overwrite  # Replace existing files too
+  forge scaffold infra --overwrite --domain=api.example.com --tls-issuer=letsencrypt-prod
+  forge scaffold infra --overwrite --ingress-class=traefik --ingress-annotation=traefik.ingress.kubernetes.io/router.tls=true`,
+	Args: cobra.NoArgs,
+	RunE: runScaffoldInfra,
+}
+
+func init() {
+	scaffoldInfraCmd.Flags().BoolVar(&scaffoldOverwrite, "overwrite", false, "Replace infrastructure files that already exist")
+	scaffoldInfraCmd.Flags().StringVar(&scaffoldDomain, "domain", "", "Domain of the API gateway ingress")
+	scaffoldInfraCmd.Flags().StringVar(&scaffoldTLSIssuer, "tls-issuer", "", "cert-manager ClusterIssuer of the API gateway certificate (enables TLS)")
+	scaffoldInfraCmd.Flags().StringVar(&scaffoldIngressClass, "ingress-class", "", "Ingress class of the API gateway (default: nginx)")
+	scaffoldInfraCmd.Flags().StringToStringVar(&scaffoldIngressAnnotations, "ingress-annotation", nil, "Extra API gateway ingress annotations (key=value pairs)")
+
+	scaffoldCmd.AddCommand(scaffoldInfraCmd)
+	rootCmd.AddCommand(scaffoldCmd)
+}
+
+func runScaffoldInfra(cmd *cobra.Command, args []string) error {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	if err := saveAPIGatewayFlags(cmd, workspaceRoot); err != nil {
+		return err
+	}
+
+	gen := generator.NewWorkspaceGenerator()
+	if err := gen.ScaffoldInfrastructure(workspaceRoot, scaffoldOverwrite); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Infrastructure scaffolding is up to date")
+	return nil
+}
+
+// saveAPIGatewayFlags stores the API gateway flags that were set in the
+// workspace's apiGateway settings of forge.json.
+func saveAPIGatewayFlags(cmd *cobra.Command, workspaceRoot string) error {
+	flags := cmd.Flags()
+	if !flags.Changed("domain") && !flags.Changed("tls-issuer") && !flags.Changed("ingress-class") && !flags.Changed("ingress-annotation") {
+		return nil
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		settings := config.Workspace.APIGateway
+		if settings == nil {
+			settings = &workspace.APIGatewayConfig{}
+			config.Workspace.APIGateway = settings
+		}
+		if flags.Changed("domain") {
+			settings.Domain = scaffoldDomain
+		}
+		if flags.Changed("tls-issuer") {
+			settings.TLSIssuer = scaffoldTLSIssuer
+		}
+		if flags.Changed("ingress-class") {
+			settings.IngressClass = scaffoldIngressClass
+		}
+		for key, value := range scaffoldIngressAnnotations {
+			if settings.Annotations == nil {
+				settings.Annotations = make(map[string]string)
+			}
+			settings.Annotations[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the API gateway settings: %w", err)
+	}
+
+	fmt.Println("✔ Saved the API gateway settings to forge.json")
+	return nil
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveEnv     string
+	serveVerbose bool
+)
+
+// serveColors are the ANSI colors used to prefix output from concurrent projects.
+var serveColors = []string{"\033[36m", "\033[35m", "\033[33m", "\033[32m", "\033[34m", "\033[31m"}
+
+const serveColorReset = "\033[0m"
+
+var serveCmd = &cobra.Command{
+	Use:   "serve <project...>",
+	Short: "Serve projects locally with hot reload",
+	Long: `Serve one or more projects locally using their serve target.
+
+The command used depends on the project language:
+  - Go:      go run <main> (default: ./cmd/server)
+  - NestJS:  npm run start:dev (-- <app> at the root of a Nest monorepo)
+  - Angular: ng serve <project>
+  - Vue:     npm run dev (Vite)
+
+The port comes from the serve target options in forge.json. When serving
+several projects, output is prefixed with the project name, and a project that
+would use the same port as another one is moved to the next free port, which
+is saved in forge.json.
+
+Examples:
+  forge serve api-server                   # Serve a single project
+  forge serve api-server web-app           # Serve several projects concurrently
+  forge serve web-app --env=development    # Use the development configuration`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVarP(&serveEnv, "env", "e", "local", "Environment/configuration to serve (local, development, production)")
+	serveCmd.Flags().BoolVarP(&serveVerbose, "verbose", "v", false, "Show the commands being run")
+}
+
+// serveProcess is a project command ready to be started.
+type serveProcess struct {
+	project string
+	cmd     *exec.Cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// Cancelled on Ctrl-C, or to stop every project when one of them fails
+	ctx, stop := context.WithCancel(cmd.Context())
+	defer stop()
+
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfig(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	for _, projectName := range args {
+		if _, exists := config.Projects[projectName]; !exists {
+			return fmt.Errorf("project %q not found in forge.json", projectName)
+		}
+	}
+	if err := assignServePorts(workspaceRoot, config, args); err != nil {
+		return err
+	}
+
+	processes := make([]serveProcess, 0, len(args))
+	for _, projectName := range args {
+		project := config.Projects[projectName]
+
+		serveCommand, err := buildServeCommand(ctx, workspaceRoot, projectName, project)
+		if err != nil {
+			return err
+		}
+		processes = append(processes, serveProcess{project: projectName, cmd: serveCommand})
+	}
+
+	prefixed := len(processes) > 1
+	width := 0
+	for _, p := range processes {
+		if len(p.project) > width {
+			width = len(p.project)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(processes))
+
+	for i, p := range processes {
+		if prefixed {
+			prefix := fmt.Sprintf("%s%-*s |%s ", serveColors[i%len(serveColors)], width, p.project, serveColorReset)
+			p.cmd.Stdout = newPrefixWriter(os.Stdout, prefix)
+			p.cmd.Stderr = newPrefixWriter(os.Stderr, prefix)
+		} else {
+			p.cmd.Stdout = os.Stdout
+			p.cmd.Stderr = os.Stderr
+		}
+
+		fmt.Printf("🚀 Serving %s (configuration: %s)\n", p.project, serveEnv)
+		if serveVerbose {
+			fmt.Printf("   %s (in %s)\n", p.cmd.String(), p.cmd.Dir)
+		}
+
+		if err := p.cmd.Start(); err != nil {
+			stop()
+			wg.Wait()
+			return fmt.Errorf("failed to start %s: %w", p.project, err)
+		}
+
+		wg.Add(1)
+		go func(p serveProcess) {
+			defer wg.Done()
+			err := p.cmd.Wait()
+			if w, ok := p.cmd.Stdout.(*prefixWriter); ok {
+				w.Flush()
+			}
+			if w, ok := p.cmd.Stderr.(*prefixWriter); ok {
+				w.Flush()
+			}
+			if err != nil && ctx.Err() == nil {
+				errs <- fmt.Errorf("❌ %s exited: %w", p.project, err)
+				// Stop the remaining projects when one of them fails
+				stop()
+			}
+		}(p)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+
+	fmt.Println("\n✅ Stopped serving")
+	return nil
+}
+
+// buildServeCommand returns the command that serves a project, based on its
+// language and the resolved serve target options.
+func buildServeCommand(ctx context.Context, workspaceRoot, projectName string, project workspace.Project) (*exec.Cmd, error) {
+	options := resolveServeOptions(project)
+	projectRoot := filepath.Join(workspaceRoot, project.Root)
+	port := serveOptionString(options, "port")
+
+	var serveCommand *exec.Cmd
+	var env []string
+
+	switch workspace.LanguageType(project.Language) {
+	case workspace.LanguageGo:
+		main := serveOptionString(options, "main")
+		if main == "" {
+			main = "."
+		}
+		serveCommand = exec.CommandContext(ctx, "go", "run", main)
+		serveCommand.Dir = projectRoot
+		env = append(env, "ENVIRONMENT="+serveEnv)
+		if port != "" {
+			env = append(env, "PORT="+port)
+		}
+
+	case workspace.LanguageNestJS:
+		serveCommand = exec.CommandContext(ctx, "npm", "run", "start:dev")
+		serveCommand.Dir = projectRoot
+		// Apps of a Nest monorepo are started by name from its root
+		if nestDir, app := workspace.FindNestMonorepo(workspaceRoot, projectRoot); nestDir != "" {
+			serveCommand = exec.CommandContext(ctx, "npm", "run", "start:dev", "--", app)
+			serveCommand.Dir = nestDir
+		}
+		env = append(env, "NODE_ENV="+serveNodeEnv(serveEnv))
+		if port != "" {
+			env = append(env, "PORT="+port)
+		}
+
+	case workspace.LanguageAngular:
+		angularRoot, err := findAngularRoot(workspaceRoot, projectRoot)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", projectName, err)
+		}
+		args := []string{"ng", "serve", projectName, "--configuration", angularServeConfiguration(project)}
+		if port != "" {
+			args = append(args, "--port", port)
+		}
+		if host := serveOptionString(options, "host"); host != "" {
+			args = append(args, "--host", host)
+		}
+		serveCommand = exec.CommandContext(ctx, "npx", args...)
+		serveCommand.Dir = angularRoot
+
+	case workspace.LanguageVue:
+		args := []string{"run", "dev", "--", "--mode", serveNodeEnv(serveEnv)}
+		if port != "" {
+			args = append(args, "--port", port)
+		}
+		if host := serveOptionString(options, "host"); host != "" {
+			args = append(args, "--host", host)
+		}
+		serveCommand = exec.CommandContext(ctx, "npm", args...)
+		serveCommand.Dir = projectRoot
+
+	default:
+		return nil, fmt.Errorf("project %s: serving %q projects is not supported", projectName, project.Language)
+	}
+
+	serveCommand.Env = append(os.Environ(), env...)
+	return serveCommand, nil
+}
+
+// assignServePorts moves projects that would serve on the same port as
+// another project being served to the next free port. The new port is stored
+// in forge.json so it stays the same across runs.
+func assignServePorts(workspaceRoot string, config *workspace.Config, projectNames []string) error {
+	used := config.UsedPorts()
+	servedBy := make(map[int]string)
+	reassigned := make(map[string]int)
+
+	for _, name := range projectNames {
+		project := config.Projects[name]
+		port := workspace.PortValue(resolveServeOptions(project)["port"])
+		if port == 0 {
+			port, _ = strconv.Atoi(getDefaultPort(project.Language))
+		}
+		if other, taken := servedBy[port]; taken {
+			newPort := workspace.NextFreePort(port, used)
+			fmt.Printf("🔀 %s and %s both serve on port %d, moving %s to port %d\n", other, name, port, name, newPort)
+			reassigned[name] = newPort
+			port = newPort
+		}
+		servedBy[port] = name
+		used[port] = true
+	}
+
+	if len(reassigned) == 0 {
+		return nil
+	}
+	return config.Update(workspaceRoot, func(c *workspace.Config) error {
+		for name, port := range reassigned {
+			project := c.Projects[name]
+			// A port set by the served configuration overrides the serve options
+			if project.Architect != nil && project.Architect.Serve != nil {
+				if cfg, ok := project.Architect.Serve.Configurations[serveEnv].(map[string]interface{}); ok && cfg["port"] != nil {
+					cfg["port"] = port
+					continue
+				}
+			}
+			project.SetServePort(port)
+			c.Projects[name] = project
+		}
+		return nil
+	})
+}
+
+// resolveServeOptions merges the serve target options with the options of the
+// selected configuration.
+func resolveServeOptions(project workspace.Project) map[string]interface{} {
+	options := make(map[string]interface{})
+	if project.Architect == nil || project.Architect.Serve == nil {
+		return options
+	}
+
+	serve := project.Architect.Serve
+	for k, v := range serve.Options {
+		options[k] = v
+	}
+	if cfg, ok := serve.Configurations[serveEnv].(map[string]interface{}); ok {
+		for k, v := range cfg {
+			options[k] = v
+		}
+	}
+	return options
+}
+
+// serveOptionString formats an option as a string. Numbers decoded from JSON
+// are float64 and are printed without a fractional part.
+func serveOptionString(options map[string]interface{}, key string) string {
+	switch v := options[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%d", int(v))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// angularServeConfiguration returns the angular.json configuration an Angular
+// app is served with, mapping the forge configuration like builds do. Forge's
+// "local" configuration has no angular.json counterpart, so it falls back to
+// "development" for apps without a mapping.
+func angularServeConfiguration(project workspace.Project) string {
+	var buildOptions map[string]interface{}
+	if project.Architect != nil {
+		buildOptions = project.Architect.Build.ResolveOptions(serveEnv)
+	}
+	configuration := workspace.MapEnvironment(buildOptions, serveEnv)
+	if configuration == "local" {
+		return "development"
+	}
+	return configuration
+}
+
+// serveNodeEnv maps a forge configuration name to a NODE_ENV value.
+func serveNodeEnv(env string) string {
+	if env == "production" {
+		return "production"
+	}
+	return "development"
+}
+
+// findAngularRoot walks up from the project root to the directory holding angular.json.
+func findAngularRoot(workspaceRoot, projectRoot string) (string, error) {
+	dir := projectRoot
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "angular.json")); err == nil {
+			return dir, nil
+		}
+		if dir == workspaceRoot || dir == filepath.Dir(dir) {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return "", fmt.Errorf("angular.json not found above %s", projectRoot)
+}
+
+// prefixWriter prefixes every line written to it. Partial lines are buffered
+// until a newline arrives so output from concurrent processes doesn't interleave.
+type prefixWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    bytes.Buffer
+}
+
+func newPrefixWriter(out io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, prefix: prefix}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			// Keep the incomplete line for the next write
+			break
+		}
+		if _, err := fmt.Fprintf(w.out, "%s%s", w.prefix, w.buf.Next(i+1)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered partial line.
+func (w *prefixWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() > 0 {
+		fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf.String())
+		w.buf.Reset()
+	}
+}
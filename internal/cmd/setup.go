@@ -2,15 +2,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/dosanma1/forge-cli/internal/bazel"
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/spf13/cobra"
 )
 
 var (
 	setupVerbose bool
+	setupJSON    bool
 )
 
 var setupCmd = &cobra.Command{
@@ -26,15 +31,22 @@ This command will verify:
   - Protocol buffer tools (protoc or buf)
   - Local Kubernetes (Kind)
 
+With --json the result is printed as a JSON array with one object per tool
+(name, category, installed, version, required, recommendedVersion), for
+scripts and dashboards. The command fails when a required tool is missing,
+in both modes.
+
 Examples:
   forge setup           # Check all required tools
-  forge setup --verbose # Show detailed output`,
+  forge setup --verbose # Show detailed output
+  forge setup --json    # Print the result as JSON`,
 	RunE: runSetup,
 }
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
 	setupCmd.Flags().BoolVarP(&setupVerbose, "verbose", "v", false, "Show detailed output")
+	setupCmd.Flags().BoolVar(&setupJSON, "json", false, "Print the tool checks as JSON")
 }
 
 type Tool struct {
@@ -46,8 +58,18 @@ type Tool struct {
 	RecommendedVersion string
 }
 
+// toolStatus is the result of checking a tool, as printed by --json.
+type toolStatus struct {
+	Name               string `json:"name"`
+	Category           string `json:"category"`
+	Installed          bool   `json:"installed"`
+	Version            string `json:"version"`
+	Required           bool   `json:"required"`
+	RecommendedVersion string `json:"recommendedVersion"`
+}
+
 func runSetup(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	tools := []Tool{
 		// Essential Tools
@@ -75,18 +97,25 @@ func runSetup(cmd *cobra.Command, args []string) error {
 		{Name: "Kind", Command: "kind", VersionFlag: "version", Required: false, Category: "Local Development", RecommendedVersion: "0.20+"},
 	}
 
-	fmt.Println("🔍 Checking required tools...\n")
+	// Also puts a Bazelisk installed by forge on PATH for the checks below
+	hasBazel := bazel.Available()
 
 	categories := make(map[string][]Tool)
 	for _, tool := range tools {
 		categories[tool.Category] = append(categories[tool.Category], tool)
 	}
 
+	categoryOrder := []string{"Essential", "Cloud", "Frameworks", "Protocol Buffers", "Local Development"}
+
+	if setupJSON {
+		return printSetupJSON(ctx, categoryOrder, categories)
+	}
+
+	fmt.Print("🔍 Checking required tools...\n\n")
+
 	allInstalled := true
 	requiredMissing := []string{}
 
-	categoryOrder := []string{"Essential", "Cloud", "Frameworks", "Protocol Buffers", "Local Development"}
-
 	for _, category := range categoryOrder {
 		tools := categories[category]
 		if len(tools) == 0 {
@@ -117,6 +146,9 @@ func runSetup(cmd *cobra.Command, args []string) error {
 		for _, tool := range requiredMissing {
 			fmt.Printf("   - %s\n", tool)
 		}
+		if !hasBazel {
+			fmt.Printf("\n%s\n", bazel.InstallHint)
+		}
 		fmt.Println("\nPlease install the missing tools. See the installation guide:")
 		fmt.Println("https://github.com/dosanma1/forge-cli#prerequisites")
 		return fmt.Errorf("missing required tools")
@@ -129,6 +161,39 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printSetupJSON prints the checks of the tools as a JSON array, in category
+// order. It fails when a required tool is missing.
+func printSetupJSON(ctx context.Context, categoryOrder []string, categories map[string][]Tool) error {
+	statuses := []toolStatus{}
+	requiredMissing := false
+	for _, category := range categoryOrder {
+		for _, tool := range categories[category] {
+			installed, version := checkTool(ctx, tool)
+			statuses = append(statuses, toolStatus{
+				Name:               tool.Name,
+				Category:           tool.Category,
+				Installed:          installed,
+				Version:            version,
+				Required:           tool.Required,
+				RecommendedVersion: tool.RecommendedVersion,
+			})
+			if tool.Required && !installed {
+				requiredMissing = true
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(statuses); err != nil {
+		return err
+	}
+	if requiredMissing {
+		return fmt.Errorf("missing required tools")
+	}
+	return nil
+}
+
 func checkTool(ctx context.Context, tool Tool) (bool, string) {
 	// Check if command exists
 	_, err := exec.LookPath(tool.Command)
@@ -138,8 +203,7 @@ func checkTool(ctx context.Context, tool Tool) (bool, string) {
 
 	// Get version
 	versionArgs := strings.Split(tool.VersionFlag, " ")
-	cmd := exec.CommandContext(ctx, tool.Command, versionArgs...)
-	output, err := cmd.CombinedOutput()
+	output, err := exec.CombinedOutput(ctx, exec.Options{Name: tool.Command, Args: versionArgs, Timeout: 10 * time.Second})
 	if err != nil {
 		// Tool exists but version command failed, still mark as installed
 		return true, "installed (version unknown)"
@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -32,6 +33,7 @@ func init() {
 }
 
 func runSetupHooks(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 
 	// Check if in a workspace
 	if _, err := os.Stat("forge.json"); os.IsNotExist(err) {
@@ -44,9 +46,9 @@ func runSetupHooks(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check for git
-	if !isGitRepo() {
+	if !isGitRepo(ctx) {
 		fmt.Println("⚠️  Not a git repository. Initializing...")
-		if err := initGit(); err != nil {
+		if err := initGit(ctx); err != nil {
 			return fmt.Errorf("failed to initialize git: %w", err)
 		}
 	}
@@ -125,7 +127,7 @@ func runSetupHooks(cmd *cobra.Command, args []string) error {
 
 	if len(packages) > 0 {
 		fmt.Println("\nInstalling packages...")
-		if err := installNpmPackages(workDir, packages, true); err != nil {
+		if err := installNpmPackages(ctx, workDir, packages, true); err != nil {
 			return err
 		}
 	}
@@ -133,7 +135,7 @@ func runSetupHooks(cmd *cobra.Command, args []string) error {
 	// Setup Husky
 	if setupHusky {
 		fmt.Println("\nSetting up Husky...")
-		if err := setupHuskyHooks(workDir); err != nil {
+		if err := setupHuskyHooks(ctx, workDir); err != nil {
 			return err
 		}
 	}
@@ -173,16 +175,13 @@ func isNodeInstalled() bool {
 	return err == nil
 }
 
-func isGitRepo() bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	return cmd.Run() == nil
+func isGitRepo(ctx context.Context) bool {
+	_, err := exec.CombinedOutput(ctx, exec.Options{Name: "git", Args: []string{"rev-parse", "--git-dir"}})
+	return err == nil
 }
 
-func initGit() error {
-	cmd := exec.Command("git", "init")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+func initGit(ctx context.Context) error {
+	return exec.Run(ctx, exec.Options{Name: "git", Args: []string{"init"}})
 }
 
 func createRootPackageJSON() error {
@@ -199,27 +198,19 @@ func createRootPackageJSON() error {
 	return os.WriteFile("package.json", []byte(content), 0644)
 }
 
-func installNpmPackages(dir string, packages []string, dev bool) error {
+func installNpmPackages(ctx context.Context, dir string, packages []string, dev bool) error {
 	args := []string{"install"}
 	if dev {
 		args = append(args, "--save-dev")
 	}
 	args = append(args, packages...)
 
-	cmd := exec.Command("npm", args...)
-	cmd.Dir = dir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return exec.Run(ctx, exec.Options{Name: "npm", Args: args, Dir: dir})
 }
 
-func setupHuskyHooks(dir string) error {
+func setupHuskyHooks(ctx context.Context, dir string) error {
 	// Initialize husky
-	cmd := exec.Command("npx", "husky", "init")
-	cmd.Dir = dir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{Name: "npx", Args: []string{"husky", "init"}, Dir: dir}); err != nil {
 		return err
 	}
 
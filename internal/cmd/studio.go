@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"syscall"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -31,77 +34,68 @@ func init() {
 }
 
 func runStudio(cmd *cobra.Command, args []string) error {
-	workspaceRoot, err := findWorkspaceRoot()
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("not in a forge workspace: %w", err)
+		return err
 	}
 
 	fmt.Println("🚀 Starting Forge Studio...")
 
 	// 1. Start API Service
-	apiCmd := exec.Command("go", "run", "cmd/server/main.go")
-	apiCmd.Dir = filepath.Join(workspaceRoot, "api")
-	apiCmd.Stdout = os.Stdout
-	apiCmd.Stderr = os.Stderr
-	apiCmd.Env = append(os.Environ(), "PORT=3002")
+	apiCmd := exec.Options{
+		Name: "go",
+		Args: []string{"run", "cmd/server/main.go"},
+		Dir:  filepath.Join(workspaceRoot, "api"),
+		Env:  []string{"PORT=3002"},
+	}
 
 	// 2. Start Studio (Angular)
+	// Fallback to legacy structure
+	studioCmd := exec.Options{
+		Name: "npm",
+		Args: []string{"run", "start"},
+		Dir:  filepath.Join(workspaceRoot, "studio"),
+	}
+
 	// Detect if using Nx monorepo structure
-	var studioCmdExec *exec.Cmd
 	nxStudioPath := filepath.Join(workspaceRoot, "apps", "studio")
 	nxConfigPath := filepath.Join(workspaceRoot, "nx.json")
 
 	if _, err := os.Stat(nxStudioPath); err == nil {
 		if _, err := os.Stat(nxConfigPath); err == nil {
 			// Nx monorepo detected
-			studioCmdExec = exec.Command("npx", "nx", "serve", "studio")
-			studioCmdExec.Dir = workspaceRoot
+			studioCmd = exec.Options{Name: "npx", Args: []string{"nx", "serve", "studio"}, Dir: workspaceRoot}
 		}
 	}
 
-	// Fallback to legacy structure
-	if studioCmdExec == nil {
-		studioCmdExec = exec.Command("npm", "run", "start")
-		studioCmdExec.Dir = filepath.Join(workspaceRoot, "studio")
-	}
-
-	studioCmdExec.Stdout = os.Stdout
-	studioCmdExec.Stderr = os.Stderr
+	// Stop both on an interrupt signal, or when either one exits
+	signalCtx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(signalCtx)
+	defer cancel()
 
 	// Start API
 	fmt.Println("📡 Starting API Service...")
-	if err := apiCmd.Start(); err != nil {
-		return fmt.Errorf("failed to start API: %w", err)
-	}
-
-	// Start Studio
-	fmt.Println("🎨 Starting Studio Frontend...")
-	if err := studioCmdExec.Start(); err != nil {
-		// Try to kill API if frontend fails to start
-		apiCmd.Process.Kill()
-		return fmt.Errorf("failed to start Studio: %w", err)
-	}
-
-	// Wait for interrupt signal to stop both
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-sigChan
-		fmt.Println("\n🛑 Shutting down...")
-		apiCmd.Process.Signal(syscall.SIGTERM)
-		studioCmdExec.Process.Signal(syscall.SIGTERM)
-	}()
-
-	// Wait for processes
+	apiDone := make(chan error, 1)
 	go func() {
-		apiCmd.Wait()
+		defer cancel()
+		err := exec.Run(ctx, apiCmd)
 		fmt.Println("📡 API stopped.")
-		sigChan <- syscall.SIGTERM
+		apiDone <- err
 	}()
 
-	err = studioCmdExec.Wait()
+	// Start Studio
+	fmt.Println("🎨 Starting Studio Frontend...")
+	studioErr := exec.Run(ctx, studioCmd)
 	fmt.Println("🎨 Studio stopped.")
+	cancel()
+	apiErr := <-apiDone
 
-	return err
+	// The one stopped because the other exited reports the cancellation
+	for _, err := range []error{studioErr, apiErr} {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
 	"github.com/dosanma1/forge-cli/internal/deployer"
+	"github.com/dosanma1/forge-cli/internal/generator"
 	"github.com/dosanma1/forge-cli/internal/ui"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ var (
 	switchForce      bool
 	switchConfigPath string
 	switchConfig     map[string]string
+	switchValuesFrom string
 )
 
 var switchCmd = &cobra.Command{
@@ -33,10 +35,7 @@ var switchDeployerCmd = &cobra.Command{
 	Long: `Switch the deployment target for a project.
 
 Available deployers:
-  - helm: Deploy to Kubernetes using Helm charts
-  - firebase: Deploy to Firebase Hosting (Angular only)
-  - cloudrun: Deploy to Google Cloud Run
-
+` + deployersHelp() + `
 The command will:
 1. Prompt for deployer-specific configuration (unless --config is provided)
 2. Update forge.json with the new deployer configuration
@@ -49,11 +48,26 @@ The command will:
   # Non-interactive mode with configuration
   forge switch deployer web-app helm --config namespace=prod,port=8080 --force
 
+  # Helm with registry, ingress domain and environment variables
+  forge switch deployer api-service helm --config 'registry=ghcr.io/acme,domain=example.com,env=LOG_LEVEL=debug;FEATURE_X=true'
+
   # Custom deployment path
   forge switch deployer dashboard firebase --config-path deploy/hosting
 
   # CloudRun deployment
-  forge switch deployer api-service cloudrun --config region=us-central1`,
+  forge switch deployer api-service cloudrun --config region=us-central1
+
+  # CloudRun with custom limits and scaling
+  forge switch deployer api-service cloudrun --config region=europe-west1,memory=1Gi,cpu=2,minInstances=1,maxInstances=20,concurrency=40
+
+  # AWS ECS (Fargate) deployment
+  forge switch deployer api-service ecs --config region=eu-west-1,cluster=prod
+
+  # Azure Container Apps deployment
+  forge switch deployer api-service aca --config resourceGroup=prod-rg,location=westeurope,environment=prod-env
+
+  # Helm with custom per-environment values (top-level keys: default, dev, prod)
+  forge switch deployer api-service helm --values-from helm-overrides.yaml`,
 	Args: cobra.ExactArgs(2),
 	RunE: runSwitchDeployer,
 }
@@ -65,6 +79,7 @@ func init() {
 	switchDeployerCmd.Flags().BoolVar(&switchForce, "force", false, "Skip all confirmation prompts")
 	switchDeployerCmd.Flags().StringVar(&switchConfigPath, "config-path", "", "Custom deployment folder path (default: deploy/<deployer>)")
 	switchDeployerCmd.Flags().StringToStringVar(&switchConfig, "config", nil, "Deployer-specific configuration (key=value pairs)")
+	switchDeployerCmd.Flags().StringVar(&switchValuesFrom, "values-from", "", "YAML file with per-environment Helm values overrides, kept in forge.json for later switches (helm only)")
 }
 
 func runSwitchDeployer(cmd *cobra.Command, args []string) error {
@@ -72,13 +87,18 @@ func runSwitchDeployer(cmd *cobra.Command, args []string) error {
 	deployerName := args[1]
 
 	// Validate deployer name
-	validDeployers := []string{"helm", "firebase", "cloudrun"}
+	validDeployers := deployer.Names("")
 	if !contains(validDeployers, deployerName) {
 		return fmt.Errorf("invalid deployer '%s'. Valid options: %v", deployerName, validDeployers)
 	}
 
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
 	// Load forge.json
-	config, err := workspace.LoadConfig(".")
+	config, err := workspace.LoadConfig(workspaceRoot)
 	if err != nil {
 		return fmt.Errorf("failed to load forge.json: %w", err)
 	}
@@ -94,16 +114,32 @@ func runSwitchDeployer(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Load Helm values overrides before touching any files. Without
+	// --values-from, the overrides stored by a previous switch are kept.
+	var valuesOverrides workspace.ValuesOverrides
+	if switchValuesFrom != "" {
+		if deployerName != "helm" {
+			return fmt.Errorf("--values-from is only supported by the helm deployer")
+		}
+		valuesOverrides, err = generator.LoadHelmValuesOverrides(switchValuesFrom)
+		if err != nil {
+			return err
+		}
+	} else if deployerName == "helm" && project.Architect != nil && project.Architect.Deploy != nil {
+		valuesOverrides = project.Architect.Deploy.Overrides
+	}
+
 	// Create prompter for interactive mode
 	prompter, err := ui.NewPrompter()
 	if err != nil {
 		return fmt.Errorf("failed to create prompter: %w", err)
 	}
+	configPrompter := prompter.WithFlag("--config")
 
 	// Get configuration - either from flags or interactive prompts
 	deployerConfig := switchConfig
 	if len(deployerConfig) == 0 {
-		deployerConfig, err = promptForDeployerConfig(prompter, deployerName, project.Language)
+		deployerConfig, err = promptForDeployerConfig(configPrompter, deployerName, project.Language)
 		if err != nil {
 			return fmt.Errorf("failed to get deployer configuration: %w", err)
 		}
@@ -122,13 +158,16 @@ func runSwitchDeployer(cmd *cobra.Command, args []string) error {
 		ProjectName:    projectName,
 		Project:        project,
 		TargetDeployer: deployerName,
-		DeployerConfig: deployerConfig,
-		Force:          switchForce,
-		WorkspaceRoot:  ".",
+		DeployerConfig: deployer.DeployerConfig{
+			Options:   deployerConfig,
+			Overrides: valuesOverrides,
+		},
+		Force:         switchForce,
+		WorkspaceRoot: workspaceRoot,
 	})
 
 	// Execute switch
-	ctx := context.Background()
+	ctx := cmd.Context()
 	if err := switcher.Switch(ctx, prompter); err != nil {
 		return err
 	}
@@ -143,17 +182,64 @@ func runSwitchDeployer(cmd *cobra.Command, args []string) error {
 }
 
 // validateDeployerCompatibility checks if the deployer is compatible with the project language
-func validateDeployerCompatibility(language, deployer string) error {
-	// Firebase is only for Angular projects
-	if deployer == "firebase" && language != "angular" {
-		return fmt.Errorf("firebase deployer is only compatible with Angular projects, found: %s", language)
+func validateDeployerCompatibility(language, deployerName string) error {
+	info, err := deployer.Resolve(deployerName)
+	if err != nil {
+		return err
+	}
+	if !info.Supports(language) {
+		return fmt.Errorf("%s deployer is only compatible with %s projects, found: %s", deployerName, strings.Join(info.Languages, ", "), language)
 	}
-
-	// All deployers support Go and NestJS
-	// Helm and CloudRun support Angular
 	return nil
 }
 
+// checkDeployer validates a deployer passed on the command line for a project
+// language and returns its name.
+func checkDeployer(name, language string) (string, error) {
+	name = strings.ToLower(name)
+	if !contains(deployer.Names(language), name) {
+		if contains(deployer.Names(""), name) {
+			return "", validateDeployerCompatibility(language, name)
+		}
+		return "", fmt.Errorf("unsupported deployer: %s (supported: %s)", name, strings.Join(deployer.Names(language), ", "))
+	}
+	return name, nil
+}
+
+// askDeployer prompts for one of the deployers offered for a project language
+// and returns its name.
+func askDeployer(ask func(label string, items []string) (string, error), label, language string) (string, error) {
+	infos := deployer.List(language)
+	if len(infos) == 0 {
+		return "", fmt.Errorf("no deployer supports %s projects", language)
+	}
+
+	labels := make([]string, len(infos))
+	for i, info := range infos {
+		labels[i] = info.Label
+	}
+
+	choice, err := ask(label, labels)
+	if err != nil {
+		return "", err
+	}
+	for _, info := range infos {
+		if info.Label == choice {
+			return info.Name, nil
+		}
+	}
+	return infos[0].Name, nil
+}
+
+// deployersHelp lists the selectable deployers for help texts.
+func deployersHelp() string {
+	var b strings.Builder
+	for _, info := range deployer.List("") {
+		fmt.Fprintf(&b, "  - %s: %s\n", info.Name, info.Description)
+	}
+	return b.String()
+}
+
 // promptForDeployerConfig prompts the user for deployer-specific configuration
 func promptForDeployerConfig(prompter *ui.Prompter, deployerName, language string) (map[string]string, error) {
 	config := make(map[string]string)
@@ -179,6 +265,14 @@ func promptForDeployerConfig(prompter *ui.Prompter, deployerName, language strin
 		}
 		config["healthPath"] = healthPath
 
+		domain, err := prompter.AskText("Ingress domain (leave empty to disable ingress)", "")
+		if err != nil {
+			return nil, err
+		}
+		if domain != "" {
+			config["domain"] = domain
+		}
+
 	case "firebase":
 		// Prompt for Firebase configuration
 		projectId, err := prompter.AskText("Firebase project ID", "")
@@ -220,6 +314,94 @@ func promptForDeployerConfig(prompter *ui.Prompter, deployerName, language strin
 			return nil, err
 		}
 		config["cpu"] = cpu
+
+		minInstances, err := prompter.AskText("Minimum instances", "0")
+		if err != nil {
+			return nil, err
+		}
+		config["minInstances"] = minInstances
+
+		maxInstances, err := prompter.AskText("Maximum instances", "10")
+		if err != nil {
+			return nil, err
+		}
+		config["maxInstances"] = maxInstances
+
+		concurrency, err := prompter.AskText("Requests per instance", "80")
+		if err != nil {
+			return nil, err
+		}
+		config["concurrency"] = concurrency
+
+	case "ecs":
+		// Prompt for ECS configuration
+		region, err := prompter.AskText("AWS region", "us-east-1")
+		if err != nil {
+			return nil, err
+		}
+		config["region"] = region
+
+		cluster, err := prompter.AskText("ECS cluster", "default")
+		if err != nil {
+			return nil, err
+		}
+		config["cluster"] = cluster
+
+		port, err := prompter.AskText("Container port", getDefaultPort(language))
+		if err != nil {
+			return nil, err
+		}
+		config["port"] = port
+
+		cpu, err := prompter.AskText("Task CPU units", "256")
+		if err != nil {
+			return nil, err
+		}
+		config["cpu"] = cpu
+
+		memory, err := prompter.AskText("Task memory (MiB)", "512")
+		if err != nil {
+			return nil, err
+		}
+		config["memory"] = memory
+
+	case "aca":
+		// Prompt for Azure Container Apps configuration
+		resourceGroup, err := prompter.AskText("Azure resource group", "")
+		if err != nil {
+			return nil, err
+		}
+		config["resourceGroup"] = resourceGroup
+
+		location, err := prompter.AskText("Azure location", "eastus")
+		if err != nil {
+			return nil, err
+		}
+		config["location"] = location
+
+		environment, err := prompter.AskText("Container Apps environment", "")
+		if err != nil {
+			return nil, err
+		}
+		config["environment"] = environment
+
+		port, err := prompter.AskText("Container port", getDefaultPort(language))
+		if err != nil {
+			return nil, err
+		}
+		config["port"] = port
+
+		cpu, err := prompter.AskText("CPU cores", "0.5")
+		if err != nil {
+			return nil, err
+		}
+		config["cpu"] = cpu
+
+		memory, err := prompter.AskText("Memory", "1Gi")
+		if err != nil {
+			return nil, err
+		}
+		config["memory"] = memory
 	}
 
 	return config, nil
@@ -230,6 +412,8 @@ func getDefaultPort(language string) string {
 	switch language {
 	case "angular":
 		return "4200"
+	case "vue":
+		return "5173"
 	case "nestjs":
 		return "3000"
 	case "go":
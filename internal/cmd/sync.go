@@ -1,19 +1,34 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/dosanma1/forge-cli/internal/daemon"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/sync"
 	"github.com/dosanma1/forge-cli/internal/ui"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	syncDryRun bool
-	syncYes    bool
+	syncDryRun            bool
+	syncYes               bool
+	syncEmitGazelleConfig bool
+	syncWatch             bool
+	syncReport            string
+	syncCheck             bool
+	syncJobs              int
 )
 
+// syncWatchDebounce is how long sync --watch waits for changes to settle
+// before regenerating, so a burst of saves triggers a single gazelle run.
+const syncWatchDebounce = 500 * time.Millisecond
+
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Synchronize Bazel configuration with forge.json",
@@ -24,8 +39,14 @@ This command will:
   2. Regenerate MODULE.bazel based on detected languages
   3. Auto-discover and generate BUILD.bazel for all Go packages
   4. Regenerate BUILD.bazel for services defined in forge.json
+  5. Register Angular projects found in angular.json but missing from forge.json
+
+Use this to recover from broken configurations or when you manually add packages.
 
-Use this to recover from broken configurations or when you manually add packages.`,
+With --check the sync runs on a temporary copy of the workspace and the
+command fails, printing the diffs, if any committed BUILD.bazel, MODULE.bazel
+or other managed file would change. The workspace is left untouched, so CI can
+enforce running 'forge sync' before committing.`,
 	Example: `  # Preview changes without applying
   forge sync --dry-run
 
@@ -33,32 +54,100 @@ Use this to recover from broken configurations or when you manually add packages
   forge sync --yes
 
   # Interactive mode (default)
-  forge sync`,
+  forge sync
+
+  # Only rewrite the root BUILD.bazel with canonical gazelle directives
+  forge sync --emit-gazelle-config
+
+  # Regenerate BUILD files for changed packages as you edit
+  forge sync --watch
+
+  # Fail CI when committed BUILD files are out of date
+  forge sync --check
+
+  # Same, with a machine-readable report of the drift
+  forge sync --check --report=json`,
 	RunE: runSync,
 }
 
 func init() {
 	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Preview changes without applying them")
 	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Skip confirmation prompt")
+	syncCmd.Flags().BoolVar(&syncEmitGazelleConfig, "emit-gazelle-config", false, "Write canonical gazelle directives to the root BUILD.bazel and exit")
+	syncCmd.Flags().BoolVarP(&syncWatch, "watch", "w", false, "Watch Go files and regenerate BUILD files for changed packages")
+	syncCmd.Flags().StringVar(&syncReport, "report", "text", "Report format: text, or json to print the created, updated and deleted files to stdout")
+	syncCmd.Flags().IntVarP(&syncJobs, "jobs", "j", 0, "Number of parallel Bazel jobs (default: workspace.build.parallel.workers, or the number of CPUs; at most 64)")
+	syncCmd.Flags().BoolVar(&syncCheck, "check", false, "Sync a temporary copy of the workspace and fail if any managed file would change")
 	rootCmd.AddCommand(syncCmd)
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
-	workspaceRoot, err := os.Getwd()
+	if syncReport != "text" && syncReport != "json" {
+		return fmt.Errorf("unknown report format %q (supported: text, json)", syncReport)
+	}
+	jsonReport := syncReport == "json"
+	if jsonReport && (syncWatch || syncEmitGazelleConfig) {
+		return fmt.Errorf("--report=json cannot be combined with --watch or --emit-gazelle-config")
+	}
+	if syncCheck && (syncDryRun || syncWatch || syncEmitGazelleConfig) {
+		return fmt.Errorf("--check cannot be combined with --dry-run, --watch or --emit-gazelle-config")
+	}
+	if jsonReport && !syncYes && !syncDryRun && !syncCheck {
+		return fmt.Errorf("--report=json requires --yes or --dry-run")
+	}
+
+	// Keep stdout for the JSON report: progress and tool output go to stderr
+	stdout := os.Stdout
+	if jsonReport {
+		os.Stdout = os.Stderr
+		log.SetOutput(os.Stderr)
+		defer func() {
+			os.Stdout = stdout
+			log.SetOutput(stdout)
+		}()
+	}
+
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
+	return runSyncIn(cmd.Context(), workspaceRoot, stdout)
+}
+
+// runSyncIn syncs the workspace at workspaceRoot with the sync flags, writing
+// a JSON report to stdout.
+func runSyncIn(ctx context.Context, workspaceRoot string, stdout *os.File) error {
+	jsonReport := syncReport == "json"
 
 	// Create syncer
 	syncer, err := sync.NewSyncer(workspaceRoot, syncDryRun)
 	if err != nil {
 		return err
 	}
+	syncer.SetJobs(syncJobs)
+
+	if syncEmitGazelleConfig {
+		return runEmitGazelleConfig(syncer)
+	}
+
+	if syncWatch {
+		if err := ensureBazel(ctx); err != nil {
+			return err
+		}
+		return runSyncWatch(ctx, workspaceRoot, syncer)
+	}
+
+	if syncCheck {
+		if err := ensureBazel(ctx); err != nil {
+			return err
+		}
+		return runSyncCheck(ctx, syncer, jsonReport, stdout)
+	}
 
 	// Confirm with user unless --yes or --dry-run
 	if !syncYes && !syncDryRun {
 		fmt.Println("⚠️  This will delete and regenerate all Bazel files.")
-		confirm, err := ui.AskConfirm("Continue?", false)
+		confirm, err := ui.WithFlag("--yes").AskConfirm("Continue?", false)
 		if err != nil {
 			return err
 		}
@@ -68,13 +157,24 @@ func runSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Sync degrades without bazel, but offer to install it first
+	if !syncDryRun {
+		offerBazelInstall(ctx)
+	}
+
 	// Run sync
 	fmt.Println("🔄 Synchronizing workspace...")
-	report, err := syncer.Sync()
+	report, err := syncer.Sync(ctx)
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
 
+	if jsonReport {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
 	// Print report
 	if syncDryRun {
 		fmt.Println("\n📋 Dry run results:")
@@ -82,6 +182,45 @@ func runSync(cmd *cobra.Command, args []string) error {
 		fmt.Println("\n✅ Sync completed!")
 	}
 
+	printSyncReport(report)
+
+	if syncDryRun {
+		fmt.Println("\n💡 Run without --dry-run to apply changes")
+	}
+
+	return nil
+}
+
+// runSyncCheck syncs a copy of the workspace and fails if the sync would
+// change any managed file.
+func runSyncCheck(ctx context.Context, syncer *sync.Syncer, jsonReport bool, stdout *os.File) error {
+	fmt.Println("🔍 Checking that Bazel files are up to date...")
+	report, err := syncer.Check(ctx)
+	if err != nil {
+		return fmt.Errorf("sync check failed: %w", err)
+	}
+
+	if jsonReport {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else if report.Changed() {
+		printSyncReport(report)
+	}
+
+	if report.Changed() {
+		return fmt.Errorf("Bazel files are out of date: run 'forge sync' and commit the result")
+	}
+	if !jsonReport {
+		fmt.Println("\n✅ Bazel files are up to date")
+	}
+	return nil
+}
+
+// printSyncReport prints the files touched by a sync and any errors.
+func printSyncReport(report *sync.SyncReport) {
 	if len(report.DeletedFiles) > 0 {
 		fmt.Printf("\n🗑️  Deleted %d files:\n", len(report.DeletedFiles))
 		for _, file := range report.DeletedFiles {
@@ -96,6 +235,13 @@ func runSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(report.UpdatedFiles) > 0 {
+		fmt.Printf("\n✏️  Updated %d files:\n", len(report.UpdatedFiles))
+		for _, file := range report.UpdatedFiles {
+			fmt.Printf("   ~ %s\n", file)
+		}
+	}
+
 	if len(report.Errors) > 0 {
 		fmt.Printf("\n❌ Encountered %d errors:\n", len(report.Errors))
 		for _, err := range report.Errors {
@@ -103,9 +249,94 @@ func runSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if report.Diff != "" {
+		fmt.Printf("\n%s", report.Diff)
+	}
+
+	fmt.Printf("\n📊 %d created, %d updated, %d deleted, %d errors\n",
+		len(report.CreatedFiles), len(report.UpdatedFiles), len(report.DeletedFiles), len(report.Errors))
+}
+
+// runSyncWatch watches Go sources and module files, re-syncing only the
+// packages that changed until ctx is cancelled (Ctrl-C).
+func runSyncWatch(ctx context.Context, workspaceRoot string, syncer *sync.Syncer) error {
+	config := daemon.DefaultWatcherConfig(workspaceRoot)
+	config.Patterns = []string{"*.go", "go.mod", "go.work"}
+	config.IgnorePatterns = []string{".git", "node_modules", "vendor", "dist", "bazel-*", ".idea", ".vscode"}
+
+	watcher, err := daemon.NewWatcher(config)
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	if err := watcher.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer watcher.Stop()
+
+	fmt.Printf("👀 Watching %s for Go changes (Ctrl-C to stop)\n", workspaceRoot)
+
+	changed := make(map[string]bool)
+	timer := time.NewTimer(syncWatchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n👋 Stopped watching")
+			return nil
+
+		case err := <-watcher.Errors():
+			fmt.Printf("⚠️  Watcher error: %v\n", err)
+
+		case event := <-watcher.Events():
+			changed[event.Path] = true
+			timer.Reset(syncWatchDebounce)
+
+		case <-timer.C:
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+			changed = make(map[string]bool)
+
+			fmt.Printf("\n🔄 %d file(s) changed, syncing...\n", len(paths))
+			report, err := syncer.SyncPaths(ctx, paths)
+			if err != nil {
+				fmt.Printf("❌ Sync failed: %v\n", err)
+				continue
+			}
+			if syncDryRun {
+				continue
+			}
+			if len(report.CreatedFiles)+len(report.UpdatedFiles)+len(report.DeletedFiles) == 0 {
+				fmt.Println("✓ BUILD files up to date")
+				continue
+			}
+			printSyncReport(report)
+		}
+	}
+}
+
+// runEmitGazelleConfig writes the canonical gazelle directives without running a full sync.
+func runEmitGazelleConfig(syncer *sync.Syncer) error {
+	report := &sync.SyncReport{}
+	gazelleConfig, err := syncer.EmitGazelleConfig(report)
+	if err != nil {
+		return fmt.Errorf("failed to emit gazelle config: %w", err)
+	}
+
+	fmt.Printf("# gazelle:prefix %s\n", gazelleConfig.Prefix)
+	for _, directive := range gazelleConfig.Directives {
+		fmt.Printf("# %s\n", directive)
+	}
+
 	if syncDryRun {
-		fmt.Println("\n💡 Run without --dry-run to apply changes")
+		fmt.Println("\n💡 Run without --dry-run to write BUILD.bazel")
+		return nil
 	}
 
+	for _, file := range report.CreatedFiles {
+		fmt.Printf("\n✅ Wrote gazelle config to %s\n", file)
+	}
 	return nil
 }
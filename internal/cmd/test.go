@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/spf13/cobra"
 )
@@ -15,22 +21,34 @@ var (
 	testVerbose  bool
 	testService  string
 	testCI       bool
-	testConfig   string
+	testEnv      string
 	testCoverage bool
 )
 
 var testCmd = &cobra.Command{
-	Use:   "test [service...]",
-	Short: "Run tests using Bazel",
-	Long: `Run tests for one or more services using Bazel.
+	Use:   "test [project...]",
+	Short: "Run project tests using their test target",
+	Long: `Run tests for one or more projects. With no arguments every project is tested.
+
+The command used depends on the project language:
+  - Go:      bazel test (or bazel coverage) on the project target,
+             falling back to go test ./... when Bazel is not available
+  - NestJS:  npm test (-- <app dir>/ at the root of a Nest monorepo)
+  - Angular: ng test <project> --watch=false
+
+Options come from the test target in forge.json, merged with the
+configuration selected with --env (or the target's defaultConfiguration):
+  - target: Bazel target relative to the project root (default: /...)
+  - args:   extra arguments passed to the test command
+  - env:    environment variables set while testing
 
 Examples:
-  forge test                       # Run all tests
-  forge test api-server            # Test specific service
+  forge test                       # Test all projects
+  forge test api-server web-app    # Test specific projects
   forge test --verbose             # Show detailed test output
   forge test --ci                  # Run in CI mode (no cache, fail fast)
-  forge test --coverage            # Generate coverage report
-  forge test --config=dev          # Test with dev configuration`,
+  forge test --coverage            # Collect coverage for every project
+  forge test --env=ci              # Use the ci test configuration`,
 	RunE: runTest,
 }
 
@@ -39,17 +57,39 @@ func init() {
 	testCmd.Flags().BoolVarP(&testVerbose, "verbose", "v", false, "Show detailed test output")
 	testCmd.Flags().StringVarP(&testService, "service", "s", "", "Test specific service")
 	testCmd.Flags().BoolVar(&testCI, "ci", false, "Run in CI mode (no cache, fail fast)")
-	testCmd.Flags().StringVarP(&testConfig, "config", "c", "local", "Test configuration (local|dev|prod)")
+	testCmd.Flags().StringVarP(&testEnv, "env", "e", "", "Test configuration from the test target (default: defaultConfiguration)")
+	testCmd.Flags().StringVarP(&testEnv, "config", "c", "", "Test configuration")
+	testCmd.Flags().MarkDeprecated("config", "use --env instead")
 	testCmd.Flags().BoolVar(&testCoverage, "coverage", false, "Generate coverage report")
 }
 
+// projectTestResult is the outcome of testing one project.
+type projectTestResult struct {
+	project  string
+	runner   string
+	passed   bool
+	skipped  string // reason the project was not tested
+	duration time.Duration
+	coverage *coverageReport
+	output   string
+	bazel    testResults
+}
+
+// coverageReport points at a project's coverage output. Percent is negative
+// when the report format could not be summarized.
+type coverageReport struct {
+	path    string
+	percent float64
+}
+
 func runTest(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
+	ctx := cmd.Context()
 
 	// Get workspace root
-	workspaceRoot, err := findWorkspaceRoot()
+	workspaceRoot, err := workspace.FindRoot()
 	if err != nil {
-		return fmt.Errorf("not in a forge workspace: %w", err)
+		return err
 	}
 
 	// Load workspace config
@@ -59,106 +99,303 @@ func runTest(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine what to test
-	var targets []string
-	if len(args) > 0 {
-		// Test specific projects
-		for _, projectName := range args {
-			target, err := projectToTestTarget(config, projectName)
-			if err != nil {
-				return err
-			}
-			targets = append(targets, target)
+	projectNames := args
+	if len(projectNames) == 0 && testService != "" {
+		projectNames = []string{testService}
+	}
+	if len(projectNames) == 0 {
+		for name := range config.Projects {
+			projectNames = append(projectNames, name)
 		}
-	} else if testService != "" {
-		target, err := projectToTestTarget(config, testService)
+		sort.Strings(projectNames)
+	}
+
+	fmt.Printf("\n🧪 Running tests...\n\n")
+
+	var results []projectTestResult
+	failed := 0
+	for _, name := range projectNames {
+		result, err := testProject(ctx, cmd, workspaceRoot, config, name)
 		if err != nil {
 			return err
 		}
-		targets = append(targets, target)
-	} else {
-		targets = append(targets, "//...")
+		results = append(results, result)
+
+		switch {
+		case result.skipped != "":
+			fmt.Printf("⚠️  %s: skipped (%s)\n", name, result.skipped)
+		case result.passed:
+			fmt.Printf("✅ %s (%.1fs)\n", name, result.duration.Seconds())
+		default:
+			failed++
+			fmt.Printf("❌ %s (%.1fs)\n", name, result.duration.Seconds())
+		}
+
+		if !result.passed && result.skipped == "" && testCI {
+			fmt.Println("\n⏹️  Stopping after the first failure (--ci)")
+			break
+		}
 	}
 
-	// Build test command
-	cmdArgs := []string{"test"}
-	cmdArgs = append(cmdArgs, targets...)
-	cmdArgs = append(cmdArgs, "--test_output=errors")
+	printTestSummary(results, time.Since(startTime))
 
-	if testVerbose {
-		cmdArgs = append(cmdArgs, "--test_output=all")
-		cmdArgs = append(cmdArgs, "--test_arg=-test.v")
-		cmdArgs = append(cmdArgs, "--nocache_test_results")
+	if failed > 0 {
+		printTestFailures(results)
+		return fmt.Errorf("tests failed for %d project(s)", failed)
 	}
 
-	if testCoverage {
-		cmdArgs = append(cmdArgs, "--coverage_report_generator=@bazel_tools//tools/test/CoverageOutputGenerator/java/com/google/devtools/coverageoutputgenerator:Main")
-		cmdArgs = append(cmdArgs, "--combined_report=lcov")
-		cmdArgs = append(cmdArgs, "--instrumentation_filter=//...")
+	return nil
+}
+
+// testProject runs the tests of a single project, or of a Bazel label given directly.
+func testProject(ctx context.Context, cmd *cobra.Command, workspaceRoot string, config *workspace.Config, name string) (projectTestResult, error) {
+	result := projectTestResult{project: name}
+
+	// Bazel labels are tested as-is
+	if strings.HasPrefix(name, "//") {
+		testCommand, coverage := bazelTestCommand(workspaceRoot, name, nil)
+		result.runner = "bazel"
+		result.coverage = coverage
+		runTestCommand(ctx, cmd, testCommand, &result)
+		return result, nil
 	}
 
-	// Show header
-	fmt.Printf("\n🧪 Running tests...\n\n")
+	project, exists := config.Projects[name]
+	if !exists {
+		return result, fmt.Errorf("project %q not found in forge.json", name)
+	}
+
+	options, err := resolveTestOptions(name, project)
+	if err != nil {
+		return result, err
+	}
 
-	// Run bazel test
-	bazelCmd := exec.Command("bazel", cmdArgs...)
-	bazelCmd.Dir = workspaceRoot
+	projectRoot := filepath.Join(workspaceRoot, project.Root)
+	extraArgs := testOptionStrings(options, "args")
 
-	var results testResults
-	var output []byte
+	var testCommand exec.Options
+	switch workspace.LanguageType(project.Language) {
+	case workspace.LanguageGo:
+		if hasBazel(workspaceRoot) {
+			target, err := projectToTestTarget(config, name)
+			if err != nil {
+				return result, err
+			}
+			// A target from the selected configuration wins over the base options
+			if configTarget := serveOptionString(options, "target"); configTarget != "" {
+				target = fmt.Sprintf("//%s%s", project.Root, configTarget)
+			}
+			result.runner = "bazel"
+			testCommand, result.coverage = bazelTestCommand(workspaceRoot, target, extraArgs)
+		} else {
+			result.runner = "go"
+			testCommand, result.coverage = goTestCommand(projectRoot, extraArgs)
+		}
 
-	if testVerbose {
-		// In verbose mode, stream output directly to terminal
-		bazelCmd.Stdout = cmd.OutOrStdout()
-		bazelCmd.Stderr = cmd.ErrOrStderr()
-		err = bazelCmd.Run()
-		duration := time.Since(startTime)
+	case workspace.LanguageNestJS:
+		result.runner = "npm"
+		args := []string{"test", "--"}
+		testDir := projectRoot
+		// Apps of a Nest monorepo share its jest config; only run the app's tests
+		if nestDir, _ := workspace.FindNestMonorepo(workspaceRoot, projectRoot); nestDir != "" {
+			appPath, err := filepath.Rel(nestDir, projectRoot)
+			if err != nil {
+				return result, err
+			}
+			testDir = nestDir
+			args = append(args, filepath.ToSlash(appPath)+"/")
+		}
+		if testCI {
+			args = append(args, "--ci")
+		}
+		if testCoverage {
+			args = append(args, "--coverage")
+			result.coverage = &coverageReport{path: filepath.Join(testDir, "coverage")}
+		}
+		testCommand = exec.Options{Name: "npm", Args: append(args, extraArgs...), Dir: testDir}
+
+	case workspace.LanguageAngular:
+		angularRoot, err := findAngularRoot(workspaceRoot, projectRoot)
 		if err != nil {
-			fmt.Printf("\n❌ Tests failed (see output above)\n")
-			fmt.Printf("   Total time: %.1fs\n", duration.Seconds())
-			return fmt.Errorf("test execution failed")
+			return result, fmt.Errorf("project %s: %w", name, err)
 		}
-		fmt.Printf("\n✅ Tests passed!\n")
-		fmt.Printf("   Total time: %.1fs\n", duration.Seconds())
-		return nil
+		result.runner = "ng"
+		args := []string{"ng", "test", name, "--watch=false"}
+		if configuration := serveOptionString(options, "configuration"); configuration != "" {
+			args = append(args, "--configuration", configuration)
+		}
+		if testCoverage {
+			args = append(args, "--code-coverage")
+			result.coverage = &coverageReport{path: filepath.Join(angularRoot, "coverage", name)}
+		}
+		testCommand = exec.Options{Name: "npx", Args: append(args, extraArgs...), Dir: angularRoot}
+
+	default:
+		result.skipped = fmt.Sprintf("testing %q projects is not supported", project.Language)
+		return result, nil
 	}
 
-	// In non-verbose mode, capture output for parsing
-	output, err = bazelCmd.CombinedOutput()
-	outputStr := string(output)
+	testCommand.Env = testOptionEnv(options)
+	runTestCommand(ctx, cmd, testCommand, &result)
+	return result, nil
+}
+
+// resolveTestOptions merges the test target options with the options of the
+// configuration selected with --env, or the target's default configuration.
+func resolveTestOptions(projectName string, project workspace.Project) (map[string]interface{}, error) {
+	options := make(map[string]interface{})
+	if project.Architect == nil || project.Architect.Test == nil {
+		return options, nil
+	}
 
-	// Parse test results
-	results = parseTestResults(outputStr)
+	test := project.Architect.Test
+	for k, v := range test.Options {
+		options[k] = v
+	}
 
-	// Print summary
-	duration := time.Since(startTime)
-	printTestSummary(results, duration)
+	configuration := testEnv
+	if configuration == "" {
+		configuration = test.DefaultConfiguration
+	}
+	if configuration == "" {
+		return options, nil
+	}
 
-	// Show failed test details
-	if len(results.failed) > 0 {
-		fmt.Println("\n❌ Failed tests:")
-		for _, fail := range results.failed {
-			fmt.Printf("  • %s\n", fail.name)
-			if fail.logPath != "" {
-				fmt.Printf("    Log: %s\n", fail.logPath)
-			}
+	cfg, ok := test.Configurations[configuration].(map[string]interface{})
+	if !ok {
+		// Only complain when the project defines test configurations at all
+		if len(test.Configurations) > 0 {
+			return nil, fmt.Errorf("project %s has no test configuration %q", projectName, configuration)
 		}
+		return options, nil
+	}
+	for k, v := range cfg {
+		options[k] = v
+	}
+	return options, nil
+}
 
-		// Suggest fixes
-		fmt.Println("\n💡 Tips:")
-		if containsBazelError(outputStr) {
-			fmt.Println("  • Some tests failed due to Bazel issues. Try running: forge sync")
+// testOptionStrings returns a string list option, accepting a single string too.
+func testOptionStrings(options map[string]interface{}, key string) []string {
+	switch v := options[key].(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
 		}
-		fmt.Println("  • View detailed logs at the paths above")
-		fmt.Println("  • Run with --verbose to see full output")
+		return values
+	}
+	return nil
+}
+
+// testOptionEnv formats the env option as KEY=value pairs.
+func testOptionEnv(options map[string]interface{}) []string {
+	envMap, ok := options["env"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	env := make([]string, 0, len(envMap))
+	for k := range envMap {
+		env = append(env, k+"="+serveOptionString(envMap, k))
+	}
+	sort.Strings(env)
+	return env
+}
 
-		return fmt.Errorf("%d test(s) failed", len(results.failed))
+// hasBazel reports whether the workspace is a Bazel module and bazel is installed.
+func hasBazel(workspaceRoot string) bool {
+	if _, err := os.Stat(filepath.Join(workspaceRoot, "MODULE.bazel")); err != nil {
+		return false
 	}
+	_, err := exec.LookPath("bazel")
+	return err == nil
+}
 
+// bazelTestCommand builds the bazel test (or coverage) command for a target.
+func bazelTestCommand(workspaceRoot, target string, extraArgs []string) (exec.Options, *coverageReport) {
+	var coverage *coverageReport
+
+	subcommand := "test"
 	if testCoverage {
-		fmt.Println("\n📊 Coverage report: bazel-out/_coverage/_coverage_report.dat")
+		subcommand = "coverage"
 	}
+	cmdArgs := []string{subcommand, target, "--test_output=errors"}
 
-	return nil
+	if testVerbose {
+		cmdArgs = append(cmdArgs, "--test_output=all")
+		cmdArgs = append(cmdArgs, "--test_arg=-test.v")
+	}
+	if testVerbose || testCI {
+		cmdArgs = append(cmdArgs, "--nocache_test_results")
+	}
+
+	if testCoverage {
+		cmdArgs = append(cmdArgs, "--combined_report=lcov")
+		cmdArgs = append(cmdArgs, "--instrumentation_filter="+instrumentationFilter(target))
+		coverage = &coverageReport{path: filepath.Join(workspaceRoot, "bazel-out", "_coverage", "_coverage_report.dat")}
+	}
+
+	return exec.Options{Name: "bazel", Args: append(cmdArgs, extraArgs...), Dir: workspaceRoot}, coverage
+}
+
+// instrumentationFilter limits coverage to the package tree of a target.
+func instrumentationFilter(target string) string {
+	pkg := strings.TrimSuffix(strings.TrimSuffix(strings.Split(target, ":")[0], "..."), "/")
+	if pkg == "" || pkg == "/" {
+		return "//..."
+	}
+	return pkg + "[/:]"
+}
+
+// goTestCommand builds the go test command used when Bazel is not available.
+func goTestCommand(projectRoot string, extraArgs []string) (exec.Options, *coverageReport) {
+	var coverage *coverageReport
+
+	cmdArgs := []string{"test", "./..."}
+	if testVerbose {
+		cmdArgs = append(cmdArgs, "-v")
+	}
+	if testVerbose || testCI {
+		cmdArgs = append(cmdArgs, "-count=1")
+	}
+	if testCoverage {
+		profile := filepath.Join(projectRoot, "coverage.out")
+		cmdArgs = append(cmdArgs, "-coverprofile="+profile)
+		coverage = &coverageReport{path: profile}
+	}
+
+	return exec.Options{Name: "go", Args: append(cmdArgs, extraArgs...), Dir: projectRoot}, coverage
+}
+
+// runTestCommand runs a test command and records the outcome. Output is streamed
+// in verbose mode and captured otherwise, to be shown only on failure.
+func runTestCommand(ctx context.Context, cmd *cobra.Command, testCommand exec.Options, result *projectTestResult) {
+	start := time.Now()
+
+	var err error
+	if testVerbose {
+		fmt.Printf("▶ %s: %s\n", result.project, testCommand)
+		testCommand.Stdout = cmd.OutOrStdout()
+		testCommand.Stderr = cmd.ErrOrStderr()
+		err = exec.Run(ctx, testCommand)
+	} else {
+		var output []byte
+		output, err = exec.CombinedOutput(ctx, testCommand)
+		result.output = string(output)
+		if result.runner == "bazel" {
+			result.bazel = parseTestResults(result.output)
+		}
+	}
+
+	result.duration = time.Since(start)
+	result.passed = err == nil
+
+	if result.coverage != nil {
+		result.coverage.percent = coveragePercent(result.coverage.path)
+	}
 }
 
 type testResults struct {
@@ -211,21 +448,151 @@ func parseTestResults(output string) testResults {
 	return results
 }
 
-func printTestSummary(results testResults, duration time.Duration) {
+// coveragePercent summarizes a Go cover profile or an LCOV report. It returns
+// -1 when the report is missing or in another format (e.g. an HTML directory).
+func coveragePercent(path string) float64 {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "lcov.info")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return -1
+	}
+	defer file.Close()
+
+	var covered, total int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "mode:"):
+			continue
+		case strings.HasPrefix(line, "LF:"):
+			n, _ := strconv.Atoi(strings.TrimPrefix(line, "LF:"))
+			total += n
+		case strings.HasPrefix(line, "LH:"):
+			n, _ := strconv.Atoi(strings.TrimPrefix(line, "LH:"))
+			covered += n
+		default:
+			// Go cover profile: file:start,end statements count
+			fields := strings.Fields(line)
+			if len(fields) != 3 || !strings.Contains(fields[0], ":") {
+				continue
+			}
+			statements, err1 := strconv.Atoi(fields[1])
+			count, err2 := strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			total += statements
+			if count > 0 {
+				covered += statements
+			}
+		}
+	}
+
+	if total == 0 {
+		return -1
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+func printTestSummary(results []projectTestResult, duration time.Duration) {
+	fmt.Println()
 	fmt.Println(strings.Repeat("─", 50))
 
-	if len(results.failed) == 0 {
-		fmt.Printf("✅ All tests passed! (%d/%d)\n", len(results.passed), results.total)
+	passed, failed, skipped := 0, 0, 0
+	for _, result := range results {
+		switch {
+		case result.skipped != "":
+			skipped++
+		case result.passed:
+			passed++
+		default:
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		fmt.Printf("✅ All tests passed! (%d/%d projects)\n", passed, passed+failed)
 	} else {
-		fmt.Printf("📊 Test Results: %d passed, %d failed (total: %d)\n",
-			len(results.passed), len(results.failed), results.total)
+		fmt.Printf("📊 Test Results: %d passed, %d failed (total: %d projects)\n", passed, failed, passed+failed)
+	}
+	if skipped > 0 {
+		fmt.Printf("   %d project(s) skipped\n", skipped)
 	}
 
-	if results.cached > 0 {
-		fmt.Printf("   %d test(s) cached\n", results.cached)
+	cached := 0
+	for _, result := range results {
+		cached += result.bazel.cached
+	}
+	if cached > 0 {
+		fmt.Printf("   %d Bazel test(s) cached\n", cached)
 	}
 
 	fmt.Printf("   Total time: %.1fs\n", duration.Seconds())
+
+	if testCoverage {
+		fmt.Println("\n📊 Coverage:")
+		for _, result := range results {
+			if result.coverage == nil {
+				continue
+			}
+			if result.coverage.percent >= 0 {
+				fmt.Printf("  • %-20s %5.1f%%  %s\n", result.project, result.coverage.percent, result.coverage.path)
+			} else {
+				fmt.Printf("  • %-20s    n/a  %s\n", result.project, result.coverage.path)
+			}
+		}
+	}
+}
+
+// printTestFailures shows the failing Bazel tests or the tail of the output of
+// each failed project.
+func printTestFailures(results []projectTestResult) {
+	bazelErrors := false
+
+	fmt.Println("\n❌ Failed tests:")
+	for _, result := range results {
+		if result.passed || result.skipped != "" {
+			continue
+		}
+
+		fmt.Printf("  • %s (%s)\n", result.project, result.runner)
+		for _, fail := range result.bazel.failed {
+			fmt.Printf("    %s\n", fail.name)
+			if fail.logPath != "" {
+				fmt.Printf("      Log: %s\n", fail.logPath)
+			}
+		}
+		if len(result.bazel.failed) == 0 && result.output != "" {
+			for _, line := range tailLines(result.output, 20) {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		if result.runner == "bazel" && containsBazelError(result.output) {
+			bazelErrors = true
+		}
+	}
+
+	// Suggest fixes
+	fmt.Println("\n💡 Tips:")
+	if bazelErrors {
+		fmt.Println("  • Some tests failed due to Bazel issues. Try running: forge sync")
+	}
+	if !testVerbose {
+		fmt.Println("  • Run with --verbose to see full output")
+	}
+}
+
+// tailLines returns the last n lines of output.
+func tailLines(output string, n int) []string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
 }
 
 func containsBazelError(output string) bool {
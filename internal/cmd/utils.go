@@ -1,37 +1,189 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"path"
+	"sort"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/bazel"
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/ui"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
 )
 
-// findWorkspaceRoot finds the workspace root by looking for forge.json
-func findWorkspaceRoot() (string, error) {
-	dir, err := os.Getwd()
+// checkName validates a workspace or project name. When the name is invalid
+// but has a valid alternative, such as "my-service" for "My Service", the
+// alternative is offered and returned once confirmed.
+func checkName(kind, name string) (string, error) {
+	err := workspace.ValidateName(name)
+	if err == nil {
+		return name, nil
+	}
+
+	var nameErr *workspace.NameError
+	if !errors.As(err, &nameErr) || nameErr.Suggestion == "" || !ui.Interactive() {
+		return "", fmt.Errorf("invalid %s name: %w", kind, err)
+	}
+	use, askErr := ui.AskConfirm(fmt.Sprintf("%q %s. Use %q instead?", name, nameErr.Reason, nameErr.Suggestion), true)
+	if askErr != nil || !use {
+		return "", fmt.Errorf("invalid %s name: %w", kind, err)
+	}
+	return nameErr.Suggestion, nil
+}
+
+// ensureBazel checks that bazel is installed before a command that needs it.
+func ensureBazel(ctx context.Context) error {
+	if offerBazelInstall(ctx) {
+		return nil
+	}
+	return fmt.Errorf("bazel is not installed (run 'forge setup' to check the required tools)")
+}
+
+// offerBazelInstall reports whether bazel is installed. When it is missing it
+// prints the same guidance as 'forge setup' and, in an interactive terminal,
+// offers to install Bazelisk into ~/.forge/bazel.
+func offerBazelInstall(ctx context.Context) bool {
+	if bazel.Available() {
+		return true
+	}
+
+	log.Warn("⚠️  Bazel is not installed\n\n%s\n", bazel.InstallHint)
+	if !ui.Interactive() {
+		return false
+	}
+	install, err := ui.AskConfirm("Install Bazelisk into ~/.forge/bazel now?", true)
+	if err != nil || !install {
+		return false
+	}
+	if err := bazel.NewInstaller(false).Install(ctx); err != nil {
+		log.Error("❌ %v", err)
+		return false
+	}
+	return bazel.Available()
+}
+
+// validateEnvironment checks that env, given with --env, is a known
+// environment of the workspace.
+func validateEnvironment(config *workspace.Config, env string) error {
+	environments := config.KnownEnvironments()
+	for _, name := range environments {
+		if name == env {
+			return nil
+		}
+	}
+	if len(environments) == 0 {
+		return fmt.Errorf("unknown environment %q: no project defines build or deploy configurations", env)
+	}
+	return fmt.Errorf("unknown environment %q (valid: %s)", env, strings.Join(environments, ", "))
+}
+
+// completeEnvironments completes --env with the known environments of the
+// workspace.
+func completeEnvironments(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
 	if err != nil {
-		return "", err
+		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
+	return config.KnownEnvironments(), cobra.ShellCompDirectiveNoFileComp
+}
 
-	// Traverse up the directory tree looking for forge.json
-	for {
-		configPath := filepath.Join(dir, workspace.ConfigFileName)
-		if _, err := os.Stat(configPath); err == nil {
-			return dir, nil
+// expandProjectPatterns expands glob patterns such as "api-*" in project
+// arguments into the matching project names of forge.json. Patterns only match
+// projects accepted by eligible (nil = all); a pattern matching nothing is an
+// error. Plain names are kept as given so callers can report unknown projects.
+func expandProjectPatterns(config *workspace.Config, args []string, eligible func(workspace.Project) bool) ([]string, error) {
+	names := make([]string, 0, len(config.Projects))
+	for name := range config.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]bool)
+	var expanded []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
 		}
+	}
 
-		// Check if we've reached the root
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			add(arg)
+			continue
+		}
+		if _, err := path.Match(arg, ""); err != nil {
+			return nil, fmt.Errorf("invalid project pattern %q: %w", arg, err)
+		}
+
+		matched := false
+		for _, name := range names {
+			if ok, _ := path.Match(arg, name); ok && (eligible == nil || eligible(config.Projects[name])) {
+				add(name)
+				matched = true
+			}
 		}
-		dir = parent
+		if !matched {
+			return nil, fmt.Errorf("pattern %q matches no project in forge.json", arg)
+		}
+	}
+
+	return expanded, nil
+}
+
+// filterProjectsByTags keeps the projects that have every tag. It fails when
+// no project is left.
+func filterProjectsByTags(config *workspace.Config, projectNames, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return projectNames, nil
 	}
 
-	return "", fmt.Errorf("forge.json not found in current directory or any parent directory")
+	var filtered []string
+	for _, name := range projectNames {
+		project := config.Projects[name]
+		hasAll := true
+		for _, tag := range tags {
+			if !contains(project.Tags, tag) {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			filtered = append(filtered, name)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("no project has tag(s) %s", strings.Join(tags, ", "))
+	}
+	return filtered, nil
+}
+
+// promptProjects asks which of candidates to act on, for commands run without
+// project arguments: always with --interactive, else when stdin is a terminal
+// and prompts are enabled. Choosing none keeps every candidate, like running
+// the command without the prompt.
+func promptProjects(verb string, candidates []string, interactive bool) ([]string, error) {
+	if len(candidates) < 2 || (!interactive && !(ui.IsTerminal() && ui.Interactive())) {
+		return candidates, nil
+	}
+
+	chosen, err := ui.WithFlag("project arguments").AskMultiSelect(fmt.Sprintf("Projects to %s (none = all)", verb), candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(chosen) == 0 {
+		return candidates, nil
+	}
+	return chosen, nil
 }
 
 // serviceToTarget converts a service name to a Bazel target
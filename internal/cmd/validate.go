@@ -1,35 +1,81 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/dosanma1/forge-cli/pkg/builder"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/dosanma1/forge-cli/schemas"
 )
 
 var validateCmd = &cobra.Command{
-	Use:   "validate",
+	Use:   "validate [project]",
 	Short: "Validate forge.json configuration",
-	Long: `Validates the forge.json configuration file against the JSON Schema.
-This ensures your workspace configuration is correct and follows the expected structure.`,
+	Long: `Validates forge.json configuration.
+
+Without arguments, the forge.json in the current directory is validated. A
+workspace forge.json is checked against the JSON Schema; a project forge.json
+(a graph of nodes and edges) is checked by the project's builder.
+
+With a project name, the project's forge.json graph is validated by its builder.
+Each error is printed with its node, field and severity, and the command exits
+non-zero if any severe error is found.
+
+Examples:
+  forge validate                  # Validate the forge.json in the current directory
+  forge validate api-server       # Validate the api-server project graph
+  forge validate api-server --strict`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runValidate,
 }
 
 var (
-	validateFix bool
+	validateFix    bool
+	validateStrict bool
 )
 
+// graphBuilders maps project languages to the pkg/builder that validates their graph.
+var graphBuilders = map[string]string{
+	"go":      "go-service",
+	"nestjs":  "nestjs-service",
+	"angular": "angular-app",
+}
+
 func init() {
 	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "Attempt to auto-fix common issues")
+	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Enable strict validation of project graphs and fail on unknown forge.json keys")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if len(args) == 1 {
+		workspaceRoot, err := workspace.FindRoot()
+		if err != nil {
+			return err
+		}
+
+		config, err := workspace.LoadConfig(workspaceRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load forge.json: %w", err)
+		}
+
+		project := config.GetProject(args[0])
+		if project == nil {
+			return fmt.Errorf("project %q not found in forge.json", args[0])
+		}
+
+		return validateProjectGraph(ctx, args[0], filepath.Join(workspaceRoot, project.Root), project.Language)
+	}
+
 	// Get current directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -42,6 +88,19 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("forge.json not found in current directory")
 	}
 
+	// A project forge.json is a graph and is validated by its builder
+	if isGraph, err := isProjectGraph(configPath); err != nil {
+		return err
+	} else if isGraph {
+		return validateProjectGraph(ctx, filepath.Base(cwd), cwd, "")
+	}
+
+	return validateWorkspaceConfig(cwd, configPath)
+}
+
+// validateWorkspaceConfig validates a workspace forge.json against the JSON Schema
+// and checks the Bazel configuration.
+func validateWorkspaceConfig(cwd, configPath string) error {
 	fmt.Println("🔍 Validating forge.json...")
 
 	// Load config
@@ -118,6 +177,102 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	return fmt.Errorf("validation failed with %d errors", len(result.Errors()))
 }
 
+// isProjectGraph reports whether a forge.json describes a project graph rather than a workspace.
+func isProjectGraph(configPath string) (bool, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read forge.json: %w", err)
+	}
+
+	var probe struct {
+		Nodes     json.RawMessage `json:"nodes"`
+		Workspace json.RawMessage `json:"workspace"`
+	}
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return false, fmt.Errorf("failed to parse forge.json: %w", err)
+	}
+
+	return probe.Nodes != nil && probe.Workspace == nil, nil
+}
+
+// validateProjectGraph parses a project's forge.json graph and validates it with
+// the builder for its type. It returns an error if any severe issue is found.
+func validateProjectGraph(ctx context.Context, projectName, projectDir, language string) error {
+	graphPath := filepath.Join(projectDir, "forge.json")
+	content, err := os.ReadFile(graphPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", graphPath, err)
+	}
+
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", graphPath, err)
+	}
+
+	builderName := header.Type
+	if builderName == "" {
+		builderName = graphBuilders[language]
+	}
+
+	b := builder.Resolve(builderName)
+	if b == nil {
+		return fmt.Errorf("no builder found for %q (available: %s)", builderName, strings.Join(builder.List(), ", "))
+	}
+
+	fmt.Printf("🔍 Validating %s with %s...\n", projectName, b.Name())
+
+	parseResult, err := b.Parse(ctx, builder.ParseOptions{
+		ProjectDir: projectDir,
+		ForgeJSON:  content,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse forge.json: %w", err)
+	}
+
+	err = b.Validate(ctx, builder.ValidateOptions{
+		ProjectDir:  projectDir,
+		ParseResult: parseResult,
+		Strict:      validateStrict,
+	})
+	if err == nil {
+		fmt.Printf("✅ %s is valid!\n", projectName)
+		return nil
+	}
+
+	result, ok := err.(*builder.ValidationResult)
+	if !ok {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	severe := 0
+	for _, e := range result.Errors {
+		icon, severity := "⚠️ ", "warning"
+		if e.Severe {
+			icon, severity = "❌", "error"
+			severe++
+		}
+
+		location := "-"
+		if e.NodeID != "" {
+			location = "node " + e.NodeID
+		}
+		if e.Field != "" {
+			location += " (" + e.Field + ")"
+		}
+
+		fmt.Printf("%s [%s] %s: %s\n", icon, severity, location, e.Message)
+	}
+
+	if severe > 0 {
+		return fmt.Errorf("validation failed with %d severe errors", severe)
+	}
+
+	fmt.Printf("✅ %s is valid with %d warnings\n", projectName, len(result.Errors))
+	return nil
+}
+
 // validateSemantics performs additional semantic validation beyond schema
 func validateSemantics(config *workspace.Config) error {
 	// Semantic validation for architect pattern
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the forge CLI version",
+	Long: `Print the version of the forge CLI and the platform it was built for.
+
+Forge checks GitHub for newer releases at most once a day and prints a notice
+after commands when one is available. Disable the check with --no-update-check
+or by setting FORGE_NO_UPDATE_CHECK.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("forge %s (%s, %s/%s)\n", version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceLockUpgrade bool
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage workspace settings",
+	Long:  `Manage workspace-level settings stored in forge.json.`,
+}
+
+var workspaceLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin tool versions in forge.json",
+	Long: `Resolve the installed versions of Go, Node.js, Bazel, the Angular CLI and
+the NestJS CLI and pin them in forge.json under workspace.toolVersions.
+
+Generators read tool versions exclusively from forge.json, so locking makes
+generated projects reproducible across machines. Versions that are already
+locked are kept unless --upgrade is given.
+
+Examples:
+  forge workspace lock             # Pin versions that are not locked yet
+  forge workspace lock --upgrade   # Re-resolve and update all pinned versions`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceLock,
+}
+
+var workspaceInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Summarize the workspace, its locked tool versions and settings",
+	Long: `Print the workspace name and forge version, the tool versions locked in
+forge.json, the registry, GCP and Kubernetes settings, and how many services,
+applications and libraries the workspace contains.
+
+Locked versions are compared with the installed tools and a warning is shown
+when their major or minor version differ.
+
+Examples:
+  forge workspace info`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceInfo,
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceLockCmd)
+	workspaceCmd.AddCommand(workspaceInfoCmd)
+	workspaceLockCmd.Flags().BoolVar(&workspaceLockUpgrade, "upgrade", false, "Re-resolve versions that are already locked")
+}
+
+// lockedTool describes how to resolve a tool version and where it is stored.
+type lockedTool struct {
+	name    string
+	field   func(*workspace.ToolVersions) *string
+	resolve func(ctx context.Context, workspaceRoot string) (string, error)
+	// npmPackage is queried on the npm registry when --upgrade is set and the tool isn't installed
+	npmPackage string
+}
+
+var lockedTools = []lockedTool{
+	{
+		name:    "go",
+		field:   func(v *workspace.ToolVersions) *string { return &v.Go },
+		resolve: resolveGoVersion,
+	},
+	{
+		name:    "node",
+		field:   func(v *workspace.ToolVersions) *string { return &v.Node },
+		resolve: resolveNodeVersion,
+	},
+	{
+		name:    "bazel",
+		field:   func(v *workspace.ToolVersions) *string { return &v.Bazel },
+		resolve: resolveBazelVersion,
+	},
+	{
+		name:       "angular",
+		field:      func(v *workspace.ToolVersions) *string { return &v.Angular },
+		resolve:    npmPackageResolver("@angular/cli"),
+		npmPackage: "@angular/cli",
+	},
+	{
+		name:       "nestjs",
+		field:      func(v *workspace.ToolVersions) *string { return &v.NestJS },
+		resolve:    npmPackageResolver("@nestjs/cli"),
+		npmPackage: "@nestjs/cli",
+	},
+}
+
+func runWorkspaceLock(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+
+	if config.Workspace.ToolVersions == nil {
+		config.Workspace.ToolVersions = &workspace.ToolVersions{}
+	}
+	locked := config.Workspace.ToolVersions
+	defaults := workspace.DefaultToolVersions()
+
+	fmt.Println("🔒 Locking tool versions...")
+
+	var changed []lockedTool
+	for _, tool := range lockedTools {
+		current := tool.field(locked)
+		if *current != "" && !workspaceLockUpgrade {
+			fmt.Printf("  ✓ %-8s %s (already locked)\n", tool.name, *current)
+			continue
+		}
+
+		version, err := tool.resolve(ctx, workspaceRoot)
+		source := "installed"
+		if err != nil && workspaceLockUpgrade && tool.npmPackage != "" {
+			version, err = resolveNpmLatest(ctx, tool.npmPackage)
+			source = "latest"
+		}
+		if err != nil {
+			if *current != "" {
+				fmt.Printf("  ⚠️  %-8s %s (kept: %v)\n", tool.name, *current, err)
+				continue
+			}
+			version = *tool.field(&defaults)
+			source = "default"
+			fmt.Printf("  ⚠️  %-8s %s (default: %v)\n", tool.name, version, err)
+		}
+
+		if version != *current {
+			changed = append(changed, tool)
+		}
+		switch {
+		case source == "default":
+			// Already reported with the resolve error
+		case *current != "" && *current != version:
+			fmt.Printf("  ✓ %-8s %s → %s (%s)\n", tool.name, *current, version, source)
+		default:
+			fmt.Printf("  ✓ %-8s %s (%s)\n", tool.name, version, source)
+		}
+		*current = version
+	}
+
+	if len(changed) == 0 {
+		fmt.Println("\n✅ Tool versions are already locked")
+		return nil
+	}
+
+	// Versions are resolved without the lock, only the changed ones are written
+	resolved := *locked
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		if config.Workspace.ToolVersions == nil {
+			config.Workspace.ToolVersions = &workspace.ToolVersions{}
+		}
+		for _, tool := range changed {
+			*tool.field(config.Workspace.ToolVersions) = *tool.field(&resolved)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save forge.json: %w", err)
+	}
+
+	fmt.Println("\n✅ Tool versions locked in forge.json")
+	return nil
+}
+
+func runWorkspaceInfo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	workspaceRoot, err := workspace.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(workspaceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load forge.json: %w", err)
+	}
+	ws := config.Workspace
+
+	fmt.Printf("📦 Workspace: %s\n", ws.Name)
+	fmt.Printf("   Root:  %s\n", workspaceRoot)
+	fmt.Printf("   Forge: %s (CLI %s)\n", ws.ForgeVersion, rootCmd.Version)
+
+	fmt.Println("\n🔒 Tool versions:")
+	locked := workspace.ToolVersions{}
+	if ws.ToolVersions != nil {
+		locked = *ws.ToolVersions
+	}
+	mismatches := 0
+	for _, tool := range lockedTools {
+		version := *tool.field(&locked)
+		installed, err := tool.resolve(ctx, workspaceRoot)
+		switch {
+		case version == "" && err != nil:
+			fmt.Printf("  -  %-8s not locked, not installed\n", tool.name)
+		case version == "":
+			fmt.Printf("  -  %-8s not locked (installed %s)\n", tool.name, installed)
+		case err != nil:
+			fmt.Printf("  -  %-8s %s (not installed)\n", tool.name, version)
+		case !sameMinorVersion(version, installed):
+			mismatches++
+			fmt.Printf("  ⚠️  %-8s %s (installed %s)\n", tool.name, version, installed)
+		default:
+			fmt.Printf("  ✓ %-8s %s (installed %s)\n", tool.name, version, installed)
+		}
+	}
+
+	fmt.Println("\n⚙️  Settings:")
+	if ws.Docker != nil && ws.Docker.Registry != "" {
+		fmt.Printf("   Registry:   %s\n", ws.Docker.Registry)
+	} else {
+		fmt.Println("   Registry:   (not set)")
+	}
+	if ws.GCP != nil && ws.GCP.ProjectID != "" {
+		region := ws.GCP.Region
+		if region == "" {
+			region = "no region"
+		}
+		fmt.Printf("   GCP:        %s (%s)\n", ws.GCP.ProjectID, region)
+	} else {
+		fmt.Println("   GCP:        (not set)")
+	}
+	if ws.Kubernetes != nil && ws.Kubernetes.Namespace != "" {
+		fmt.Printf("   Kubernetes: namespace %s", ws.Kubernetes.Namespace)
+		if ws.Kubernetes.Context != "" {
+			fmt.Printf(", context %s", ws.Kubernetes.Context)
+		}
+		if ws.Kubernetes.Domain != "" {
+			fmt.Printf(", domain %s", ws.Kubernetes.Domain)
+		}
+		fmt.Println()
+	} else {
+		fmt.Println("   Kubernetes: (not set)")
+	}
+	if ws.GitHub != nil && ws.GitHub.Org != "" {
+		fmt.Printf("   GitHub:     %s\n", ws.GitHub.Org)
+	}
+
+	counts := make(map[string]int)
+	for _, project := range config.Projects {
+		counts[project.ProjectType]++
+	}
+	fmt.Printf("\n📊 Projects: %d services, %d applications, %d libraries\n",
+		counts["service"], counts["application"], counts["library"])
+
+	if mismatches > 0 {
+		fmt.Printf("\n⚠️  %d installed tool(s) differ from forge.json; install the locked versions or run 'forge workspace lock --upgrade'\n", mismatches)
+	}
+
+	return nil
+}
+
+// sameMinorVersion reports whether two versions share their major and minor
+// components, so "1.24" matches "1.24.3" but not "1.21.5".
+func sameMinorVersion(a, b string) bool {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < 2 && i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveGoVersion returns the version of the Go toolchain on PATH (e.g., "1.24.0").
+func resolveGoVersion(ctx context.Context, _ string) (string, error) {
+	out, err := commandOutput(ctx, "", "go", "env", "GOVERSION")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("go env GOVERSION returned no version")
+	}
+	return strings.TrimPrefix(fields[0], "go"), nil
+}
+
+// resolveNodeVersion returns the installed Node.js version without the "v" prefix.
+func resolveNodeVersion(ctx context.Context, _ string) (string, error) {
+	out, err := commandOutput(ctx, "", "node", "--version")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(out, "v"), nil
+}
+
+// resolveBazelVersion returns the Bazel version used in the workspace, which
+// honors .bazelversion when Bazelisk is installed.
+func resolveBazelVersion(ctx context.Context, workspaceRoot string) (string, error) {
+	out, err := commandOutput(ctx, workspaceRoot, "bazel", "--version")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("unexpected bazel --version output: %q", out)
+	}
+	return fields[1], nil
+}
+
+// npmPackageResolver returns a resolver for an npm package, preferring a copy
+// installed in the workspace over a global one.
+func npmPackageResolver(pkg string) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, workspaceRoot string) (string, error) {
+		if version, err := npmInstalledVersion(ctx, workspaceRoot, pkg, false); err == nil {
+			return version, nil
+		}
+		return npmInstalledVersion(ctx, workspaceRoot, pkg, true)
+	}
+}
+
+// npmInstalledVersion reads the installed version of a package from `npm ls`.
+func npmInstalledVersion(ctx context.Context, workspaceRoot, pkg string, global bool) (string, error) {
+	args := []string{"ls", pkg, "--depth=0", "--json"}
+	if global {
+		args = append(args, "--global")
+	}
+
+	// npm ls exits non-zero when the package is missing but still prints JSON
+	out, _ := commandOutput(ctx, workspaceRoot, "npm", args...)
+
+	var tree struct {
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(out), &tree); err != nil {
+		return "", fmt.Errorf("failed to read npm ls output: %w", err)
+	}
+
+	dep, ok := tree.Dependencies[pkg]
+	if !ok || dep.Version == "" {
+		return "", fmt.Errorf("%s is not installed", pkg)
+	}
+	return dep.Version, nil
+}
+
+// resolveNpmLatest returns the latest published version of a package.
+func resolveNpmLatest(ctx context.Context, pkg string) (string, error) {
+	return commandOutput(ctx, "", "npm", "view", pkg, "version")
+}
+
+// commandOutput runs a command and returns its trimmed stdout, which is also
+// returned when the command fails. Standard error is discarded.
+func commandOutput(ctx context.Context, dir, name string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	err := exec.Run(ctx, exec.Options{Name: name, Args: args, Dir: dir, Stdout: &stdout, Stderr: io.Discard})
+	out := strings.TrimSpace(stdout.String())
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+)
+
+// controlService is the gRPC service name of the control API, matching the
+// service in proto/daemon/daemon.proto.
+const controlService = "forge.daemon.v1.Daemon"
+
+// ErrNotRunning is returned when no daemon answers on the socket.
+var ErrNotRunning = errors.New("daemon is not running")
+
+// The control API has no generated proto code yet, so its messages are sent
+// as JSON. gRPC picks the codec from the content subtype of each call, which
+// leaves the default proto codec in place for the other services.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// ShutdownRequest asks the daemon to stop.
+type ShutdownRequest struct {
+	Force bool
+}
+
+// ShutdownResponse acknowledges a shutdown request.
+type ShutdownResponse struct {
+	Success bool
+}
+
+type statusRequest struct{}
+
+// controlServer is implemented by Daemon.
+type controlServer interface {
+	Status() *StatusInfo
+	shutdown(force bool)
+}
+
+var controlServiceDesc = grpc.ServiceDesc{
+	ServiceName: controlService,
+	HandlerType: (*controlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				if err := dec(&statusRequest{}); err != nil {
+					return nil, err
+				}
+				return srv.(controlServer).Status(), nil
+			},
+		},
+		{
+			MethodName: "Shutdown",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &ShutdownRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				srv.(controlServer).shutdown(req.Force)
+				return &ShutdownResponse{Success: true}, nil
+			},
+		},
+	},
+	Metadata: "proto/daemon/daemon.proto",
+}
+
+// shutdown stops the daemon once the Shutdown call has been answered, since
+// a graceful stop waits for in-flight calls to finish.
+func (d *Daemon) shutdown(force bool) {
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if force {
+			d.server.Stop()
+		}
+		d.Stop()
+	}()
+}
+
+// QueryStatus asks the daemon listening on socketPath for its status. It
+// returns ErrNotRunning when nothing answers.
+func QueryStatus(ctx context.Context, socketPath string) (*StatusInfo, error) {
+	status := &StatusInfo{}
+	if err := callControl(ctx, socketPath, "Status", &statusRequest{}, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// RequestShutdown asks the daemon listening on socketPath to stop.
+func RequestShutdown(ctx context.Context, socketPath string, force bool) error {
+	return callControl(ctx, socketPath, "Shutdown", &ShutdownRequest{Force: force}, &ShutdownResponse{})
+}
+
+func callControl(ctx context.Context, socketPath, method string, req, resp interface{}) error {
+	if _, err := os.Stat(socketPath); err != nil {
+		return ErrNotRunning
+	}
+
+	conn, err := grpc.NewClient("unix://"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = conn.Invoke(ctx, "/"+controlService+"/"+method, req, resp, grpc.CallContentSubtype(jsonCodec{}.Name()))
+	if err != nil {
+		// A socket left behind by a daemon that died refuses connections
+		if strings.Contains(err.Error(), "connection refused") || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrNotRunning
+		}
+		return fmt.Errorf("daemon %s failed: %w", strings.ToLower(method), err)
+	}
+	return nil
+}
+
+// ReadPID returns the process ID recorded in the PID file, or 0 when there is none.
+func ReadPID(pidPath string) int {
+	data, err := os.ReadFile(pidPath)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// Cleanup removes the socket and PID file left behind by a daemon that did
+// not stop cleanly.
+func Cleanup(config *Config) error {
+	for _, path := range []string{config.SocketPath, config.PIDPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
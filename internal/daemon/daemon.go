@@ -6,11 +6,14 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/pkg/builder"
 	"github.com/dosanma1/forge-cli/pkg/generator"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"google.golang.org/grpc"
 )
 
@@ -19,6 +22,9 @@ type Config struct {
 	// SocketPath is the Unix socket path for gRPC communication
 	SocketPath string
 
+	// PIDPath is the file the daemon records its process ID in
+	PIDPath string
+
 	// WorkspaceDir is the workspace directory to serve
 	WorkspaceDir string
 
@@ -31,6 +37,7 @@ func DefaultConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
 	return &Config{
 		SocketPath:   filepath.Join(homeDir, ".forge", "daemon.sock"),
+		PIDPath:      filepath.Join(homeDir, ".forge", "daemon.pid"),
 		WorkspaceDir: ".",
 		Version:      "1.0.0",
 	}
@@ -90,9 +97,15 @@ func (d *Daemon) Start(ctx context.Context) error {
 	// Create gRPC server
 	d.server = grpc.NewServer()
 
-	// Register the daemon service
-	// Note: We'd normally use generated proto code here, but for now we'll skip registration
-	// and implement the service methods directly
+	// Register the control API (status and shutdown); the remaining methods
+	// of the daemon service are still called directly
+	d.server.RegisterService(&controlServiceDesc, d)
+
+	if d.config.PIDPath != "" {
+		if err := os.WriteFile(d.config.PIDPath, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+			return fmt.Errorf("failed to write PID file: %w", err)
+		}
+	}
 
 	d.startTime = time.Now()
 
@@ -119,6 +132,11 @@ func (d *Daemon) Stop() error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	select {
+	case <-d.done:
+		return nil // already stopped
+	default:
+	}
 	close(d.done)
 
 	// Stop watcher
@@ -136,15 +154,30 @@ func (d *Daemon) Stop() error {
 		d.listener.Close()
 	}
 
-	// Remove socket file
+	// Remove socket and PID files
 	os.Remove(d.config.SocketPath)
+	if d.config.PIDPath != "" {
+		os.Remove(d.config.PIDPath)
+	}
 
 	return nil
 }
 
+// Done returns a channel that is closed once the daemon starts stopping.
+func (d *Daemon) Done() <-chan struct{} {
+	return d.done
+}
+
 // startWatcher starts the file watcher
 func (d *Daemon) startWatcher(ctx context.Context) error {
 	config := DefaultWatcherConfig(d.config.WorkspaceDir)
+
+	// Merge the watch settings from forge.json; the defaults still apply when
+	// the workspace config cannot be read
+	if wsConfig, err := workspace.LoadConfigWithoutProjectValidation(d.config.WorkspaceDir); err == nil {
+		config.ApplyWorkspaceConfig(wsConfig)
+	}
+
 	watcher, err := NewWatcher(config)
 	if err != nil {
 		return err
@@ -162,7 +195,8 @@ func (d *Daemon) startWatcher(ctx context.Context) error {
 	return nil
 }
 
-// forwardEvents forwards file events to all subscribers
+// forwardEvents forwards file events to all subscribers and logs watcher
+// errors, such as reaching the OS watch limit
 func (d *Daemon) forwardEvents(ctx context.Context) {
 	for {
 		select {
@@ -172,6 +206,8 @@ func (d *Daemon) forwardEvents(ctx context.Context) {
 			return
 		case event := <-d.watcher.Events():
 			d.broadcastEvent(event)
+		case err := <-d.watcher.Errors():
+			log.Warn("File watcher: %v", err)
 		}
 	}
 }
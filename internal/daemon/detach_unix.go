@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package daemon
+
+import "syscall"
+
+// DetachedProcAttr starts a process in its own session, so it outlives the
+// terminal it was started from.
+func DetachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
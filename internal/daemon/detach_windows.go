@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package daemon
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// DetachedProcAttr starts a process without a console in its own process
+// group, so it outlives the terminal it was started from.
+func DetachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS}
+}
@@ -3,12 +3,19 @@ package daemon
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 	"github.com/fsnotify/fsnotify"
 )
 
@@ -52,9 +59,19 @@ type WatcherConfig struct {
 	// Patterns are glob patterns to match (e.g., "*.go", "forge.json")
 	Patterns []string
 
-	// IgnorePatterns are patterns to ignore (e.g., ".git", "node_modules")
+	// IgnorePatterns are patterns to ignore (e.g., ".git", "node_modules").
+	// Patterns match any path component below ProjectDir, or the path of a
+	// file or directory relative to ProjectDir when they contain a slash.
 	IgnorePatterns []string
 
+	// DirPatterns are extra patterns matched only below a directory, keyed by
+	// the directory relative to ProjectDir
+	DirPatterns map[string][]string
+
+	// DirIgnorePatterns are extra ignore patterns applied only below a
+	// directory, keyed by the directory relative to ProjectDir
+	DirIgnorePatterns map[string][]string
+
 	// Debounce is the debounce duration for rapid events
 	Debounce time.Duration
 }
@@ -66,6 +83,8 @@ func DefaultWatcherConfig(projectDir string) *WatcherConfig {
 		Patterns:   []string{"*.go", "forge.json", "*.proto"},
 		IgnorePatterns: []string{
 			".git",
+			".forge",
+			"bazel-*",
 			"node_modules",
 			"vendor",
 			"dist",
@@ -79,6 +98,52 @@ func DefaultWatcherConfig(projectDir string) *WatcherConfig {
 	}
 }
 
+// ApplyWorkspaceConfig merges the watch sections of forge.json into the
+// configuration: the workspace section extends the global patterns and each
+// project section the patterns below the project root.
+func (c *WatcherConfig) ApplyWorkspaceConfig(config *workspace.Config) {
+	if config.Watch != nil {
+		c.Patterns = appendMissing(c.Patterns, config.Watch.Patterns)
+		c.IgnorePatterns = appendMissing(c.IgnorePatterns, config.Watch.Ignore)
+	}
+
+	for _, project := range config.Projects {
+		if project.Watch == nil {
+			continue
+		}
+		dir := path.Clean(filepath.ToSlash(project.Root))
+		if len(project.Watch.Patterns) > 0 {
+			if c.DirPatterns == nil {
+				c.DirPatterns = make(map[string][]string)
+			}
+			c.DirPatterns[dir] = appendMissing(c.DirPatterns[dir], project.Watch.Patterns)
+		}
+		if len(project.Watch.Ignore) > 0 {
+			if c.DirIgnorePatterns == nil {
+				c.DirIgnorePatterns = make(map[string][]string)
+			}
+			c.DirIgnorePatterns[dir] = appendMissing(c.DirIgnorePatterns[dir], project.Watch.Ignore)
+		}
+	}
+}
+
+// appendMissing appends the items of extra that list does not hold yet.
+func appendMissing(list, extra []string) []string {
+	for _, item := range extra {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // Watcher watches for file changes in a project directory
 type Watcher struct {
 	config   *WatcherConfig
@@ -92,6 +157,32 @@ type Watcher struct {
 	// Debouncing
 	pending   map[string]*pendingEvent
 	pendingMu sync.Mutex
+
+	// Watched directories and whether the OS watch limit was reported
+	watchMu       sync.Mutex
+	watched       int
+	limitReported bool
+}
+
+// WatchLimitError reports that the OS limit on file watches was reached. The
+// directories watched before it stay watched; the others are not.
+type WatchLimitError struct {
+	Watched int    // Directories watched when the limit was reached
+	Dir     string // First directory that could not be watched
+	Err     error
+}
+
+func (e *WatchLimitError) Error() string {
+	hint := "raise the open file limit with 'ulimit -n'"
+	if runtime.GOOS == "linux" {
+		hint = "raise the inotify limit with 'sudo sysctl fs.inotify.max_user_watches=524288' (add it to /etc/sysctl.conf to keep it)"
+	}
+	return fmt.Sprintf("file watch limit reached after %d directories, so %s and the directories after it are not watched (%v): %s, or add large directories to the watch ignore patterns of forge.json",
+		e.Watched, e.Dir, e.Err, hint)
+}
+
+func (e *WatchLimitError) Unwrap() error {
+	return e.Err
 }
 
 type pendingEvent struct {
@@ -162,27 +253,63 @@ func (w *Watcher) Errors() <-chan error {
 	return w.errors
 }
 
-// addRecursive adds a directory and all subdirectories to the watcher
+// addRecursive adds a directory and all subdirectories to the watcher.
+// Unreadable subdirectories are skipped, and reaching the OS watch limit is
+// reported on Errors instead of failing, so large workspaces stay partly
+// watched.
 func (w *Watcher) addRecursive(dir string) error {
-	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
-			return err
+			if path == dir {
+				return err
+			}
+			return filepath.SkipDir
 		}
 
 		// Skip ignored directories
-		if info.IsDir() {
-			for _, pattern := range w.config.IgnorePatterns {
-				if matched, _ := filepath.Match(pattern, info.Name()); matched {
-					return filepath.SkipDir
-				}
+		if d.IsDir() {
+			if w.shouldIgnore(path) {
+				return filepath.SkipDir
 			}
-			return w.watcher.Add(path)
+			return w.add(path)
 		}
 
 		return nil
 	})
 }
 
+// add watches a single directory. At the OS watch limit it reports a
+// WatchLimitError, once, and returns filepath.SkipAll to stop the walk.
+func (w *Watcher) add(dir string) error {
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+
+	err := w.watcher.Add(dir)
+	switch {
+	case err == nil:
+		w.watched++
+		return nil
+	case errors.Is(err, os.ErrNotExist):
+		// Removed since it was listed
+		return nil
+	case errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE):
+		if !w.limitReported {
+			w.limitReported = true
+			w.reportError(&WatchLimitError{Watched: w.watched, Dir: dir, Err: err})
+		}
+		return filepath.SkipAll
+	}
+	return err
+}
+
+// reportError sends an error to Errors unless its buffer is full.
+func (w *Watcher) reportError(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
+
 // processEvents processes fsnotify events and emits debounced FileEvents
 func (w *Watcher) processEvents(ctx context.Context) {
 	for {
@@ -200,16 +327,21 @@ func (w *Watcher) processEvents(ctx context.Context) {
 			if !ok {
 				return
 			}
-			select {
-			case w.errors <- err:
-			default:
-			}
+			w.reportError(err)
 		}
 	}
 }
 
 // handleEvent handles a single fsnotify event
 func (w *Watcher) handleEvent(event fsnotify.Event) {
+	// Start watching directories created after Start
+	if event.Op&fsnotify.Create == fsnotify.Create && !w.shouldIgnore(event.Name) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.watchNewDir(event.Name)
+			return
+		}
+	}
+
 	// Skip if doesn't match any pattern
 	if !w.matchesPattern(event.Name) {
 		return
@@ -245,6 +377,25 @@ func (w *Watcher) handleEvent(event fsnotify.Event) {
 	w.debounce(fileEvent)
 }
 
+// watchNewDir adds a newly created directory to the watcher and emits create
+// events for matching files written to it before the watch was in place.
+func (w *Watcher) watchNewDir(dir string) {
+	if err := w.addRecursive(dir); err != nil {
+		w.reportError(err)
+		return
+	}
+
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if w.matchesPattern(path) && !w.shouldIgnore(path) {
+			w.debounce(FileEvent{Path: path, Type: FileEventCreated, Timestamp: time.Now()})
+		}
+		return nil
+	})
+}
+
 // debounce debounces file events
 func (w *Watcher) debounce(event FileEvent) {
 	w.pendingMu.Lock()
@@ -276,7 +427,7 @@ func (w *Watcher) debounce(event FileEvent) {
 
 // matchesPattern checks if a file matches any of the watch patterns
 func (w *Watcher) matchesPattern(path string) bool {
-	if len(w.config.Patterns) == 0 {
+	if len(w.config.Patterns) == 0 && len(w.config.DirPatterns) == 0 {
 		return true
 	}
 
@@ -287,16 +438,69 @@ func (w *Watcher) matchesPattern(path string) bool {
 		}
 	}
 
+	rel := w.relPath(path)
+	for dir, patterns := range w.config.DirPatterns {
+		if _, ok := below(rel, dir); !ok {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(pattern, base); matched {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
-// shouldIgnore checks if a file should be ignored
+// shouldIgnore checks if a file or directory should be ignored
 func (w *Watcher) shouldIgnore(path string) bool {
-	// Check each path component
-	parts := strings.Split(path, string(filepath.Separator))
-	for _, part := range parts {
-		for _, pattern := range w.config.IgnorePatterns {
-			if matched, _ := filepath.Match(pattern, part); matched {
+	rel := w.relPath(path)
+	if matchesIgnore(rel, w.config.IgnorePatterns) {
+		return true
+	}
+
+	for dir, patterns := range w.config.DirIgnorePatterns {
+		if sub, ok := below(rel, dir); ok && matchesIgnore(sub, patterns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relPath returns path relative to the project directory with forward
+// slashes, so directories above the project never match ignore patterns.
+func (w *Watcher) relPath(p string) string {
+	rel, err := filepath.Rel(w.config.ProjectDir, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// matchesIgnore reports whether a slash-separated relative path matches an
+// ignore pattern. Patterns without a slash match any path component; patterns
+// with one match the path itself or one of its parent directories.
+func matchesIgnore(rel string, patterns []string) bool {
+	if rel == "." {
+		return false
+	}
+
+	parts := strings.Split(rel, "/")
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			pattern = strings.Trim(pattern, "/")
+			for i := range parts {
+				if matched, _ := path.Match(pattern, strings.Join(parts[:i+1], "/")); matched {
+					return true
+				}
+			}
+			continue
+		}
+
+		for _, part := range parts {
+			if matched, _ := path.Match(pattern, part); matched {
 				return true
 			}
 		}
@@ -305,6 +509,19 @@ func (w *Watcher) shouldIgnore(path string) bool {
 	return false
 }
 
+// below returns rel relative to dir when rel is dir or inside it.
+func below(rel, dir string) (string, bool) {
+	switch {
+	case dir == ".":
+		return rel, true
+	case rel == dir:
+		return ".", true
+	case strings.HasPrefix(rel, dir+"/"):
+		return rel[len(dir)+1:], true
+	}
+	return "", false
+}
+
 // IsRunning returns whether the watcher is running
 func (w *Watcher) IsRunning() bool {
 	w.mu.RLock()
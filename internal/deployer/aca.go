@@ -0,0 +1,221 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"gopkg.in/yaml.v3"
+)
+
+// ACADeployer implements Azure Container Apps deployment with the az CLI.
+// It applies the project's container app definition, creating the container
+// app on the first deploy.
+type ACADeployer struct{}
+
+func init() {
+	Register(Info{
+		Name:        "aca",
+		Label:       "Azure Container Apps",
+		Description: "Deploy to Azure Container Apps (services only)",
+		Languages:   []string{"go", "nestjs"},
+		Order:       4,
+		New:         func() Deployer { return NewACADeployer() },
+	})
+}
+
+// NewACADeployer creates a new Azure Container Apps deployer
+func NewACADeployer() *ACADeployer {
+	return &ACADeployer{}
+}
+
+// Name returns the deployer identifier
+func (d *ACADeployer) Name() string {
+	return "@forge/aca:deploy"
+}
+
+// SupportsSkaffold returns false as Skaffold cannot deploy to Container Apps
+func (d *ACADeployer) SupportsSkaffold() bool {
+	return false
+}
+
+// Deploy creates or updates the container app from its definition
+func (d *ACADeployer) Deploy(ctx context.Context, opts *DeployOptions) error {
+	resourceGroup := stringOption(opts.Options, "resourceGroup", os.Getenv("AZURE_RESOURCE_GROUP"))
+	if resourceGroup == "" {
+		return fmt.Errorf("no resource group for %s: set the resourceGroup deploy option or AZURE_RESOURCE_GROUP", opts.Project)
+	}
+	name := stringOption(opts.Options, "name", opts.Project)
+	deployDir := filepath.Join(opts.ProjectRoot, stringOption(opts.Options, "configPath", "deploy/aca"))
+
+	if opts.Verbose {
+		fmt.Printf("🚀 Deploying to Azure Container Apps: %s (resource group: %s, app: %s)\n", opts.Project, resourceGroup, name)
+	}
+
+	vars := &acaVariables{
+		ctx:           ctx,
+		env:           opts.Configuration,
+		resourceGroup: resourceGroup,
+		location:      stringOption(opts.Options, "location", os.Getenv("AZURE_LOCATION")),
+		environment:   stringOption(opts.Options, "environment", os.Getenv("ACA_ENVIRONMENT")),
+	}
+
+	definition, err := expandVariables(filepath.Join(deployDir, "containerapp.yaml"), "container app definition", vars.lookup)
+	if err != nil {
+		return err
+	}
+	image := deployImage(opts)
+	if opts.Artifact != nil && image == "" {
+		return fmt.Errorf("the build of %s produced no image for Container Apps to run: build it with a builder producing a named image, or deploy an image already pushed with --skip-build and the image deploy option", opts.Project)
+	}
+	definition, err = setACAImage(definition, opts.Project, image)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		fmt.Printf("# Container app definition for %s (resource group: %s, app: %s)\n", opts.Project, resourceGroup, name)
+		fmt.Println(string(definition))
+		return nil
+	}
+
+	// Container Apps pulls the image from its registry: push the one just
+	// built before the container app references it
+	if opts.Artifact != nil {
+		if err := pushACRImage(ctx, image, opts.Verbose); err != nil {
+			return err
+		}
+	}
+
+	workDir := filepath.Join(opts.WorkspaceRoot, ".forge", "aca-deploy", opts.Project)
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create Container Apps deploy directory: %w", err)
+	}
+	definitionPath := filepath.Join(workDir, "containerapp.yaml")
+	if err := os.WriteFile(definitionPath, definition, 0644); err != nil {
+		return fmt.Errorf("failed to write container app definition: %w", err)
+	}
+
+	output, err := azCLI(ctx, "containerapp", "list",
+		"--resource-group", resourceGroup,
+		"--query", fmt.Sprintf("length([?name=='%s'])", name), "--output", "tsv")
+	if err != nil {
+		return fmt.Errorf("failed to list container apps in %s: %w", resourceGroup, err)
+	}
+
+	// az containerapp update replaces the app with the definition; the first
+	// deploy creates it
+	action := "update"
+	if strings.TrimSpace(string(output)) == "0" {
+		action = "create"
+	}
+	_, err = azCLI(ctx, "containerapp", action,
+		"--name", name, "--resource-group", resourceGroup,
+		"--yaml", definitionPath)
+	if err != nil {
+		return fmt.Errorf("failed to %s container app %s: %w", action, name, err)
+	}
+
+	if opts.Verbose {
+		fmt.Printf("✅ Container Apps deployment completed\n")
+	}
+
+	return nil
+}
+
+// acrRegistry matches the host of an Azure Container Registry,
+// <name>.azurecr.io, capturing the registry name.
+var acrRegistry = regexp.MustCompile(`^([a-zA-Z0-9]+)\.azurecr\.io$`)
+
+// pushACRImage pushes an image to its registry, logging Docker in with
+// az acr login first when it is an Azure Container Registry. Other registries
+// use the existing docker login.
+func pushACRImage(ctx context.Context, image string, verbose bool) error {
+	registry, _, _ := strings.Cut(image, "/")
+	if match := acrRegistry.FindStringSubmatch(registry); match != nil {
+		if _, err := azCLI(ctx, "acr", "login", "--name", match[1]); err != nil {
+			return fmt.Errorf("failed to log in to %s: %w", registry, err)
+		}
+	}
+	return dockerPush(ctx, image, verbose)
+}
+
+// setACAImage sets the image of the project's container in a container app
+// definition, or of its only container.
+func setACAImage(definition []byte, containerName, image string) ([]byte, error) {
+	var app map[string]interface{}
+	if err := yaml.Unmarshal(definition, &app); err != nil {
+		return nil, fmt.Errorf("invalid container app definition: %w", err)
+	}
+	if image == "" {
+		return yaml.Marshal(app)
+	}
+
+	properties, _ := app["properties"].(map[string]interface{})
+	template, _ := properties["template"].(map[string]interface{})
+	containers, _ := template["containers"].([]interface{})
+	var target map[string]interface{}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if ok && (container["name"] == containerName || len(containers) == 1) {
+			target = container
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("container app definition has no container named %s", containerName)
+	}
+	target["image"] = image
+
+	return yaml.Marshal(app)
+}
+
+// acaVariables expands the ${VAR} placeholders of the container app
+// definition: ENV is the deploy configuration, AZURE_RESOURCE_GROUP,
+// AZURE_LOCATION and ACA_ENVIRONMENT the deployer options and
+// AZURE_SUBSCRIPTION_ID the subscription of the current az login. Other
+// variables come from the environment.
+type acaVariables struct {
+	ctx            context.Context
+	env            string
+	resourceGroup  string
+	location       string
+	environment    string
+	subscriptionID string
+}
+
+func (v *acaVariables) lookup(name string) (string, error) {
+	switch name {
+	case "ENV":
+		return v.env, nil
+	case "AZURE_RESOURCE_GROUP":
+		return v.resourceGroup, nil
+	case "AZURE_LOCATION":
+		return v.location, nil
+	case "ACA_ENVIRONMENT":
+		return v.environment, nil
+	case "AZURE_SUBSCRIPTION_ID":
+		if v.subscriptionID == "" {
+			v.subscriptionID = os.Getenv("AZURE_SUBSCRIPTION_ID")
+		}
+		if v.subscriptionID == "" {
+			output, err := azCLI(v.ctx, "account", "show", "--query", "id", "--output", "tsv")
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve Azure subscription ID: %w", err)
+			}
+			v.subscriptionID = strings.TrimSpace(string(output))
+		}
+		return v.subscriptionID, nil
+	default:
+		return os.Getenv(name), nil
+	}
+}
+
+// azCLI runs an az CLI command and returns its output.
+func azCLI(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.Output(ctx, exec.Options{Name: "az", Args: args})
+}
@@ -0,0 +1,81 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+func TestACADeployPushesImage(t *testing.T) {
+	const image = "acmeshop.azurecr.io/orders:abc123"
+
+	var ran []string
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts.String())
+		if strings.HasPrefix(opts.String(), "az containerapp list") {
+			fmt.Fprintln(opts.Stdout, "1")
+		}
+		return nil
+	}))()
+
+	root := t.TempDir()
+	projectRoot := filepath.Join(root, "backend", "services", "orders")
+	if err := os.MkdirAll(filepath.Join(projectRoot, "deploy", "aca"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	definition := "properties:\n  template:\n    containers:\n      - name: orders\n        image: orders:latest\n"
+	if err := os.WriteFile(filepath.Join(projectRoot, "deploy", "aca", "containerapp.yaml"), []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &DeployOptions{
+		Project:       "orders",
+		Artifact:      &builder.BuildArtifact{Type: builder.ArtifactTypeImage, ImageName: image, Tag: "abc123"},
+		Configuration: "production",
+		Options:       map[string]interface{}{"resourceGroup": "shop"},
+		WorkspaceRoot: root,
+		ProjectRoot:   projectRoot,
+	}
+	if err := NewACADeployer().Deploy(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"az acr login --name acmeshop",
+		"docker push " + image,
+		"az containerapp list",
+		"az containerapp update",
+	}
+	if len(ran) != len(want) {
+		t.Fatalf("ran %q, want %q", ran, want)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(ran[i], prefix) {
+			t.Errorf("command %d = %q, want %q", i, ran[i], prefix)
+		}
+	}
+
+	applied, err := os.ReadFile(filepath.Join(root, ".forge", "aca-deploy", "orders", "containerapp.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(applied), image) {
+		t.Errorf("container app definition has no %s:\n%s", image, applied)
+	}
+
+	// A build without an image is not deployed with the one of the definition
+	ran = nil
+	opts.Artifact = &builder.BuildArtifact{Type: builder.ArtifactTypeBinary, Path: filepath.Join(projectRoot, "bazel-bin")}
+	if err := NewACADeployer().Deploy(context.Background(), opts); err == nil {
+		t.Error("Deploy() succeeded without an image to push")
+	}
+	if len(ran) != 0 {
+		t.Errorf("ran %q without an image to push", ran)
+	}
+}
@@ -0,0 +1,218 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// Deployer names that support canary rollouts
+const (
+	helmDeployer     = "@forge/helm:deploy"
+	cloudRunDeployer = "@forge/cloudrun:deploy"
+)
+
+// CanaryTarget identifies where a project's canary runs for one configuration.
+type CanaryTarget struct {
+	Project   string
+	Deployer  string
+	Service   string // Cloud Run service or Helm release name
+	Namespace string // Helm only
+	Region    string // Cloud Run only
+	ProjectID string // Cloud Run only
+}
+
+// CanaryRelease is the Helm release holding a project's canary pods.
+func (t *CanaryTarget) CanaryRelease() string {
+	return t.Service + "-canary"
+}
+
+// ResolveCanaryTarget resolves the canary target of a project from its deploy
+// options merged with the given configuration.
+func ResolveCanaryTarget(config *workspace.Config, projectName, configuration string) (*CanaryTarget, error) {
+	return resolveTarget(config, projectName, configuration, "canary deployments")
+}
+
+// resolveTarget resolves the Helm release or Cloud Run service of a project
+// for a feature only those deployers support, named in the error otherwise.
+func resolveTarget(config *workspace.Config, projectName, configuration, feature string) (*CanaryTarget, error) {
+	project := config.GetProject(projectName)
+	if project == nil {
+		return nil, fmt.Errorf("project %q not found in forge.json", projectName)
+	}
+	if project.Architect == nil || project.Architect.Deploy == nil {
+		return nil, fmt.Errorf("project %q has no deploy configuration", projectName)
+	}
+
+	deploy := project.Architect.Deploy
+	options := deploy.ResolveOptions(configuration)
+
+	target := &CanaryTarget{
+		Project:  projectName,
+		Deployer: deploy.Deployer,
+		Service:  stringOption(options, "service", projectName),
+	}
+
+	switch deploy.Deployer {
+	case helmDeployer:
+		target.Namespace = stringOption(options, "namespace", "default")
+	case cloudRunDeployer:
+		var gcp workspace.GCPConfig
+		if config.Workspace.GCP != nil {
+			gcp = *config.Workspace.GCP
+		}
+		target.Region = stringOption(options, "region", gcp.Region)
+		target.ProjectID = stringOption(options, "projectId", gcp.ProjectID)
+	default:
+		return nil, fmt.Errorf("project %q uses %s, which does not support %s (supported: helm, cloudrun)", projectName, deploy.Deployer, feature)
+	}
+
+	return target, nil
+}
+
+// TrafficSplit returns the stable and canary traffic percentages for a canary
+// weight. The weight must leave traffic on both sides.
+func TrafficSplit(percent int) (stable, canary int, err error) {
+	if percent < 1 || percent > 99 {
+		return 0, 0, fmt.Errorf("canary percentage must be between 1 and 99, got %d", percent)
+	}
+	return 100 - percent, percent, nil
+}
+
+// CloudRunServingRevision returns the revision currently serving all traffic,
+// which stays the stable revision while a canary runs.
+func CloudRunServingRevision(ctx context.Context, target *CanaryTarget) (string, error) {
+	status, err := describeCloudRunService(ctx, target)
+	if err != nil {
+		return "", err
+	}
+
+	for _, traffic := range status.Traffic {
+		if traffic.Percent == 100 {
+			if traffic.RevisionName != "" {
+				return traffic.RevisionName, nil
+			}
+			return status.LatestReadyRevisionName, nil
+		}
+	}
+	return "", fmt.Errorf("cloud run service %s already splits traffic; promote or abort the running canary first", target.Service)
+}
+
+// SplitCloudRunTraffic routes percent of traffic to the latest revision and the
+// rest to the stable revision.
+func SplitCloudRunTraffic(ctx context.Context, target *CanaryTarget, stableRevision string, percent int) error {
+	stable, canary, err := TrafficSplit(percent)
+	if err != nil {
+		return err
+	}
+	return updateCloudRunTraffic(ctx, target, "--to-revisions", fmt.Sprintf("%s=%d,LATEST=%d", stableRevision, stable, canary))
+}
+
+// RestoreCloudRunTraffic sends all traffic back to the stable revision.
+func RestoreCloudRunTraffic(ctx context.Context, target *CanaryTarget, stableRevision string) error {
+	return updateCloudRunTraffic(ctx, target, "--to-revisions", stableRevision+"=100")
+}
+
+// PromoteCanary sends all traffic to the canary. For Helm the stable release has
+// already been redeployed with the canary build, so only the canary release is removed.
+func PromoteCanary(ctx context.Context, target *CanaryTarget) error {
+	switch target.Deployer {
+	case cloudRunDeployer:
+		return updateCloudRunTraffic(ctx, target, "--to-latest")
+	case helmDeployer:
+		return uninstallHelmRelease(ctx, target.CanaryRelease(), target.Namespace)
+	}
+	return fmt.Errorf("canary is not supported for %s", target.Deployer)
+}
+
+// AbortCanary sends all traffic back to the stable version and removes the canary.
+func AbortCanary(ctx context.Context, target *CanaryTarget) error {
+	switch target.Deployer {
+	case cloudRunDeployer:
+		status, err := describeCloudRunService(ctx, target)
+		if err != nil {
+			return err
+		}
+		for _, traffic := range status.Traffic {
+			if traffic.RevisionName != "" && traffic.RevisionName != status.LatestCreatedRevisionName && traffic.Percent > 0 {
+				return updateCloudRunTraffic(ctx, target, "--to-revisions", traffic.RevisionName+"=100")
+			}
+		}
+		return fmt.Errorf("no stable revision receiving traffic for cloud run service %s", target.Service)
+	case helmDeployer:
+		return uninstallHelmRelease(ctx, target.CanaryRelease(), target.Namespace)
+	}
+	return fmt.Errorf("canary is not supported for %s", target.Deployer)
+}
+
+// cloudRunStatus is the subset of 'gcloud run services describe' output used
+// for canaries and rollouts.
+type cloudRunStatus struct {
+	LatestReadyRevisionName   string `json:"latestReadyRevisionName"`
+	LatestCreatedRevisionName string `json:"latestCreatedRevisionName"`
+	Traffic                   []struct {
+		RevisionName string `json:"revisionName"`
+		Percent      int    `json:"percent"`
+	} `json:"traffic"`
+	Conditions []struct {
+		Type    string `json:"type"`
+		Status  string `json:"status"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"conditions"`
+}
+
+func describeCloudRunService(ctx context.Context, target *CanaryTarget) (*cloudRunStatus, error) {
+	args := append([]string{"run", "services", "describe", target.Service, "--format", "json"}, target.gcloudFlags()...)
+	output, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe cloud run service %s: %w", target.Service, err)
+	}
+
+	var service struct {
+		Status cloudRunStatus `json:"status"`
+	}
+	if err := json.Unmarshal(output, &service); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud run service %s: %w", target.Service, err)
+	}
+	return &service.Status, nil
+}
+
+func updateCloudRunTraffic(ctx context.Context, target *CanaryTarget, trafficArgs ...string) error {
+	args := append([]string{"run", "services", "update-traffic", target.Service}, trafficArgs...)
+	args = append(args, target.gcloudFlags()...)
+	if _, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args}); err != nil {
+		return fmt.Errorf("failed to update traffic for cloud run service %s: %w", target.Service, err)
+	}
+	return nil
+}
+
+func uninstallHelmRelease(ctx context.Context, release, namespace string) error {
+	if _, err := exec.Capture(ctx, exec.Options{Name: "helm", Args: []string{"uninstall", release, "--namespace", namespace}}); err != nil {
+		return fmt.Errorf("failed to uninstall helm release %s: %w", release, err)
+	}
+	return nil
+}
+
+// gcloudFlags returns the region and project flags for gcloud commands.
+func (t *CanaryTarget) gcloudFlags() []string {
+	var flags []string
+	if t.Region != "" {
+		flags = append(flags, "--region", t.Region)
+	}
+	if t.ProjectID != "" {
+		flags = append(flags, "--project", t.ProjectID)
+	}
+	return flags
+}
+
+// stringOption returns a non-empty string option or the default.
+func stringOption(options map[string]interface{}, key, defaultValue string) string {
+	if value, ok := options[key].(string); ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,41 @@
+package deployer
+
+import "testing"
+
+func TestTrafficSplit(t *testing.T) {
+	tests := []struct {
+		percent    int
+		wantStable int
+		wantCanary int
+		wantErr    bool
+	}{
+		{percent: 1, wantStable: 99, wantCanary: 1},
+		{percent: 10, wantStable: 90, wantCanary: 10},
+		{percent: 50, wantStable: 50, wantCanary: 50},
+		{percent: 99, wantStable: 1, wantCanary: 99},
+		{percent: 0, wantErr: true},
+		{percent: 100, wantErr: true},
+		{percent: -5, wantErr: true},
+		{percent: 150, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		stable, canary, err := TrafficSplit(tt.percent)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("TrafficSplit(%d) = %d, %d, want error", tt.percent, stable, canary)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("TrafficSplit(%d) returned error: %v", tt.percent, err)
+			continue
+		}
+		if stable != tt.wantStable || canary != tt.wantCanary {
+			t.Errorf("TrafficSplit(%d) = %d, %d, want %d, %d", tt.percent, stable, canary, tt.wantStable, tt.wantCanary)
+		}
+		if stable+canary != 100 {
+			t.Errorf("TrafficSplit(%d) splits %d%% of traffic, want 100%%", tt.percent, stable+canary)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package deployer
+
+// Cloud Run services are deployed by Skaffold; there is no direct deployer.
+func init() {
+	Register(Info{
+		Name:             "cloudrun",
+		Label:            "CloudRun",
+		Description:      "Deploy to Google Cloud Run",
+		SkaffoldBuilders: []string{"@forge/bazel:build", "@forge/docker:build"},
+		Order:            2,
+	})
+}
@@ -0,0 +1,284 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+// ECSDeployer implements AWS ECS (Fargate) deployment with the aws CLI.
+// It registers a new revision of the project's task definition and points the
+// ECS service at it, creating the service on the first deploy.
+type ECSDeployer struct{}
+
+func init() {
+	Register(Info{
+		Name:        "ecs",
+		Label:       "ECS (AWS Fargate)",
+		Description: "Deploy to AWS ECS on Fargate (services only)",
+		Languages:   []string{"go", "nestjs"},
+		Order:       3,
+		New:         func() Deployer { return NewECSDeployer() },
+	})
+}
+
+// NewECSDeployer creates a new ECS deployer
+func NewECSDeployer() *ECSDeployer {
+	return &ECSDeployer{}
+}
+
+// Name returns the deployer identifier
+func (d *ECSDeployer) Name() string {
+	return "@forge/ecs:deploy"
+}
+
+// SupportsSkaffold returns false as Skaffold cannot deploy to ECS
+func (d *ECSDeployer) SupportsSkaffold() bool {
+	return false
+}
+
+// Deploy registers the task definition and updates the ECS service
+func (d *ECSDeployer) Deploy(ctx context.Context, opts *DeployOptions) error {
+	region := stringOption(opts.Options, "region", os.Getenv("AWS_REGION"))
+	cluster := stringOption(opts.Options, "cluster", "default")
+	service := stringOption(opts.Options, "service", opts.Project)
+	deployDir := filepath.Join(opts.ProjectRoot, stringOption(opts.Options, "configPath", "deploy/ecs"))
+
+	if opts.Verbose {
+		fmt.Printf("🚀 Deploying to ECS: %s (cluster: %s, service: %s)\n", opts.Project, cluster, service)
+	}
+
+	vars := &ecsVariables{ctx: ctx, region: region, env: opts.Configuration}
+
+	taskDefinition, err := vars.expandFile(filepath.Join(deployDir, "task-definition.json"))
+	if err != nil {
+		return err
+	}
+	image := deployImage(opts)
+	if opts.Artifact != nil && image == "" {
+		return fmt.Errorf("the build of %s produced no image for ECS to run: build it with a builder producing a named image, or deploy an image already pushed with --skip-build and the image deploy option", opts.Project)
+	}
+	taskDefinition, err = setECSImage(taskDefinition, opts.Project, image)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		fmt.Printf("# Task definition for %s (cluster: %s, service: %s)\n", opts.Project, cluster, service)
+		fmt.Println(string(taskDefinition))
+		return nil
+	}
+
+	// ECS pulls the image from its registry: push the one just built before
+	// the task definition references it
+	if opts.Artifact != nil {
+		if err := pushECRImage(ctx, image, opts.Verbose); err != nil {
+			return err
+		}
+	}
+
+	workDir := filepath.Join(opts.WorkspaceRoot, ".forge", "ecs-deploy", opts.Project)
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return fmt.Errorf("failed to create ECS deploy directory: %w", err)
+	}
+	taskDefinitionPath := filepath.Join(workDir, "task-definition.json")
+	if err := os.WriteFile(taskDefinitionPath, taskDefinition, 0644); err != nil {
+		return fmt.Errorf("failed to write task definition: %w", err)
+	}
+
+	output, err := awsCLI(ctx, region, "ecs", "register-task-definition",
+		"--cli-input-json", "file://"+taskDefinitionPath,
+		"--query", "taskDefinition.taskDefinitionArn", "--output", "text")
+	if err != nil {
+		return fmt.Errorf("failed to register task definition: %w", err)
+	}
+	taskDefinitionArn := strings.TrimSpace(string(output))
+
+	if opts.Verbose {
+		fmt.Printf("   Registered %s\n", taskDefinitionArn)
+	}
+
+	output, err = awsCLI(ctx, region, "ecs", "describe-services",
+		"--cluster", cluster, "--services", service,
+		"--query", "length(services[?status=='ACTIVE'])", "--output", "text")
+	if err != nil {
+		return fmt.Errorf("failed to describe ECS service %s: %w", service, err)
+	}
+
+	if strings.TrimSpace(string(output)) != "0" {
+		_, err = awsCLI(ctx, region, "ecs", "update-service",
+			"--cluster", cluster, "--service", service,
+			"--task-definition", taskDefinitionArn)
+		if err != nil {
+			return fmt.Errorf("failed to update ECS service %s: %w", service, err)
+		}
+	} else {
+		// First deploy: create the service from the service definition
+		serviceDefinition, err := vars.expandFile(filepath.Join(deployDir, "service.json"))
+		if err != nil {
+			return err
+		}
+		serviceDefinitionPath := filepath.Join(workDir, "service.json")
+		if err := os.WriteFile(serviceDefinitionPath, serviceDefinition, 0644); err != nil {
+			return fmt.Errorf("failed to write service definition: %w", err)
+		}
+
+		_, err = awsCLI(ctx, region, "ecs", "create-service",
+			"--cli-input-json", "file://"+serviceDefinitionPath,
+			"--cluster", cluster, "--service-name", service,
+			"--task-definition", taskDefinitionArn)
+		if err != nil {
+			return fmt.Errorf("failed to create ECS service %s: %w", service, err)
+		}
+	}
+
+	if opts.Verbose {
+		fmt.Printf("✅ ECS deployment completed\n")
+	}
+
+	return nil
+}
+
+// deployImage returns the image to deploy: the built image, with the exact tag
+// it was built with, when the builder produced a named one, then the image
+// option. An empty result keeps the image of the deployment definition.
+func deployImage(opts *DeployOptions) string {
+	if opts.Artifact != nil && opts.Artifact.ImageName != "" {
+		name := opts.Artifact.ImageName
+		if strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+			return name
+		}
+		if opts.Artifact.Tag != "" {
+			return opts.Artifact.ImageName + ":" + opts.Artifact.Tag
+		}
+		return opts.Artifact.ImageName
+	}
+	return stringOption(opts.Options, "image", "")
+}
+
+// ecrRegistry matches the host of an ECR registry,
+// <account>.dkr.ecr.<region>.amazonaws.com, capturing its region.
+var ecrRegistry = regexp.MustCompile(`^\d{12}\.dkr\.ecr\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+// pushECRImage pushes an image to its registry, logging Docker in to the
+// registry first when it is an ECR one. Other registries use the existing
+// docker login.
+func pushECRImage(ctx context.Context, image string, verbose bool) error {
+	registry, _, _ := strings.Cut(image, "/")
+	if match := ecrRegistry.FindStringSubmatch(registry); match != nil {
+		password, err := awsCLI(ctx, match[1], "ecr", "get-login-password")
+		if err != nil {
+			return fmt.Errorf("failed to get an ECR login password for %s: %w", registry, err)
+		}
+		if err := dockerLogin(ctx, registry, "AWS", password); err != nil {
+			return err
+		}
+	}
+	return dockerPush(ctx, image, verbose)
+}
+
+// dockerLogin logs Docker in to a registry, passing the password on stdin.
+func dockerLogin(ctx context.Context, registry, username string, password []byte) error {
+	output, err := exec.CombinedOutput(ctx, exec.Options{
+		Name:  "docker",
+		Args:  []string{"login", "--username", username, "--password-stdin", registry},
+		Stdin: bytes.NewReader(bytes.TrimSpace(password)),
+	})
+	if err != nil {
+		return fmt.Errorf("docker login to %s failed: %w\n%s", registry, err, output)
+	}
+	return nil
+}
+
+// dockerPush pushes an image to its registry.
+func dockerPush(ctx context.Context, image string, verbose bool) error {
+	if verbose {
+		fmt.Printf("   Pushing %s\n", image)
+	}
+	if err := exec.Run(ctx, exec.Options{Name: "docker", Args: []string{"push", image}}); err != nil {
+		return fmt.Errorf("failed to push %s: %w", image, err)
+	}
+	return nil
+}
+
+// setECSImage sets the image of the project's container in a task definition,
+// or of its only container.
+func setECSImage(taskDefinition []byte, containerName, image string) ([]byte, error) {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(taskDefinition, &definition); err != nil {
+		return nil, fmt.Errorf("invalid task definition: %w", err)
+	}
+	if image == "" {
+		return json.MarshalIndent(definition, "", "  ")
+	}
+
+	containers, _ := definition["containerDefinitions"].([]interface{})
+	var target map[string]interface{}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if ok && (container["name"] == containerName || len(containers) == 1) {
+			target = container
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("task definition has no container named %s", containerName)
+	}
+	target["image"] = image
+
+	return json.MarshalIndent(definition, "", "  ")
+}
+
+// ecsVariables expands the ${VAR} placeholders of the ECS definition files:
+// ENV is the deploy configuration, AWS_REGION the deploy region and
+// AWS_ACCOUNT_ID the account of the current credentials. Other variables come
+// from the environment.
+type ecsVariables struct {
+	ctx       context.Context
+	region    string
+	env       string
+	accountID string
+}
+
+func (v *ecsVariables) expandFile(path string) ([]byte, error) {
+	return expandVariables(path, "ECS definition", v.lookup)
+}
+
+func (v *ecsVariables) lookup(name string) (string, error) {
+	switch name {
+	case "ENV":
+		return v.env, nil
+	case "AWS_REGION":
+		return v.region, nil
+	case "AWS_ACCOUNT_ID":
+		if v.accountID == "" {
+			v.accountID = os.Getenv("AWS_ACCOUNT_ID")
+		}
+		if v.accountID == "" {
+			output, err := awsCLI(v.ctx, v.region, "sts", "get-caller-identity", "--query", "Account", "--output", "text")
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve AWS account ID: %w", err)
+			}
+			v.accountID = strings.TrimSpace(string(output))
+		}
+		return v.accountID, nil
+	default:
+		return os.Getenv(name), nil
+	}
+}
+
+// awsCLI runs an aws CLI command and returns its output. The region is passed
+// when set; otherwise the CLI uses its own configuration.
+func awsCLI(ctx context.Context, region string, args ...string) ([]byte, error) {
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	return exec.Output(ctx, exec.Options{Name: "aws", Args: args})
+}
@@ -0,0 +1,102 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+func TestECSDeployPushesImage(t *testing.T) {
+	const image = "123456789012.dkr.ecr.eu-west-1.amazonaws.com/orders:abc123"
+
+	var ran []string
+	var loginPassword string
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts.String())
+		switch {
+		case strings.HasPrefix(opts.String(), "aws ecr get-login-password"):
+			fmt.Fprintln(opts.Stdout, "secret")
+		case strings.HasPrefix(opts.String(), "docker login"):
+			password, _ := io.ReadAll(opts.Stdin)
+			loginPassword = string(password)
+		case strings.HasPrefix(opts.String(), "aws ecs register-task-definition"):
+			fmt.Fprintln(opts.Stdout, "arn:aws:ecs:eu-west-1:123456789012:task-definition/orders:2")
+		case strings.HasPrefix(opts.String(), "aws ecs describe-services"):
+			fmt.Fprintln(opts.Stdout, "1")
+		}
+		return nil
+	}))()
+
+	root := t.TempDir()
+	projectRoot := filepath.Join(root, "backend", "services", "orders")
+	if err := os.MkdirAll(filepath.Join(projectRoot, "deploy", "ecs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	taskDefinition := `{"family": "orders", "containerDefinitions": [{"name": "orders", "image": "orders:latest"}]}`
+	if err := os.WriteFile(filepath.Join(projectRoot, "deploy", "ecs", "task-definition.json"), []byte(taskDefinition), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &DeployOptions{
+		Project:       "orders",
+		Artifact:      &builder.BuildArtifact{Type: builder.ArtifactTypeImage, ImageName: image, Tag: "abc123"},
+		Configuration: "production",
+		Options:       map[string]interface{}{"region": "eu-west-1", "cluster": "shop"},
+		WorkspaceRoot: root,
+		ProjectRoot:   projectRoot,
+	}
+	if err := NewECSDeployer().Deploy(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"aws ecr get-login-password --region eu-west-1",
+		"docker login --username AWS --password-stdin 123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+		"docker push " + image,
+		"aws ecs register-task-definition",
+	}
+	if len(ran) < len(want) {
+		t.Fatalf("ran %q, want %q first", ran, want)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(ran[i], prefix) {
+			t.Errorf("command %d = %q, want %q", i, ran[i], prefix)
+		}
+	}
+	if loginPassword != "secret" {
+		t.Errorf("docker login password = %q, want the ECR login password", loginPassword)
+	}
+
+	registered, err := os.ReadFile(filepath.Join(root, ".forge", "ecs-deploy", "orders", "task-definition.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(registered), image) {
+		t.Errorf("registered task definition has no %s:\n%s", image, registered)
+	}
+
+	// A failed push stops the deploy before the task definition is registered
+	ran = nil
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts.String())
+		if strings.HasPrefix(opts.String(), "docker push") {
+			return fmt.Errorf("denied")
+		}
+		return nil
+	}))()
+	if err := NewECSDeployer().Deploy(context.Background(), opts); err == nil {
+		t.Error("Deploy() succeeded with an image that was not pushed")
+	}
+	for _, command := range ran {
+		if strings.HasPrefix(command, "aws ecs") {
+			t.Errorf("ran %q after the push failed", command)
+		}
+	}
+}
@@ -4,15 +4,27 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/dosanma1/forge-cli/internal/builder"
+	"github.com/dosanma1/forge-cli/internal/exec"
 )
 
 // FirebaseDeployer implements Firebase deployment
 type FirebaseDeployer struct{}
 
+func init() {
+	// Firebase never uses Skaffold
+	Register(Info{
+		Name:        "firebase",
+		Label:       "Firebase",
+		Description: "Deploy to Firebase Hosting (Angular and Vue only)",
+		Languages:   []string{"angular", "vue"},
+		Order:       0,
+		New:         func() Deployer { return NewFirebaseDeployer() },
+	})
+}
+
 // NewFirebaseDeployer creates a new Firebase deployer
 func NewFirebaseDeployer() *FirebaseDeployer {
 	return &FirebaseDeployer{}
@@ -65,8 +77,7 @@ func (d *FirebaseDeployer) Deploy(ctx context.Context, opts *DeployOptions) erro
 		}
 
 		// Extract tar
-		cmd := exec.CommandContext(ctx, "tar", "-xzf", opts.Artifact.Path, "-C", extractDir)
-		if err := cmd.Run(); err != nil {
+		if _, err := exec.Capture(ctx, exec.Options{Name: "tar", Args: []string{"-xzf", opts.Artifact.Path, "-C", extractDir}}); err != nil {
 			return fmt.Errorf("failed to extract artifact: %w", err)
 		}
 
@@ -89,16 +100,16 @@ func (d *FirebaseDeployer) Deploy(ctx context.Context, opts *DeployOptions) erro
 	// Add public directory override
 	args = append(args, "--public", publicDir)
 
+	// A dry run validates the configuration and files without uploading them
+	if opts.DryRun {
+		args = append(args, "--dry-run")
+	}
+
 	if opts.Verbose {
 		fmt.Printf("   Running: firebase %v\n", args)
 	}
 
-	cmd := exec.CommandContext(ctx, "firebase", args...)
-	cmd.Dir = opts.ProjectRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{Name: "firebase", Args: args, Dir: opts.ProjectRoot}); err != nil {
 		return fmt.Errorf("firebase deploy failed: %w", err)
 	}
 
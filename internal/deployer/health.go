@@ -0,0 +1,269 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// healthPollInterval is the wait between two health requests.
+const healthPollInterval = 2 * time.Second
+
+// HealthCheck identifies the health endpoint of a deployed project.
+type HealthCheck struct {
+	Project   string
+	Deployer  string
+	Path      string // healthPath deploy option
+	Service   string // Kubernetes or Cloud Run service
+	Namespace string // Helm only
+	Port      int    // Helm only: service port
+	Region    string // Cloud Run only
+	ProjectID string // Cloud Run only
+}
+
+// ResolveHealthCheck resolves the health endpoint of a project from its deploy
+// options merged with the given configuration. It returns nil when the project
+// has no healthPath or its deployer cannot be checked (only helm and cloudrun can).
+func ResolveHealthCheck(config *workspace.Config, projectName, configuration string) *HealthCheck {
+	project := config.GetProject(projectName)
+	if project == nil || project.Architect == nil || project.Architect.Deploy == nil {
+		return nil
+	}
+
+	deploy := project.Architect.Deploy
+	options := deploy.ResolveOptions(configuration)
+	path := stringOption(options, "healthPath", "")
+	if path == "" {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	check := &HealthCheck{
+		Project:  projectName,
+		Deployer: deploy.Deployer,
+		Path:     path,
+		Service:  stringOption(options, "service", projectName),
+	}
+
+	switch deploy.Deployer {
+	case helmDeployer:
+		check.Namespace = stringOption(options, "namespace", "default")
+		check.Port = intOption(options, "port", 8080)
+	case cloudRunDeployer:
+		var gcp workspace.GCPConfig
+		if config.Workspace.GCP != nil {
+			gcp = *config.Workspace.GCP
+		}
+		check.Region = stringOption(options, "region", gcp.Region)
+		check.ProjectID = stringOption(options, "projectId", gcp.ProjectID)
+	default:
+		return nil
+	}
+
+	return check
+}
+
+// WaitHealthy polls the health endpoint until it answers 200 OK. Helm services
+// are reached through kubectl port-forward and Cloud Run services through
+// their URL. It fails with the last response when timeout expires first.
+func WaitHealthy(ctx context.Context, check *HealthCheck, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var probe func() (string, bool)
+	switch check.Deployer {
+	case helmDeployer:
+		forward := &portForward{check: check}
+		defer forward.stop()
+		probe = func() (string, bool) {
+			localPort, err := forward.ensure(ctx)
+			if err != nil {
+				return err.Error(), false
+			}
+			return getHealth(ctx, fmt.Sprintf("http://127.0.0.1:%d%s", localPort, check.Path), nil)
+		}
+
+	case cloudRunDeployer:
+		url, err := cloudRunURL(ctx, check)
+		if err != nil {
+			return err
+		}
+		header := http.Header{}
+		// Private services need an identity token; public ones ignore it
+		if token, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: []string{"auth", "print-identity-token"}}); err == nil {
+			header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		}
+		probe = func() (string, bool) {
+			return getHealth(ctx, url+check.Path, header)
+		}
+
+	default:
+		return fmt.Errorf("health checks are not supported for %s", check.Deployer)
+	}
+
+	last := "no response"
+	for {
+		response, healthy := probe()
+		if healthy {
+			return nil
+		}
+		if response != "" {
+			last = response
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not become healthy within %s (last response from %s: %s)", check.Project, timeout, check.Path, last)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// getHealth requests a health endpoint and describes the response.
+func getHealth(ctx context.Context, url string, header http.Header) (string, bool) {
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return err.Error(), false
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", false
+		}
+		return err.Error(), false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return "", true
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return strings.TrimSpace(fmt.Sprintf("%s %s", resp.Status, body)), false
+}
+
+// cloudRunURL returns the URL of a Cloud Run service without a trailing slash.
+func cloudRunURL(ctx context.Context, check *HealthCheck) (string, error) {
+	target := &CanaryTarget{Service: check.Service, Region: check.Region, ProjectID: check.ProjectID}
+	args := append([]string{"run", "services", "describe", check.Service, "--format", "value(status.url)"}, target.gcloudFlags()...)
+	output, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the URL of cloud run service %s: %w", check.Service, err)
+	}
+	url := strings.TrimSuffix(strings.TrimSpace(string(output)), "/")
+	if url == "" {
+		return "", fmt.Errorf("cloud run service %s has no URL", check.Service)
+	}
+	return url, nil
+}
+
+// portForward keeps a kubectl port-forward to a Helm service running. The
+// forward ends when the pod it picked dies, so it is restarted on demand.
+type portForward struct {
+	check     *HealthCheck
+	cancel    context.CancelFunc
+	done      chan struct{}
+	stderr    bytes.Buffer
+	localPort int
+}
+
+// ensure starts the port-forward unless it is running and returns its local port.
+func (f *portForward) ensure(ctx context.Context) (int, error) {
+	if f.cancel != nil {
+		select {
+		case <-f.done:
+			return 0, f.exited()
+		default:
+			return f.localPort, nil
+		}
+	}
+
+	localPort, err := freePort()
+	if err != nil {
+		return 0, err
+	}
+
+	f.stderr.Reset()
+	forwardCtx, cancel := context.WithCancel(ctx)
+	opts := exec.Options{
+		Name: "kubectl",
+		Args: []string{"port-forward",
+			"svc/" + f.check.Service, fmt.Sprintf("%d:%d", localPort, f.check.Port),
+			"--namespace", f.check.Namespace},
+		Stdout: io.Discard,
+		Stderr: &f.stderr,
+	}
+
+	f.cancel = cancel
+	f.localPort = localPort
+	f.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		exec.Run(forwardCtx, opts)
+	}(f.done)
+
+	// Give kubectl a moment to bind the local port
+	select {
+	case <-f.done:
+		return 0, f.exited()
+	case <-time.After(500 * time.Millisecond):
+	}
+	return localPort, nil
+}
+
+// exited reports a port-forward that stopped and resets it for a restart.
+func (f *portForward) exited() error {
+	err := fmt.Errorf("kubectl port-forward exited: %s", strings.TrimSpace(f.stderr.String()))
+	f.cancel()
+	f.cancel = nil
+	return err
+}
+
+func (f *portForward) stop() {
+	if f.cancel != nil {
+		f.cancel()
+		<-f.done
+	}
+}
+
+// freePort returns a local TCP port that is free at the time of the call.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// intOption returns an integer option, accepting JSON numbers and numeric strings.
+func intOption(options map[string]interface{}, key string, defaultValue int) int {
+	switch value := options[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	case string:
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
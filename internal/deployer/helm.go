@@ -3,6 +3,10 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
 )
 
 // HelmDeployer implements Helm deployment
@@ -10,6 +14,17 @@ import (
 // When Skaffold is available, deployment is handled by Skaffold orchestration
 type HelmDeployer struct{}
 
+func init() {
+	Register(Info{
+		Name:             "helm",
+		Label:            "Helm (Kubernetes)",
+		Description:      "Deploy to Kubernetes using Helm charts",
+		SkaffoldBuilders: []string{"@forge/bazel:build", "@forge/docker:build"},
+		Order:            1,
+		New:              func() Deployer { return NewHelmDeployer() },
+	})
+}
+
 // NewHelmDeployer creates a new Helm deployer
 func NewHelmDeployer() *HelmDeployer {
 	return &HelmDeployer{}
@@ -32,9 +47,49 @@ func (d *HelmDeployer) Deploy(ctx context.Context, opts *DeployOptions) error {
 		fmt.Printf("🚀 Deploying with Helm (direct mode): %s\n", opts.Project)
 	}
 
+	if opts.DryRun {
+		return d.template(ctx, opts)
+	}
+
 	// TODO: Implement direct Helm deployment
 	// This would be used for cases where Skaffold cannot be used
 	// (e.g., builder doesn't support Skaffold)
 
 	return fmt.Errorf("direct Helm deployment not yet implemented - use Skaffold-compatible builders")
 }
+
+// template prints the manifests of the project's local chart with `helm
+// template`, rendered with the same release name, namespace and values a
+// Skaffold deploy uses.
+func (d *HelmDeployer) template(ctx context.Context, opts *DeployOptions) error {
+	chart := stringOption(opts.Options, "chartPath", "")
+	if chart == "" {
+		chart = stringOption(opts.Options, "configPath", "")
+	}
+	if chart == "" {
+		return fmt.Errorf("helm dry run of %s requires a local chart (chartPath or configPath)", opts.Project)
+	}
+	chartPath := filepath.Join(opts.ProjectRoot, chart)
+
+	args := []string{"template", opts.Project, chartPath,
+		"--namespace", stringOption(opts.Options, "namespace", "default"),
+		"--set", "nameOverride=" + opts.Project,
+		"--set", "fullnameOverride=" + opts.Project,
+	}
+	if values := filepath.Join(chartPath, "values.yaml"); fileExists(values) {
+		args = append(args, "-f", values)
+	}
+	if values := filepath.Join(chartPath, "envs", opts.Configuration, "values.yaml"); fileExists(values) {
+		args = append(args, "-f", values)
+	}
+
+	if err := exec.Run(ctx, exec.Options{Name: "helm", Args: args}); err != nil {
+		return fmt.Errorf("helm template failed: %w", err)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,13 @@
+package deployer
+
+// Raw Kubernetes manifests are applied by Skaffold. forge does not generate
+// them, so the deployer is only available to projects configured by hand.
+func init() {
+	Register(Info{
+		Name:             "kubectl",
+		Label:            "kubectl (Kubernetes manifests)",
+		Description:      "Apply Kubernetes manifests with kubectl",
+		SkaffoldBuilders: []string{"@forge/bazel:build", "@forge/docker:build"},
+		Hidden:           true,
+	})
+}
@@ -0,0 +1,243 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+const firebaseDeployer = "@forge/firebase:deploy"
+
+// ProjectURL returns the URL a deployed project is served at: the service URL
+// for Cloud Run, the hosting site of .firebaserc for Firebase and the ingress
+// host of the chart values for Helm.
+func ProjectURL(ctx context.Context, config *workspace.Config, workspaceRoot, projectName, configuration string) (string, error) {
+	project, options, err := deployOptions(config, projectName, configuration)
+	if err != nil {
+		return "", err
+	}
+	projectRoot := filepath.Join(workspaceRoot, project.Root)
+
+	switch project.Architect.Deploy.Deployer {
+	case cloudRunDeployer:
+		target, err := resolveTarget(config, projectName, configuration, "forge open")
+		if err != nil {
+			return "", err
+		}
+		return cloudRunURL(ctx, &HealthCheck{Service: target.Service, Region: target.Region, ProjectID: target.ProjectID})
+	case firebaseDeployer:
+		site, err := firebaseSite(config, firebaseConfigDir(projectRoot, options), projectName, options)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("https://%s.web.app", site), nil
+	case helmDeployer:
+		chart := stringOption(options, "chartPath", stringOption(options, "configPath", ""))
+		if chart == "" {
+			return "", fmt.Errorf("project %q has no local chart (chartPath or configPath) to read the ingress host from", projectName)
+		}
+		return helmIngressURL(filepath.Join(projectRoot, chart), configuration)
+	}
+	return "", fmt.Errorf("project %q uses %s, which has no known URL (supported: cloudrun, firebase, helm)", projectName, project.Architect.Deploy.Deployer)
+}
+
+// DashboardURL returns the console page of a deployed project: the Cloud Run
+// service, the Firebase hosting dashboard or the GKE workloads of the
+// namespace for Helm.
+func DashboardURL(config *workspace.Config, workspaceRoot, projectName, configuration string) (string, error) {
+	project, options, err := deployOptions(config, projectName, configuration)
+	if err != nil {
+		return "", err
+	}
+
+	var gcpProject string
+	if config.Workspace.GCP != nil {
+		gcpProject = config.Workspace.GCP.ProjectID
+	}
+
+	switch project.Architect.Deploy.Deployer {
+	case cloudRunDeployer:
+		target, err := resolveTarget(config, projectName, configuration, "forge open")
+		if err != nil {
+			return "", err
+		}
+		if target.Region == "" || target.ProjectID == "" {
+			return "", fmt.Errorf("project %q needs a region and a GCP project (deploy options or workspace.gcp) for its console URL", projectName)
+		}
+		return fmt.Sprintf("https://console.cloud.google.com/run/detail/%s/%s?project=%s",
+			target.Region, target.Service, url.QueryEscape(target.ProjectID)), nil
+	case firebaseDeployer:
+		rc, err := readFirebaserc(firebaseConfigDir(filepath.Join(workspaceRoot, project.Root), options))
+		if err != nil {
+			return "", err
+		}
+		firebaseProject := firebaseProjectID(rc, options, gcpProject)
+		if firebaseProject == "" {
+			return "", fmt.Errorf("project %q has no Firebase project (project deploy option, .firebaserc or workspace.gcp)", projectName)
+		}
+		return fmt.Sprintf("https://console.firebase.google.com/project/%s/hosting", firebaseProject), nil
+	case helmDeployer:
+		if gcpProject == "" {
+			return "", fmt.Errorf("project %q needs workspace.gcp.projectId for its console URL", projectName)
+		}
+		namespace := stringOption(options, "namespace", "default")
+		return fmt.Sprintf("https://console.cloud.google.com/kubernetes/workload/overview?project=%s&namespace=%s",
+			url.QueryEscape(gcpProject), url.QueryEscape(namespace)), nil
+	}
+	return "", fmt.Errorf("project %q uses %s, which has no known console (supported: cloudrun, firebase, helm)", projectName, project.Architect.Deploy.Deployer)
+}
+
+// deployOptions returns a project and its deploy options merged with the
+// given configuration.
+func deployOptions(config *workspace.Config, projectName, configuration string) (*workspace.Project, map[string]interface{}, error) {
+	project := config.GetProject(projectName)
+	if project == nil {
+		return nil, nil, fmt.Errorf("project %q not found in forge.json", projectName)
+	}
+	if project.Architect == nil || project.Architect.Deploy == nil {
+		return nil, nil, fmt.Errorf("project %q has no deploy configuration", projectName)
+	}
+	return project, project.Architect.Deploy.ResolveOptions(configuration), nil
+}
+
+// firebaserc is the part of .firebaserc naming the project and hosting sites.
+type firebaserc struct {
+	Projects map[string]string `json:"projects"`
+	Targets  map[string]struct {
+		Hosting map[string][]string `json:"hosting"`
+	} `json:"targets"`
+}
+
+// firebaseConfigDir returns the directory holding the Firebase configuration
+// of an app: its configPath deploy option, relative to the project root, as
+// the Firebase deployer resolves it.
+func firebaseConfigDir(projectRoot string, options map[string]interface{}) string {
+	return filepath.Join(projectRoot, stringOption(options, "configPath", ""))
+}
+
+// readFirebaserc reads the .firebaserc in dir. A missing file is empty.
+func readFirebaserc(dir string) (*firebaserc, error) {
+	var rc firebaserc
+	content, err := os.ReadFile(filepath.Join(dir, ".firebaserc"))
+	if os.IsNotExist(err) {
+		return &rc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &rc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, ".firebaserc"), err)
+	}
+	return &rc, nil
+}
+
+// firebaseProjectID returns the Firebase project of the project deploy option,
+// the default project of .firebaserc or the workspace GCP project.
+func firebaseProjectID(rc *firebaserc, options map[string]interface{}, gcpProject string) string {
+	if id := stringOption(options, "project", ""); id != "" {
+		return id
+	}
+	if id := rc.Projects["default"]; id != "" {
+		return id
+	}
+	return gcpProject
+}
+
+// firebaseSite returns the hosting site of an app: the site of its hosting
+// target in .firebaserc (the target deploy option, the target named after the
+// app or the only target), or the default site, named after the Firebase
+// project.
+func firebaseSite(config *workspace.Config, configDir, projectName string, options map[string]interface{}) (string, error) {
+	rc, err := readFirebaserc(configDir)
+	if err != nil {
+		return "", err
+	}
+
+	var gcpProject string
+	if config.Workspace.GCP != nil {
+		gcpProject = config.Workspace.GCP.ProjectID
+	}
+	firebaseProject := firebaseProjectID(rc, options, gcpProject)
+
+	hosting := rc.Targets[firebaseProject].Hosting
+	if sites := hosting[stringOption(options, "target", projectName)]; len(sites) > 0 {
+		return sites[0], nil
+	}
+	if len(hosting) == 1 {
+		for _, sites := range hosting {
+			if len(sites) > 0 {
+				return sites[0], nil
+			}
+		}
+	}
+	if firebaseProject == "" {
+		return "", fmt.Errorf("project %q has no Firebase project (project deploy option, .firebaserc or workspace.gcp)", projectName)
+	}
+	return firebaseProject, nil
+}
+
+// helmIngress is the ingress section of Helm chart values.
+type helmIngress struct {
+	Enabled *bool `yaml:"enabled"`
+	Hosts   []struct {
+		Host string `yaml:"host"`
+	} `yaml:"hosts"`
+	TLS []interface{} `yaml:"tls"`
+}
+
+// helmIngressURL returns the URL of the first ingress host of a chart, read
+// from values.yaml overridden by envs/<configuration>/values.yaml as deploys
+// apply them. TLS ingresses are served over https.
+func helmIngressURL(chartPath, configuration string) (string, error) {
+	var ingress helmIngress
+	for _, path := range []string{
+		filepath.Join(chartPath, "values.yaml"),
+		filepath.Join(chartPath, "envs", configuration, "values.yaml"),
+	} {
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		var values struct {
+			Ingress *helmIngress `yaml:"ingress"`
+		}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if values.Ingress == nil {
+			continue
+		}
+		if values.Ingress.Enabled != nil {
+			ingress.Enabled = values.Ingress.Enabled
+		}
+		if values.Ingress.Hosts != nil {
+			ingress.Hosts = values.Ingress.Hosts
+		}
+		if values.Ingress.TLS != nil {
+			ingress.TLS = values.Ingress.TLS
+		}
+	}
+
+	if ingress.Enabled == nil || !*ingress.Enabled {
+		return "", fmt.Errorf("the chart at %s has no ingress enabled for %s; use 'kubectl port-forward' to reach the service", chartPath, configuration)
+	}
+	if len(ingress.Hosts) == 0 || ingress.Hosts[0].Host == "" {
+		return "", fmt.Errorf("the ingress of the chart at %s has no host", chartPath)
+	}
+
+	scheme := "http"
+	if len(ingress.TLS) > 0 {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, ingress.Hosts[0].Host), nil
+}
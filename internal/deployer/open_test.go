@@ -0,0 +1,139 @@
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestFirebaseSite(t *testing.T) {
+	tests := []struct {
+		name       string
+		firebaserc string
+		options    map[string]interface{}
+		want       string
+	}{
+		{
+			name:       "target named after the app",
+			firebaserc: `{"projects":{"default":"acme"},"targets":{"acme":{"hosting":{"web":["acme-web"],"admin":["acme-admin"]}}}}`,
+			want:       "acme-web",
+		},
+		{
+			name:       "only target",
+			firebaserc: `{"projects":{"default":"acme"},"targets":{"acme":{"hosting":{"site":["acme-site"]}}}}`,
+			want:       "acme-site",
+		},
+		{
+			name:       "default site",
+			firebaserc: `{"projects":{"default":"acme"}}`,
+			want:       "acme",
+		},
+		{
+			name:       "project option",
+			firebaserc: `{"projects":{"default":"acme"},"targets":{"acme-prod":{"hosting":{"web":["acme-prod-web"]}}}}`,
+			options:    map[string]interface{}{"project": "acme-prod"},
+			want:       "acme-prod-web",
+		},
+		{
+			name:       "target option",
+			firebaserc: `{"projects":{"default":"acme"},"targets":{"acme":{"hosting":{"web":["acme-web"],"storefront":["acme-storefront"]}}}}`,
+			options:    map[string]interface{}{"target": "storefront"},
+			want:       "acme-storefront",
+		},
+		{
+			name: "workspace project",
+			want: "gcp-project",
+		},
+	}
+
+	config := &workspace.Config{Workspace: workspace.WorkspaceMetadata{GCP: &workspace.GCPConfig{ProjectID: "gcp-project"}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.firebaserc != "" {
+				if err := os.WriteFile(filepath.Join(root, ".firebaserc"), []byte(tt.firebaserc), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := firebaseSite(config, root, "web", tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("firebaseSite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelmIngressURL(t *testing.T) {
+	const base = `ingress:
+  enabled: false
+  hosts:
+    - host: api.local
+  tls: []
+`
+	tests := []struct {
+		name    string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			wantErr: true,
+		},
+		{
+			name: "enabled by the environment",
+			env:  "ingress:\n  enabled: true\n",
+			want: "http://api.local",
+		},
+		{
+			name: "environment host with tls",
+			env: `ingress:
+  enabled: true
+  hosts:
+    - host: api.example.com
+  tls:
+    - hosts: [api.example.com]
+      secretName: api-tls
+`,
+			want: "https://api.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := t.TempDir()
+			if err := os.WriteFile(filepath.Join(chart, "values.yaml"), []byte(base), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.env != "" {
+				envDir := filepath.Join(chart, "envs", "production")
+				if err := os.MkdirAll(envDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(envDir, "values.yaml"), []byte(tt.env), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := helmIngressURL(chart, "production")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("helmIngressURL() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("helmIngressURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
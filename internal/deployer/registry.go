@@ -1,64 +1,172 @@
 package deployer
 
-import "fmt"
-
-// skaffoldSupport defines which builder+deployer combinations support Skaffold
-var skaffoldSupport = map[string]map[string]bool{
-	"@forge/helm:deploy": {
-		"@forge/bazel:build":   true,
-		"@forge/docker:build":  true,
-		"@forge/angular:build": false,
-		"@forge/go:build":      false,
-	},
-	"@forge/kubectl:deploy": {
-		"@forge/bazel:build":  true,
-		"@forge/docker:build": true,
-	},
-	"@forge/cloudrun:deploy": {
-		"@forge/bazel:build":  true,
-		"@forge/docker:build": true,
-	},
-	"@forge/firebase:deploy": {
-		// Firebase never uses Skaffold
-		"@forge/bazel:build":   false,
-		"@forge/angular:build": false,
-	},
-}
-
-// Registry of available deployers
-var deployers = map[string]func() Deployer{
-	"@forge/firebase:deploy": func() Deployer { return NewFirebaseDeployer() },
-	"@forge/helm:deploy":     func() Deployer { return NewHelmDeployer() },
-}
-
-// GetDeployer returns a deployer instance by name
-func GetDeployer(name string) (Deployer, error) {
-	factory, ok := deployers[name]
-	if !ok {
-		return nil, fmt.Errorf("unknown deployer: %s", name)
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Info describes a deployment target. Each deployer registers its Info from an
+// init function, so adding a deployer does not touch the commands offering it.
+type Info struct {
+	// Name is the short name used by forge switch and forge new (e.g., "helm").
+	Name string
+
+	// Label is the display name used in interactive prompts (e.g., "Helm (Kubernetes)").
+	Label string
+
+	// Description is a one-line summary shown in help texts.
+	Description string
+
+	// Languages are the project languages the deployer supports (empty = all).
+	Languages []string
+
+	// SkaffoldBuilders are the builders whose artifacts Skaffold deploys with
+	// this deployer. Other builders go through the direct deployer.
+	SkaffoldBuilders []string
+
+	// Order positions the deployer in prompts, lowest first.
+	Order int
+
+	// Hidden deployers can be configured in forge.json but are not offered by
+	// forge switch, forge new and forge generate.
+	Hidden bool
+
+	// New creates the deployer used for direct deployments (nil when the
+	// deployer only deploys through Skaffold).
+	New func() Deployer
+}
+
+// ID returns the deployer identifier used in forge.json (e.g., "@forge/helm:deploy").
+func (i Info) ID() string {
+	return fmt.Sprintf("@forge/%s:deploy", i.Name)
+}
+
+// Supports reports whether the deployer can deploy projects of a language.
+func (i Info) Supports(language string) bool {
+	if len(i.Languages) == 0 {
+		return true
+	}
+	for _, l := range i.Languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry manages available deployers keyed by name.
+type Registry struct {
+	deployers map[string]Info
+}
+
+// NewRegistry creates a new deployer registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		deployers: make(map[string]Info),
+	}
+}
+
+// Register adds a deployer to the registry.
+func (r *Registry) Register(info Info) error {
+	if _, exists := r.deployers[info.Name]; exists {
+		return fmt.Errorf("deployer %q already registered", info.Name)
 	}
-	return factory(), nil
+	r.deployers[info.Name] = info
+	return nil
 }
 
-// RegisterDeployer adds a new deployer to the registry
-func RegisterDeployer(name string, factory func() Deployer) {
-	deployers[name] = factory
+// Resolve finds a deployer by short name ("helm") or identifier ("@forge/helm:deploy").
+func (r *Registry) Resolve(name string) (Info, error) {
+	info, exists := r.deployers[shortName(name)]
+	if !exists {
+		return Info{}, fmt.Errorf("unknown deployer: %s (available: %s)", name, strings.Join(r.Names(""), ", "))
+	}
+	return info, nil
+}
+
+// List returns the deployers offered for a language ("" = any language),
+// sorted by Order. Hidden deployers are left out.
+func (r *Registry) List(language string) []Info {
+	infos := make([]Info, 0, len(r.deployers))
+	for _, info := range r.deployers {
+		if info.Hidden || (language != "" && !info.Supports(language)) {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Order != infos[j].Order {
+			return infos[i].Order < infos[j].Order
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
 }
 
-// ListDeployers returns all registered deployer names
-func ListDeployers() []string {
-	names := make([]string, 0, len(deployers))
-	for name := range deployers {
-		names = append(names, name)
+// Names returns the names of the deployers offered for a language.
+func (r *Registry) Names(language string) []string {
+	infos := r.List(language)
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
 	}
 	return names
 }
 
+// shortName turns "@forge/helm:deploy" into "helm".
+func shortName(name string) string {
+	name = strings.TrimPrefix(name, "@forge/")
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+// DefaultRegistry is the global deployer registry.
+var DefaultRegistry = NewRegistry()
+
+// Register registers a deployer in the default registry.
+func Register(info Info) error {
+	return DefaultRegistry.Register(info)
+}
+
+// Resolve finds a deployer in the default registry.
+func Resolve(name string) (Info, error) {
+	return DefaultRegistry.Resolve(name)
+}
+
+// List returns the deployers in the default registry offered for a language.
+func List(language string) []Info {
+	return DefaultRegistry.List(language)
+}
+
+// Names returns the names of the deployers in the default registry offered for a language.
+func Names(language string) []string {
+	return DefaultRegistry.Names(language)
+}
+
+// GetDeployer returns the direct deployer of a registered deployer
+func GetDeployer(name string) (Deployer, error) {
+	info, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.New == nil {
+		return nil, fmt.Errorf("deployer %s only deploys through Skaffold", info.ID())
+	}
+	return info.New(), nil
+}
+
 // CanUseSkaffold checks if a builder+deployer combination supports Skaffold
 func CanUseSkaffold(deployerName, builderName string) bool {
-	if builders, ok := skaffoldSupport[deployerName]; ok {
-		if supported, exists := builders[builderName]; exists {
-			return supported
+	info, err := Resolve(deployerName)
+	if err != nil {
+		return false
+	}
+	for _, builder := range info.SkaffoldBuilders {
+		if builder == builderName {
+			return true
 		}
 	}
 	return false
@@ -0,0 +1,174 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// ResolveRollbackTarget resolves the Helm release or Cloud Run service a
+// rollback of the project applies to, from its deploy options merged with the
+// given configuration.
+func ResolveRollbackTarget(config *workspace.Config, projectName, configuration string) (*CanaryTarget, error) {
+	return resolveTarget(config, projectName, configuration, "rollbacks")
+}
+
+// Rollback returns a project to an earlier release and reports the revision it
+// rolled back to. For Helm the release is rolled back to revision, a release
+// revision number; for Cloud Run all traffic is routed to revision, a revision
+// name. An empty revision selects the last good one before the current.
+func Rollback(ctx context.Context, target *CanaryTarget, revision string) (string, error) {
+	switch target.Deployer {
+	case helmDeployer:
+		return rollbackHelm(ctx, target, revision)
+	case cloudRunDeployer:
+		return rollbackCloudRun(ctx, target, revision)
+	}
+	return "", fmt.Errorf("rollback is not supported for %s", target.Deployer)
+}
+
+// helmRevision is an entry of 'helm history -o json'.
+type helmRevision struct {
+	Revision int    `json:"revision"`
+	Status   string `json:"status"`
+}
+
+func rollbackHelm(ctx context.Context, target *CanaryTarget, revision string) (string, error) {
+	if revision == "" {
+		previous, err := previousHelmRevision(ctx, target)
+		if err != nil {
+			return "", err
+		}
+		revision = strconv.Itoa(previous)
+	} else if _, err := strconv.Atoi(revision); err != nil {
+		return "", fmt.Errorf("helm revision must be a number, got %q", revision)
+	}
+
+	args := []string{"rollback", target.Service, revision, "--namespace", target.Namespace, "--wait"}
+	if _, err := exec.Capture(ctx, exec.Options{Name: "helm", Args: args}); err != nil {
+		return "", fmt.Errorf("failed to roll back helm release %s: %w", target.Service, err)
+	}
+	return revision, nil
+}
+
+// previousHelmRevision returns the newest revision before the deployed one
+// that was deployed successfully, skipping failed upgrades.
+func previousHelmRevision(ctx context.Context, target *CanaryTarget) (int, error) {
+	args := []string{"history", target.Service, "--namespace", target.Namespace, "--output", "json"}
+	output, err := exec.Capture(ctx, exec.Options{Name: "helm", Args: args})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history of helm release %s: %w", target.Service, err)
+	}
+
+	var history []helmRevision
+	if err := json.Unmarshal(output, &history); err != nil {
+		return 0, fmt.Errorf("failed to parse history of helm release %s: %w", target.Service, err)
+	}
+	return previousRevision(history)
+}
+
+func previousRevision(history []helmRevision) (int, error) {
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Revision < history[j].Revision
+	})
+
+	current := -1
+	for i, entry := range history {
+		if entry.Status == "deployed" {
+			current = i
+		}
+	}
+	if current == -1 {
+		return 0, fmt.Errorf("no deployed revision to roll back from")
+	}
+
+	for i := current - 1; i >= 0; i-- {
+		switch history[i].Status {
+		case "superseded", "deployed":
+			return history[i].Revision, nil
+		}
+	}
+	return 0, fmt.Errorf("no earlier revision than %d to roll back to", history[current].Revision)
+}
+
+// cloudRunRevision is an entry of 'gcloud run revisions list --format json'.
+type cloudRunRevision struct {
+	Metadata struct {
+		Name              string    `json:"name"`
+		CreationTimestamp time.Time `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Status struct {
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+func (r *cloudRunRevision) ready() bool {
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == "Ready" {
+			return condition.Status == "True"
+		}
+	}
+	return false
+}
+
+func rollbackCloudRun(ctx context.Context, target *CanaryTarget, revision string) (string, error) {
+	if revision == "" {
+		serving, err := CloudRunServingRevision(ctx, target)
+		if err != nil {
+			return "", err
+		}
+		if revision, err = previousCloudRunRevision(ctx, target, serving); err != nil {
+			return "", err
+		}
+	}
+
+	if err := RestoreCloudRunTraffic(ctx, target, revision); err != nil {
+		return "", err
+	}
+	return revision, nil
+}
+
+// previousCloudRunRevision returns the newest ready revision created before
+// the serving one.
+func previousCloudRunRevision(ctx context.Context, target *CanaryTarget, serving string) (string, error) {
+	args := append([]string{"run", "revisions", "list", "--service", target.Service, "--format", "json"}, target.gcloudFlags()...)
+	output, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args})
+	if err != nil {
+		return "", fmt.Errorf("failed to list revisions of cloud run service %s: %w", target.Service, err)
+	}
+
+	var revisions []cloudRunRevision
+	if err := json.Unmarshal(output, &revisions); err != nil {
+		return "", fmt.Errorf("failed to parse revisions of cloud run service %s: %w", target.Service, err)
+	}
+	return previousReadyRevision(revisions, serving)
+}
+
+func previousReadyRevision(revisions []cloudRunRevision, serving string) (string, error) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Metadata.CreationTimestamp.After(revisions[j].Metadata.CreationTimestamp)
+	})
+
+	found := false
+	for _, revision := range revisions {
+		switch {
+		case revision.Metadata.Name == serving:
+			found = true
+		case found && revision.ready():
+			return revision.Metadata.Name, nil
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("serving revision %s not found", serving)
+	}
+	return "", fmt.Errorf("no ready revision older than %s to roll back to", serving)
+}
@@ -0,0 +1,100 @@
+package deployer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreviousRevision(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []helmRevision
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "previous",
+			history: []helmRevision{{1, "superseded"}, {2, "superseded"}, {3, "deployed"}},
+			want:    2,
+		},
+		{
+			name:    "skips failed upgrades",
+			history: []helmRevision{{1, "superseded"}, {2, "failed"}, {3, "deployed"}},
+			want:    1,
+		},
+		{
+			name:    "deployed before a failed upgrade",
+			history: []helmRevision{{4, "failed"}, {2, "superseded"}, {3, "deployed"}},
+			want:    2,
+		},
+		{
+			name:    "first release",
+			history: []helmRevision{{1, "deployed"}},
+			wantErr: true,
+		},
+		{
+			name:    "nothing deployed",
+			history: []helmRevision{{1, "failed"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := previousRevision(tt.history)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("previousRevision() = %d, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("previousRevision() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviousReadyRevision(t *testing.T) {
+	var revisions []cloudRunRevision
+	err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "api-00001", "creationTimestamp": "2026-01-01T10:00:00Z"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}},
+		{"metadata": {"name": "api-00004", "creationTimestamp": "2026-01-04T10:00:00Z"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}},
+		{"metadata": {"name": "api-00003", "creationTimestamp": "2026-01-03T10:00:00Z"}, "status": {"conditions": [{"type": "Ready", "status": "False"}]}},
+		{"metadata": {"name": "api-00002", "creationTimestamp": "2026-01-02T10:00:00Z"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}}
+	]`), &revisions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		serving string
+		want    string
+		wantErr bool
+	}{
+		{serving: "api-00004", want: "api-00002"},
+		{serving: "api-00002", want: "api-00001"},
+		{serving: "api-00001", wantErr: true},
+		{serving: "api-00009", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := previousReadyRevision(revisions, tt.serving)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("previousReadyRevision(%s) = %s, want error", tt.serving, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("previousReadyRevision(%s) returned error: %v", tt.serving, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("previousReadyRevision(%s) = %s, want %s", tt.serving, got, tt.want)
+		}
+	}
+}
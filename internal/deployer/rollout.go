@@ -0,0 +1,77 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// rolloutPollInterval is the wait between two rollout status requests.
+const rolloutPollInterval = 3 * time.Second
+
+// ResolveRolloutTarget resolves the Helm release or Cloud Run service of a
+// project to wait for its rollout.
+func ResolveRolloutTarget(config *workspace.Config, projectName, configuration string) (*CanaryTarget, error) {
+	return resolveTarget(config, projectName, configuration, "waiting for rollouts")
+}
+
+// WaitCloudRunRollout polls a Cloud Run service until its latest revision is
+// Ready and serving. It fails as soon as the Ready condition turns False, or
+// with the last status when timeout expires first.
+func WaitCloudRunRollout(ctx context.Context, target *CanaryTarget, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	last := "no status"
+	for {
+		status, err := describeCloudRunService(ctx, target)
+		if err != nil {
+			if ctx.Err() == nil {
+				last = err.Error()
+			}
+		} else {
+			ready, failed, message := cloudRunRolloutState(status)
+			if ready {
+				return nil
+			}
+			if failed {
+				return fmt.Errorf("cloud run service %s failed to roll out: %s", target.Service, message)
+			}
+			last = message
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cloud run service %s did not become ready within %s (last status: %s)", target.Service, timeout, last)
+		case <-time.After(rolloutPollInterval):
+		}
+	}
+}
+
+// cloudRunRolloutState reads the Ready condition of a service: ready once it is
+// True for the latest created revision, failed once it is False.
+func cloudRunRolloutState(status *cloudRunStatus) (ready, failed bool, message string) {
+	for _, condition := range status.Conditions {
+		if condition.Type != "Ready" {
+			continue
+		}
+		message = strings.TrimSpace(strings.Join([]string{condition.Reason, condition.Message}, " "))
+		switch condition.Status {
+		case "True":
+			if status.LatestCreatedRevisionName != "" && status.LatestReadyRevisionName != status.LatestCreatedRevisionName {
+				return false, false, fmt.Sprintf("revision %s is not ready yet", status.LatestCreatedRevisionName)
+			}
+			return true, false, ""
+		case "False":
+			return false, true, message
+		}
+		if message == "" {
+			message = "Ready condition is " + condition.Status
+		}
+		return false, false, message
+	}
+	return false, false, "no Ready condition yet"
+}
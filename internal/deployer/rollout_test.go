@@ -0,0 +1,61 @@
+package deployer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCloudRunRolloutState(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		wantReady   bool
+		wantFailed  bool
+		wantMessage string
+	}{
+		{
+			name:      "ready",
+			status:    `{"latestCreatedRevisionName": "api-00002", "latestReadyRevisionName": "api-00002", "conditions": [{"type": "Ready", "status": "True"}]}`,
+			wantReady: true,
+		},
+		{
+			name:        "previous revision still serving",
+			status:      `{"latestCreatedRevisionName": "api-00002", "latestReadyRevisionName": "api-00001", "conditions": [{"type": "Ready", "status": "True"}]}`,
+			wantMessage: "api-00002 is not ready",
+		},
+		{
+			name:        "in progress",
+			status:      `{"conditions": [{"type": "ConfigurationsReady", "status": "True"}, {"type": "Ready", "status": "Unknown", "reason": "Deploying"}]}`,
+			wantMessage: "Deploying",
+		},
+		{
+			name:        "failed",
+			status:      `{"conditions": [{"type": "Ready", "status": "False", "reason": "HealthCheckContainerError", "message": "container failed to start"}]}`,
+			wantFailed:  true,
+			wantMessage: "HealthCheckContainerError container failed to start",
+		},
+		{
+			name:        "no conditions",
+			status:      `{}`,
+			wantMessage: "no Ready condition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status cloudRunStatus
+			if err := json.Unmarshal([]byte(tt.status), &status); err != nil {
+				t.Fatal(err)
+			}
+
+			ready, failed, message := cloudRunRolloutState(&status)
+			if ready != tt.wantReady || failed != tt.wantFailed {
+				t.Errorf("cloudRunRolloutState() = ready %v, failed %v, want %v, %v", ready, failed, tt.wantReady, tt.wantFailed)
+			}
+			if !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("message = %q, want it to contain %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package deployer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+// secretManagerScheme prefixes --secrets-from sources read from Google Secret Manager.
+const secretManagerScheme = "gsm://"
+
+// SecretSource is where deploy secrets are read from: a dotenv file or Google
+// Secret Manager. Secret values are only ever held in memory; they are passed
+// to kubectl on stdin and never logged or written to forge.json.
+type SecretSource struct {
+	// File is the dotenv file holding KEY=VALUE lines
+	File string
+	// SecretManager is set for gsm:// sources
+	SecretManager bool
+	// ProjectID is the GCP project holding the secrets (gsm:// only, empty for
+	// the gcloud default project)
+	ProjectID string
+}
+
+// ParseSecretSource parses a --secrets-from value: a dotenv file path, or
+// gsm://[project] for Google Secret Manager.
+func ParseSecretSource(spec string) (*SecretSource, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("secret source cannot be empty")
+	}
+
+	if strings.HasPrefix(spec, secretManagerScheme) {
+		projectID := strings.Trim(strings.TrimPrefix(spec, secretManagerScheme), "/")
+		if strings.Contains(projectID, "/") {
+			return nil, fmt.Errorf("invalid secret source %q: expected gsm://[project]", spec)
+		}
+		return &SecretSource{SecretManager: true, ProjectID: projectID}, nil
+	}
+
+	if _, err := os.Stat(spec); err != nil {
+		return nil, fmt.Errorf("secret file %s not found: %w", spec, err)
+	}
+	return &SecretSource{File: spec}, nil
+}
+
+// String describes the source without revealing any secret.
+func (s *SecretSource) String() string {
+	if s.SecretManager {
+		return secretManagerScheme + s.ProjectID
+	}
+	return s.File
+}
+
+// ProjectSecrets returns the secrets deploy option of a project, mapping
+// environment variable names to their key in the secret source. The option is
+// either a list of names, looked up under the same key, or an object mapping
+// names to keys. Secret Manager keys may pin a version as "name:version".
+func ProjectSecrets(options map[string]interface{}) (map[string]string, error) {
+	secrets := make(map[string]string)
+
+	switch value := options["secrets"].(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range value {
+			name, ok := item.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("secrets must list environment variable names, got %v", item)
+			}
+			secrets[name] = name
+		}
+	case map[string]interface{}:
+		for name, item := range value {
+			key, ok := item.(string)
+			if !ok || key == "" {
+				return nil, fmt.Errorf("secret %s must map to a key name, got %v", name, item)
+			}
+			secrets[name] = key
+		}
+	default:
+		return nil, fmt.Errorf("secrets must be a list of names or an object mapping names to keys")
+	}
+
+	return secrets, nil
+}
+
+// Values resolves the values of secrets (environment variable name to source
+// key) from the source.
+func (s *SecretSource) Values(ctx context.Context, secrets map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(secrets))
+
+	if s.SecretManager {
+		for name, key := range secrets {
+			secret, version := splitSecretVersion(key)
+			args := []string{"secrets", "versions", "access", version, "--secret", secret}
+			if s.ProjectID != "" {
+				args = append(args, "--project", s.ProjectID)
+			}
+			output, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read secret %s from Secret Manager: %w", secret, err)
+			}
+			values[name] = string(output)
+		}
+		return values, nil
+	}
+
+	file, err := readEnvFile(s.File)
+	if err != nil {
+		return nil, err
+	}
+	for name, key := range secrets {
+		value, ok := file[key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s not found in %s", key, s.File)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// CloudRunSecrets returns the --update-secrets value mapping each environment
+// variable to its Secret Manager secret. Cloud Run reads the secrets itself, so
+// they must come from Secret Manager.
+func (s *SecretSource) CloudRunSecrets(secrets map[string]string) (string, error) {
+	if !s.SecretManager {
+		return "", fmt.Errorf("cloud run secrets must come from Secret Manager (--secrets-from=gsm://[project]), not %s", s.File)
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	refs := make([]string, 0, len(names))
+	for _, name := range names {
+		secret, version := splitSecretVersion(secrets[name])
+		if s.ProjectID != "" {
+			secret = fmt.Sprintf("projects/%s/secrets/%s", s.ProjectID, secret)
+		}
+		refs = append(refs, fmt.Sprintf("%s=%s:%s", name, secret, version))
+	}
+	return strings.Join(refs, ","), nil
+}
+
+// UpdateCloudRunSecrets points the environment of a Cloud Run service at its
+// Secret Manager secrets, creating a new revision.
+func UpdateCloudRunSecrets(ctx context.Context, target *CanaryTarget, refs string) error {
+	args := append([]string{"run", "services", "update", target.Service, "--update-secrets", refs}, target.gcloudFlags()...)
+	if _, err := exec.Capture(ctx, exec.Options{Name: "gcloud", Args: args}); err != nil {
+		return fmt.Errorf("failed to update secrets of cloud run service %s: %w", target.Service, err)
+	}
+	return nil
+}
+
+// SecretName is the Kubernetes Secret holding a project's deploy secrets. It
+// differs from the chart's own secret, which is named after the release.
+func SecretName(projectName string) string {
+	return projectName + "-secrets"
+}
+
+// ApplyKubernetesSecret creates or updates an Opaque Secret, creating its
+// namespace when missing. The manifest goes to kubectl on stdin so values never
+// appear in process arguments or output.
+func ApplyKubernetesSecret(ctx context.Context, name, namespace string, values map[string]string) error {
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": namespace},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"type":       "Opaque",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": namespace,
+					"labels":    map[string]interface{}{"app.kubernetes.io/managed-by": "forge"},
+				},
+				"stringData": values,
+			},
+		},
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode secret %s: %w", name, err)
+	}
+
+	opts := exec.Options{Name: "kubectl", Args: []string{"apply", "-f", "-"}, Stdin: bytes.NewReader(data)}
+	if _, err := exec.Capture(ctx, opts); err != nil {
+		return fmt.Errorf("failed to apply secret %s in namespace %s: %w", name, namespace, err)
+	}
+	return nil
+}
+
+// HelmReleaseNames returns the Helm releases of a project: one per entry of
+// the instances deploy option, or a single release named after the project.
+func HelmReleaseNames(projectName string, options map[string]interface{}) []string {
+	instances, ok := options["instances"].([]interface{})
+	if !ok || len(instances) == 0 {
+		return []string{projectName}
+	}
+
+	releases := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		releases = append(releases, fmt.Sprintf("%s-%v", projectName, instance))
+	}
+	return releases
+}
+
+// splitSecretVersion splits a Secret Manager key into secret and version,
+// defaulting to the latest version.
+func splitSecretVersion(key string) (secret, version string) {
+	if i := strings.LastIndex(key, ":"); i > 0 && i < len(key)-1 {
+		return key[:i], key[i+1:]
+	}
+	return key, "latest"
+}
+
+// readEnvFile reads KEY=VALUE lines, skipping blank lines and comments. An
+// optional "export " prefix and matching surrounding quotes are removed.
+func readEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secret file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			// Do not echo the line: it may hold a secret
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+	return values, nil
+}
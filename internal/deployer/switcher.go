@@ -17,9 +17,20 @@ type SwitcherOptions struct {
 	ProjectName    string
 	Project        *workspace.Project
 	TargetDeployer string
-	DeployerConfig map[string]string
+	DeployerConfig DeployerConfig
 	Force          bool
 	WorkspaceRoot  string
+	// KeepExistingFiles leaves a deployment folder that already exists as it
+	// is instead of generating files into it
+	KeepExistingFiles bool
+}
+
+// DeployerConfig is the configuration of the deployer a project switches to
+type DeployerConfig struct {
+	// Options are the deployer options (projectId, region, configPath, ...)
+	Options map[string]string
+	// Overrides holds per-environment Helm values merged into the generated values files
+	Overrides workspace.ValuesOverrides
 }
 
 // Switcher handles switching deployment targets for a project
@@ -103,7 +114,7 @@ func (s *Switcher) removeOldDeploymentFiles(prompter *ui.Prompter) error {
 
 	// Prompt for confirmation unless --force is set
 	if !s.opts.Force {
-		confirm, err := prompter.AskConfirm(
+		confirm, err := prompter.WithFlag("--force").AskConfirm(
 			fmt.Sprintf("Delete old deployment folder '%s'?", oldConfigPath),
 			true,
 		)
@@ -129,8 +140,28 @@ func (s *Switcher) removeOldDeploymentFiles(prompter *ui.Prompter) error {
 func (s *Switcher) updateForgeConfig() error {
 	fmt.Println("📝 Updating forge.json...")
 
-	project := s.opts.Project
+	// The project is reloaded under the workspace lock so concurrent writers
+	// don't lose their changes
+	err := s.opts.Config.Update(s.opts.WorkspaceRoot, func(config *workspace.Config) error {
+		project, ok := config.Projects[s.opts.ProjectName]
+		if !ok {
+			return fmt.Errorf("project %q not found in forge.json", s.opts.ProjectName)
+		}
+		s.applyDeployer(&project)
+		config.Projects[s.opts.ProjectName] = project
+		*s.opts.Project = project
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Updated forge.json")
+	return nil
+}
 
+// applyDeployer points the project's deploy target and metadata at the new deployer.
+func (s *Switcher) applyDeployer(project *workspace.Project) {
 	// Ensure architect structure exists
 	if project.Architect == nil {
 		project.Architect = &workspace.Architect{}
@@ -144,11 +175,14 @@ func (s *Switcher) updateForgeConfig() error {
 
 	// Update options
 	options := make(map[string]interface{})
-	for k, v := range s.opts.DeployerConfig {
+	for k, v := range s.opts.DeployerConfig.Options {
 		options[k] = v
 	}
 	project.Architect.Deploy.Options = options
 
+	// Stored so the values files keep them when they are regenerated
+	project.Architect.Deploy.Overrides = s.opts.DeployerConfig.Overrides
+
 	// Update configurations (environment-specific overrides)
 	configurations := s.getDefaultConfigurations()
 	project.Architect.Deploy.Configurations = configurations
@@ -164,17 +198,6 @@ func (s *Switcher) updateForgeConfig() error {
 	deploymentMeta := project.Metadata["deployment"].(map[string]interface{})
 	deploymentMeta["target"] = s.opts.TargetDeployer
 	project.Metadata["deployment"] = deploymentMeta
-
-	// Update project in config
-	s.opts.Config.Projects[s.opts.ProjectName] = *project
-
-	// Save forge.json
-	if err := s.opts.Config.SaveToDir(s.opts.WorkspaceRoot); err != nil {
-		return err
-	}
-
-	fmt.Println("✓ Updated forge.json")
-	return nil
 }
 
 // getDefaultConfigurations returns default environment configurations for the deployer
@@ -198,7 +221,7 @@ func (s *Switcher) getDefaultConfigurations() map[string]interface{} {
 		}
 
 	case "firebase":
-		if projectId, ok := s.opts.DeployerConfig["projectId"]; ok {
+		if projectId, ok := s.opts.DeployerConfig.Options["projectId"]; ok {
 			configs["production"] = map[string]interface{}{
 				"projectId": projectId,
 			}
@@ -211,7 +234,7 @@ func (s *Switcher) getDefaultConfigurations() map[string]interface{} {
 		}
 
 	case "cloudrun":
-		if region, ok := s.opts.DeployerConfig["region"]; ok {
+		if region, ok := s.opts.DeployerConfig.Options["region"]; ok {
 			configs["production"] = map[string]interface{}{
 				"region": region,
 			}
@@ -222,6 +245,30 @@ func (s *Switcher) getDefaultConfigurations() map[string]interface{} {
 				"region": region,
 			}
 		}
+
+	case "ecs":
+		if region, ok := s.opts.DeployerConfig.Options["region"]; ok {
+			configs["production"] = map[string]interface{}{
+				"region": region,
+			}
+			configs["development"] = map[string]interface{}{
+				"region": region,
+			}
+			configs["local"] = map[string]interface{}{
+				"region": region,
+			}
+		}
+
+	case "aca":
+		for env := range configs {
+			options := make(map[string]interface{})
+			for _, key := range []string{"resourceGroup", "location", "environment"} {
+				if value := s.opts.DeployerConfig.Options[key]; value != "" {
+					options[key] = value
+				}
+			}
+			configs[env] = options
+		}
 	}
 
 	return configs
@@ -232,9 +279,16 @@ func (s *Switcher) generateDeploymentFiles() error {
 	fmt.Printf("\n📦 Generating %s deployment files...\n", s.opts.TargetDeployer)
 
 	projectRoot := filepath.Join(s.opts.WorkspaceRoot, s.opts.Project.Root)
-	configPath := s.opts.DeployerConfig["configPath"]
+	configPath := s.opts.DeployerConfig.Options["configPath"]
 	deployPath := filepath.Join(projectRoot, configPath)
 
+	if s.opts.KeepExistingFiles {
+		if entries, err := os.ReadDir(deployPath); err == nil && len(entries) > 0 {
+			fmt.Printf("✓ Keeping existing deployment files in %s\n", configPath)
+			return nil
+		}
+	}
+
 	// Create deployment directory
 	if err := os.MkdirAll(deployPath, 0755); err != nil {
 		return fmt.Errorf("failed to create deployment directory: %w", err)
@@ -248,6 +302,10 @@ func (s *Switcher) generateDeploymentFiles() error {
 		return s.generateFirebaseFiles(projectRoot, deployPath)
 	case "cloudrun":
 		return s.generateCloudRunFiles(projectRoot, deployPath)
+	case "ecs":
+		return s.generateECSFiles(projectRoot, deployPath)
+	case "aca":
+		return s.generateACAFiles(projectRoot, deployPath)
 	default:
 		return fmt.Errorf("unsupported deployer: %s", s.opts.TargetDeployer)
 	}
@@ -258,7 +316,7 @@ func (s *Switcher) generateHelmFiles(projectRoot, deployPath string) error {
 	deployGen := generator.NewDeploymentFileGenerator(s.opts.Project, s.opts.ProjectName, s.opts.Config)
 
 	// Generate Helm values files
-	if err := deployGen.GenerateHelmValues(deployPath, s.opts.DeployerConfig); err != nil {
+	if err := deployGen.GenerateHelmValues(deployPath, s.opts.DeployerConfig.Options, s.opts.DeployerConfig.Overrides); err != nil {
 		return err
 	}
 
@@ -276,7 +334,7 @@ func (s *Switcher) generateFirebaseFiles(projectRoot, deployPath string) error {
 	deployGen := generator.NewDeploymentFileGenerator(s.opts.Project, s.opts.ProjectName, s.opts.Config)
 
 	// Generate Firebase configuration files
-	if err := deployGen.GenerateFirebaseConfig(deployPath, s.opts.DeployerConfig); err != nil {
+	if err := deployGen.GenerateFirebaseConfig(deployPath, s.opts.DeployerConfig.Options); err != nil {
 		return err
 	}
 
@@ -293,7 +351,7 @@ func (s *Switcher) generateCloudRunFiles(projectRoot, deployPath string) error {
 	deployGen := generator.NewDeploymentFileGenerator(s.opts.Project, s.opts.ProjectName, s.opts.Config)
 
 	// Generate Cloud Run service definition
-	if err := deployGen.GenerateCloudRunConfig(deployPath, s.opts.DeployerConfig); err != nil {
+	if err := deployGen.GenerateCloudRunConfig(deployPath, s.opts.DeployerConfig.Options); err != nil {
 		return err
 	}
 
@@ -304,6 +362,39 @@ func (s *Switcher) generateCloudRunFiles(projectRoot, deployPath string) error {
 	return nil
 }
 
+// generateECSFiles generates AWS ECS deployment files
+func (s *Switcher) generateECSFiles(projectRoot, deployPath string) error {
+	deployGen := generator.NewDeploymentFileGenerator(s.opts.Project, s.opts.ProjectName, s.opts.Config)
+
+	// Generate ECS task and service definitions
+	if err := deployGen.GenerateECSConfig(deployPath, s.opts.DeployerConfig.Options); err != nil {
+		return err
+	}
+
+	fmt.Println("  ✓ task-definition.json")
+	fmt.Println("  ✓ service.json")
+	fmt.Println("  ✓ README.md")
+	fmt.Println("✓ ECS deployment files generated")
+
+	return nil
+}
+
+// generateACAFiles generates Azure Container Apps deployment files
+func (s *Switcher) generateACAFiles(projectRoot, deployPath string) error {
+	deployGen := generator.NewDeploymentFileGenerator(s.opts.Project, s.opts.ProjectName, s.opts.Config)
+
+	// Generate the container app definition
+	if err := deployGen.GenerateACAConfig(deployPath, s.opts.DeployerConfig.Options); err != nil {
+		return err
+	}
+
+	fmt.Println("  ✓ containerapp.yaml")
+	fmt.Println("  ✓ README.md")
+	fmt.Println("✓ Azure Container Apps deployment files generated")
+
+	return nil
+}
+
 // updateGitHubWorkflows updates GitHub Actions workflows based on active deployers
 func (s *Switcher) updateGitHubWorkflows() error {
 	fmt.Println("\n🔧 Updating GitHub Actions workflows...")
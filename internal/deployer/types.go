@@ -24,6 +24,8 @@ type DeployOptions struct {
 	WorkspaceRoot string
 	// ProjectRoot is the absolute path to the project root
 	ProjectRoot string
+	// DryRun shows what would be deployed without changing anything
+	DryRun bool
 }
 
 // Deployer is the interface that all deployers must implement
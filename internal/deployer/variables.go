@@ -0,0 +1,46 @@
+package deployer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// expandVariables reads a deployment definition generated by forge switch
+// deployer and expands its ${VAR} placeholders with lookup. A placeholder
+// that expands to nothing is an error, so a definition is never deployed with
+// holes in it. kind names the definition in errors, e.g. "ECS definition".
+func expandVariables(path, kind string, lookup func(name string) (string, error)) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s (run 'forge switch deployer' to generate it): %w", kind, err)
+	}
+
+	missing := make(map[string]bool)
+	var lookupErr error
+	expanded := os.Expand(string(content), func(name string) string {
+		value, err := lookup(name)
+		if err != nil && lookupErr == nil {
+			lookupErr = err
+		}
+		if value == "" {
+			missing[name] = true
+		}
+		return value
+	})
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%s references unset variables: %s", filepath.Base(path), strings.Join(names, ", "))
+	}
+
+	return []byte(expanded), nil
+}
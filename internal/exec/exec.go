@@ -0,0 +1,222 @@
+// Package exec runs external commands for forge with consistent output
+// wiring, environment, timeouts and context cancellation. Commands go through
+// a Runner so tests can replace it with a fake.
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+)
+
+// waitDelay bounds how long a cancelled command may take to exit after being
+// interrupted, and keep its output pipes open, before it is killed.
+const waitDelay = 5 * time.Second
+
+// Options describes a command to run.
+type Options struct {
+	// Name is the program to run, looked up in PATH.
+	Name string
+
+	// Args are the program arguments.
+	Args []string
+
+	// Dir is the working directory (default: the current directory).
+	Dir string
+
+	// Env is added to the environment of the forge process, e.g. "CI=true".
+	Env []string
+
+	// Stdin is the command input (default: none).
+	Stdin io.Reader
+
+	// Stdout and Stderr receive the command output (default: os.Stdout and os.Stderr).
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Timeout kills the command when it runs longer (0 = no timeout).
+	Timeout time.Duration
+
+	// SysProcAttr holds OS-specific process attributes, e.g. to detach the
+	// command from the forge session (default: none).
+	SysProcAttr *syscall.SysProcAttr
+}
+
+// String returns the command line, for logs and error messages.
+func (o Options) String() string {
+	return strings.TrimSpace(o.Name + " " + strings.Join(o.Args, " "))
+}
+
+// Runner runs commands.
+type Runner interface {
+	Run(ctx context.Context, opts Options) error
+}
+
+// RunnerFunc adapts a function to a Runner.
+type RunnerFunc func(ctx context.Context, opts Options) error
+
+// Run calls f(ctx, opts).
+func (f RunnerFunc) Run(ctx context.Context, opts Options) error {
+	return f(ctx, opts)
+}
+
+var (
+	mu     sync.RWMutex
+	runner Runner = RunnerFunc(runCommand)
+)
+
+// SetRunner replaces the runner used by Run and returns a function restoring
+// the previous one.
+func SetRunner(r Runner) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	previous := runner
+	runner = r
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		runner = previous
+	}
+}
+
+// Run runs a command and waits for it to finish. The command is interrupted
+// when ctx is cancelled or its timeout expires, so it can stop its own
+// subprocesses, and killed if it has not exited shortly after.
+func Run(ctx context.Context, opts Options) error {
+	mu.RLock()
+	r := runner
+	mu.RUnlock()
+	return r.Run(ctx, opts)
+}
+
+// Output runs a command and returns its standard output.
+func Output(ctx context.Context, opts Options) ([]byte, error) {
+	var stdout bytes.Buffer
+	opts.Stdout = &stdout
+	err := Run(ctx, opts)
+	return stdout.Bytes(), err
+}
+
+// LookPath searches for an executable in PATH, so callers checking for a tool
+// before running it don't need os/exec.
+func LookPath(file string) (string, error) {
+	return osexec.LookPath(file)
+}
+
+// ExitCode returns the exit code of a command that ran and failed, or -1 when
+// err does not come from the command exiting.
+func ExitCode(err error) int {
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// CombinedOutput runs a command and returns its standard output and standard
+// error interleaved.
+func CombinedOutput(ctx context.Context, opts Options) ([]byte, error) {
+	var output bytes.Buffer
+	opts.Stdout = &output
+	opts.Stderr = &output
+	err := Run(ctx, opts)
+	return output.Bytes(), err
+}
+
+// Capture runs a command and returns its standard output. Standard error is
+// not printed; it is added to the error when the command fails.
+func Capture(ctx context.Context, opts Options) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	opts.Stdout = &stdout
+	opts.Stderr = &stderr
+	if err := Run(ctx, opts); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// Start starts a command without waiting for it and returns its process ID,
+// for processes that outlive forge like the daemon. The command is not tied to
+// ctx once started, and unlike Run it does not go through the Runner.
+func Start(ctx context.Context, opts Options) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", opts.Name, err)
+	}
+
+	log.Debug("  $ %s &", opts)
+
+	cmd := command(osexec.Command(opts.Name, opts.Args...), opts)
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+	return pid, nil
+}
+
+func runCommand(ctx context.Context, opts Options) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	log.Debug("  $ %s", opts)
+
+	cmd := command(osexec.CommandContext(ctx, opts.Name, opts.Args...), opts)
+	cmd.Cancel = func() error {
+		return interrupt(cmd.Process)
+	}
+	cmd.WaitDelay = waitDelay
+
+	err := cmd.Run()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && opts.Timeout > 0 {
+		return fmt.Errorf("%s timed out after %s", opts.Name, opts.Timeout)
+	}
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("%s: %w", opts.Name, context.Canceled)
+	}
+	return err
+}
+
+// command wires the directory, input, output, environment and process
+// attributes of opts into cmd.
+func command(cmd *osexec.Cmd, opts Options) *osexec.Cmd {
+	cmd.Dir = opts.Dir
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = opts.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if len(opts.Env) > 0 {
+		cmd.Env = append(os.Environ(), opts.Env...)
+	}
+	cmd.SysProcAttr = opts.SysProcAttr
+	return cmd
+}
+
+// interrupt asks a process to stop like Ctrl-C does, or kills it where
+// interrupts are not supported (Windows).
+func interrupt(process *os.Process) error {
+	if err := process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return process.Kill()
+	}
+	return nil
+}
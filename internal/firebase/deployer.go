@@ -3,10 +3,9 @@ package firebase
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
@@ -47,12 +46,7 @@ func Deploy(ctx context.Context, projectName string, project workspace.Project,
 
 // setFirebaseProject runs "firebase use <projectId>"
 func setFirebaseProject(ctx context.Context, workDir string, projectID string) error {
-	cmd := exec.CommandContext(ctx, "firebase", "use", projectID)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{Name: "firebase", Args: []string{"use", projectID}, Dir: workDir}); err != nil {
 		return fmt.Errorf("firebase use failed: %w", err)
 	}
 
@@ -68,12 +62,7 @@ func deployToHosting(ctx context.Context, workDir string, target string) error {
 		args = append(args, "hosting")
 	}
 
-	cmd := exec.CommandContext(ctx, "firebase", args...)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{Name: "firebase", Args: args, Dir: workDir}); err != nil {
 		return fmt.Errorf("firebase deploy failed: %w", err)
 	}
 
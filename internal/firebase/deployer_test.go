@@ -0,0 +1,46 @@
+package firebase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestDeploy(t *testing.T) {
+	var ran []exec.Options
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts)
+		return nil
+	}))()
+
+	project := workspace.Project{Root: "frontend/apps/storefront"}
+	options := map[string]interface{}{"projectId": "acme", "target": "storefront", "configPath": "../../.."}
+	if err := Deploy(context.Background(), "storefront", project, options, "production"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"firebase use acme", "firebase deploy --only hosting:storefront"}
+	if len(ran) != len(want) {
+		t.Fatalf("ran %v, want %q", ran, want)
+	}
+	for i, command := range want {
+		if ran[i].String() != command {
+			t.Errorf("command %d = %q, want %q", i, ran[i].String(), command)
+		}
+		if ran[i].Dir != "." {
+			t.Errorf("%s ran in %q, want the workspace root", command, ran[i].Dir)
+		}
+	}
+
+	// Without a project ID nothing runs
+	ran = nil
+	if err := Deploy(context.Background(), "storefront", project, map[string]interface{}{}, "production"); err == nil || !strings.Contains(err.Error(), "projectId") {
+		t.Errorf("Deploy() error = %v, want the missing projectId", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("ran %v without a project ID", ran)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"gopkg.in/yaml.v3"
 )
 
 // DeploymentFileGenerator generates deployment configuration files
@@ -27,10 +30,25 @@ func NewDeploymentFileGenerator(project *workspace.Project, projectName string,
 	}
 }
 
-// GenerateHelmValues generates Helm values files
-func (g *DeploymentFileGenerator) GenerateHelmValues(deployPath string, config map[string]string) error {
+// helmValuesEnvironments maps override environment keys to the values file they apply to
+var helmValuesEnvironments = map[string]string{
+	"default": "values.yaml",
+	"dev":     "values-dev.yaml",
+	"prod":    "values-prod.yaml",
+}
+
+// GenerateHelmValues generates Helm values files.
+// Overrides are keyed by environment ("default", "dev", "prod") and are deep-merged
+// into the generated values for that environment only.
+func (g *DeploymentFileGenerator) GenerateHelmValues(deployPath string, config map[string]string, overrides workspace.ValuesOverrides) error {
 	data := g.prepareTemplateData(config)
 
+	for env := range overrides {
+		if _, ok := helmValuesEnvironments[env]; !ok {
+			return fmt.Errorf("unknown values override environment %q (expected default, dev or prod)", env)
+		}
+	}
+
 	helmTemplates := map[string]string{
 		"values.yaml":      "service/deploy/helm/values.yaml.tmpl",
 		"values-dev.yaml":  "service/deploy/helm/values-dev.yaml.tmpl",
@@ -44,6 +62,15 @@ func (g *DeploymentFileGenerator) GenerateHelmValues(deployPath string, config m
 			return fmt.Errorf("failed to render %s: %w", filename, err)
 		}
 
+		for env, valuesFile := range helmValuesEnvironments {
+			if valuesFile == filename && len(overrides[env]) > 0 {
+				content, err = mergeHelmValues(content, overrides[env])
+				if err != nil {
+					return fmt.Errorf("failed to apply %s overrides to %s: %w", env, filename, err)
+				}
+			}
+		}
+
 		filePath := filepath.Join(deployPath, filename)
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
@@ -53,6 +80,98 @@ func (g *DeploymentFileGenerator) GenerateHelmValues(deployPath string, config m
 	return nil
 }
 
+// LoadHelmValuesOverrides reads a YAML overrides file whose top-level keys are
+// environments ("default", "dev", "prod") and whose values are Helm values.
+func LoadHelmValuesOverrides(path string) (workspace.ValuesOverrides, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values overrides: %w", err)
+	}
+
+	var overrides workspace.ValuesOverrides
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse values overrides: %w", err)
+	}
+
+	for env := range overrides {
+		if _, ok := helmValuesEnvironments[env]; !ok {
+			return nil, fmt.Errorf("unknown environment %q in %s (expected default, dev or prod)", env, path)
+		}
+	}
+
+	return overrides, nil
+}
+
+// mergeHelmValues deep-merges overrides into rendered Helm values.
+// Generated keys that are not overridden keep their default values, and the
+// comments and key order of the generated file are preserved.
+func mergeHelmValues(rendered string, overrides map[string]interface{}) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(rendered), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse generated values: %w", err)
+	}
+
+	var src yaml.Node
+	if err := src.Encode(overrides); err != nil {
+		return "", fmt.Errorf("failed to encode values overrides: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&src}}
+	} else {
+		deepMergeValues(doc.Content[0], &src)
+	}
+
+	var merged strings.Builder
+	encoder := yaml.NewEncoder(&merged)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to marshal merged values: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal merged values: %w", err)
+	}
+
+	return merged.String(), nil
+}
+
+// deepMergeValues merges the mapping src into dst, recursing into nested
+// mappings. Other values in src (including lists) replace the value in dst,
+// keeping its comments; keys missing from dst are appended in sorted order.
+func deepMergeValues(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		*dst = replaceValueNode(dst, src)
+		return
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		found := false
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				deepMergeValues(dst.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			// An empty flow mapping like "annotations: {}" reads better as a block once filled
+			dst.Style &^= yaml.FlowStyle
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+}
+
+// replaceValueNode returns src carrying the comments of the node it replaces.
+func replaceValueNode(dst, src *yaml.Node) yaml.Node {
+	replacement := *src
+	replacement.HeadComment = dst.HeadComment
+	replacement.LineComment = dst.LineComment
+	replacement.FootComment = dst.FootComment
+	return replacement
+}
+
 // GenerateFirebaseConfig generates Firebase configuration files
 func (g *DeploymentFileGenerator) GenerateFirebaseConfig(deployPath string, config map[string]string) error {
 	data := g.prepareTemplateData(config)
@@ -87,8 +206,8 @@ func (g *DeploymentFileGenerator) GenerateCloudRunConfig(deployPath string, conf
 		"README.md":    "service/deploy/cloudrun/README.md.tmpl",
 	}
 
-	// For Angular projects, also generate Dockerfile and nginx.conf
-	if g.project.Language == "angular" {
+	// For Angular and Vue projects, also generate Dockerfile and nginx.conf
+	if g.project.Language == "angular" || g.project.Language == "vue" {
 		cloudRunTemplates["Dockerfile"] = "frontend/deploy/cloudrun/Dockerfile.tmpl"
 		cloudRunTemplates["nginx.conf"] = "frontend/deploy/cloudrun/nginx.conf.tmpl"
 	}
@@ -108,6 +227,55 @@ func (g *DeploymentFileGenerator) GenerateCloudRunConfig(deployPath string, conf
 	return nil
 }
 
+// GenerateECSConfig generates AWS ECS task and service definitions
+func (g *DeploymentFileGenerator) GenerateECSConfig(deployPath string, config map[string]string) error {
+	data := g.prepareTemplateData(config)
+
+	ecsTemplates := map[string]string{
+		"task-definition.json": "service/deploy/ecs/task-definition.json.tmpl",
+		"service.json":         "service/deploy/ecs/service.json.tmpl",
+		"README.md":            "service/deploy/ecs/README.md.tmpl",
+	}
+
+	for filename, templatePath := range ecsTemplates {
+		content, err := g.engine.RenderTemplate(templatePath, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", filename, err)
+		}
+
+		filePath := filepath.Join(deployPath, filename)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// GenerateACAConfig generates the Azure Container Apps container app definition
+func (g *DeploymentFileGenerator) GenerateACAConfig(deployPath string, config map[string]string) error {
+	data := g.prepareTemplateData(config)
+
+	acaTemplates := map[string]string{
+		"containerapp.yaml": "service/deploy/aca/containerapp.yaml.tmpl",
+		"README.md":         "service/deploy/aca/README.md.tmpl",
+	}
+
+	for filename, templatePath := range acaTemplates {
+		content, err := g.engine.RenderTemplate(templatePath, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", filename, err)
+		}
+
+		filePath := filepath.Join(deployPath, filename)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
 // prepareTemplateData prepares data for template rendering
 func (g *DeploymentFileGenerator) prepareTemplateData(config map[string]string) map[string]interface{} {
 	data := map[string]interface{}{
@@ -117,6 +285,8 @@ func (g *DeploymentFileGenerator) prepareTemplateData(config map[string]string)
 		"ServiceNameCamel":  template.Camelize(g.projectName),
 		"Language":          g.project.Language,
 		"ProjectType":       g.project.ProjectType,
+		"GRPC":              g.project.ProtoDir() != "",
+		"GRPCGateway":       g.project.GRPCGateway(),
 	}
 
 	// Add deployer-specific config
@@ -134,6 +304,43 @@ func (g *DeploymentFileGenerator) prepareTemplateData(config map[string]string)
 	if g.config.Workspace.GCP != nil {
 		data["GCPProjectID"] = g.config.Workspace.GCP.ProjectID
 	}
+	if g.config.Workspace.Kubernetes != nil && g.config.Workspace.Kubernetes.Domain != "" {
+		data["Domain"] = g.config.Workspace.Kubernetes.Domain
+	}
+
+	// Deployer config takes precedence over workspace defaults
+	if registry := config["registry"]; registry != "" {
+		data["Registry"] = registry
+	}
+	if domain := config["domain"]; domain != "" {
+		data["Domain"] = domain
+	}
+	data["Env"] = parseEnvConfig(config["env"])
 
 	return data
 }
+
+// EnvVar is an environment variable rendered into deployment files.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// parseEnvConfig parses "KEY=value;OTHER=value" into environment variables sorted by name.
+// Pairs are separated by ';' because ',' already separates --config entries.
+func parseEnvConfig(raw string) []EnvVar {
+	var env []EnvVar
+	for _, pair := range strings.Split(raw, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		env = append(env, EnvVar{Name: name, Value: value})
+	}
+
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateHelmValuesOverrides(t *testing.T) {
+	config := &workspace.Config{Workspace: workspace.WorkspaceMetadata{Name: "shop"}}
+	project := &workspace.Project{Language: "go", ProjectType: "service"}
+	deployGen := NewDeploymentFileGenerator(project, "api", config)
+
+	readValues := func(t *testing.T, dir, filename string) map[string]interface{} {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			t.Fatalf("%s is invalid: %v\n%s", filename, err, content)
+		}
+		return values
+	}
+
+	defaultsDir := t.TempDir()
+	if err := deployGen.GenerateHelmValues(defaultsDir, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	overrides := workspace.ValuesOverrides{
+		"prod": {
+			"replicaCount": 5,
+			"resources": map[string]interface{}{
+				"limits": map[string]interface{}{"memory": "2Gi"},
+			},
+			"env": map[string]interface{}{"FEATURE_X": "on"},
+		},
+	}
+	if err := deployGen.GenerateHelmValues(dir, nil, overrides); err != nil {
+		t.Fatal(err)
+	}
+
+	prod := readValues(t, dir, "values-prod.yaml")
+	if prod["replicaCount"] != 5 {
+		t.Errorf("prod replicaCount = %v, want 5", prod["replicaCount"])
+	}
+	limits := prod["resources"].(map[string]interface{})["limits"].(map[string]interface{})
+	if limits["memory"] != "2Gi" || limits["cpu"] != "1000m" {
+		t.Errorf("prod resources.limits = %v, want memory 2Gi and the generated cpu", limits)
+	}
+	if env, ok := prod["env"].(map[string]interface{}); !ok || env["FEATURE_X"] != "on" {
+		t.Errorf("prod env = %v, want FEATURE_X added", prod["env"])
+	}
+	if prod["image"].(map[string]interface{})["tag"] != "prod-latest" {
+		t.Errorf("prod image = %v, want the generated tag", prod["image"])
+	}
+
+	// Environments without overrides keep the generated files
+	for _, filename := range []string{"values.yaml", "values-dev.yaml"} {
+		want, err := os.ReadFile(filepath.Join(defaultsDir, filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s changed without overrides for its environment", filename)
+		}
+	}
+
+	if err := deployGen.GenerateHelmValues(t.TempDir(), nil, workspace.ValuesOverrides{"staging": {"replicaCount": 2}}); err == nil {
+		t.Error("GenerateHelmValues() accepted overrides for an unknown environment")
+	}
+}
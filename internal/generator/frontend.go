@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
@@ -24,6 +24,11 @@ func NewFrontendGenerator() *FrontendGenerator {
 	}
 }
 
+func init() {
+	// Register the Angular application generator
+	Register(workspace.ProjectKindApplication, workspace.LanguageAngular, "Angular", NewFrontendGenerator())
+}
+
 // Name returns the generator name.
 func (g *FrontendGenerator) Name() string {
 	return "frontend"
@@ -34,8 +39,9 @@ func (g *FrontendGenerator) Description() string {
 	return "Generate a new Angular frontend application"
 }
 
-// Generate creates a new Angular application.
-func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions) error {
+// Generate creates a new Angular application. On failure the files it created
+// are removed and forge.json is restored.
+func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions) (err error) {
 	appName := opts.Name
 	if appName == "" {
 		return fmt.Errorf("application name is required")
@@ -60,39 +66,68 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 	if err != nil {
 		return fmt.Errorf("failed to load workspace config: %w", err)
 	}
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
 
-	frontendDir := filepath.Join(opts.OutputDir, "frontend")
-	frontendAppDir := filepath.Join(frontendDir, "apps", appName)
+	// Get deployment target from opts.Data or default to firebase
+	deploymentTarget := frontendDeploymentTarget(opts.Data)
 
-	if opts.DryRun {
-		fmt.Printf("Would create Angular application: %s\n", appName)
-		return nil
+	// Server-side rendered apps run a Node server, which static hosting cannot
+	ssr, _ := opts.Data["ssr"].(bool)
+	if ssr && deploymentTarget == "firebase" {
+		return fmt.Errorf("server-side rendering needs a server: deploy with cloudrun or helm instead of firebase")
 	}
 
-	// Create frontend/apps directory structure
-	frontendAppsDir := filepath.Join(frontendDir, "apps")
-	if err := os.MkdirAll(frontendAppsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create frontend/apps directory: %w", err)
+	// Determine app path using workspace.paths or default
+	appsPath := config.FrontendAppsPath(workspace.DefaultFrontendAppsPath)
+	frontendAppsDir := filepath.Join(opts.OutputDir, appsPath)
+	frontendAppDir := filepath.Join(frontendAppsDir, appName)
+
+	p := newPlan(opts.OutputDir, opts.DryRun)
+
+	undo, err := newRollback(opts.OutputDir, opts)
+	if err != nil {
+		return err
 	}
+	defer undo.restoreOnError(&err)
 
-	// Create Angular app at frontend/apps/<app-name> using ng new
-	fmt.Printf("📦 Generating Angular application: %s\n", appName)
+	// Create the apps directory structure
+	undo.trackDirs(opts.OutputDir, appsPath)
+	undo.track(frontendAppDir)
+	if err := p.mkdirAll(frontendAppsDir); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", appsPath, err)
+	}
+
+	// Create Angular app at <apps path>/<app-name> using ng new
+	p.info("📦 Generating Angular application: %s", appName)
 
-	if err := g.runAngularCLI(frontendAppsDir, config, []string{
+	ngNewArgs := []string{
 		"new", appName,
 		"--directory=" + appName,
 		"--routing=true",
 		"--style=css",
 		"--skip-git=true",
 		"--package-manager=npm",
-		"--standalone=true", // Use standalone components (Angular 19+)
-	}); err != nil {
+		"--standalone=true",   // Use standalone components (Angular 19+)
+		"--skip-install=true", // Installed below so network failures can be retried
+	}
+	if ssr {
+		ngNewArgs = append(ngNewArgs, "--ssr=true")
+	}
+	if err := g.runAngularCLI(ctx, p, frontendAppsDir, config, ngNewArgs); err != nil {
 		return fmt.Errorf("failed to generate Angular application: %w", err)
 	}
 
+	p.info("📦 Installing dependencies...")
+	if err := g.runNpmCommand(ctx, p, frontendAppDir, []string{"install"}); err != nil {
+		return fmt.Errorf("failed to install dependencies: %w", err)
+	}
+
 	// Initialize Tailwind CSS
-	fmt.Println("🎨 Installing Tailwind CSS...")
-	if err := g.runNpmCommand(frontendAppDir, []string{"install", "tailwindcss", "@tailwindcss/postcss", "postcss", "--save-dev"}); err != nil {
+	p.info("🎨 Installing Tailwind CSS...")
+	if err := g.runNpmCommand(ctx, p, frontendAppDir, []string{"install", "tailwindcss", "@tailwindcss/postcss", "postcss", "--save-dev"}); err != nil {
 		return fmt.Errorf("failed to install Tailwind: %w", err)
 	}
 
@@ -102,7 +137,7 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		return fmt.Errorf("failed to render .postcssrc.json: %w", err)
 	}
 	postcssPath := filepath.Join(frontendAppDir, ".postcssrc.json")
-	if err := os.WriteFile(postcssPath, []byte(postcssContent), 0644); err != nil {
+	if err := p.writeFile(postcssPath, []byte(postcssContent)); err != nil {
 		return fmt.Errorf("failed to create .postcssrc.json: %w", err)
 	}
 
@@ -112,10 +147,21 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		return fmt.Errorf("failed to render .npmrc: %w", err)
 	}
 	npmrcPath := filepath.Join(frontendAppDir, ".npmrc")
-	if err := os.WriteFile(npmrcPath, []byte(npmrcContent), 0644); err != nil {
+	if err := p.writeFile(npmrcPath, []byte(npmrcContent)); err != nil {
 		return fmt.Errorf("failed to create .npmrc: %w", err)
 	}
 
+	// Pin the workspace's Node.js version for nvm and CI setup-node
+	nvmrcContent, err := g.engine.RenderTemplate("frontend/.nvmrc.tmpl", map[string]interface{}{
+		"NodeVersion": toolVersions.Node,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render .nvmrc: %w", err)
+	}
+	if err := p.writeFile(filepath.Join(frontendAppDir, ".nvmrc"), []byte(nvmrcContent)); err != nil {
+		return fmt.Errorf("failed to create .nvmrc: %w", err)
+	}
+
 	// Update app's styles.css with Tailwind import
 	appDir := frontendAppDir
 	appStylesPath := filepath.Join(appDir, "src", "styles.css")
@@ -125,62 +171,63 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		return fmt.Errorf("failed to render styles.css: %w", err)
 	}
 
-	if err := os.WriteFile(appStylesPath, []byte(stylesContent), 0644); err != nil {
+	if err := p.writeFile(appStylesPath, []byte(stylesContent)); err != nil {
 		return fmt.Errorf("failed to update app styles.css: %w", err)
 	}
 
-	// Get deployment target from opts.Data or default to firebase
-	deploymentTarget := "firebase"
-	if opts.Data != nil {
-		if deployment, ok := opts.Data["deployment"].(string); ok && deployment != "" {
-			// Convert from display names to internal names
-			switch deployment {
-			case "Firebase":
-				deploymentTarget = "firebase"
-			case "CloudRun":
-				deploymentTarget = "cloudrun"
-			case "GKE":
-				deploymentTarget = "gke"
-			default:
-				deploymentTarget = strings.ToLower(deployment)
-			}
-		}
+	if err := g.updateAngularJsonSchematics(p, appDir); err != nil {
+		return err
 	}
 
 	// Generate environment files
-	if err := g.generateEnvironmentFiles(appDir, appName, deploymentTarget); err != nil {
+	if err := g.generateEnvironmentFiles(p, appDir, appName, deploymentTarget, frontendAPIURLs(config, opts.Data)); err != nil {
 		return fmt.Errorf("failed to generate environment files: %w", err)
 	}
 
 	// Generate deployment configuration based on target
-	if err := g.generateDeploymentConfig(opts.OutputDir, appName, deploymentTarget, config); err != nil {
+	if err := g.generateDeploymentConfig(p, appDir, appName, deploymentTarget, config, opts.Data); err != nil {
 		return fmt.Errorf("failed to generate deployment config: %w", err)
 	}
 
 	// Generate BUILD.bazel for Bazel builds (self-contained)
-	if err := g.generateFrontendBuildFile(appDir, appName, deploymentTarget); err != nil {
+	if err := g.generateFrontendBuildFile(p, appDir, appName, deploymentTarget, ssr); err != nil {
 		return fmt.Errorf("failed to generate BUILD.bazel: %w", err)
 	}
 
+	buildOptions := map[string]interface{}{
+		"target":     ":build",
+		"outputPath": fmt.Sprintf("dist/%s", appName),
+		"environmentMapper": map[string]string{
+			"local":   "development",
+			"dev":     "development",
+			"staging": "production",
+			"prod":    "production",
+		},
+	}
+	tags := []string{"frontend", "angular", deploymentTarget}
+	if ssr {
+		buildOptions["ssr"] = true
+		// Deployed servers render with optimized production bundles; the
+		// development configuration is left to 'forge serve'
+		buildOptions["environmentMapper"] = map[string]string{
+			"local":   "development",
+			"dev":     "production",
+			"staging": "production",
+			"prod":    "production",
+		}
+		tags = append(tags, "ssr")
+	}
+
 	// Add project to workspace config with new architect pattern
 	project := &workspace.Project{
 		ProjectType: "application",
 		Language:    "angular",
-		Root:        fmt.Sprintf("frontend/apps/%s", appName),
-		Tags:        []string{"frontend", "angular", deploymentTarget},
+		Root:        filepath.ToSlash(filepath.Join(appsPath, appName)),
+		Tags:        tags,
 		Architect: &workspace.Architect{
 			Build: &workspace.ArchitectTarget{
 				Builder: "@forge/bazel:build",
-				Options: map[string]interface{}{
-					"target":     ":build",
-					"outputPath": fmt.Sprintf("dist/%s", appName),
-					"environmentMapper": map[string]string{
-						"local":   "development",
-						"dev":     "development",
-						"staging": "production",
-						"prod":    "production",
-					},
-				},
+				Options: buildOptions,
 				Configurations: map[string]interface{}{
 					"production": map[string]interface{}{
 						"optimization": true,
@@ -206,9 +253,7 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 			},
 			Deploy: &workspace.ArchitectTarget{
 				Deployer: fmt.Sprintf("@forge/%s:deploy", deploymentTarget),
-				Options: map[string]interface{}{
-					"configPath": fmt.Sprintf("deploy/%s", deploymentTarget),
-				},
+				Options:  frontendDeployOptions(filepath.Join(appsPath, appName), appName, deploymentTarget),
 				Configurations: map[string]interface{}{
 					"production":  map[string]interface{}{},
 					"development": map[string]interface{}{},
@@ -224,133 +269,149 @@ func (g *FrontendGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		},
 	}
 
-	if err := config.AddProject(appName, project); err != nil {
-		return fmt.Errorf("failed to add project to config: %w", err)
+	if opts.DryRun {
+		p.update(filepath.Join(opts.OutputDir, workspace.ConfigFileName), fmt.Sprintf("register project %q", appName))
+		p.print(fmt.Sprintf("Angular application %q in %s", appName, p.rel(appDir)))
+		return nil
 	}
 
-	if err := config.SaveToDir(opts.OutputDir); err != nil {
-		return fmt.Errorf("failed to save workspace config: %w", err)
+	err = config.Update(opts.OutputDir, func(config *workspace.Config) error {
+		return config.AddProject(appName, project)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register project in workspace config: %w", err)
 	}
 
-	fmt.Printf("✓ Angular application %q created successfully\n", appName)
-	fmt.Printf("✓ Location: %s\n", appDir)
-	fmt.Printf("✓ Run 'cd frontend && ng serve %s' to start the development server\n", appName)
-	fmt.Printf("✓ Open http://localhost:4200 in your browser\n")
+	log.Info("✓ Angular application %q created successfully", appName)
+	log.Info("✓ Location: %s", appDir)
+	log.Info("✓ Run 'forge serve %s' to start the development server", appName)
+	log.Info("✓ Open http://localhost:4200 in your browser")
 
 	return nil
 }
 
 // runAngularCLI executes Angular CLI commands
-func (g *FrontendGenerator) runAngularCLI(workDir string, config *workspace.Config, args []string) error {
-	angularVersion := "21.0.2" // default
-	if config.Workspace.ToolVersions != nil && config.Workspace.ToolVersions.Angular != "" {
-		angularVersion = config.Workspace.ToolVersions.Angular
+func (g *FrontendGenerator) runAngularCLI(ctx context.Context, p *plan, workDir string, config *workspace.Config, args []string) error {
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
 	}
-	return g.runCommand(workDir, "npx", append([]string{fmt.Sprintf("@angular/cli@%s", angularVersion)}, args...)...)
+	return g.runCommand(ctx, p, workDir, "npx", append([]string{fmt.Sprintf("@angular/cli@%s", toolVersions.Angular)}, args...)...)
 }
 
 // runNpmCommand executes npm commands
-func (g *FrontendGenerator) runNpmCommand(workDir string, args []string) error {
-	return g.runCommand(workDir, "npm", args...)
+func (g *FrontendGenerator) runNpmCommand(ctx context.Context, p *plan, workDir string, args []string) error {
+	return g.runCommand(ctx, p, workDir, "npm", args...)
 }
 
 // runNpxCommand executes npx commands
-func (g *FrontendGenerator) runNpxCommand(workDir string, args []string) error {
-	return g.runCommand(workDir, "npx", args...)
+func (g *FrontendGenerator) runNpxCommand(ctx context.Context, p *plan, workDir string, args []string) error {
+	return g.runCommand(ctx, p, workDir, "npx", args...)
 }
 
-// runCommand executes a shell command
-func (g *FrontendGenerator) runCommand(workDir, command string, args ...string) error {
-	cmd := exec.Command(command, args...)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	// Set environment variables to make Angular CLI non-interactive
-	cmd.Env = append(os.Environ(),
-		"NG_CLI_ANALYTICS=false", // Disable analytics prompts
-		"CI=true",                // Treat as CI environment (non-interactive)
-	)
-
-	fmt.Printf("  Running: %s %v\n", command, args)
+// runCommand executes a shell command, retrying transient network failures.
+// Dry runs only record it.
+func (g *FrontendGenerator) runCommand(ctx context.Context, p *plan, workDir, command string, args ...string) error {
+	if p.command(workDir, command, args...) {
+		return nil
+	}
 
-	if err := cmd.Run(); err != nil {
+	log.Info("  Running: %s %v", command, args)
+
+	err := runWithRetry(ctx, exec.Options{
+		Name:  command,
+		Args:  args,
+		Dir:   workDir,
+		Stdin: os.Stdin,
+		// Set environment variables to make Angular CLI non-interactive
+		Env: []string{
+			"NG_CLI_ANALYTICS=false", // Disable analytics prompts
+			"CI=true",                // Treat as CI environment (non-interactive)
+		},
+	})
+	if err != nil {
 		return fmt.Errorf("command failed: %w", err)
 	}
 
 	return nil
 }
 
-// updateAngularJsonSchematics updates angular.json with default schematics
-func (g *FrontendGenerator) updateAngularJsonSchematics(frontendDir string) error {
+// angularSchematicsDefaults are the schematics options forge sets in the
+// angular.json of generated applications.
+var angularSchematicsDefaults = map[string]map[string]interface{}{
+	"@schematics/angular:component":   {"style": "css", "standalone": true},
+	"@schematics/angular:directive":   {"standalone": true},
+	"@schematics/angular:pipe":        {"standalone": true},
+	"@schematics/angular:guard":       {"typeSeparator": "."},
+	"@schematics/angular:interceptor": {"typeSeparator": "."},
+	"@schematics/angular:resolver":    {"typeSeparator": "."},
+	"@schematics/angular:service":     {"typeSeparator": "."},
+}
+
+// updateAngularJsonSchematics adds the default schematics to angular.json.
+// Schematics already configured, by an earlier run or by hand, are kept as
+// they are, so running it again changes nothing.
+func (g *FrontendGenerator) updateAngularJsonSchematics(p *plan, frontendDir string) error {
 	angularJsonPath := filepath.Join(frontendDir, "angular.json")
+	if p.dryRun {
+		p.update(angularJsonPath, "add Angular schematics defaults")
+		return nil
+	}
 
-	// Read angular.json
-	data, err := os.ReadFile(angularJsonPath)
+	config, err := readJSONObject(angularJsonPath)
 	if err != nil {
 		return fmt.Errorf("failed to read angular.json: %w", err)
 	}
 
-	// Parse JSON manually (simple string replacement approach)
-	// We'll add schematics section after "version"
-	schematicsConfig := `
-  "schematics": {
-    "@schematics/angular:component": {
-      "style": "css",
-      "standalone": true
-    },
-    "@schematics/angular:directive": {
-      "standalone": true
-    },
-    "@schematics/angular:pipe": {
-      "standalone": true
-    },
-    "@schematics/angular:guard": {
-      "typeSeparator": "."
-    },
-    "@schematics/angular:interceptor": {
-      "typeSeparator": "."
-    },
-    "@schematics/angular:resolver": {
-      "typeSeparator": "."
-    },
-    "@schematics/angular:service": {
-      "typeSeparator": "."
-    }
-  },`
-
-	// Find the position after "version": "1" line
-	content := string(data)
-	versionLine := `"version": 1,`
-	if idx := strings.Index(content, versionLine); idx != -1 {
-		// Insert schematics config after version line
-		newContent := content[:idx+len(versionLine)] + schematicsConfig + content[idx+len(versionLine):]
-		if err := os.WriteFile(angularJsonPath, []byte(newContent), 0644); err != nil {
-			return fmt.Errorf("failed to write angular.json: %w", err)
-		}
-		fmt.Println("  ✓ Added Angular schematics defaults")
+	if !addAngularSchematics(config) {
+		return nil
+	}
+	if err := writeJSONObject(angularJsonPath, config); err != nil {
+		return fmt.Errorf("failed to write angular.json: %w", err)
 	}
 
+	log.Info("  ✓ Added Angular schematics defaults")
 	return nil
 }
 
+// addAngularSchematics adds the default schematics missing from the
+// "schematics" block of an angular.json document. It reports whether it added
+// any.
+func addAngularSchematics(config map[string]interface{}) bool {
+	schematics := jsonObject(config, "schematics")
+
+	changed := false
+	for name, options := range angularSchematicsDefaults {
+		if _, exists := schematics[name]; exists {
+			continue
+		}
+		entry := make(map[string]interface{}, len(options))
+		for k, v := range options {
+			entry[k] = v
+		}
+		schematics[name] = entry
+		changed = true
+	}
+	return changed
+}
+
 // generateFrontendBuildFile creates BUILD.bazel for frontend app
-func (g *FrontendGenerator) generateFrontendBuildFile(appDir, appName, deploymentTarget string) error {
+func (g *FrontendGenerator) generateFrontendBuildFile(p *plan, appDir, appName, deploymentTarget string, ssr bool) error {
 	buildFilePath := filepath.Join(appDir, "BUILD.bazel")
 
 	content, err := g.engine.RenderTemplate("frontend/BUILD.bazel.tmpl", map[string]interface{}{
 		"AppName":          appName,
 		"DeploymentTarget": deploymentTarget,
+		"SSR":              ssr,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to render BUILD.bazel template: %w", err)
 	}
 
-	if err := os.WriteFile(buildFilePath, []byte(content), 0644); err != nil {
+	if err := p.writeFile(buildFilePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write BUILD.bazel: %w", err)
 	}
 
-	fmt.Printf("  ✓ Generated BUILD.bazel for Bazel builds\n")
+	p.info("  ✓ Generated BUILD.bazel for Bazel builds")
 	return nil
 }
@@ -1,155 +1,369 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/dosanma1/forge-cli/pkg/xos"
 )
 
 // generateEnvironmentFiles creates environment.ts files for different environments
-func (g *FrontendGenerator) generateEnvironmentFiles(appDir, appName, deploymentTarget string) error {
+func (g *FrontendGenerator) generateEnvironmentFiles(p *plan, appDir, appName, deploymentTarget string, apiURLs map[string]string) error {
 	envDir := filepath.Join(appDir, "src", "environments")
-	if err := os.MkdirAll(envDir, 0755); err != nil {
+	if err := p.mkdirAll(envDir); err != nil {
 		return fmt.Errorf("failed to create environments directory: %w", err)
 	}
 
-	// environment.ts (local development)
-	envContent := `export const environment = {
-  production: false,
-  apiUrl: 'http://localhost:8080/api',
-  deployment: '` + deploymentTarget + `'
-};
-`
-	if err := os.WriteFile(filepath.Join(envDir, "environment.ts"), []byte(envContent), 0644); err != nil {
-		return err
+	files := []struct {
+		name       string
+		env        string
+		production bool
+	}{
+		{"environment.ts", "local", false}, // local development
+		{"environment.dev.ts", "dev", false},
+		{"environment.prod.ts", "prod", true},
 	}
 
-	// environment.dev.ts
-	envDevContent := `export const environment = {
-  production: false,
-  apiUrl: 'https://api-dev.example.com/api',
-  deployment: '` + deploymentTarget + `'
+	for _, file := range files {
+		content := fmt.Sprintf(`export const environment = {
+  production: %t,
+  apiUrl: '%s',
+  deployment: '%s'
 };
-`
-	if err := os.WriteFile(filepath.Join(envDir, "environment.dev.ts"), []byte(envDevContent), 0644); err != nil {
-		return err
+`, file.production, apiURLs[file.env], deploymentTarget)
+		if err := p.writeFile(filepath.Join(envDir, file.name), []byte(content)); err != nil {
+			return err
+		}
 	}
 
-	// environment.prod.ts
-	envProdContent := `export const environment = {
-  production: true,
-  apiUrl: 'https://api.example.com/api',
-  deployment: '` + deploymentTarget + `'
-};
-`
-	if err := os.WriteFile(filepath.Join(envDir, "environment.prod.ts"), []byte(envProdContent), 0644); err != nil {
-		return err
+	p.info("  ✓ Generated environment files")
+	return nil
+}
+
+// frontendDeploymentTarget reads the deployment target of a frontend app from
+// the generator data, defaulting to Firebase.
+func frontendDeploymentTarget(data map[string]interface{}) string {
+	target, _ := data["deployer"].(string)
+	if target == "" {
+		target, _ = data["deployment"].(string)
 	}
 
-	fmt.Println("  ✓ Generated environment files")
-	return nil
+	switch strings.ToLower(target) {
+	case "", "firebase":
+		return "firebase"
+	case "gke", "helm", "helm (kubernetes)":
+		return "helm"
+	default:
+		return strings.ToLower(target)
+	}
+}
+
+// frontendAPIEnvironments are the environments frontend apps get an API URL for.
+var frontendAPIEnvironments = []string{"local", "dev", "prod"}
+
+// frontendAPIURLs returns the API URL of each frontend environment. URLs set
+// with --config apiUrl.<env>=... win; otherwise local points at the first
+// backend service and dev/prod are derived from the ingress domain, or the
+// Firebase Hosting site of the GCP project for prod.
+func frontendAPIURLs(config *workspace.Config, data map[string]interface{}) map[string]string {
+	urls := map[string]string{
+		"local": fmt.Sprintf("http://localhost:%d/api", backendServicePort(config)),
+		"dev":   "https://api-dev.example.com/api",
+		"prod":  "https://api.example.com/api",
+	}
+
+	if config != nil {
+		switch {
+		case config.Workspace.Kubernetes != nil && config.Workspace.Kubernetes.Domain != "":
+			domain := config.Workspace.Kubernetes.Domain
+			urls["dev"] = fmt.Sprintf("https://api-dev.%s/api", domain)
+			urls["prod"] = fmt.Sprintf("https://api.%s/api", domain)
+		case config.Workspace.GCP != nil && config.Workspace.GCP.ProjectID != "":
+			urls["prod"] = fmt.Sprintf("https://%s.web.app/api", config.Workspace.GCP.ProjectID)
+		}
+	}
+
+	if overrides, ok := data["apiUrls"].(map[string]string); ok {
+		for env, url := range overrides {
+			urls[env] = url
+		}
+	}
+
+	return urls
+}
+
+// backendServicePort returns the serve port of the first backend service in
+// the workspace, by name, or 8080 when there is none.
+func backendServicePort(config *workspace.Config) int {
+	if config == nil {
+		return 8080
+	}
+
+	names := make([]string, 0, len(config.Projects))
+	for name, project := range config.Projects {
+		if project.ProjectType == string(workspace.ProjectKindService) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		project := config.Projects[name]
+		if project.Architect == nil || project.Architect.Serve == nil {
+			continue
+		}
+		switch port := project.Architect.Serve.Options["port"].(type) {
+		case float64:
+			return int(port)
+		case int:
+			return port
+		}
+	}
+	return 8080
+}
+
+// cloudRunDefaults are the Cloud Run settings used when the deployer config
+// leaves them out. They match the service deploy template.
+var cloudRunDefaults = map[string]string{
+	"memory":       "512Mi",
+	"cpu":          "1000m",
+	"minInstances": "0",
+	"maxInstances": "10",
+	"concurrency":  "80",
+}
+
+// imageRegistry returns the image repository of a deployed app: the
+// deployer's registry, the workspace Docker registry, or the GCP project's
+// Container Registry, in that order.
+func imageRegistry(config *workspace.Config, data map[string]interface{}) string {
+	if registry, ok := data["registry"].(string); ok && registry != "" {
+		return registry
+	}
+	if config != nil {
+		if config.Workspace.Docker != nil && config.Workspace.Docker.Registry != "" {
+			return config.Workspace.Docker.Registry
+		}
+		if config.Workspace.GCP != nil && config.Workspace.GCP.ProjectID != "" {
+			return "gcr.io/" + config.Workspace.GCP.ProjectID
+		}
+	}
+	return "gcr.io/your-project"
+}
+
+// ingressDomain returns the domain apps are exposed under: the deployer's
+// domain or the workspace Kubernetes domain, "" when neither is set.
+func ingressDomain(config *workspace.Config, data map[string]interface{}) string {
+	if domain, ok := data["domain"].(string); ok && domain != "" {
+		return domain
+	}
+	if config != nil && config.Workspace.Kubernetes != nil {
+		return config.Workspace.Kubernetes.Domain
+	}
+	return ""
+}
+
+// frontendDeployOptions returns the deploy options of a frontend app at
+// appRoot, relative to the workspace root. Firebase apps deploy their own
+// hosting target from the workspace's shared Firebase configuration.
+func frontendDeployOptions(appRoot, appName, deploymentTarget string) map[string]interface{} {
+	if deploymentTarget != "firebase" {
+		return map[string]interface{}{
+			"configPath": fmt.Sprintf("deploy/%s", deploymentTarget),
+		}
+	}
+
+	workspaceRoot := strings.Repeat("../", strings.Count(filepath.ToSlash(appRoot), "/")+1)
+	return map[string]interface{}{
+		"configPath": strings.TrimSuffix(workspaceRoot, "/"),
+		"target":     appName,
+	}
 }
 
 // generateDeploymentConfig generates deployment configuration based on target
-func (g *FrontendGenerator) generateDeploymentConfig(workspaceDir, appName, deploymentTarget string, config *workspace.Config) error {
+func (g *FrontendGenerator) generateDeploymentConfig(p *plan, appDir, appName, deploymentTarget string, config *workspace.Config, data map[string]interface{}) error {
 	switch deploymentTarget {
 	case "firebase":
-		return g.generateFirebaseConfig(workspaceDir, appName, config)
-	case "gke":
-		return g.generateGKEConfig(workspaceDir, appName)
+		return g.generateFirebaseConfig(p, appDir, appName, config)
+	case "gke", "helm":
+		return g.generateGKEConfig(p, appDir, appName, config, data)
 	case "cloudrun":
-		return g.generateCloudRunConfig(workspaceDir, appName)
+		return g.generateCloudRunConfig(p, appDir, appName, config, data)
 	default:
 		return fmt.Errorf("unknown deployment target: %s", deploymentTarget)
 	}
 }
 
-// generateFirebaseConfig generates Firebase hosting configuration
-func (g *FrontendGenerator) generateFirebaseConfig(workspaceDir, appName string, config *workspace.Config) error {
-	// Put Firebase config in the app directory (self-contained)
-	appDir := filepath.Join(workspaceDir, "frontend", "apps", appName)
-
+// generateFirebaseConfig adds the app as a hosting site of the workspace's
+// Firebase configuration. All apps share one .firebaserc and firebase.json at
+// the workspace root, each deployed through its own hosting target.
+func (g *FrontendGenerator) generateFirebaseConfig(p *plan, appDir, appName string, config *workspace.Config) error {
 	// Get project ID from config or use default
 	projectID := "your-project-id"
 	if config != nil && config.Workspace.GCP != nil && config.Workspace.GCP.ProjectID != "" {
 		projectID = config.Workspace.GCP.ProjectID
 	}
 
-	// Check if .firebaserc exists
-	firebasercPath := filepath.Join(appDir, ".firebaserc")
-	firebaseExists := false
-	if _, err := os.Stat(firebasercPath); err == nil {
-		firebaseExists = true
-	}
-
-	if !firebaseExists {
-		// Create new .firebaserc with multi-site support
-		firebasercContent := `{
-  "projects": {
-    "default": "` + projectID + `"
-  },
-  "targets": {
-    "` + projectID + `": {
-      "hosting": {
-        "` + appName + `": [
-          "` + appName + `"
-        ]
-      }
-    }
-  }
+	appRoot, err := filepath.Rel(p.root, appDir)
+	if err != nil {
+		return err
+	}
+	public := filepath.ToSlash(filepath.Join(appRoot, "dist"))
+
+	firebasercPath := filepath.Join(p.root, ".firebaserc")
+	firebaseJSONPath := filepath.Join(p.root, "firebase.json")
+	if p.dryRun {
+		p.update(firebasercPath, fmt.Sprintf("add hosting target %s", appName))
+		p.update(firebaseJSONPath, fmt.Sprintf("add hosting site %s", appName))
+		return nil
+	}
+
+	if err := addFirebaseHostingTarget(firebasercPath, projectID, appName); err != nil {
+		return fmt.Errorf("failed to update .firebaserc: %w", err)
+	}
+	if err := addFirebaseHostingSite(firebaseJSONPath, appName, public); err != nil {
+		return fmt.Errorf("failed to update firebase.json: %w", err)
+	}
+
+	p.info("  ✓ Added hosting target %s to the workspace Firebase configuration", appName)
+	return nil
 }
-`
-		if err := os.WriteFile(firebasercPath, []byte(firebasercContent), 0644); err != nil {
+
+// addFirebaseHostingTarget registers appName as a hosting target of the
+// default project of .firebaserc (projectID when it has none), keeping the
+// targets already there. A missing .firebaserc is created.
+func addFirebaseHostingTarget(path, projectID, appName string) error {
+	rc := map[string]interface{}{}
+	if _, err := os.Stat(path); err == nil {
+		if rc, err = readJSONObject(path); err != nil {
 			return err
 		}
+	}
 
-		// Create firebase.json with hosting config
-		firebaseJsonPath := filepath.Join(appDir, "firebase.json")
-		firebaseJsonContent := `{
-  "hosting": [
-    {
-      "target": "` + appName + `",
-      "public": "dist",
-      "ignore": [
-        "firebase.json",
-        "**/.*",
-        "**/node_modules/**"
-      ],
-      "rewrites": [
-        {
-          "source": "**",
-          "destination": "/index.html"
-        }
-      ]
-    }
-  ]
+	projects := jsonObject(rc, "projects")
+	if current, ok := projects["default"].(string); ok && current != "" {
+		projectID = current
+	} else {
+		projects["default"] = projectID
+	}
+
+	hosting := jsonObject(jsonObject(jsonObject(rc, "targets"), projectID), "hosting")
+	if _, exists := hosting[appName]; !exists {
+		hosting[appName] = []interface{}{appName}
+	}
+
+	return writeJSONObject(path, rc)
 }
-`
-		if err := os.WriteFile(firebaseJsonPath, []byte(firebaseJsonContent), 0644); err != nil {
+
+// addFirebaseHostingSite appends a hosting entry for appName, serving the
+// public directory, to firebase.json unless one already targets it. A
+// single-site "hosting" object is turned into the multi-site array form.
+func addFirebaseHostingSite(path, appName, public string) error {
+	config := map[string]interface{}{}
+	if _, err := os.Stat(path); err == nil {
+		if config, err = readJSONObject(path); err != nil {
 			return err
 		}
-	} else {
-		// TODO: Update existing .firebaserc and firebase.json to add new site
-		fmt.Println("  ℹ️  Firebase config exists, please manually add hosting target for " + appName)
 	}
 
-	fmt.Printf("  ✓ Generated Firebase configuration (target: %s)\n", appName)
-	return nil
+	var sites []interface{}
+	switch hosting := config["hosting"].(type) {
+	case []interface{}:
+		sites = hosting
+	case map[string]interface{}:
+		sites = []interface{}{hosting}
+	}
+
+	for _, site := range sites {
+		if entry, ok := site.(map[string]interface{}); ok && entry["target"] == appName {
+			return nil
+		}
+	}
+
+	config["hosting"] = append(sites, map[string]interface{}{
+		"target": appName,
+		"public": public,
+		"ignore": []interface{}{
+			"firebase.json",
+			"**/.*",
+			"**/node_modules/**",
+		},
+		"rewrites": []interface{}{
+			map[string]interface{}{
+				"source":      "**",
+				"destination": "/index.html",
+			},
+		},
+	})
+
+	return writeJSONObject(path, config)
+}
+
+// readJSONObject reads a JSON file whose top level is an object.
+func readJSONObject(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return obj, nil
+}
+
+// writeJSONObject writes obj with two-space indentation.
+func writeJSONObject(path string, obj map[string]interface{}) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+	return xos.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// jsonObject returns parent[key] as an object, creating it when missing.
+func jsonObject(parent map[string]interface{}, key string) map[string]interface{} {
+	if obj, ok := parent[key].(map[string]interface{}); ok {
+		return obj
+	}
+	obj := map[string]interface{}{}
+	parent[key] = obj
+	return obj
 }
 
 // generateGKEConfig generates Kubernetes/Helm configuration
-func (g *FrontendGenerator) generateGKEConfig(workspaceDir, appName string) error {
-	deployDir := filepath.Join(workspaceDir, "frontend", "projects", appName, "deploy", "helm")
-	if err := os.MkdirAll(deployDir, 0755); err != nil {
+func (g *FrontendGenerator) generateGKEConfig(p *plan, appDir, appName string, config *workspace.Config, data map[string]interface{}) error {
+	deployDir := filepath.Join(appDir, "deploy", "helm")
+	if err := p.mkdirAll(deployDir); err != nil {
 		return err
 	}
 
+	// nginx serves static apps on port 80, the SSR server listens on 8080
+	servicePort := "80"
+	if ssr, _ := data["ssr"].(bool); ssr {
+		servicePort = "8080"
+		if err := g.writeSSRDockerfile(p, deployDir, appName, config); err != nil {
+			return err
+		}
+	}
+
+	// The ingress is only enabled once there is a domain to route, like for services
+	domain := ingressDomain(config, data)
+	host := appName + ".local"
+	if domain != "" {
+		host = appName + "." + domain
+	}
+
 	// Create values.yaml for frontend Helm chart
 	valuesContent := `# Helm values for ` + appName + ` frontend
 image:
-  repository: gcr.io/your-project/` + appName + `
+  repository: ` + imageRegistry(config, data) + `/` + appName + `
   tag: latest
   pullPolicy: IfNotPresent
 
@@ -157,55 +371,83 @@ replicaCount: 2
 
 service:
   type: ClusterIP
-  port: 80
+  port: ` + servicePort + `
 
 ingress:
-  enabled: true
+  enabled: ` + strconv.FormatBool(domain != "") + `
   className: nginx
   hosts:
-    - host: ` + appName + `.example.com
+    - host: ` + host + `
       paths:
         - path: /
           pathType: Prefix
 `
 	valuesPath := filepath.Join(deployDir, "values.yaml")
-	if err := os.WriteFile(valuesPath, []byte(valuesContent), 0644); err != nil {
+	if err := p.writeFile(valuesPath, []byte(valuesContent)); err != nil {
 		return err
 	}
 
-	fmt.Println("  ✓ Generated GKE/Helm configuration")
+	p.info("  ✓ Generated GKE/Helm configuration")
 	return nil
 }
 
-// generateCloudRunConfig generates Cloud Run configuration
-func (g *FrontendGenerator) generateCloudRunConfig(workspaceDir, appName string) error {
-	deployDir := filepath.Join(workspaceDir, "frontend", "projects", appName, "deploy", "cloudrun")
-	if err := os.MkdirAll(deployDir, 0755); err != nil {
+// generateCloudRunConfig generates Cloud Run configuration. Region, limits,
+// scaling and concurrency come from the deployer config in data.
+func (g *FrontendGenerator) generateCloudRunConfig(p *plan, appDir, appName string, config *workspace.Config, data map[string]interface{}) error {
+	deployDir := filepath.Join(appDir, "deploy", "cloudrun")
+	if err := p.mkdirAll(deployDir); err != nil {
 		return err
 	}
 
+	setting := func(key string) string {
+		if value, ok := data[key].(string); ok && value != "" {
+			return value
+		}
+		return cloudRunDefaults[key]
+	}
+
+	labels := ""
+	if region := setting("region"); region != "" {
+		labels = `
+  labels:
+    cloud.googleapis.com/location: ` + region
+	}
+
 	// Create service.yaml for Cloud Run
 	serviceContent := `apiVersion: serving.knative.dev/v1
 kind: Service
 metadata:
-  name: ` + appName + `
+  name: ` + appName + labels + `
 spec:
   template:
+    metadata:
+      annotations:
+        autoscaling.knative.dev/minScale: "` + setting("minInstances") + `"
+        autoscaling.knative.dev/maxScale: "` + setting("maxInstances") + `"
     spec:
+      containerConcurrency: ` + setting("concurrency") + `
       containers:
-        - image: gcr.io/your-project/` + appName + `:latest
+        - image: ` + imageRegistry(config, data) + `/` + appName + `:latest
           ports:
             - containerPort: 8080
           resources:
             limits:
-              memory: 512Mi
-              cpu: 1000m
+              memory: ` + setting("memory") + `
+              cpu: ` + setting("cpu") + `
 `
 	servicePath := filepath.Join(deployDir, "service.yaml")
-	if err := os.WriteFile(servicePath, []byte(serviceContent), 0644); err != nil {
+	if err := p.writeFile(servicePath, []byte(serviceContent)); err != nil {
 		return err
 	}
 
+	if ssr, _ := data["ssr"].(bool); ssr {
+		if err := g.writeSSRDockerfile(p, deployDir, appName, config); err != nil {
+			return err
+		}
+		p.info("  ✓ Generated Cloud Run configuration (SSR server)")
+		return nil
+	}
+
 	// Create nginx Dockerfile
 	dockerfileContent := `FROM nginx:alpine
 COPY dist/` + appName + ` /usr/share/nginx/html
@@ -214,7 +456,7 @@ EXPOSE 8080
 CMD ["nginx", "-g", "daemon off;"]
 `
 	dockerfilePath := filepath.Join(deployDir, "Dockerfile")
-	if err := os.WriteFile(dockerfilePath, []byte(dockerfileContent), 0644); err != nil {
+	if err := p.writeFile(dockerfilePath, []byte(dockerfileContent)); err != nil {
 		return err
 	}
 
@@ -237,10 +479,27 @@ CMD ["nginx", "-g", "daemon off;"]
 }
 `
 	nginxPath := filepath.Join(deployDir, "nginx.conf")
-	if err := os.WriteFile(nginxPath, []byte(nginxContent), 0644); err != nil {
+	if err := p.writeFile(nginxPath, []byte(nginxContent)); err != nil {
 		return err
 	}
 
-	fmt.Println("  ✓ Generated Cloud Run configuration")
+	p.info("  ✓ Generated Cloud Run configuration")
 	return nil
 }
+
+// writeSSRDockerfile writes the Dockerfile of a server-side rendered app: a
+// Node image running the server bundle of the build on port 8080.
+func (g *FrontendGenerator) writeSSRDockerfile(p *plan, deployDir, appName string, config *workspace.Config) error {
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+	content, err := g.engine.RenderTemplate("frontend/ssr/Dockerfile.tmpl", map[string]interface{}{
+		"AppName":     appName,
+		"NodeVersion": toolVersions.Node,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render SSR Dockerfile: %w", err)
+	}
+	return p.writeFile(filepath.Join(deployDir, "Dockerfile"), []byte(content))
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpdateAngularJsonSchematics(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "angular.json")
+
+	// An existing schematics block with a manual edit, and no "version": 1, line
+	src := `{
+  "$schema": "./node_modules/@angular/cli/lib/config/schema.json",
+  "version":1,
+  "schematics": {
+    "@schematics/angular:component": {"style": "scss"}
+  },
+  "projects": {}
+}`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewFrontendGenerator()
+	p := newPlan(dir, false)
+	if err := g.updateAngularJsonSchematics(p, dir); err != nil {
+		t.Fatal(err)
+	}
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := readJSONObject(path)
+	if err != nil {
+		t.Fatalf("angular.json is not valid JSON: %v\n%s", err, first)
+	}
+	schematics := config["schematics"].(map[string]interface{})
+	if len(schematics) != len(angularSchematicsDefaults) {
+		t.Errorf("got %d schematics, want %d", len(schematics), len(angularSchematicsDefaults))
+	}
+	component := schematics["@schematics/angular:component"].(map[string]interface{})
+	if component["style"] != "scss" {
+		t.Errorf("component style = %v, want the existing scss", component["style"])
+	}
+	if _, ok := config["projects"]; !ok {
+		t.Error("projects was dropped")
+	}
+
+	// Running again must leave the file untouched
+	if err := g.updateAngularJsonSchematics(p, dir); err != nil {
+		t.Fatal(err)
+	}
+	second, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(second) != string(first) {
+		t.Errorf("second run changed angular.json:\n%s", second)
+	}
+}
+
+func TestGenerateGKEConfig(t *testing.T) {
+	readValues := func(t *testing.T, dir string) map[string]interface{} {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dir, "deploy", "helm", "values.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			t.Fatalf("values.yaml is invalid: %v\n%s", err, content)
+		}
+		return values
+	}
+
+	g := NewFrontendGenerator()
+	config := workspace.NewConfig("shop")
+	config.Workspace.Docker = &workspace.DockerConfig{Registry: "europe-docker.pkg.dev/acme/shop"}
+	config.Workspace.Kubernetes = &workspace.KubernetesConfig{Domain: "shop.acme.dev"}
+
+	dir := t.TempDir()
+	if err := g.generateGKEConfig(newPlan(dir, false), dir, "storefront", config, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	values := readValues(t, dir)
+	if repository := values["image"].(map[string]interface{})["repository"]; repository != "europe-docker.pkg.dev/acme/shop/storefront" {
+		t.Errorf("image.repository = %v, want the workspace registry", repository)
+	}
+	ingress := values["ingress"].(map[string]interface{})
+	host := ingress["hosts"].([]interface{})[0].(map[string]interface{})["host"]
+	if ingress["enabled"] != true || host != "storefront.shop.acme.dev" {
+		t.Errorf("ingress = %v, want it enabled for storefront.shop.acme.dev", ingress)
+	}
+
+	// The deployer config wins, and without a domain the ingress stays off
+	config.Workspace.Kubernetes = nil
+	dir = t.TempDir()
+	if err := g.generateGKEConfig(newPlan(dir, false), dir, "storefront", config, map[string]interface{}{"registry": "ghcr.io/acme"}); err != nil {
+		t.Fatal(err)
+	}
+	values = readValues(t, dir)
+	if repository := values["image"].(map[string]interface{})["repository"]; repository != "ghcr.io/acme/storefront" {
+		t.Errorf("image.repository = %v, want the deployer registry", repository)
+	}
+	if ingress := values["ingress"].(map[string]interface{}); ingress["enabled"] != false {
+		t.Errorf("ingress = %v, want it disabled without a domain", ingress)
+	}
+}
+
+func TestGenerateFirebaseConfigSharedByApps(t *testing.T) {
+	g := NewFrontendGenerator()
+	config := workspace.NewConfig("shop")
+	config.Workspace.GCP = &workspace.GCPConfig{ProjectID: "acme"}
+
+	root := t.TempDir()
+	for _, appName := range []string{"storefront", "admin", "storefront"} {
+		appDir := filepath.Join(root, "frontend", "apps", appName)
+		if err := g.generateDeploymentConfig(newPlan(root, false), appDir, appName, "firebase", config, map[string]interface{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, appName := range []string{"storefront", "admin"} {
+		for _, filename := range []string{".firebaserc", "firebase.json"} {
+			if _, err := os.Stat(filepath.Join(root, "frontend", "apps", appName, filename)); !os.IsNotExist(err) {
+				t.Errorf("%s was written into the %s app", filename, appName)
+			}
+		}
+	}
+
+	var rc struct {
+		Projects map[string]string `json:"projects"`
+		Targets  map[string]struct {
+			Hosting map[string][]string `json:"hosting"`
+		} `json:"targets"`
+	}
+	content, err := os.ReadFile(filepath.Join(root, ".firebaserc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(content, &rc); err != nil {
+		t.Fatal(err)
+	}
+	hosting := rc.Targets["acme"].Hosting
+	if rc.Projects["default"] != "acme" || len(hosting) != 2 || hosting["storefront"][0] != "storefront" || hosting["admin"][0] != "admin" {
+		t.Errorf(".firebaserc = %s, want hosting targets storefront and admin of acme", content)
+	}
+
+	var firebaseJSON struct {
+		Hosting []struct {
+			Target string `json:"target"`
+			Public string `json:"public"`
+		} `json:"hosting"`
+	}
+	content, err = os.ReadFile(filepath.Join(root, "firebase.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(content, &firebaseJSON); err != nil {
+		t.Fatal(err)
+	}
+	sites := firebaseJSON.Hosting
+	if len(sites) != 2 || sites[0].Target != "storefront" || sites[0].Public != "frontend/apps/storefront/dist" ||
+		sites[1].Target != "admin" || sites[1].Public != "frontend/apps/admin/dist" {
+		t.Errorf("firebase.json = %s, want one site per app", content)
+	}
+
+	// Each app deploys its own target from the workspace root
+	options := frontendDeployOptions("frontend/apps/admin", "admin", "firebase")
+	if options["configPath"] != "../../.." || options["target"] != "admin" {
+		t.Errorf("deploy options = %v, want the workspace root and the admin target", options)
+	}
+}
@@ -0,0 +1,250 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// HandlerGenerator adds an HTTP handler to an existing service.
+type HandlerGenerator struct {
+	engine *template.Engine
+}
+
+// NewHandlerGenerator creates a new handler generator.
+func NewHandlerGenerator() *HandlerGenerator {
+	return &HandlerGenerator{
+		engine: template.NewEngine(),
+	}
+}
+
+// Name returns the generator name.
+func (g *HandlerGenerator) Name() string {
+	return "handler"
+}
+
+// Description returns the generator description.
+func (g *HandlerGenerator) Description() string {
+	return "Add an HTTP handler to an existing service"
+}
+
+var (
+	nonAlphanumeric    = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	goEndpointsFunc    = regexp.MustCompile(`func \(\w+ \*(\w+)\) Endpoints\(\) \[\]rest\.Endpoint \{`)
+	nestControllerDecl = regexp.MustCompile(`export class (\w+)`)
+)
+
+// Generate adds the handler. opts.Name is the service name; opts.Data must contain
+// "path" and may contain "method" (defaults to GET).
+func (g *HandlerGenerator) Generate(ctx context.Context, opts GeneratorOptions) error {
+	path, _ := opts.Data["path"].(string)
+	if path == "" {
+		return fmt.Errorf("endpoint path is required")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	method := http.MethodGet
+	if m, ok := opts.Data["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("unsupported HTTP method %q (supported: GET, POST, PUT, PATCH, DELETE)", method)
+	}
+
+	config, err := workspace.LoadConfigWithoutProjectValidation(opts.OutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to load workspace config: %w", err)
+	}
+
+	project, exists := config.Projects[opts.Name]
+	if !exists {
+		return fmt.Errorf("project %q not found in forge.json", opts.Name)
+	}
+	if project.ProjectType != string(workspace.ProjectKindService) {
+		return fmt.Errorf("project %q is a %s, handlers can only be added to services", opts.Name, project.ProjectType)
+	}
+
+	handlerPascal := template.Pascalize(strings.ToLower(method) + " " + nonAlphanumeric.ReplaceAllString(path, " "))
+	serviceDir := filepath.Join(opts.OutputDir, project.Root)
+
+	switch project.Language {
+	case string(workspace.LanguageGo):
+		return g.generateGoHandler(serviceDir, method, path, handlerPascal, opts.DryRun)
+	case string(workspace.LanguageNestJS):
+		return g.generateNestJSHandler(serviceDir, method, path, handlerPascal, opts.DryRun)
+	default:
+		return fmt.Errorf("handlers are not supported for %s services", project.Language)
+	}
+}
+
+// generateGoHandler writes the handler stub and registers it in the controller's Endpoints.
+func (g *HandlerGenerator) generateGoHandler(serviceDir, method, path, handlerPascal string, dryRun bool) error {
+	transportPath := filepath.Join(serviceDir, "internal", "transport_rest.go")
+	content, err := os.ReadFile(transportPath)
+	if err != nil {
+		return fmt.Errorf("failed to read REST transport: %w", err)
+	}
+	transport := string(content)
+
+	match := goEndpointsFunc.FindStringSubmatchIndex(transport)
+	if match == nil {
+		return fmt.Errorf("no REST controller with Endpoints() found in %s", transportPath)
+	}
+	controllerType := transport[match[2]:match[3]]
+
+	// Insert the route at the end of the returned endpoint list
+	listStart := strings.Index(transport[match[1]:], "[]rest.Endpoint{")
+	if listStart == -1 {
+		return fmt.Errorf("could not find endpoint list in %s", transportPath)
+	}
+	listStart += match[1]
+	listEnd := strings.Index(transport[listStart:], "\n\t}")
+	if listEnd == -1 {
+		return fmt.Errorf("could not find end of endpoint list in %s", transportPath)
+	}
+	listEnd += listStart
+
+	handlerName := strings.ToLower(handlerPascal[:1]) + handlerPascal[1:]
+	if strings.Contains(transport[listStart:listEnd], "c."+handlerName+")") {
+		return fmt.Errorf("handler %s is already registered", handlerName)
+	}
+
+	routePath := strings.NewReplacer("{", ":", "}", "").Replace(path)
+	route := fmt.Sprintf("\n\t\trest.NewEndpoint(http.Method%s, %q, c.%s),", template.Pascalize(strings.ToLower(method)), routePath, handlerName)
+	transport = transport[:listEnd] + route + transport[listEnd:]
+
+	handlerFile := filepath.Join(serviceDir, "internal", fmt.Sprintf("handler_%s.go", template.SnakeCase(handlerPascal)))
+	if _, err := os.Stat(handlerFile); err == nil {
+		return fmt.Errorf("handler file already exists: %s", handlerFile)
+	}
+
+	data := map[string]interface{}{
+		"HandlerName":       handlerName,
+		"HandlerNamePascal": handlerPascal,
+		"ControllerType":    controllerType,
+		"Method":            method,
+		"Path":              path,
+		"HasBody":           method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch,
+	}
+	handler, err := g.engine.RenderTemplate("service/internal/handler.go.tmpl", data)
+	if err != nil {
+		return fmt.Errorf("failed to render handler: %w", err)
+	}
+
+	if dryRun {
+		log.Info("Would create %s", handlerFile)
+		log.Info("Would register %s %s in %s", method, routePath, transportPath)
+		return nil
+	}
+
+	if err := os.WriteFile(handlerFile, []byte(handler), 0644); err != nil {
+		return fmt.Errorf("failed to write handler: %w", err)
+	}
+	if err := os.WriteFile(transportPath, []byte(transport), 0644); err != nil {
+		return fmt.Errorf("failed to update REST transport: %w", err)
+	}
+
+	log.Info("✓ Created %s", handlerFile)
+	log.Info("✓ Registered %s %s in %s", method, routePath, transportPath)
+	return nil
+}
+
+// generateNestJSHandler adds a controller method to the service's root
+// controller: app.controller.ts, or <app>.controller.ts for apps of a Nest
+// monorepo.
+func (g *HandlerGenerator) generateNestJSHandler(serviceDir, method, path, handlerPascal string, dryRun bool) error {
+	controllerPath := filepath.Join(serviceDir, "src", "app.controller.ts")
+	if _, err := os.Stat(controllerPath); os.IsNotExist(err) {
+		controllerPath = filepath.Join(serviceDir, "src", filepath.Base(serviceDir)+".controller.ts")
+	}
+	content, err := os.ReadFile(controllerPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(controllerPath), err)
+	}
+	controller := string(content)
+
+	if !nestControllerDecl.MatchString(controller) {
+		return fmt.Errorf("no controller class found in %s", controllerPath)
+	}
+
+	methodName := strings.ToLower(handlerPascal[:1]) + handlerPascal[1:]
+	if strings.Contains(controller, " "+methodName+"(") {
+		return fmt.Errorf("controller method %s already exists", methodName)
+	}
+
+	decorator := template.Pascalize(strings.ToLower(method))
+	routePath := strings.NewReplacer("{", ":", "}", "").Replace(strings.TrimPrefix(path, "/"))
+
+	// Ensure the HTTP method decorator (and Body for write methods) is imported
+	needed := []string{decorator}
+	hasBody := method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+	if hasBody {
+		needed = append(needed, "Body")
+	}
+	controller = addNestCommonImports(controller, needed)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "\n  @%s('%s')\n", decorator, routePath)
+	if hasBody {
+		fmt.Fprintf(&body, "  %s(@Body() body: Record<string, unknown>): Record<string, unknown> {\n", methodName)
+	} else {
+		fmt.Fprintf(&body, "  %s(): Record<string, unknown> {\n", methodName)
+	}
+	fmt.Fprintf(&body, "    // TODO: Implement %s\n    return {};\n  }\n", methodName)
+
+	closing := strings.LastIndex(controller, "}")
+	if closing == -1 {
+		return fmt.Errorf("could not find end of controller class in %s", controllerPath)
+	}
+	controller = strings.TrimRight(controller[:closing], "\n") + "\n" + body.String() + controller[closing:]
+
+	if dryRun {
+		log.Info("Would add %s %s (%s) to %s", method, path, methodName, controllerPath)
+		return nil
+	}
+
+	if err := os.WriteFile(controllerPath, []byte(controller), 0644); err != nil {
+		return fmt.Errorf("failed to write app.controller.ts: %w", err)
+	}
+
+	log.Info("✓ Added %s %s (%s) to %s", method, path, methodName, controllerPath)
+	return nil
+}
+
+// addNestCommonImports adds names to the '@nestjs/common' import, creating it if needed.
+func addNestCommonImports(content string, names []string) string {
+	importRe := regexp.MustCompile(`import \{([^}]*)\} from '@nestjs/common';`)
+	match := importRe.FindStringSubmatch(content)
+	if match == nil {
+		return fmt.Sprintf("import { %s } from '@nestjs/common';\n", strings.Join(names, ", ")) + content
+	}
+
+	existing := make(map[string]bool)
+	var imports []string
+	for _, name := range strings.Split(match[1], ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			existing[name] = true
+			imports = append(imports, name)
+		}
+	}
+	for _, name := range names {
+		if !existing[name] {
+			imports = append(imports, name)
+		}
+	}
+
+	return strings.Replace(content, match[0], fmt.Sprintf("import { %s } from '@nestjs/common';", strings.Join(imports, ", ")), 1)
+}
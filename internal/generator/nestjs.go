@@ -2,16 +2,22 @@ package generator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
+// nestMonorepoDir is the directory, in the services path, of the Nest
+// monorepo shared by the services generated with the monorepo option.
+const nestMonorepoDir = "nest"
+
 // NestJSServiceGenerator generates a new NestJS microservice.
 type NestJSServiceGenerator struct {
 	engine *template.Engine
@@ -24,6 +30,11 @@ func NewNestJSServiceGenerator() *NestJSServiceGenerator {
 	}
 }
 
+func init() {
+	// Register the NestJS service generator
+	Register(workspace.ProjectKindService, workspace.LanguageNestJS, "NestJS", NewNestJSServiceGenerator())
+}
+
 // Name returns the generator name.
 func (g *NestJSServiceGenerator) Name() string {
 	return "nestjs-service"
@@ -34,8 +45,9 @@ func (g *NestJSServiceGenerator) Description() string {
 	return "Generate a new NestJS microservice"
 }
 
-// Generate creates a new NestJS service.
-func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions) error {
+// Generate creates a new NestJS service. On failure the service directory is
+// removed and forge.json is restored.
+func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions) (err error) {
 	serviceName := opts.Name
 	if serviceName == "" {
 		return fmt.Errorf("service name is required")
@@ -70,41 +82,100 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 	if err != nil {
 		return fmt.Errorf("failed to load workspace config: %w", err)
 	}
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
 
 	// Determine service path using workspace.paths or default
-	servicesPath := "backend/services"
-	if config.Workspace.Paths != nil && config.Workspace.Paths.Services != "" {
-		servicesPath = config.Workspace.Paths.Services
-	}
+	servicesPath := config.ServicesPath()
 
 	servicesDir := filepath.Join(workspaceRoot, servicesPath)
 	serviceDir := filepath.Join(servicesDir, serviceName)
 
-	// Check if service already exists
+	// Apps of the Nest monorepo live in its apps/ directory and share the
+	// dependencies installed at its root
+	monorepo, _ := opts.Data["monorepo"].(bool)
+	installDir := serviceDir
+	if monorepo {
+		if serviceName == nestMonorepoDir {
+			return fmt.Errorf("service name %q is reserved for the Nest monorepo", serviceName)
+		}
+		installDir = filepath.Join(servicesDir, nestMonorepoDir)
+		serviceDir = filepath.Join(installDir, "apps", serviceName)
+	}
+	serviceRoot, err := filepath.Rel(workspaceRoot, serviceDir)
+	if err != nil {
+		return err
+	}
+
+	// Check if service already exists. With resume, finish a generation that
+	// was interrupted (e.g. by a failed npm install) instead.
+	resume, _ := opts.Data["resume"].(bool)
 	if _, err := os.Stat(serviceDir); err == nil {
-		return fmt.Errorf("service %s already exists at %s", serviceName, serviceDir)
+		if !resume {
+			return fmt.Errorf("service %s already exists at %s (use --resume to finish an interrupted generation)", serviceName, serviceDir)
+		}
+		if config.GetProject(serviceName) != nil {
+			return fmt.Errorf("service %s is already registered in forge.json, nothing to resume", serviceName)
+		}
+		if !nestjsStepDone(serviceDir, "package.json") && !nestjsStepDone(serviceDir, "src", "main.ts") {
+			return fmt.Errorf("%s has no package.json; remove it and generate the service again", serviceDir)
+		}
+		log.Info("♻️  Resuming generation of %s", serviceName)
 	}
 
-	if opts.DryRun {
-		fmt.Printf("Would create NestJS service: %s at %s\n", serviceName, serviceDir)
-		return nil
+	port, err := servePort(config, opts.Data, 3000)
+	if err != nil {
+		return err
+	}
+
+	p := newPlan(workspaceRoot, opts.DryRun)
+
+	undo, err := newRollback(workspaceRoot, opts)
+	if err != nil {
+		return err
+	}
+	defer undo.restoreOnError(&err)
+	undo.track(servicesDir)
+	if monorepo {
+		undo.track(installDir)
+		undo.trackDirs(installDir, filepath.Join("apps", serviceName))
+	} else {
+		undo.track(serviceDir)
 	}
 
 	// Ensure services directory exists
-	if err := os.MkdirAll(servicesDir, 0755); err != nil {
+	if err := p.mkdirAll(servicesDir); err != nil {
 		return fmt.Errorf("failed to create services directory: %w", err)
 	}
 
 	// Generate NestJS project using Nest CLI
-	fmt.Printf("🚀 Generating NestJS project: %s\n", serviceName)
+	if monorepo {
+		if err := g.generateMonorepoApp(ctx, p, servicesDir, installDir, serviceName, config); err != nil {
+			return err
+		}
+	} else if nestjsStepDone(serviceDir, "package.json") {
+		log.Info("✓ NestJS project already generated")
+	} else {
+		p.info("🚀 Generating NestJS project: %s", serviceName)
 
-	if err := g.runNestJSCLI(servicesDir, config, []string{
-		"new", serviceName,
-		"--package-manager", "npm",
-		"--skip-git",
-		"--strict",
-	}); err != nil {
-		return fmt.Errorf("failed to generate NestJS project: %w", err)
+		if err := g.runNestJSCLI(ctx, p, servicesDir, config, newNestProjectArgs(serviceName)); err != nil {
+			return fmt.Errorf("failed to generate NestJS project: %w", err)
+		}
+	}
+
+	// From here on a failure leaves the project in place for --resume
+	undo.untrack(servicesDir, installDir, serviceDir)
+
+	// npm writes node_modules/.package-lock.json once an install completes
+	if nestjsStepDone(installDir, "node_modules", ".package-lock.json") {
+		log.Info("✓ Dependencies already installed")
+	} else {
+		p.info("📦 Installing dependencies...")
+		if err := g.runNpmCommand(ctx, p, installDir, []string{"install"}); err != nil {
+			return fmt.Errorf("failed to install dependencies (rerun with --resume to continue): %w", err)
+		}
 	}
 
 	// Determine registry
@@ -125,9 +196,13 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 	}
 
 	// Install additional dependencies
-	fmt.Println("📦 Installing additional dependencies...")
-	if err := g.runNpmCommand(serviceDir, []string{"install", "@nestjs/terminus", "--save"}); err != nil {
-		return fmt.Errorf("failed to install @nestjs/terminus: %w", err)
+	if nestjsStepDone(installDir, "node_modules", "@nestjs", "terminus", "package.json") {
+		log.Info("✓ @nestjs/terminus already installed")
+	} else {
+		p.info("📦 Installing additional dependencies...")
+		if err := g.runNpmCommand(ctx, p, installDir, []string{"install", "@nestjs/terminus", "--save"}); err != nil {
+			return fmt.Errorf("failed to install @nestjs/terminus (rerun with --resume to continue): %w", err)
+		}
 	}
 
 	// Get deployer from opts.Data or default to helm
@@ -140,7 +215,7 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 
 	// Create deploy directory for selected deployer only
 	deployDir := filepath.Join(serviceDir, "deploy", deployerTarget)
-	if err := os.MkdirAll(deployDir, 0755); err != nil {
+	if err := p.mkdirAll(deployDir); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", deployDir, err)
 	}
 
@@ -150,6 +225,8 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 		"Registry":      registry,
 		"WorkspaceName": workspaceName,
 		"ServicesPath":  servicesPath,
+		"NodeVersion":   toolVersions.Node,
+		"NestApp":       "",
 	}
 
 	// Base files that are always generated
@@ -158,6 +235,14 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 		"Dockerfile":                      "Dockerfile.tmpl",
 		"src/health/health.controller.ts": "src/health/health.controller.ts.tmpl",
 	}
+	if monorepo {
+		data["NestApp"] = serviceName
+		data["NestAppPath"] = filepath.ToSlash(filepath.Join("apps", serviceName))
+		delete(forgeFiles, "BUILD.bazel")
+		if err := g.writeMonorepoBuildFiles(p, workspaceRoot, installDir, serviceDir, serviceName, workspaceName); err != nil {
+			return err
+		}
+	}
 
 	// Add deployer-specific files
 	switch deployerTarget {
@@ -165,18 +250,15 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 		forgeFiles["deploy/helm/values.yaml"] = "deploy/helm/values.yaml.tmpl"
 	case "cloudrun":
 		forgeFiles["deploy/cloudrun/service.yaml"] = "deploy/cloudrun/service.yaml.tmpl"
+	case "ecs":
+		forgeFiles["deploy/ecs/task-definition.json"] = "deploy/ecs/task-definition.json.tmpl"
+		forgeFiles["deploy/ecs/service.json"] = "deploy/ecs/service.json.tmpl"
+	case "aca":
+		forgeFiles["deploy/aca/containerapp.yaml"] = "deploy/aca/containerapp.yaml.tmpl"
 	}
 
 	for outputPath, templatePath := range forgeFiles {
-		fullPath := filepath.Join(serviceDir, outputPath)
-
-		// Ensure parent directory exists
-		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-			return fmt.Errorf("failed to create directory for %s: %w", outputPath, err)
-		}
-
-		// Read template from embedded filesystem
-		templateContent, err := template.TemplatesFS.ReadFile("templates/nestjs/" + templatePath)
+		templateContent, err := g.engine.ReadEmbeddedFile("nestjs/" + templatePath)
 		if err != nil {
 			return fmt.Errorf("failed to read template %s: %w", templatePath, err)
 		}
@@ -186,29 +268,38 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 			return fmt.Errorf("failed to render template for %s: %w", outputPath, err)
 		}
 
-		if err := os.WriteFile(fullPath, []byte(rendered), 0644); err != nil {
+		if err := p.writeFile(filepath.Join(serviceDir, outputPath), []byte(rendered)); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", outputPath, err)
 		}
 	}
 
-	// Update app.module.ts to import TerminusModule and HealthController
-	fmt.Println("🔧 Configuring health check module...")
-	if err := g.updateAppModule(serviceDir); err != nil {
-		return fmt.Errorf("failed to update app.module.ts: %w", err)
+	// Update the root module to import TerminusModule and HealthController.
+	// 'nest generate app' names it after the app.
+	appModulePath := filepath.Join(serviceDir, "src", "app.module.ts")
+	if monorepo {
+		appModulePath = filepath.Join(serviceDir, "src", serviceName+".module.ts")
+	}
+	if opts.DryRun {
+		p.update(appModulePath, "import TerminusModule and HealthController")
+	} else {
+		log.Info("🔧 Configuring health check module...")
+		if err := g.updateAppModule(appModulePath); err != nil {
+			return fmt.Errorf("failed to update %s: %w", filepath.Base(appModulePath), err)
+		}
 	}
 
 	// Register service in forge.json
 	project := workspace.Project{
 		ProjectType: "service",
 		Language:    "nestjs",
-		Root:        filepath.Join(servicesPath, serviceName),
+		Root:        filepath.ToSlash(serviceRoot),
 		Tags:        []string{"backend", "nestjs", "service"},
 		Architect: &workspace.Architect{
 			Build: &workspace.ArchitectTarget{
 				Builder: "@forge/bazel:build",
 				Options: map[string]interface{}{
 					"target":      ":image_tarball.tar",
-					"nodeVersion": "22.0.0",
+					"nodeVersion": toolVersions.Node,
 					"registry":    registry,
 					"dockerfile":  "Dockerfile",
 				},
@@ -225,7 +316,7 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 			Serve: &workspace.ArchitectTarget{
 				Builder: "@forge/nestjs:serve",
 				Options: map[string]interface{}{
-					"port": 3000,
+					"port": port,
 				},
 			},
 			Deploy: &workspace.ArchitectTarget{
@@ -257,120 +348,248 @@ func (g *NestJSServiceGenerator) Generate(ctx context.Context, opts GeneratorOpt
 		},
 	}
 
-	config.Projects[serviceName] = project
+	addDeployerOptions(deployerTarget, project.Architect.Deploy.Options, opts.Data)
+
+	if opts.DryRun {
+		p.update(filepath.Join(workspaceRoot, workspace.ConfigFileName), fmt.Sprintf("register project %q", serviceName))
+		p.print(fmt.Sprintf("NestJS service %q in %s", serviceName, p.rel(serviceDir)))
+		return nil
+	}
 
-	if err := config.SaveToDir(workspaceRoot); err != nil {
+	err = config.Update(workspaceRoot, func(config *workspace.Config) error {
+		config.Projects[serviceName] = project
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save workspace config: %w", err)
 	}
 
-	fmt.Printf("\n✓ Created NestJS service: %s\n", serviceName)
-	fmt.Printf("  Location: %s\n", serviceDir)
-	fmt.Printf("  Registry: %s\n", registry)
-	fmt.Printf("\nNext steps:\n")
-	fmt.Printf("  1. cd %s\n", filepath.Join(servicesPath, serviceName))
-	fmt.Printf("  2. npm install\n")
-	fmt.Printf("  3. npm run start:dev\n")
-	fmt.Printf("  4. forge deploy --env=local\n")
+	log.Info("\n✓ Created NestJS service: %s", serviceName)
+	log.Info("  Location: %s", serviceDir)
+	log.Info("  Registry: %s", registry)
+	log.Info("\nNext steps:")
+	log.Info("  1. cd %s && npm install", p.rel(installDir))
+	log.Info("  2. forge serve %s", serviceName)
+	log.Info("  3. forge deploy --env=local")
 
 	return nil
 }
 
-// runNestJSCLI executes NestJS CLI commands
-func (g *NestJSServiceGenerator) runNestJSCLI(workDir string, config *workspace.Config, args []string) error {
-	nestjsVersion := "10.4.9" // default
-	if config.Workspace.ToolVersions != nil && config.Workspace.ToolVersions.NestJS != "" {
-		nestjsVersion = config.Workspace.ToolVersions.NestJS
+// newNestProjectArgs returns the Nest CLI arguments creating a project.
+func newNestProjectArgs(name string) []string {
+	return []string{
+		"new", name,
+		"--package-manager", "npm",
+		"--skip-git",
+		"--strict",
+		"--skip-install", // Installed below so network failures can be retried
 	}
-	return g.runCommand(workDir, "npx", append([]string{fmt.Sprintf("@nestjs/cli@%s", nestjsVersion)}, args...)...)
 }
 
-// runNpmCommand executes npm commands
-func (g *NestJSServiceGenerator) runNpmCommand(workDir string, args []string) error {
-	return g.runCommand(workDir, "npm", args...)
-}
+// generateMonorepoApp adds a service as an app of the Nest monorepo in
+// nestDir with 'nest generate app', creating the monorepo first when the
+// services path has none. The app created by 'nest new' along with the
+// monorepo is replaced by the first service.
+func (g *NestJSServiceGenerator) generateMonorepoApp(ctx context.Context, p *plan, servicesDir, nestDir, serviceName string, config *workspace.Config) error {
+	appDir := filepath.Join(nestDir, "apps", serviceName)
 
-// runCommand executes a shell command
-func (g *NestJSServiceGenerator) runCommand(workDir, command string, args ...string) error {
-	cmd := exec.Command(command, args...)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	for name, project := range config.Projects {
+		if filepath.Join(p.root, project.Root) == nestDir {
+			return fmt.Errorf("%s is the root of project %s, not a Nest monorepo", p.rel(nestDir), name)
+		}
+	}
 
-	// Set environment variables to make CLI non-interactive
-	cmd.Env = append(os.Environ(),
-		"CI=true", // Treat as CI environment (non-interactive)
-	)
+	if !nestjsStepDone(nestDir, "package.json") {
+		p.info("🚀 Creating Nest monorepo: %s", p.rel(nestDir))
+		if err := g.runNestJSCLI(ctx, p, servicesDir, config, newNestProjectArgs(nestMonorepoDir)); err != nil {
+			return fmt.Errorf("failed to create the Nest monorepo: %w", err)
+		}
+	} else if workspace.IsNestMonorepo(nestDir) {
+		log.Info("✓ Using the Nest monorepo in %s", p.rel(nestDir))
+	}
 
-	fmt.Printf("  Running: %s %s\n", command, strings.Join(args, " "))
+	if nestjsStepDone(appDir, "src", "main.ts") {
+		log.Info("✓ NestJS app already generated")
+	} else {
+		p.info("🚀 Generating NestJS app: %s", serviceName)
+		if err := g.runNestJSCLI(ctx, p, nestDir, config, []string{"generate", "app", serviceName}); err != nil {
+			return fmt.Errorf("failed to generate NestJS app: %w", err)
+		}
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("command failed: %w", err)
+	if p.dryRun {
+		return nil
+	}
+	if err := replaceNestPlaceholderApp(nestDir, nestMonorepoDir, serviceName); err != nil {
+		return err
 	}
 
-	return nil
+	// Apps generated by 'nest generate app' read the lowercase port variable
+	return replaceInFile(filepath.Join(appDir, "src", "main.ts"), "process.env.port", "process.env.PORT")
 }
 
-// updateAppModule updates app.module.ts to import TerminusModule and HealthController
-func (g *NestJSServiceGenerator) updateAppModule(serviceDir string) error {
-	appModulePath := filepath.Join(serviceDir, "src", "app.module.ts")
+// replaceNestPlaceholderApp removes the app 'nest new' created in the
+// monorepo, which 'nest generate app' moved to apps/<placeholder>, and makes
+// app the default project of nest-cli.json instead. It does nothing once the
+// placeholder is gone.
+func replaceNestPlaceholderApp(nestDir, placeholder, app string) error {
+	nestCLIPath := filepath.Join(nestDir, workspace.NestCLIFileName)
+	content, err := os.ReadFile(nestCLIPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", workspace.NestCLIFileName, err)
+	}
+	var nestCLI map[string]interface{}
+	if err := json.Unmarshal(content, &nestCLI); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", workspace.NestCLIFileName, err)
+	}
 
-	// Read app.module.ts
-	data, err := os.ReadFile(appModulePath)
+	projects := jsonObject(nestCLI, "projects")
+	if _, ok := projects[placeholder]; !ok {
+		return nil
+	}
+	delete(projects, placeholder)
+
+	appRoot := "apps/" + app
+	nestCLI["root"] = appRoot
+	nestCLI["sourceRoot"] = appRoot + "/src"
+	jsonObject(nestCLI, "compilerOptions")["tsConfigPath"] = appRoot + "/tsconfig.app.json"
+
+	content, err = json.MarshalIndent(nestCLI, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to read app.module.ts: %w", err)
+		return err
+	}
+	if err := os.WriteFile(nestCLIPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", workspace.NestCLIFileName, err)
 	}
 
-	content := string(data)
-	lines := strings.Split(content, "\n")
+	// The e2e test script points at the default project
+	if err := replaceInFile(filepath.Join(nestDir, "package.json"), "apps/"+placeholder+"/", appRoot+"/"); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(nestDir, "apps", placeholder))
+}
 
-	// Find the last import statement
-	lastImportIdx := -1
-	for i, line := range lines {
-		if strings.HasPrefix(strings.TrimSpace(line), "import ") {
-			lastImportIdx = i
-		}
+// replaceInFile replaces every occurrence of old in a file, if any.
+func replaceInFile(path, old, new string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
 	}
+	if !strings.Contains(string(content), old) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(string(content), old, new)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
 
-	// Add imports after the last import statement
-	if lastImportIdx != -1 {
-		newImports := []string{}
+// writeMonorepoBuildFiles writes the BUILD.bazel of a monorepo app and, when
+// missing, the one at the monorepo root exporting its shared files.
+func (g *NestJSServiceGenerator) writeMonorepoBuildFiles(p *plan, workspaceRoot, nestDir, appDir, app, workspaceName string) error {
+	nestRoot, err := filepath.Rel(workspaceRoot, nestDir)
+	if err != nil {
+		return err
+	}
+	appRoot, err := filepath.Rel(workspaceRoot, appDir)
+	if err != nil {
+		return err
+	}
+	data := map[string]interface{}{
+		"WorkspaceName": workspaceName,
+		"ServiceName":   app,
+		"AppName":       app,
+		"PackagePath":   filepath.ToSlash(appRoot),
+		"NestRoot":      filepath.ToSlash(nestRoot),
+		"AppPath":       "apps/" + app,
+	}
 
-		// Add TerminusModule import if not present
-		if !strings.Contains(content, "@nestjs/terminus") {
-			newImports = append(newImports, "import { TerminusModule } from '@nestjs/terminus';")
+	files := map[string]string{
+		filepath.Join(appDir, "BUILD.bazel"): "bazel/nestjs-app.BUILD.bazel.tmpl",
+	}
+	if !nestjsStepDone(nestDir, "BUILD.bazel") {
+		files[filepath.Join(nestDir, "BUILD.bazel")] = "bazel/nestjs-monorepo.BUILD.bazel.tmpl"
+	}
+	for path, templatePath := range files {
+		content, err := g.engine.RenderTemplate(templatePath, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", p.rel(path), err)
 		}
-
-		// Add HealthController import if not present
-		if !strings.Contains(content, "./health/health.controller") {
-			newImports = append(newImports, "import { HealthController } from './health/health.controller';")
+		if err := p.writeFile(path, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", p.rel(path), err)
 		}
+	}
+	return nil
+}
 
-		if len(newImports) > 0 {
-			lines = append(lines[:lastImportIdx+1], append(newImports, lines[lastImportIdx+1:]...)...)
-			content = strings.Join(lines, "\n")
-		}
+// nestjsStepDone reports whether a file left by a completed generation step
+// exists in the service directory.
+func nestjsStepDone(serviceDir string, elem ...string) bool {
+	_, err := os.Stat(filepath.Join(append([]string{serviceDir}, elem...)...))
+	return err == nil
+}
+
+// runNestJSCLI executes NestJS CLI commands
+func (g *NestJSServiceGenerator) runNestJSCLI(ctx context.Context, p *plan, workDir string, config *workspace.Config, args []string) error {
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
 	}
+	return g.runCommand(ctx, p, workDir, "npx", append([]string{fmt.Sprintf("@nestjs/cli@%s", toolVersions.NestJS)}, args...)...)
+}
 
-	// Add TerminusModule to imports array if not already there
-	// Check if TerminusModule is imported but not in the imports array
-	if strings.Contains(content, "import { TerminusModule }") && !strings.Contains(content, "imports: [TerminusModule") {
-		// Check if imports array is empty or has items
-		if strings.Contains(content, "imports: []") {
-			content = strings.Replace(content, "imports: []", "imports: [TerminusModule]", 1)
-		} else {
-			content = strings.Replace(content, "imports: [", "imports: [TerminusModule, ", 1)
-		}
+// runNpmCommand executes npm commands
+func (g *NestJSServiceGenerator) runNpmCommand(ctx context.Context, p *plan, workDir string, args []string) error {
+	return g.runCommand(ctx, p, workDir, "npm", args...)
+}
+
+// runCommand executes a shell command, retrying transient network failures.
+// Dry runs only record it.
+func (g *NestJSServiceGenerator) runCommand(ctx context.Context, p *plan, workDir, command string, args ...string) error {
+	if p.command(workDir, command, args...) {
+		return nil
 	}
 
-	// Add HealthController to controllers array if not already there
-	if strings.Contains(content, "controllers: [") && strings.Contains(content, "HealthController") && !strings.Contains(content, "controllers: [HealthController") && !strings.Contains(content, "controllers: [AppController, HealthController") {
-		content = strings.Replace(content, "controllers: [AppController", "controllers: [AppController, HealthController", 1)
+	log.Info("  Running: %s %s", command, strings.Join(args, " "))
+
+	err := runWithRetry(ctx, exec.Options{
+		Name:  command,
+		Args:  args,
+		Dir:   workDir,
+		Stdin: os.Stdin,
+		// Set environment variables to make CLI non-interactive
+		Env: []string{
+			"CI=true", // Treat as CI environment (non-interactive)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	return nil
+}
+
+// updateAppModule updates the root module of a service to import
+// TerminusModule and HealthController. A module it cannot edit safely is left
+// alone and the snippet to add is printed instead.
+func (g *NestJSServiceGenerator) updateAppModule(appModulePath string) error {
+	data, err := os.ReadFile(appModulePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(appModulePath), err)
+	}
+
+	content, err := addHealthCheckToModule(string(data))
+	if err != nil {
+		log.Warn("⚠️  Could not add the health check to %s: %v", appModulePath, err)
+		log.Warn("   Add it by hand:\n\n%s\n", healthModuleSnippet)
+		return nil
+	}
+	if content == string(data) {
+		return nil
 	}
 
-	// Write updated app.module.ts
 	if err := os.WriteFile(appModulePath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write app.module.ts: %w", err)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(appModulePath), err)
 	}
 
 	return nil
@@ -0,0 +1,300 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// healthModuleSnippet is printed when app.module.ts cannot be edited safely,
+// so the health check can be wired up by hand.
+const healthModuleSnippet = `import { TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({
+  imports: [/* existing modules */, TerminusModule],
+  controllers: [/* existing controllers */, HealthController],
+})`
+
+// importDeclaration matches a complete ES import, including imports spread
+// over several lines and side-effect imports.
+var importDeclaration = regexp.MustCompile(`(?m)^import\s+(?:[^;'"]*?\s+from\s+)?['"][^'"\n]+['"]\s*;?`)
+
+// addHealthCheckToModule imports TerminusModule and HealthController in the
+// source of an app.module.ts and adds them to the imports and controllers of
+// its @Module decorator. It fails instead of guessing when the module does not
+// have the expected shape, e.g. two decorators or an imports array built
+// elsewhere.
+func addHealthCheckToModule(src string) (string, error) {
+	var err error
+	if src, err = addModuleProperty(src, "imports", "TerminusModule"); err != nil {
+		return "", err
+	}
+	if src, err = addModuleProperty(src, "controllers", "HealthController"); err != nil {
+		return "", err
+	}
+
+	src = addImport(src, "TerminusModule", "@nestjs/terminus")
+	src = addImport(src, "HealthController", "./health/health.controller")
+
+	if !balanced(src) {
+		return "", fmt.Errorf("the edited module has unbalanced brackets")
+	}
+	return src, nil
+}
+
+// addImport adds an import of name from module after the last import
+// declaration, unless name is already imported.
+func addImport(src, name, module string) string {
+	declarations := importDeclaration.FindAllStringIndex(src, -1)
+	identifier := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	for _, loc := range declarations {
+		if identifier.MatchString(src[loc[0]:loc[1]]) {
+			return src
+		}
+	}
+
+	line := fmt.Sprintf("import { %s } from '%s';", name, module)
+	if len(declarations) == 0 {
+		return line + "\n" + src
+	}
+	end := declarations[len(declarations)-1][1]
+	return src[:end] + "\n" + line + src[end:]
+}
+
+// addModuleProperty adds element to the array of the given @Module property,
+// creating the property when the decorator does not have it yet.
+func addModuleProperty(src, property, element string) (string, error) {
+	objectStart, objectEnd, err := findModuleObject(src)
+	if err != nil {
+		return "", err
+	}
+
+	valueStart := findProperty(src, objectStart, objectEnd, property)
+	if valueStart == -1 {
+		return insertProperty(src, objectStart, objectEnd, fmt.Sprintf("%s: [%s]", property, element)), nil
+	}
+
+	open := skipSpace(src, valueStart)
+	if open >= len(src) || src[open] != '[' {
+		return "", fmt.Errorf("%s of @Module is not an array literal", property)
+	}
+	closing := matchBracket(src, open)
+	if closing == -1 {
+		return "", fmt.Errorf("%s of @Module is not closed", property)
+	}
+
+	if regexp.MustCompile(`\b` + regexp.QuoteMeta(element) + `\b`).MatchString(src[open+1 : closing]) {
+		return src, nil
+	}
+	return appendElement(src, open, closing, element)
+}
+
+// findModuleObject returns the braces of the object passed to the only
+// @Module decorator in src.
+func findModuleObject(src string) (int, int, error) {
+	switch strings.Count(src, "@Module(") {
+	case 0:
+		return 0, 0, fmt.Errorf("no @Module decorator found")
+	case 1:
+	default:
+		return 0, 0, fmt.Errorf("more than one @Module decorator found")
+	}
+
+	start := skipSpace(src, strings.Index(src, "@Module(")+len("@Module("))
+	if start >= len(src) || src[start] != '{' {
+		return 0, 0, fmt.Errorf("@Module is not passed an object literal")
+	}
+	end := matchBracket(src, start)
+	if end == -1 {
+		return 0, 0, fmt.Errorf("@Module object is not closed")
+	}
+	return start, end, nil
+}
+
+// findProperty returns the offset just after "name:" among the top-level
+// properties of the object between start and end, or -1.
+func findProperty(src string, start, end int, name string) int {
+	for i := start + 1; i < end; {
+		if next := skipNonCode(src, i); next != i {
+			i = next
+			continue
+		}
+		switch c := src[i]; {
+		case c == '{' || c == '[' || c == '(':
+			closing := matchBracket(src, i)
+			if closing == -1 {
+				return -1
+			}
+			i = closing + 1
+		case strings.HasPrefix(src[i:], name) && (i == 0 || !isIdentByte(src[i-1])):
+			after := skipSpace(src, i+len(name))
+			if after < end && src[after] == ':' {
+				return after + 1
+			}
+			i += len(name)
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// insertProperty adds property as the first entry of the object between
+// start and end, following the indentation of the existing entries.
+func insertProperty(src string, start, end int, property string) string {
+	inner := src[start+1 : end]
+	if strings.TrimSpace(inner) == "" {
+		indent := lineIndent(src, start)
+		return src[:start+1] + "\n" + indent + "  " + property + ",\n" + indent + src[end:]
+	}
+	if !strings.Contains(inner, "\n") {
+		return src[:start+1] + " " + property + "," + src[start+1:]
+	}
+
+	first := skipSpace(src, start+1)
+	return src[:start+1] + "\n" + lineIndent(src, first) + property + "," + src[start+1:]
+}
+
+// appendElement adds element as the last entry of the array between open
+// and closing, keeping its layout: one entry per line or all on one line.
+func appendElement(src string, open, closing int, element string) (string, error) {
+	inner := src[open+1 : closing]
+	trimmed := strings.TrimRight(inner, " \t\r\n")
+	if trimmed == "" {
+		return src[:open+1] + element + src[closing:], nil
+	}
+
+	last := open + 1 + len(trimmed)
+	lastLine := trimmed[strings.LastIndex(trimmed, "\n")+1:]
+	if strings.Contains(lastLine, "//") || strings.HasSuffix(trimmed, "*/") {
+		return "", fmt.Errorf("the array ends with a comment")
+	}
+	trailingComma := strings.HasSuffix(trimmed, ",")
+
+	if !strings.Contains(inner, "\n") {
+		if trailingComma {
+			return src[:last] + " " + element + src[last:], nil
+		}
+		return src[:last] + ", " + element + src[last:], nil
+	}
+
+	indent := lineIndent(src, skipSpace(src, open+1))
+	if trailingComma {
+		return src[:last] + "\n" + indent + element + "," + src[last:], nil
+	}
+	return src[:last] + ",\n" + indent + element + src[last:], nil
+}
+
+// matchBracket returns the offset of the bracket closing the one at open,
+// ignoring brackets in strings and comments, or -1.
+func matchBracket(src string, open int) int {
+	var stack []byte
+	for i := open; i < len(src); {
+		if next := skipNonCode(src, i); next != i {
+			i = next
+			continue
+		}
+		switch c := src[i]; c {
+		case '(', '[', '{':
+			stack = append(stack, c)
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != openingBracket(c) {
+				return -1
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return -1
+}
+
+// balanced reports whether every bracket in src outside strings and comments
+// is closed by a matching one.
+func balanced(src string) bool {
+	var stack []byte
+	for i := 0; i < len(src); {
+		if next := skipNonCode(src, i); next != i {
+			if next > len(src) {
+				return false
+			}
+			i = next
+			continue
+		}
+		switch c := src[i]; c {
+		case '(', '[', '{':
+			stack = append(stack, c)
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != openingBracket(c) {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		}
+		i++
+	}
+	return len(stack) == 0
+}
+
+// skipNonCode returns the offset after the string literal or comment that
+// starts at i, or i when there is none. An unterminated literal or comment
+// runs to len(src)+1.
+func skipNonCode(src string, i int) int {
+	switch {
+	case strings.HasPrefix(src[i:], "//"):
+		if end := strings.IndexByte(src[i:], '\n'); end != -1 {
+			return i + end
+		}
+		return len(src)
+	case strings.HasPrefix(src[i:], "/*"):
+		if end := strings.Index(src[i+2:], "*/"); end != -1 {
+			return i + 2 + end + 2
+		}
+		return len(src) + 1
+	case src[i] == '\'' || src[i] == '"' || src[i] == '`':
+		quote := src[i]
+		for j := i + 1; j < len(src); j++ {
+			switch src[j] {
+			case '\\':
+				j++
+			case quote:
+				return j + 1
+			}
+		}
+		return len(src) + 1
+	}
+	return i
+}
+
+func openingBracket(c byte) byte {
+	switch c {
+	case ')':
+		return '('
+	case ']':
+		return '['
+	}
+	return '{'
+}
+
+func skipSpace(src string, i int) int {
+	for i < len(src) && strings.IndexByte(" \t\r\n", src[i]) != -1 {
+		i++
+	}
+	return i
+}
+
+// lineIndent returns the leading whitespace of the line containing offset i.
+func lineIndent(src string, i int) string {
+	start := strings.LastIndexByte(src[:i], '\n') + 1
+	end := start
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	return src[start:end]
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,160 @@
+package generator
+
+import "testing"
+
+func TestAddHealthCheckToModule(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "nest new",
+			src: `import { Module } from '@nestjs/common';
+import { AppController } from './app.controller';
+import { AppService } from './app.service';
+
+@Module({
+  imports: [],
+  controllers: [AppController],
+  providers: [AppService],
+})
+export class AppModule {}
+`,
+			want: `import { Module } from '@nestjs/common';
+import { AppController } from './app.controller';
+import { AppService } from './app.service';
+import { TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({
+  imports: [TerminusModule],
+  controllers: [AppController, HealthController],
+  providers: [AppService],
+})
+export class AppModule {}
+`,
+		},
+		{
+			name: "multi-line arrays and imports",
+			src: `import {
+  Module,
+} from '@nestjs/common';
+import { ConfigModule } from '@nestjs/config';
+
+@Module({
+  providers: [{ provide: 'imports', useValue: [] }],
+  imports: [
+    ConfigModule.forRoot({ isGlobal: true }),
+    UsersModule,
+  ],
+  controllers: [
+    AppController
+  ],
+})
+export class AppModule {}
+`,
+			want: `import {
+  Module,
+} from '@nestjs/common';
+import { ConfigModule } from '@nestjs/config';
+import { TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({
+  providers: [{ provide: 'imports', useValue: [] }],
+  imports: [
+    ConfigModule.forRoot({ isGlobal: true }),
+    UsersModule,
+    TerminusModule,
+  ],
+  controllers: [
+    AppController,
+    HealthController
+  ],
+})
+export class AppModule {}
+`,
+		},
+		{
+			name: "already configured",
+			src: `import { Module } from '@nestjs/common';
+import { HealthCheckService, TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({ imports: [TerminusModule], controllers: [HealthController] })
+export class AppModule {}
+`,
+			want: `import { Module } from '@nestjs/common';
+import { HealthCheckService, TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({ imports: [TerminusModule], controllers: [HealthController] })
+export class AppModule {}
+`,
+		},
+		{
+			name: "missing properties",
+			src: `import { Module } from '@nestjs/common';
+
+@Module({})
+export class AppModule {}
+`,
+			want: `import { Module } from '@nestjs/common';
+import { TerminusModule } from '@nestjs/terminus';
+import { HealthController } from './health/health.controller';
+
+@Module({
+  controllers: [HealthController],
+  imports: [TerminusModule],
+})
+export class AppModule {}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := addHealthCheckToModule(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddHealthCheckToModuleRefusesToGuess(t *testing.T) {
+	tests := map[string]string{
+		"no decorator": "export class AppModule {}\n",
+		"two decorators": `@Module({ imports: [] })
+export class AppModule {}
+
+@Module({ imports: [] })
+export class OtherModule {}
+`,
+		"imports built elsewhere": `const modules = [UsersModule];
+
+@Module({ imports: modules, controllers: [] })
+export class AppModule {}
+`,
+		"array ends with a comment": `@Module({
+  imports: [],
+  controllers: [
+    AppController, // main
+  ],
+})
+export class AppModule {}
+`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got, err := addHealthCheckToModule(src); err == nil {
+				t.Errorf("edited the module instead of failing:\n%s", got)
+			}
+		})
+	}
+}
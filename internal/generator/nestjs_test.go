@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestReplaceNestPlaceholderApp(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A monorepo as 'nest generate app orders' leaves it after 'nest new nest'
+	write("nest-cli.json", `{
+  "$schema": "https://json.schemastore.org/nest-cli",
+  "collection": "@nestjs/schematics",
+  "sourceRoot": "apps/nest/src",
+  "compilerOptions": {
+    "deleteOutDir": true,
+    "webpack": true,
+    "tsConfigPath": "apps/nest/tsconfig.app.json"
+  },
+  "monorepo": true,
+  "root": "apps/nest",
+  "projects": {
+    "nest": {"type": "application", "root": "apps/nest", "sourceRoot": "apps/nest/src"},
+    "orders": {"type": "application", "root": "apps/orders", "sourceRoot": "apps/orders/src"}
+  }
+}`)
+	write("package.json", `{"scripts": {"test:e2e": "jest --config ./apps/nest/test/jest-e2e.json"}}`)
+	write("apps/nest/src/main.ts", "")
+	write("apps/orders/src/main.ts", "")
+
+	if err := replaceNestPlaceholderApp(dir, "nest", "orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "apps", "nest")); !os.IsNotExist(err) {
+		t.Error("the placeholder app was not removed")
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "nest-cli.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nestCLI struct {
+		Root            string                     `json:"root"`
+		SourceRoot      string                     `json:"sourceRoot"`
+		CompilerOptions map[string]interface{}     `json:"compilerOptions"`
+		Projects        map[string]json.RawMessage `json:"projects"`
+	}
+	if err := json.Unmarshal(content, &nestCLI); err != nil {
+		t.Fatal(err)
+	}
+	if nestCLI.Root != "apps/orders" || nestCLI.SourceRoot != "apps/orders/src" || nestCLI.CompilerOptions["tsConfigPath"] != "apps/orders/tsconfig.app.json" {
+		t.Errorf("default project = %s, %s, %v, want apps/orders", nestCLI.Root, nestCLI.SourceRoot, nestCLI.CompilerOptions["tsConfigPath"])
+	}
+	if nestCLI.CompilerOptions["webpack"] != true {
+		t.Error("compilerOptions.webpack was dropped")
+	}
+	if _, ok := nestCLI.Projects["nest"]; ok || len(nestCLI.Projects) != 1 {
+		t.Errorf("projects = %v, want only orders", nestCLI.Projects)
+	}
+
+	packageJSON, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(packageJSON), "./apps/orders/test/jest-e2e.json") {
+		t.Errorf("test:e2e still points at the placeholder: %s", packageJSON)
+	}
+	if !workspace.IsNestMonorepo(dir) {
+		t.Error("the workspace is no monorepo anymore")
+	}
+
+	// Later apps leave the monorepo alone
+	if err := replaceNestPlaceholderApp(dir, "nest", "billing"); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(dir, "nest-cli.json")); string(after) != string(content) {
+		t.Error("nest-cli.json changed without a placeholder app")
+	}
+}
+
+func TestRunNestJSCLIUsesLockedVersion(t *testing.T) {
+	var ran []exec.Options
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts)
+		return nil
+	}))()
+
+	dir := t.TempDir()
+	g := NewNestJSServiceGenerator()
+	config := workspace.NewConfig("shop")
+	config.Workspace.ToolVersions = &workspace.ToolVersions{
+		Go:      "1.24.3",
+		Node:    "22.12.0",
+		Bazel:   "7.4.1",
+		Angular: "21.0.2",
+		NestJS:  "11.0.7",
+	}
+
+	if err := g.runNestJSCLI(context.Background(), newPlan(dir, false), dir, config, []string{"new", "orders"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 1 || ran[0].Name != "npx" || len(ran[0].Args) == 0 || ran[0].Args[0] != "@nestjs/cli@11.0.7" {
+		t.Fatalf("ran %v, want npx @nestjs/cli@11.0.7", ran)
+	}
+
+	// Without a lock the CLI is not run with a default version
+	ran = nil
+	config.Workspace.ToolVersions = nil
+	if err := g.runNestJSCLI(context.Background(), newPlan(dir, false), dir, config, []string{"new", "orders"}); err == nil {
+		t.Error("runNestJSCLI() succeeded without locked tool versions")
+	}
+	if len(ran) != 0 {
+		t.Errorf("ran %v without locked tool versions", ran)
+	}
+}
@@ -0,0 +1,351 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPISpec is the subset of an OpenAPI 3 document used to scaffold a service.
+type OpenAPISpec struct {
+	OpenAPI    string                            `yaml:"openapi"`
+	Info       OpenAPIInfo                       `yaml:"info"`
+	Paths      map[string]map[string]interface{} `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*OpenAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// OpenAPIInfo contains the spec title and version.
+type OpenAPIInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// OpenAPIOperation is a single path + method operation.
+type OpenAPIOperation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Parameters  []OpenAPIParameter  `yaml:"parameters"`
+	RequestBody *OpenAPIRequestBody `yaml:"requestBody"`
+	Responses   map[string]struct {
+		Content map[string]OpenAPIMediaType `yaml:"content"`
+	} `yaml:"responses"`
+	Callbacks map[string]interface{} `yaml:"callbacks"`
+}
+
+// OpenAPIParameter is a path, query or header parameter.
+type OpenAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *OpenAPISchema `yaml:"schema"`
+	Ref      string         `yaml:"$ref"`
+}
+
+// OpenAPIRequestBody is an operation request body.
+type OpenAPIRequestBody struct {
+	Required bool                        `yaml:"required"`
+	Content  map[string]OpenAPIMediaType `yaml:"content"`
+}
+
+// OpenAPIMediaType holds the schema for a content type.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `yaml:"schema"`
+}
+
+// OpenAPISchema is the subset of JSON schema supported by the generator.
+type OpenAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Format     string                    `yaml:"format"`
+	Items      *OpenAPISchema            `yaml:"items"`
+	Properties map[string]*OpenAPISchema `yaml:"properties"`
+	Required   []string                  `yaml:"required"`
+	AllOf      []*OpenAPISchema          `yaml:"allOf"`
+	OneOf      []*OpenAPISchema          `yaml:"oneOf"`
+	AnyOf      []*OpenAPISchema          `yaml:"anyOf"`
+}
+
+// OpenAPIRoute is a parsed operation ready for template rendering.
+type OpenAPIRoute struct {
+	Method       string
+	MethodConst  string
+	Path         string
+	RoutePath    string
+	HandlerName  string
+	TypeName     string
+	Summary      string
+	Params       []OpenAPIField
+	BodyType     string
+	ResponseType string
+}
+
+// OpenAPIType is a named Go struct generated from components.schemas.
+type OpenAPIType struct {
+	Name   string
+	Fields []OpenAPIField
+}
+
+// OpenAPIField is a struct field with its JSON name and Go type.
+type OpenAPIField struct {
+	Name     string
+	JSONName string
+	Type     string
+	In       string
+	Required bool
+}
+
+// OpenAPIModel is the result of parsing a spec: routes, types and any
+// constructs the generator could not translate.
+type OpenAPIModel struct {
+	Title       string
+	Routes      []OpenAPIRoute
+	Types       []OpenAPIType
+	Unsupported []string
+	UsesTime    bool
+}
+
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+var openAPIPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// LoadOpenAPISpec reads and parses an OpenAPI 3 spec (YAML or JSON).
+func LoadOpenAPISpec(path string) (*OpenAPIModel, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q (only 3.x is supported)", spec.OpenAPI)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI spec has no paths")
+	}
+
+	return buildOpenAPIModel(&spec)
+}
+
+// buildOpenAPIModel converts a parsed spec into routes and types.
+func buildOpenAPIModel(spec *OpenAPISpec) (*OpenAPIModel, error) {
+	model := &OpenAPIModel{Title: spec.Info.Title}
+
+	schemaNames := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+
+	for _, name := range schemaNames {
+		schema := spec.Components.Schemas[name]
+		location := fmt.Sprintf("components.schemas.%s", name)
+		if schema == nil || (schema.Type != "" && schema.Type != "object") {
+			model.Unsupported = append(model.Unsupported, fmt.Sprintf("%s: only object schemas become types", location))
+			continue
+		}
+		model.Types = append(model.Types, OpenAPIType{
+			Name:   template.Pascalize(name),
+			Fields: model.fieldsFor(schema, location),
+		})
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	seenHandlers := make(map[string]bool)
+	for _, path := range paths {
+		for _, method := range openAPIMethods {
+			raw, ok := spec.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			var op OpenAPIOperation
+			node, err := yaml.Marshal(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if err := yaml.Unmarshal(node, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: invalid operation: %w", strings.ToUpper(method), path, err)
+			}
+
+			route, err := model.routeFor(method, path, &op)
+			if err != nil {
+				return nil, err
+			}
+			if seenHandlers[route.HandlerName] {
+				return nil, fmt.Errorf("%s %s: duplicate operation name %q", strings.ToUpper(method), path, route.HandlerName)
+			}
+			seenHandlers[route.HandlerName] = true
+			model.Routes = append(model.Routes, *route)
+		}
+	}
+
+	if len(model.Routes) == 0 {
+		return nil, fmt.Errorf("OpenAPI spec has no operations")
+	}
+
+	return model, nil
+}
+
+// routeFor builds a route for a single operation.
+func (m *OpenAPIModel) routeFor(method, path string, op *OpenAPIOperation) (*OpenAPIRoute, error) {
+	opContext := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+
+	name := op.OperationID
+	if name == "" {
+		name = method + " " + openAPIPathParam.ReplaceAllString(path, "by $1")
+	}
+	name = strings.NewReplacer("/", " ", "_", " ", ".", " ").Replace(name)
+	typeName := template.Pascalize(strings.Join(strings.Fields(name), "-"))
+	if typeName == "" {
+		return nil, fmt.Errorf("%s: cannot derive an operation name", opContext)
+	}
+
+	route := &OpenAPIRoute{
+		Method:      strings.ToUpper(method),
+		MethodConst: "http.Method" + template.Pascalize(method),
+		Path:        path,
+		RoutePath:   openAPIPathParam.ReplaceAllString(path, ":$1"),
+		HandlerName: strings.ToLower(typeName[:1]) + typeName[1:],
+		TypeName:    typeName,
+		Summary:     op.Summary,
+	}
+
+	for _, param := range op.Parameters {
+		if param.Ref != "" {
+			m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: parameter $ref %s", opContext, param.Ref))
+			continue
+		}
+		if param.In == "cookie" {
+			m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: cookie parameter %q", opContext, param.Name))
+			continue
+		}
+		route.Params = append(route.Params, OpenAPIField{
+			Name:     template.Pascalize(param.Name),
+			JSONName: param.Name,
+			Type:     m.goType(param.Schema, fmt.Sprintf("%s: parameter %q", opContext, param.Name)),
+			In:       param.In,
+			Required: param.Required || param.In == "path",
+		})
+	}
+
+	if op.RequestBody != nil {
+		route.BodyType = m.contentType(op.RequestBody.Content, opContext+": request body")
+	}
+
+	for _, code := range []string{"200", "201", "202"} {
+		if resp, ok := op.Responses[code]; ok {
+			route.ResponseType = m.contentType(resp.Content, fmt.Sprintf("%s: response %s", opContext, code))
+			break
+		}
+	}
+
+	if len(op.Callbacks) > 0 {
+		m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: callbacks", opContext))
+	}
+
+	return route, nil
+}
+
+// contentType returns the Go type of the application/json schema in a content map.
+func (m *OpenAPIModel) contentType(content map[string]OpenAPIMediaType, location string) string {
+	if len(content) == 0 {
+		return ""
+	}
+	media, ok := content["application/json"]
+	if !ok {
+		m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: only application/json content is supported", location))
+		return ""
+	}
+	return m.goType(media.Schema, location)
+}
+
+// fieldsFor returns struct fields for an object schema.
+func (m *OpenAPIModel) fieldsFor(schema *OpenAPISchema, location string) []OpenAPIField {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]OpenAPIField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, OpenAPIField{
+			Name:     template.Pascalize(name),
+			JSONName: name,
+			Type:     m.goType(schema.Properties[name], location+"."+name),
+			Required: required[name],
+		})
+	}
+	return fields
+}
+
+// goType maps a schema to a Go type, recording constructs it cannot express.
+func (m *OpenAPIModel) goType(schema *OpenAPISchema, location string) string {
+	if schema == nil {
+		return "string"
+	}
+	if schema.Ref != "" {
+		const prefix = "#/components/schemas/"
+		if !strings.HasPrefix(schema.Ref, prefix) {
+			m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: external $ref %s", location, schema.Ref))
+			return "interface{}"
+		}
+		return template.Pascalize(strings.TrimPrefix(schema.Ref, prefix))
+	}
+	if len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: allOf/oneOf/anyOf", location))
+		return "interface{}"
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			m.UsesTime = true
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + m.goType(schema.Items, location+"[]")
+	case "object", "":
+		if len(schema.Properties) > 0 {
+			m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: inline object schemas (move them to components.schemas)", location))
+		}
+		return "map[string]interface{}"
+	default:
+		m.Unsupported = append(m.Unsupported, fmt.Sprintf("%s: unknown type %q", location, schema.Type))
+		return "interface{}"
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+)
+
+const petsSpec = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      summary: Create a pet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          schema:
+            type: string
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        bornAt:
+          type: string
+          format: date-time
+`
+
+func TestOpenAPIHandlers(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(specPath, []byte(petsSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := LoadOpenAPISpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Unsupported) > 0 {
+		t.Errorf("Unsupported = %v, want none", model.Unsupported)
+	}
+
+	data := map[string]interface{}{
+		"ServiceNamePascal": "PetStore",
+		"ServiceNameCamel":  "petStore",
+		"OpenAPI":           model,
+	}
+	engine := template.NewEngine()
+	rendered := make(map[string]string)
+	for _, templatePath := range []string{"service/internal/transport_openapi.go.tmpl", "service/internal/api_types.go.tmpl"} {
+		content, err := engine.RenderTemplate(templatePath, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := format.Source([]byte(content)); err != nil {
+			t.Fatalf("%s is not valid Go: %v\n%s", templatePath, err, content)
+		}
+		rendered[templatePath] = content
+	}
+
+	transport := rendered["service/internal/transport_openapi.go.tmpl"]
+	for _, want := range []string{
+		`rest.NewEndpoint(http.MethodPost, "/pets", c.createPet)`,
+		`rest.NewEndpoint(http.MethodGet, "/pets/:petId", c.getPetsByPetId)`,
+		"func (c *petStoreAPIController) createPet(w http.ResponseWriter, r *http.Request)",
+		"func (c *petStoreAPIController) getPetsByPetId(w http.ResponseWriter, r *http.Request)",
+	} {
+		if !strings.Contains(transport, want) {
+			t.Errorf("transport has no %q:\n%s", want, transport)
+		}
+	}
+
+	types := rendered["service/internal/api_types.go.tmpl"]
+	for _, want := range []string{
+		"type Pet struct",
+		"type CreatePetRequest struct",
+		"Body Pet",
+		"type CreatePetResponse = Pet",
+		"type GetPetsByPetIdRequest struct",
+		"PetId string",
+		`import "time"`,
+	} {
+		if !strings.Contains(types, want) {
+			t.Errorf("types have no %q:\n%s", want, types)
+		}
+	}
+}
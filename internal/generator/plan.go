@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+)
+
+// plan carries out the file writes and commands of a generator. In dry-run
+// mode it records them instead, so the whole plan can be printed without
+// touching the workspace or running external CLIs like ng new or nest new.
+type plan struct {
+	dryRun bool
+
+	// root is the workspace root; paths are printed relative to it
+	root string
+
+	files    []plannedFile
+	commands []string
+	updates  []string
+}
+
+// plannedFile is a file a dry run would write.
+type plannedFile struct {
+	path   string
+	size   int
+	exists bool
+}
+
+// newPlan returns a plan for a generator run in root.
+func newPlan(root string, dryRun bool) *plan {
+	return &plan{root: root, dryRun: dryRun}
+}
+
+// mkdirAll creates a directory and its parents. Dry runs create nothing.
+func (p *plan) mkdirAll(path string) error {
+	if p.dryRun {
+		return nil
+	}
+	return os.MkdirAll(path, 0755)
+}
+
+// writeFile writes a generated file, creating its parent directories.
+func (p *plan) writeFile(path string, content []byte) error {
+	if p.dryRun {
+		_, err := os.Stat(path)
+		p.files = append(p.files, plannedFile{path: p.rel(path), size: len(content), exists: err == nil})
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// command records an external command in dry-run mode. It reports whether it
+// did, in which case the caller must not run the command.
+func (p *plan) command(dir, name string, args ...string) bool {
+	if !p.dryRun {
+		return false
+	}
+	p.commands = append(p.commands, fmt.Sprintf("(in %s) %s %s", p.rel(dir), name, strings.Join(args, " ")))
+	return true
+}
+
+// update records a change a dry run would make to an existing file that is
+// edited in place rather than rendered, such as forge.json.
+func (p *plan) update(path, change string) {
+	p.updates = append(p.updates, fmt.Sprintf("%s: %s", p.rel(path), change))
+}
+
+// info logs generator progress. Dry runs make none, so they stay quiet until
+// the plan is printed.
+func (p *plan) info(format string, args ...interface{}) {
+	if !p.dryRun {
+		log.Info(format, args...)
+	}
+}
+
+// print shows the recorded plan.
+func (p *plan) print(what string) {
+	log.Info("📋 Dry run: %s", what)
+
+	if len(p.commands) > 0 {
+		log.Info("\nCommands that would run (their output cannot be previewed):")
+		for _, command := range p.commands {
+			log.Info("  $ %s", command)
+		}
+	}
+
+	if len(p.files) > 0 {
+		sort.Slice(p.files, func(i, j int) bool {
+			return p.files[i].path < p.files[j].path
+		})
+		total := 0
+		log.Info("\nFiles that would be written (+ new, ~ overwritten):")
+		for _, file := range p.files {
+			marker := "+"
+			if file.exists {
+				marker = "~"
+			}
+			log.Info("  %s %s (%d bytes)", marker, file.path, file.size)
+			total += file.size
+		}
+		log.Info("  %d files, %d bytes", len(p.files), total)
+	}
+
+	if len(p.updates) > 0 {
+		log.Info("\nFiles that would be updated:")
+		for _, update := range p.updates {
+			log.Info("  ~ %s", update)
+		}
+	}
+
+	log.Info("\n💡 Run without --dry-run to generate")
+}
+
+// rel returns path relative to the workspace root when it is inside it.
+func (p *plan) rel(path string) string {
+	root, err := filepath.Abs(p.root)
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
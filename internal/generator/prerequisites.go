@@ -6,6 +6,8 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
 )
 
 // PrerequisiteError represents a missing or incompatible prerequisite.
@@ -71,7 +73,7 @@ Please upgrade Node.js: %s
 
 	// Warn if <18 but continue
 	if majorVersion < 18 {
-		fmt.Printf("⚠️  Warning: Node.js %s detected. Node.js 18+ is recommended for best compatibility.\n", version)
+		log.Warn("⚠️  Warning: Node.js %s detected. Node.js 18+ is recommended for best compatibility.", version)
 	}
 
 	return nil
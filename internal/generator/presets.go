@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+)
+
+// presetsDir is the directory of the embedded workspace presets.
+const presetsDir = "presets"
+
+// Preset is a named starting point for forge new: the services and frontends
+// a workspace is created with instead of prompting for them.
+type Preset struct {
+	Name        string           `json:"-"`
+	Description string           `json:"description"`
+	Services    []PresetService  `json:"services"`
+	Frontends   []PresetFrontend `json:"frontends"`
+}
+
+// PresetService is a backend service of a preset.
+type PresetService struct {
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	Deployer       string                 `json:"deployer"`
+	DeployerConfig map[string]string      `json:"deployerConfig"`
+	Options        map[string]interface{} `json:"options"`
+}
+
+// PresetFrontend is a frontend application of a preset.
+type PresetFrontend struct {
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	Deployer       string            `json:"deployer"`
+	DeployerConfig map[string]string `json:"deployerConfig"`
+}
+
+// LoadPreset reads the preset with the given name from templates/presets,
+// honoring template overrides.
+func LoadPreset(name string) (*Preset, error) {
+	content, err := template.NewEngine().ReadEmbeddedFile(presetsDir + "/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(ListPresets(), ", "))
+	}
+
+	var preset Preset
+	if err := json.Unmarshal(content, &preset); err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	preset.Name = name
+	return &preset, nil
+}
+
+// ListPresets returns the names of the embedded presets, sorted.
+func ListPresets() []string {
+	entries, err := fs.ReadDir(template.TemplatesFS, "templates/"+presetsDir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ServicesData returns the preset's services in the form the workspace
+// generator expects in GeneratorOptions.Data["services"].
+func (p *Preset) ServicesData() []interface{} {
+	services := []interface{}{}
+	for _, svc := range p.Services {
+		deployerConfig := svc.DeployerConfig
+		if deployerConfig == nil {
+			deployerConfig = map[string]string{}
+		}
+		service := map[string]interface{}{
+			"Name":           svc.Name,
+			"Type":           svc.Type,
+			"Deployer":       svc.Deployer,
+			"DeployerConfig": deployerConfig,
+		}
+		if len(svc.Options) > 0 {
+			service["Options"] = svc.Options
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// FrontendsData returns the preset's frontends in the form the workspace
+// generator expects in GeneratorOptions.Data["frontends"].
+func (p *Preset) FrontendsData() []interface{} {
+	frontends := []interface{}{}
+	for _, app := range p.Frontends {
+		deployerConfig := app.DeployerConfig
+		if deployerConfig == nil {
+			deployerConfig = map[string]string{}
+		}
+		frontends = append(frontends, map[string]interface{}{
+			"Name":           app.Name,
+			"Type":           app.Type,
+			"Deployment":     app.Deployer,
+			"DeployerConfig": deployerConfig,
+		})
+	}
+	return frontends
+}
@@ -4,6 +4,10 @@ package generator
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
 // Generator defines the interface for all generators.
@@ -33,51 +37,92 @@ type GeneratorOptions struct {
 	DryRun bool
 }
 
-// Registry manages available generators.
+// Entry describes a generator registered for a project kind and language.
+type Entry struct {
+	// Kind is the kind of project the generator creates.
+	Kind workspace.ProjectKind
+
+	// Language is the language or framework of the generated project.
+	Language workspace.LanguageType
+
+	// Label is the display name used in interactive prompts (e.g., "NestJS").
+	Label string
+
+	// Generator is the generator instance.
+	Generator Generator
+}
+
+// registryKey identifies a generator by project kind and language.
+type registryKey struct {
+	kind     workspace.ProjectKind
+	language workspace.LanguageType
+}
+
+// Registry manages available generators keyed by (kind, language).
 type Registry struct {
-	generators map[string]Generator
+	generators map[registryKey]Entry
 }
 
 // NewRegistry creates a new generator registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		generators: make(map[string]Generator),
+		generators: make(map[registryKey]Entry),
 	}
 }
 
-// Register adds a generator to the registry.
-func (r *Registry) Register(generator Generator) error {
-	name := generator.Name()
-	if _, exists := r.generators[name]; exists {
-		return fmt.Errorf("generator %q already registered", name)
+// Register adds a generator for a project kind and language.
+func (r *Registry) Register(kind workspace.ProjectKind, language workspace.LanguageType, label string, generator Generator) error {
+	key := registryKey{kind: kind, language: language}
+	if _, exists := r.generators[key]; exists {
+		return fmt.Errorf("generator for %s %q already registered", kind, language)
 	}
 
-	r.generators[name] = generator
+	r.generators[key] = Entry{
+		Kind:      kind,
+		Language:  language,
+		Label:     label,
+		Generator: generator,
+	}
 	return nil
 }
 
-// Get retrieves a generator by name.
-func (r *Registry) Get(name string) (Generator, error) {
-	generator, exists := r.generators[name]
+// Resolve finds the generator for a project kind and language.
+func (r *Registry) Resolve(kind workspace.ProjectKind, language workspace.LanguageType) (Generator, error) {
+	entry, exists := r.generators[registryKey{kind: kind, language: language}]
 	if !exists {
-		return nil, fmt.Errorf("generator %q not found", name)
+		return nil, fmt.Errorf("unsupported %s language: %s (supported: %s)", kind, language, strings.Join(r.Languages(kind), ", "))
 	}
 
-	return generator, nil
+	return entry.Generator, nil
+}
+
+// List returns the generators registered for a project kind, sorted by label.
+func (r *Registry) List(kind workspace.ProjectKind) []Entry {
+	entries := make([]Entry, 0, len(r.generators))
+	for key, entry := range r.generators {
+		if key.kind == kind {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Label < entries[j].Label
+	})
+	return entries
 }
 
-// List returns all registered generator names.
-func (r *Registry) List() []string {
-	names := make([]string, 0, len(r.generators))
-	for name := range r.generators {
-		names = append(names, name)
+// Languages returns the languages registered for a project kind.
+func (r *Registry) Languages(kind workspace.ProjectKind) []string {
+	entries := r.List(kind)
+	languages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		languages = append(languages, string(entry.Language))
 	}
-	return names
+	return languages
 }
 
-// Has checks if a generator is registered.
-func (r *Registry) Has(name string) bool {
-	_, exists := r.generators[name]
+// Has checks if a generator is registered for a project kind and language.
+func (r *Registry) Has(kind workspace.ProjectKind, language workspace.LanguageType) bool {
+	_, exists := r.generators[registryKey{kind: kind, language: language}]
 	return exists
 }
 
@@ -85,16 +130,16 @@ func (r *Registry) Has(name string) bool {
 var DefaultRegistry = NewRegistry()
 
 // Register registers a generator in the default registry.
-func Register(generator Generator) error {
-	return DefaultRegistry.Register(generator)
+func Register(kind workspace.ProjectKind, language workspace.LanguageType, label string, generator Generator) error {
+	return DefaultRegistry.Register(kind, language, label, generator)
 }
 
-// Get retrieves a generator from the default registry.
-func Get(name string) (Generator, error) {
-	return DefaultRegistry.Get(name)
+// Resolve finds a generator in the default registry.
+func Resolve(kind workspace.ProjectKind, language workspace.LanguageType) (Generator, error) {
+	return DefaultRegistry.Resolve(kind, language)
 }
 
-// List returns all generators in the default registry.
-func List() []string {
-	return DefaultRegistry.List()
+// List returns the generators in the default registry for a project kind.
+func List(kind workspace.ProjectKind) []Entry {
+	return DefaultRegistry.List(kind)
 }
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
+)
+
+// commandAttempts is how many times an external command runs before a
+// transient network or registry failure is reported.
+const commandAttempts = 3
+
+// commandTimeout bounds a single attempt, so a CLI stuck on the network or
+// waiting for input is killed instead of hanging forge.
+const commandTimeout = 10 * time.Minute
+
+// commandRetryDelay is the wait before the first retry. It doubles after each attempt.
+var commandRetryDelay = 2 * time.Second
+
+// transientErrorPatterns match npm/npx output for failures caused by the network
+// or the package registry rather than by the command itself.
+var transientErrorPatterns = []string{
+	"ETIMEDOUT",
+	"ECONNRESET",
+	"ECONNREFUSED",
+	"EAI_AGAIN",
+	"ENOTFOUND",
+	"ENETUNREACH",
+	"EHOSTUNREACH",
+	"ERR_SOCKET_TIMEOUT",
+	"socket hang up",
+	"network timeout",
+	"fetch failed",
+	"E429",
+	"E500",
+	"E502",
+	"E503",
+	"E504",
+}
+
+// runWithRetry runs the command described by opts, retrying with exponential
+// backoff while it fails with a transient network error. Output is streamed as
+// usual and captured to tell network failures from real ones. Each attempt is
+// killed after commandTimeout or when ctx is cancelled. Only idempotent
+// commands should be retried: scaffolding CLIs run with their install step
+// skipped, so a retry never finds a half-created project.
+func runWithRetry(ctx context.Context, opts exec.Options) error {
+	if opts.Timeout == 0 {
+		opts.Timeout = commandTimeout
+	}
+
+	delay := commandRetryDelay
+	for attempt := 1; ; attempt++ {
+		var output bytes.Buffer
+		opts.Stdout = io.MultiWriter(os.Stdout, &output)
+		opts.Stderr = io.MultiWriter(os.Stderr, &output)
+
+		err := exec.Run(ctx, opts)
+		if err == nil {
+			return nil
+		}
+		if attempt >= commandAttempts || ctx.Err() != nil || !isTransientFailure(output.String()) {
+			return err
+		}
+
+		log.Warn("  ⚠️  Network error, retrying in %s (attempt %d/%d)", delay, attempt+1, commandAttempts)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// isTransientFailure reports whether command output points at a network or registry error.
+func isTransientFailure(output string) bool {
+	for _, pattern := range transientErrorPatterns {
+		if strings.Contains(output, pattern) {
+			return true
+		}
+	}
+	return false
+}
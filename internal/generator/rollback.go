@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"github.com/dosanma1/forge-cli/pkg/xos"
+)
+
+// rollback undoes what a generator created when it fails midway, so the
+// command can simply be rerun instead of failing with "already exists".
+type rollback struct {
+	// paths created by the generator, removed in reverse order
+	paths []string
+
+	// forge.json content before the generator ran
+	configPath     string
+	configSnapshot []byte
+
+	// keep disables the rollback to inspect a failed generation (--keep-on-failure)
+	keep bool
+}
+
+// newRollback snapshots forge.json in workspaceRoot before a generator changes it.
+func newRollback(workspaceRoot string, opts GeneratorOptions) (*rollback, error) {
+	r := &rollback{
+		configPath: filepath.Join(workspaceRoot, workspace.ConfigFileName),
+	}
+	r.keep, _ = opts.Data["keepOnFailure"].(bool)
+
+	snapshot, err := os.ReadFile(r.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", workspace.ConfigFileName, err)
+	}
+	r.configSnapshot = snapshot
+
+	return r, nil
+}
+
+// track records a path the generator is about to create. Paths that already
+// exist belong to the user and are never removed.
+func (r *rollback) track(path string) {
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	r.paths = append(r.paths, path)
+}
+
+// untrack keeps paths that were tracked, for generation steps that --resume
+// can continue from instead of starting over.
+func (r *rollback) untrack(paths ...string) {
+	kept := r.paths[:0]
+	for _, tracked := range r.paths {
+		if !containsPath(paths, tracked) {
+			kept = append(kept, tracked)
+		}
+	}
+	r.paths = kept
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// trackDirs tracks every directory of a path relative to root, so the
+// directories created for a nested path like "apps/web" are all removed.
+func (r *rollback) trackDirs(root, rel string) {
+	dir := root
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		dir = filepath.Join(dir, part)
+		r.track(dir)
+	}
+}
+
+// restoreOnError is deferred by generators with their named error result. On
+// failure it removes the tracked paths and restores forge.json.
+func (r *rollback) restoreOnError(err *error) {
+	if *err == nil {
+		return
+	}
+
+	if r.keep {
+		log.Warn("\n⚠️  Keeping partially generated files (--keep-on-failure):")
+		for _, path := range r.paths {
+			log.Warn("   %s", path)
+		}
+		return
+	}
+
+	log.Info("\n🔄 Rolling back partially generated files...")
+	for i := len(r.paths) - 1; i >= 0; i-- {
+		if rmErr := os.RemoveAll(r.paths[i]); rmErr != nil {
+			log.Warn("⚠️  Failed to remove %s: %v", r.paths[i], rmErr)
+			continue
+		}
+		log.Info("  ✓ Removed %s", r.paths[i])
+	}
+
+	current, readErr := os.ReadFile(r.configPath)
+	if readErr == nil && string(current) == string(r.configSnapshot) {
+		return
+	}
+	// The changed forge.json is kept as forge.json.bak to inspect the failure
+	if writeErr := xos.WriteFileWithBackup(r.configPath, r.configSnapshot, 0644); writeErr != nil {
+		log.Warn("⚠️  Failed to restore %s: %v", workspace.ConfigFileName, writeErr)
+		return
+	}
+	log.Info("  ✓ Restored %s (changes kept in %s.bak)", workspace.ConfigFileName, workspace.ConfigFileName)
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestRollbackRestoreOnError(t *testing.T) {
+	root := t.TempDir()
+	if err := workspace.NewConfig("shop").Save(root); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(root, workspace.ConfigFileName)
+	original, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	undo, err := newRollback(root, GeneratorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serviceDir := filepath.Join(root, "backend", "services", "orders")
+	undo.track(serviceDir)
+
+	// The generator created its directory and registered the project, then failed
+	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	changed := []byte(`{"version": "1", "projects": {"orders": {}}}`)
+	if err := os.WriteFile(configPath, changed, 0644); err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("npm install failed")
+	undo.restoreOnError(&failure)
+
+	if _, err := os.Stat(serviceDir); !os.IsNotExist(err) {
+		t.Error("the service directory was not removed")
+	}
+	if restored, _ := os.ReadFile(configPath); string(restored) != string(original) {
+		t.Errorf("forge.json = %s, want the snapshot", restored)
+	}
+	if backup, _ := os.ReadFile(configPath + ".bak"); string(backup) != string(changed) {
+		t.Errorf("forge.json.bak = %s, want the forge.json of the failed generation", backup)
+	}
+}
@@ -3,11 +3,14 @@ package generator
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
+	"go/format"
 	"path/filepath"
+	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/builder"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
@@ -23,6 +26,11 @@ func NewServiceGenerator() *ServiceGenerator {
 	}
 }
 
+func init() {
+	// Register the Go service generator
+	Register(workspace.ProjectKindService, workspace.LanguageGo, "Go", NewServiceGenerator())
+}
+
 // Name returns the generator name.
 func (g *ServiceGenerator) Name() string {
 	return "service"
@@ -50,6 +58,10 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 	if err != nil {
 		return fmt.Errorf("failed to load workspace config: %w", err)
 	}
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
 
 	// Check if service already exists
 	if config.GetProject(serviceName) != nil {
@@ -57,20 +69,63 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 	}
 
 	// Determine service path using workspace.paths or default
-	servicesPath := "backend/services"
-	if config.Workspace.Paths != nil && config.Workspace.Paths.Services != "" {
-		servicesPath = config.Workspace.Paths.Services
-	}
+	servicesPath := config.ServicesPath()
 
 	serviceDir := filepath.Join(opts.OutputDir, servicesPath, serviceName)
 
-	if opts.DryRun {
-		fmt.Printf("Would create service: %s\n", serviceDir)
-		return nil
+	// Parse the OpenAPI spec up front so an invalid spec doesn't leave a half-generated service
+	var openAPI *OpenAPIModel
+	if opts.Data != nil {
+		if specPath, ok := opts.Data["openapiSpec"].(string); ok && specPath != "" {
+			openAPI, err = LoadOpenAPISpec(specPath)
+			if err != nil {
+				return fmt.Errorf("invalid OpenAPI spec %s: %w", specPath, err)
+			}
+			for _, construct := range openAPI.Unsupported {
+				log.Warn("⚠️  Unsupported OpenAPI construct, generated as a placeholder: %s", construct)
+			}
+		}
+	}
+
+	sqlMigrations := false
+	rateLimit := 0.0
+	auth := ""
+	grpc := false
+	grpcGateway := false
+	if opts.Data != nil {
+		sqlMigrations, _ = opts.Data["sqlMigrations"].(bool)
+		rateLimit, _ = opts.Data["rateLimit"].(float64)
+		auth, _ = opts.Data["auth"].(string)
+		grpc, _ = opts.Data["grpc"].(bool)
+		grpcGateway, _ = opts.Data["http"].(bool)
+	}
+	if rateLimit < 0 {
+		return fmt.Errorf("rate limit must be positive, got %v", rateLimit)
+	}
+	if auth != "" && auth != "jwt" && auth != "oidc" {
+		return fmt.Errorf("unsupported auth mode %q (supported: jwt, oidc)", auth)
+	}
+	if grpcGateway && !grpc {
+		return fmt.Errorf("the HTTP gateway requires a gRPC service")
+	}
+	if grpc && (openAPI != nil || rateLimit > 0 || auth != "") {
+		return fmt.Errorf("gRPC services do not support OpenAPI specs, rate limiting or auth middleware")
+	}
+
+	// gRPC-only services serve gRPC on their local port
+	basePort := 8080
+	if grpc && !grpcGateway {
+		basePort = 50051
+	}
+	port, err := servePort(config, opts.Data, basePort)
+	if err != nil {
+		return err
 	}
 
+	p := newPlan(opts.OutputDir, opts.DryRun)
+
 	// Create service directory
-	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+	if err := p.mkdirAll(serviceDir); err != nil {
 		return fmt.Errorf("failed to create service directory: %w", err)
 	}
 
@@ -89,11 +144,27 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		"ServiceName":       serviceName,
 		"ServiceNamePascal": template.Pascalize(serviceName),
 		"ServiceNameCamel":  template.Camelize(serviceName),
-		"ModulePath":        fmt.Sprintf("%s/%s/backend/services/%s", githubOrg, config.Workspace.Name, serviceName),
+		"ModulePath":        fmt.Sprintf("%s/%s/%s/%s", githubOrg, config.Workspace.Name, filepath.ToSlash(servicesPath), serviceName),
 		"WorkspaceName":     config.Workspace.Name,
 		"GitHubOrg":         config.Workspace.GitHub.Org, // Just the org name without github.com/
 		"Registry":          dockerRegistry,
 		"ProjectName":       config.Workspace.Name,
+		"GoVersion":         toolVersions.Go,
+		"ServicePath":       filepath.ToSlash(filepath.Join(servicesPath, serviceName)),
+		"SQLMigrations":     sqlMigrations,
+		"RateLimit":         rateLimit,
+		"Auth":              auth,
+		"GRPC":              grpc,
+		"GRPCGateway":       grpcGateway,
+		"HasTests":          true,
+	}
+	if grpc {
+		protoName := strings.ReplaceAll(serviceName, "-", "_")
+		data["GRPCService"] = grpcServiceName(serviceName)
+		data["ProtoFile"] = protoName + ".proto"
+		data["ProtoPackage"] = protoName + ".v1"
+		data["ProtoTarget"] = protoName
+		data["GoProtoPackage"] = strings.ReplaceAll(protoName, "_", "") + "pb"
 	}
 
 	// Generate directory structure
@@ -111,7 +182,7 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 
 	for _, dir := range dirs {
 		dirPath := filepath.Join(serviceDir, dir)
-		if err := os.MkdirAll(dirPath, 0755); err != nil {
+		if err := p.mkdirAll(dirPath); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
@@ -131,7 +202,7 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		}
 
 		filePath := filepath.Join(serviceDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -139,11 +210,17 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 	// Generate cmd/server files
 	cmdServerTemplates := map[string]string{
 		"cmd/server/main.go":       "service/cmd/server/main.go.tmpl",
+		"cmd/server/main_test.go":  "service/cmd/server/main_test.go.tmpl",
 		"cmd/server/BUILD.bazel":   "service/cmd/server/BUILD.bazel.tmpl",
 		"cmd/migrator/doc.go":      "service/cmd/migrator/doc.go.tmpl",
 		"cmd/migrator/BUILD.bazel": "service/cmd/migrator/BUILD.bazel.tmpl",
 	}
 
+	if grpc {
+		cmdServerTemplates["cmd/server/main.go"] = "service/cmd/server/grpc_main.go.tmpl"
+		cmdServerTemplates["cmd/server/main_test.go"] = "service/cmd/server/grpc_main_test.go.tmpl"
+	}
+
 	for filename, templatePath := range cmdServerTemplates {
 		content, err := g.engine.RenderTemplate(templatePath, data)
 		if err != nil {
@@ -151,7 +228,7 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		}
 
 		filePath := filepath.Join(serviceDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -181,11 +258,125 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		}
 
 		filePath := filepath.Join(serviceDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
 
+	// Generate the proto definitions and the gRPC server skeleton
+	if grpc {
+		grpcTemplates := map[string]string{
+			"proto/" + data["ProtoFile"].(string): "service/proto/service.proto.tmpl",
+			"proto/BUILD.bazel":                   "service/proto/BUILD.bazel.tmpl",
+			"proto/buf.yaml":                      "service/proto/buf.yaml.tmpl",
+			"proto/buf.gen.yaml":                  "service/proto/buf.gen.yaml.tmpl",
+			"internal/grpc_server.go":             "service/internal/grpc_server.go.tmpl",
+		}
+
+		if err := p.mkdirAll(filepath.Join(serviceDir, "proto")); err != nil {
+			return fmt.Errorf("failed to create directory proto: %w", err)
+		}
+
+		for filename, templatePath := range grpcTemplates {
+			content, err := g.engine.RenderTemplate(templatePath, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", filename, err)
+			}
+
+			filePath := filepath.Join(serviceDir, filename)
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+		if grpcGateway {
+			p.info("✓ Generated gRPC service %s with an HTTP gateway", data["GRPCService"])
+		} else {
+			p.info("✓ Generated gRPC service %s", data["GRPCService"])
+		}
+	}
+
+	// Generate handlers, routes and types from the OpenAPI spec
+	if openAPI != nil {
+		data["OpenAPI"] = openAPI
+		openAPITemplates := map[string]string{
+			"internal/transport_openapi.go": "service/internal/transport_openapi.go.tmpl",
+			"internal/api_types.go":         "service/internal/api_types.go.tmpl",
+		}
+
+		for filename, templatePath := range openAPITemplates {
+			content, err := g.engine.RenderTemplate(templatePath, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", filename, err)
+			}
+
+			// Struct fields come from the spec, so align them with gofmt
+			if formatted, err := format.Source([]byte(content)); err == nil {
+				content = string(formatted)
+			}
+
+			filePath := filepath.Join(serviceDir, filename)
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+		p.info("✓ Generated %d handler(s) from OpenAPI spec", len(openAPI.Routes))
+	}
+
+	// Generate SQL migrations scaffold and runner
+	if sqlMigrations {
+		migrationTemplates := map[string]string{
+			"migrations/000001_init.up.sql":   "service/migrations/000001_init.up.sql.tmpl",
+			"migrations/000001_init.down.sql": "service/migrations/000001_init.down.sql.tmpl",
+			"migrations/BUILD.bazel":          "service/migrations/BUILD.bazel.tmpl",
+			"cmd/server/migrate.go":           "service/cmd/server/migrate.go.tmpl",
+		}
+
+		if err := p.mkdirAll(filepath.Join(serviceDir, "migrations")); err != nil {
+			return fmt.Errorf("failed to create directory migrations: %w", err)
+		}
+
+		for filename, templatePath := range migrationTemplates {
+			content, err := g.engine.RenderTemplate(templatePath, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", filename, err)
+			}
+
+			filePath := filepath.Join(serviceDir, filename)
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+		p.info("✓ Generated SQL migrations scaffold")
+	}
+
+	// Generate per-client rate limiting middleware
+	if rateLimit > 0 {
+		content, err := g.engine.RenderTemplate("service/cmd/server/ratelimit.go.tmpl", data)
+		if err != nil {
+			return fmt.Errorf("failed to render cmd/server/ratelimit.go: %w", err)
+		}
+
+		filePath := filepath.Join(serviceDir, "cmd/server/ratelimit.go")
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write cmd/server/ratelimit.go: %w", err)
+		}
+		p.info("✓ Added rate limiting middleware (%v req/s per client)", rateLimit)
+	}
+
+	// Generate bearer token authentication for /api routes
+	if auth != "" {
+		content, err := g.engine.RenderTemplate("service/cmd/server/auth.go.tmpl", data)
+		if err != nil {
+			return fmt.Errorf("failed to render cmd/server/auth.go: %w", err)
+		}
+
+		filePath := filepath.Join(serviceDir, "cmd/server/auth.go")
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write cmd/server/auth.go: %w", err)
+		}
+		p.info("✓ Added %s authentication middleware for /api routes", strings.ToUpper(auth))
+	}
+
 	// Generate test and deploy README files
 	readmeTemplates := map[string]string{
 		"test/README.md":   "service/test/README.md.tmpl",
@@ -199,7 +390,7 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		}
 
 		filePath := filepath.Join(serviceDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := p.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -221,6 +412,10 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 			"deploy/helm/values-dev.yaml":  "service/deploy/helm/values-dev.yaml.tmpl",
 			"deploy/helm/values-prod.yaml": "service/deploy/helm/values-prod.yaml.tmpl",
 		}
+		if sqlMigrations {
+			// Helm only renders hooks from the chart's templates directory
+			helmTemplates["deploy/helm/templates/migrate-job.yaml"] = "service/deploy/helm/templates/migrate-job.yaml.tmpl"
+		}
 
 		for filename, templatePath := range helmTemplates {
 			content, err := g.engine.RenderTemplate(templatePath, data)
@@ -229,7 +424,7 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 			}
 
 			filePath := filepath.Join(serviceDir, filename)
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
 				return fmt.Errorf("failed to write %s: %w", filename, err)
 			}
 		}
@@ -239,6 +434,9 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		cloudRunTemplate := map[string]string{
 			"deploy/cloudrun/service.yaml": "service/deploy/cloudrun/service.yaml.tmpl",
 		}
+		if sqlMigrations {
+			cloudRunTemplate["deploy/cloudrun/migrate-job.yaml"] = "service/deploy/cloudrun/migrate-job.yaml.tmpl"
+		}
 
 		for filename, templatePath := range cloudRunTemplate {
 			content, err := g.engine.RenderTemplate(templatePath, data)
@@ -247,7 +445,54 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 			}
 
 			filePath := filepath.Join(serviceDir, filename)
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+
+	case "ecs":
+		// Generate ECS task and service definitions
+		if err := p.mkdirAll(filepath.Join(serviceDir, "deploy", "ecs")); err != nil {
+			return fmt.Errorf("failed to create directory deploy/ecs: %w", err)
+		}
+
+		ecsTemplates := map[string]string{
+			"deploy/ecs/task-definition.json": "service/deploy/ecs/task-definition.json.tmpl",
+			"deploy/ecs/service.json":         "service/deploy/ecs/service.json.tmpl",
+			"deploy/ecs/README.md":            "service/deploy/ecs/README.md.tmpl",
+		}
+
+		for filename, templatePath := range ecsTemplates {
+			content, err := g.engine.RenderTemplate(templatePath, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", filename, err)
+			}
+
+			filePath := filepath.Join(serviceDir, filename)
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+
+	case "aca":
+		// Generate the Azure Container Apps container app definition
+		if err := p.mkdirAll(filepath.Join(serviceDir, "deploy", "aca")); err != nil {
+			return fmt.Errorf("failed to create directory deploy/aca: %w", err)
+		}
+
+		acaTemplates := map[string]string{
+			"deploy/aca/containerapp.yaml": "service/deploy/aca/containerapp.yaml.tmpl",
+			"deploy/aca/README.md":         "service/deploy/aca/README.md.tmpl",
+		}
+
+		for filename, templatePath := range acaTemplates {
+			content, err := g.engine.RenderTemplate(templatePath, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", filename, err)
+			}
+
+			filePath := filepath.Join(serviceDir, filename)
+			if err := p.writeFile(filePath, []byte(content)); err != nil {
 				return fmt.Errorf("failed to write %s: %w", filename, err)
 			}
 		}
@@ -257,14 +502,14 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 	project := &workspace.Project{
 		ProjectType: "service",
 		Language:    "go",
-		Root:        filepath.Join(servicesPath, serviceName),
+		Root:        filepath.ToSlash(filepath.Join(servicesPath, serviceName)),
 		Tags:        []string{"backend", "service"},
 		Architect: &workspace.Architect{
 			Build: &workspace.ArchitectTarget{
 				Builder: "@forge/bazel:build",
 				Options: map[string]interface{}{
 					"target":     "/...",
-					"goVersion":  config.Workspace.ToolVersions.Go,
+					"goVersion":  toolVersions.Go,
 					"registry":   dockerRegistry,
 					"dockerfile": "Dockerfile",
 				},
@@ -280,6 +525,13 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 				},
 				DefaultConfiguration: "production",
 			},
+			Serve: &workspace.ArchitectTarget{
+				Builder: "@forge/go:serve",
+				Options: map[string]interface{}{
+					"main": "./cmd/server",
+					"port": port,
+				},
+			},
 			Deploy: &workspace.ArchitectTarget{
 				Deployer: fmt.Sprintf("@forge/%s:deploy", deployerTarget),
 				Options: map[string]interface{}{
@@ -309,46 +561,83 @@ func (g *ServiceGenerator) Generate(ctx context.Context, opts GeneratorOptions)
 		},
 	}
 
-	if err := config.AddProject(serviceName, project); err != nil {
-		return fmt.Errorf("failed to add project to config: %w", err)
+	addDeployerOptions(deployerTarget, project.Architect.Deploy.Options, opts.Data)
+
+	if grpc {
+		// Register the proto directory for 'forge proto'
+		project.Metadata["grpc"] = map[string]interface{}{
+			"protoDir": "proto",
+			"gateway":  grpcGateway,
+		}
+		project.Tags = append(project.Tags, "grpc")
+		if !grpcGateway {
+			// There is no HTTP endpoint to poll after a deploy
+			delete(project.Architect.Deploy.Options, "healthPath")
+		}
+	}
+
+	if opts.DryRun {
+		// Register in memory only, so MODULE.bazel and go.work render with the service
+		err = config.AddProject(serviceName, project)
+		p.update(filepath.Join(opts.OutputDir, workspace.ConfigFileName), fmt.Sprintf("register project %q", serviceName))
+	} else {
+		err = config.Update(opts.OutputDir, func(config *workspace.Config) error {
+			return config.AddProject(serviceName, project)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to register project in workspace config: %w", err)
 	}
 
-	if err := config.SaveToDir(opts.OutputDir); err != nil {
-		return fmt.Errorf("failed to save workspace config: %w", err)
+	// Compile the protos so go mod tidy finds the stubs
+	if grpc && !p.command(filepath.Join(serviceDir, "proto"), "forge", "proto") {
+		compiled, err := builder.CompileGoProtos(ctx, filepath.Join(serviceDir, "proto"))
+		if err != nil {
+			log.Warn("⚠️  Warning: proto compilation failed: %v", err)
+		} else if !compiled {
+			log.Warn("⚠️  Protobuf compiler not found, run 'forge proto' to generate the gRPC stubs")
+		}
 	}
 
 	// Run go mod tidy automatically
-	fmt.Printf("📦 Running go mod tidy for %s...\n", serviceName)
-	if err := g.runGoModTidy(serviceDir); err != nil {
-		// Warn but don't fail - user can run manually
-		fmt.Printf("⚠️  Warning: go mod tidy failed: %v\n", err)
-		fmt.Printf("   Run 'cd %s && go mod tidy' manually\n", serviceDir)
-	} else {
-		fmt.Println("✓ Dependencies synchronized")
+	if !p.command(serviceDir, "go", "mod", "tidy") {
+		log.Info("📦 Running go mod tidy for %s...", serviceName)
+		if err := g.runGoModTidy(ctx, serviceDir); err != nil {
+			// Warn but don't fail - user can run manually
+			log.Warn("⚠️  Warning: go mod tidy failed: %v", err)
+			log.Info("   Run 'cd %s && go mod tidy' manually", serviceDir)
+		} else {
+			log.Info("✓ Dependencies synchronized")
+		}
 	}
 
 	// Update MODULE.bazel to include this service's go.mod
-	if err := g.updateModuleBazel(opts.OutputDir, config); err != nil {
+	if err := g.updateModuleBazel(p, opts.OutputDir, config); err != nil {
 		return fmt.Errorf("failed to update MODULE.bazel: %w", err)
 	}
 
 	// Update go.work to include this service
-	if err := g.updateGoWork(opts.OutputDir, config); err != nil {
+	if err := g.updateGoWork(p, opts.OutputDir, config); err != nil {
 		return fmt.Errorf("failed to update go.work: %w", err)
 	}
 
-	fmt.Printf("✓ Service %q created successfully\n", serviceName)
-	fmt.Printf("✓ Location: %s\n", serviceDir)
-	fmt.Printf("✓ Run 'cd %s && go mod tidy' to install dependencies\n", serviceDir)
-	fmt.Printf("✓ Run 'forge build %s' to build the service\n", serviceName)
-	fmt.Printf("✓ Run 'forge test %s' to run tests\n", serviceName)
-	fmt.Printf("✓ Run 'forge run %s' to start the service\n", serviceName)
+	if opts.DryRun {
+		p.print(fmt.Sprintf("Go service %q in %s", serviceName, p.rel(serviceDir)))
+		return nil
+	}
+
+	log.Info("✓ Service %q created successfully", serviceName)
+	log.Info("✓ Location: %s", serviceDir)
+	log.Info("✓ Run 'cd %s && go mod tidy' to install dependencies", serviceDir)
+	log.Info("✓ Run 'forge build %s' to build the service", serviceName)
+	log.Info("✓ Run 'forge test %s' to run tests", serviceName)
+	log.Info("✓ Run 'forge serve %s' to start the service", serviceName)
 
 	return nil
 }
 
 // updateModuleBazel updates MODULE.bazel to include the new service's go.mod
-func (g *ServiceGenerator) updateModuleBazel(workspaceDir string, config *workspace.Config) error {
+func (g *ServiceGenerator) updateModuleBazel(p *plan, workspaceDir string, config *workspace.Config) error {
 	// Collect all services
 	var services []map[string]interface{}
 	for name, project := range config.Projects {
@@ -362,18 +651,24 @@ func (g *ServiceGenerator) updateModuleBazel(workspaceDir string, config *worksp
 	// Check if frontend exists
 	hasFrontend := false
 	for _, project := range config.Projects {
-		if project.Language == "angular" {
+		if project.Language == "angular" || project.Language == "vue" {
 			hasFrontend = true
 			break
 		}
 	}
 
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
 		"ProjectName": config.Workspace.Name,
 		"Version":     "0.1.0",
-		"GoVersion":   config.Workspace.ToolVersions.Go,
-		"NodeVersion": "20.18.1",
+		"GoVersion":   toolVersions.Go,
+		"NodeVersion": toolVersions.Node,
 		"HasFrontend": hasFrontend,
+		"HasProto":    config.HasProto(),
 		"Services":    services,
 	}
 
@@ -383,7 +678,7 @@ func (g *ServiceGenerator) updateModuleBazel(workspaceDir string, config *worksp
 	}
 
 	modulePath := filepath.Join(workspaceDir, "MODULE.bazel")
-	if err := os.WriteFile(modulePath, []byte(content), 0644); err != nil {
+	if err := p.writeFile(modulePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write MODULE.bazel: %w", err)
 	}
 
@@ -391,19 +686,25 @@ func (g *ServiceGenerator) updateModuleBazel(workspaceDir string, config *worksp
 }
 
 // updateGoWork updates go.work to include the new service
-func (g *ServiceGenerator) updateGoWork(workspaceDir string, config *workspace.Config) error {
+func (g *ServiceGenerator) updateGoWork(p *plan, workspaceDir string, config *workspace.Config) error {
 	// Collect all services
 	var services []map[string]interface{}
 	for name, project := range config.Projects {
 		if project.Language == "go" {
 			services = append(services, map[string]interface{}{
 				"Name": name,
+				"Root": filepath.ToSlash(project.Root),
 			})
 		}
 	}
 
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
-		"GoVersion": config.Workspace.ToolVersions.Go,
+		"GoVersion": toolVersions.Go,
 		"Services":  services,
 	}
 
@@ -413,19 +714,60 @@ func (g *ServiceGenerator) updateGoWork(workspaceDir string, config *workspace.C
 	}
 
 	goWorkPath := filepath.Join(workspaceDir, "go.work")
-	if err := os.WriteFile(goWorkPath, []byte(content), 0644); err != nil {
+	if err := p.writeFile(goWorkPath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write go.work: %w", err)
 	}
 
 	return nil
 }
 
-// runGoModTidy runs go mod tidy in the specified directory
-func (g *ServiceGenerator) runGoModTidy(serviceDir string) error {
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = serviceDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// deployerOptions are the deployer settings collected by forge new that each
+// deployer reads from the deploy options.
+var deployerOptions = map[string][]string{
+	"ecs": {"region", "cluster"},
+	"aca": {"resourceGroup", "location", "environment"},
+}
+
+// addDeployerOptions copies the deployer settings in data that the deployer
+// needs at deploy time into the deploy options.
+func addDeployerOptions(deployerTarget string, options map[string]interface{}, data map[string]interface{}) {
+	for _, key := range deployerOptions[deployerTarget] {
+		if value, ok := data[key].(string); ok && value != "" {
+			options[key] = value
+		}
+	}
+}
 
-	return cmd.Run()
+// grpcServiceName returns the proto service name of a gRPC service, e.g.
+// "OrdersService" for "orders" and "UserService" for "user-service".
+func grpcServiceName(serviceName string) string {
+	name := template.Pascalize(serviceName)
+	if !strings.HasSuffix(name, "Service") {
+		name += "Service"
+	}
+	return name
+}
+
+// servePort returns the local serve port of a new service: the "port" option
+// when given, else the first port from base upward that no project serves on,
+// so services run side by side with 'forge serve' without colliding.
+func servePort(config *workspace.Config, data map[string]interface{}, base int) (int, error) {
+	if value, ok := data["port"].(string); ok && value != "" {
+		port, err := workspace.ParsePort(value)
+		if err != nil {
+			return 0, err
+		}
+		for name, project := range config.Projects {
+			if project.ServePort() == port {
+				log.Warn("⚠️  Warning: port %d is already used by %s", port, name)
+			}
+		}
+		return port, nil
+	}
+	return workspace.NextFreePort(base, config.UsedPorts()), nil
+}
+
+// runGoModTidy runs go mod tidy in the specified directory
+func (g *ServiceGenerator) runGoModTidy(ctx context.Context, serviceDir string) error {
+	return exec.Run(ctx, exec.Options{Name: "go", Args: []string{"mod", "tidy"}, Dir: serviceDir, Timeout: commandTimeout})
 }
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"context"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// generateTestService generates a Go service in a new workspace with locked
+// tool versions, without running external commands, and returns its directory.
+func generateTestService(t *testing.T, name string, data map[string]interface{}) string {
+	t.Helper()
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		return nil
+	}))()
+
+	root := t.TempDir()
+	config := workspace.NewConfig("shop")
+	config.Workspace.GitHub = &workspace.GitHubConfig{Org: "acme"}
+	toolVersions := workspace.DefaultToolVersions()
+	config.Workspace.ToolVersions = &toolVersions
+	if err := config.Save(root); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewServiceGenerator().Generate(context.Background(), GeneratorOptions{
+		Name:      name,
+		OutputDir: root,
+		Data:      data,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(root, "backend", "services", name)
+}
+
+func TestServiceSQLMigrations(t *testing.T) {
+	serviceDir := generateTestService(t, "orders", map[string]interface{}{"sqlMigrations": true})
+	for _, filename := range []string{
+		"migrations/000001_init.up.sql",
+		"migrations/000001_init.down.sql",
+		"migrations/BUILD.bazel",
+		"cmd/server/migrate.go",
+		"deploy/helm/templates/migrate-job.yaml",
+	} {
+		if _, err := os.Stat(filepath.Join(serviceDir, filename)); err != nil {
+			t.Errorf("%s was not generated: %v", filename, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(serviceDir, "deploy", "helm", "migrate-job.yaml")); !os.IsNotExist(err) {
+		t.Error("the migration Job was written outside the chart's templates directory")
+	}
+
+	job, err := os.ReadFile(filepath.Join(serviceDir, "deploy", "helm", "templates", "migrate-job.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"helm.sh/hook: pre-install,pre-upgrade",
+		`image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"`,
+		`args: ["migrate", "up"]`,
+		"name: orders-database",
+	} {
+		if !strings.Contains(string(job), want) {
+			t.Errorf("migrate-job.yaml has no %q:\n%s", want, job)
+		}
+	}
+
+	runner, err := os.ReadFile(filepath.Join(serviceDir, "cmd", "server", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(runner), "runMigrations(os.Args[2:])") {
+		t.Errorf("main.go does not dispatch to the migration runner:\n%s", runner)
+	}
+}
+
+func TestServiceRateLimit(t *testing.T) {
+	serviceDir := generateTestService(t, "orders", map[string]interface{}{"rateLimit": 2.5})
+
+	for _, filename := range []string{"main.go", "ratelimit.go"} {
+		content, err := os.ReadFile(filepath.Join(serviceDir, "cmd", "server", filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := format.Source(content); err != nil {
+			t.Fatalf("%s is not valid Go: %v\n%s", filename, err, content)
+		}
+	}
+
+	main, err := os.ReadFile(filepath.Join(serviceDir, "cmd", "server", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"limiter, err := newRateLimiter(2.5)",
+		"Handler:      limiter.middleware(mux),",
+	} {
+		if !strings.Contains(string(main), want) {
+			t.Errorf("main.go has no %q:\n%s", want, main)
+		}
+	}
+
+	// Services without --rate-limit serve the mux directly
+	serviceDir = generateTestService(t, "billing", nil)
+	if _, err := os.Stat(filepath.Join(serviceDir, "cmd", "server", "ratelimit.go")); !os.IsNotExist(err) {
+		t.Error("ratelimit.go was generated without --rate-limit")
+	}
+	main, err = os.ReadFile(filepath.Join(serviceDir, "cmd", "server", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(main), "newRateLimiter") {
+		t.Errorf("main.go registers a rate limiter without --rate-limit:\n%s", main)
+	}
+}
+
+func TestServiceAuth(t *testing.T) {
+	for _, mode := range []string{"jwt", "oidc"} {
+		t.Run(mode, func(t *testing.T) {
+			serviceDir := generateTestService(t, "accounts", map[string]interface{}{"auth": mode})
+
+			files := make(map[string]string)
+			for _, filename := range []string{"main.go", "main_test.go", "auth.go"} {
+				content, err := os.ReadFile(filepath.Join(serviceDir, "cmd", "server", filename))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := format.Source(content); err != nil {
+					t.Fatalf("%s is not valid Go: %v\n%s", filename, err, content)
+				}
+				files[filename] = string(content)
+			}
+
+			if !strings.Contains(files["main.go"], "mux := newRouter(logger, auth)") {
+				t.Errorf("main.go does not serve the authenticated router:\n%s", files["main.go"])
+			}
+			// The generated test goes through the router main serves
+			for _, want := range []string{
+				"func TestProtectedRoutes(t *testing.T)",
+				"return newRouter(log.New(io.Discard, \"\", 0), auth)",
+				"router.ServeHTTP(rec, req)",
+				`{name: "health stays open", path: "/health", wantStatus: http.StatusOK}`,
+				`{name: "api without token", path: "/api/accounts", wantStatus: http.StatusUnauthorized}`,
+			} {
+				if !strings.Contains(files["main_test.go"], want) {
+					t.Errorf("main_test.go has no %q:\n%s", want, files["main_test.go"])
+				}
+			}
+		})
+	}
+}
+
+func TestServiceHandlersTest(t *testing.T) {
+	for _, mode := range []string{"", "jwt", "oidc"} {
+		t.Run("auth="+mode, func(t *testing.T) {
+			data := map[string]interface{}{}
+			if mode != "" {
+				data["auth"] = mode
+			}
+			serviceDir := generateTestService(t, "accounts", data)
+
+			content, err := os.ReadFile(filepath.Join(serviceDir, "cmd", "server", "main_test.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			formatted, err := format.Source(content)
+			if err != nil {
+				t.Fatalf("main_test.go is not valid Go: %v\n%s", err, content)
+			}
+			if string(formatted) != string(content) {
+				t.Errorf("main_test.go is not gofmt-formatted:\n%s", content)
+			}
+
+			// Requests go through the router main serves, not the handlers
+			test := string(content)
+			for _, want := range []string{"router := newTestRouter(t)", "router.ServeHTTP(rec, req)"} {
+				if !strings.Contains(test, want) {
+					t.Errorf("main_test.go has no %q:\n%s", want, test)
+				}
+			}
+			if strings.Contains(test, "Handler(logger)") {
+				t.Errorf("main_test.go calls handlers directly:\n%s", test)
+			}
+			// OIDC tokens come from the provider, so only TestProtectedRoutes covers /api
+			if strings.Contains(test, `"Hello from accounts!"`) != (mode != "oidc") {
+				t.Errorf("main_test.go hello case does not match auth %q:\n%s", mode, test)
+			}
+			if mode == "jwt" && !strings.Contains(test, `authorization: "Bearer " + testToken(t)`) {
+				t.Errorf("main_test.go sends no signed token to /api:\n%s", test)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// vueDefaultPort is the Vite dev server port.
+const vueDefaultPort = 5173
+
+// vueDefaultAppsPath is where Vue apps go when workspace.paths.frontendApps is not set.
+const vueDefaultAppsPath = "frontend/projects"
+
+// VueGenerator generates a new Vite + Vue 3 + TypeScript application.
+type VueGenerator struct {
+	engine *template.Engine
+
+	// frontend provides the command runners and deployment configuration shared with Angular apps
+	frontend *FrontendGenerator
+}
+
+// NewVueGenerator creates a new Vue generator.
+func NewVueGenerator() *VueGenerator {
+	return &VueGenerator{
+		engine:   template.NewEngine(),
+		frontend: NewFrontendGenerator(),
+	}
+}
+
+func init() {
+	// Register the Vue application generator
+	Register(workspace.ProjectKindApplication, workspace.LanguageVue, "Vue", NewVueGenerator())
+}
+
+// Name returns the generator name.
+func (g *VueGenerator) Name() string {
+	return "vue"
+}
+
+// Description returns the generator description.
+func (g *VueGenerator) Description() string {
+	return "Generate a new Vue 3 frontend application (Vite + TypeScript)"
+}
+
+// Generate creates a new Vue application. On failure the files it created are
+// removed and forge.json is restored.
+func (g *VueGenerator) Generate(ctx context.Context, opts GeneratorOptions) (err error) {
+	appName := opts.Name
+	if appName == "" {
+		return fmt.Errorf("application name is required")
+	}
+
+	// Check prerequisites
+	if err := CheckNodeJS(); err != nil {
+		return err
+	}
+
+	if err := CheckNPM(); err != nil {
+		return err
+	}
+
+	// Validate name
+	if err := workspace.ValidateName(appName); err != nil {
+		return fmt.Errorf("invalid application name: %w", err)
+	}
+
+	// Load workspace config (without project validation during workspace creation)
+	config, err := workspace.LoadConfigWithoutProjectValidation(opts.OutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to load workspace config: %w", err)
+	}
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+
+	// Determine app path using workspace.paths or default
+	appsPath := config.FrontendAppsPath(vueDefaultAppsPath)
+	projectsDir := filepath.Join(opts.OutputDir, appsPath)
+	appDir := filepath.Join(projectsDir, appName)
+
+	if _, err := os.Stat(appDir); err == nil {
+		return fmt.Errorf("directory %s already exists", appDir)
+	}
+
+	p := newPlan(opts.OutputDir, opts.DryRun)
+
+	undo, err := newRollback(opts.OutputDir, opts)
+	if err != nil {
+		return err
+	}
+	defer undo.restoreOnError(&err)
+	undo.trackDirs(opts.OutputDir, appsPath)
+	undo.track(appDir)
+
+	if err := p.mkdirAll(projectsDir); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", appsPath, err)
+	}
+
+	// Create the Vite app at <apps path>/<app-name>
+	p.info("📦 Generating Vue application: %s", appName)
+
+	if err := g.frontend.runNpmCommand(ctx, p, projectsDir, []string{
+		"create", "vite@latest", appName, "--",
+		"--template", "vue-ts",
+		"--no-interactive",
+	}); err != nil {
+		return fmt.Errorf("failed to generate Vue application: %w", err)
+	}
+
+	if err := g.frontend.runNpmCommand(ctx, p, appDir, []string{"install"}); err != nil {
+		return fmt.Errorf("failed to install dependencies: %w", err)
+	}
+
+	// Initialize Tailwind CSS through its Vite plugin
+	p.info("🎨 Installing Tailwind CSS...")
+	if err := g.frontend.runNpmCommand(ctx, p, appDir, []string{"install", "tailwindcss", "@tailwindcss/vite", "--save-dev"}); err != nil {
+		return fmt.Errorf("failed to install Tailwind: %w", err)
+	}
+
+	files := map[string]string{
+		"vite.config.ts": "frontend/vue/vite.config.ts.tmpl",
+		"src/style.css":  "frontend/styles.css.tmpl",
+		"src/env.d.ts":   "frontend/vue/env.d.ts.tmpl",
+		".npmrc":         "frontend/.npmrc.tmpl",
+		".nvmrc":         "frontend/.nvmrc.tmpl",
+		"BUILD.bazel":    "bazel/vue.BUILD.bazel.tmpl",
+	}
+	data := map[string]interface{}{
+		"AppName":       appName,
+		"WorkspaceName": config.Workspace.Name,
+		"PackagePath":   filepath.ToSlash(filepath.Join(appsPath, appName)),
+		"Port":          vueDefaultPort,
+		"NodeVersion":   toolVersions.Node,
+	}
+	for filename, templatePath := range files {
+		content, err := g.engine.RenderTemplate(templatePath, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", filename, err)
+		}
+		if err := p.writeFile(filepath.Join(appDir, filename), []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+	}
+	p.info("  ✓ Generated BUILD.bazel for Bazel builds")
+
+	deploymentTarget := frontendDeploymentTarget(opts.Data)
+
+	// Generate environment files
+	if err := g.generateEnvironmentFiles(p, appDir, deploymentTarget, frontendAPIURLs(config, opts.Data)); err != nil {
+		return fmt.Errorf("failed to generate environment files: %w", err)
+	}
+
+	// Generate deployment configuration based on target
+	if err := g.frontend.generateDeploymentConfig(p, appDir, appName, deploymentTarget, config, opts.Data); err != nil {
+		return fmt.Errorf("failed to generate deployment config: %w", err)
+	}
+
+	// Add project to workspace config
+	project := &workspace.Project{
+		ProjectType: "application",
+		Language:    string(workspace.LanguageVue),
+		Root:        filepath.ToSlash(filepath.Join(appsPath, appName)),
+		Tags:        []string{"frontend", "vue", deploymentTarget},
+		Architect: &workspace.Architect{
+			Build: &workspace.ArchitectTarget{
+				Builder: "@forge/bazel:build",
+				Options: map[string]interface{}{
+					"target":     ":build",
+					"outputPath": "dist",
+					"environmentMapper": map[string]string{
+						"local":   "development",
+						"dev":     "dev",
+						"staging": "production",
+						"prod":    "production",
+					},
+				},
+				Configurations: map[string]interface{}{
+					"production": map[string]interface{}{
+						"mode": "production",
+					},
+					"development": map[string]interface{}{
+						"mode": "development",
+					},
+					"local": map[string]interface{}{
+						"mode": "development",
+					},
+				},
+				DefaultConfiguration: "production",
+			},
+			Serve: &workspace.ArchitectTarget{
+				Builder: "@forge/vue:serve",
+				Options: map[string]interface{}{
+					"port": vueDefaultPort,
+					"host": "localhost",
+				},
+			},
+			Deploy: &workspace.ArchitectTarget{
+				Deployer: fmt.Sprintf("@forge/%s:deploy", deploymentTarget),
+				Options:  frontendDeployOptions(filepath.Join(appsPath, appName), appName, deploymentTarget),
+				Configurations: map[string]interface{}{
+					"production":  map[string]interface{}{},
+					"development": map[string]interface{}{},
+					"local":       map[string]interface{}{},
+				},
+				DefaultConfiguration: "production",
+			},
+		},
+		Metadata: map[string]interface{}{
+			"deployment": map[string]interface{}{
+				"target": deploymentTarget,
+			},
+		},
+	}
+
+	if opts.DryRun {
+		p.update(filepath.Join(opts.OutputDir, workspace.ConfigFileName), fmt.Sprintf("register project %q", appName))
+		p.print(fmt.Sprintf("Vue application %q in %s", appName, p.rel(appDir)))
+		return nil
+	}
+
+	err = config.Update(opts.OutputDir, func(config *workspace.Config) error {
+		return config.AddProject(appName, project)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register project in workspace config: %w", err)
+	}
+
+	log.Info("✓ Vue application %q created successfully", appName)
+	log.Info("✓ Location: %s", appDir)
+	log.Info("✓ Run 'forge serve %s' to start the development server", appName)
+	log.Info("✓ Open http://localhost:%d in your browser", vueDefaultPort)
+
+	return nil
+}
+
+// generateEnvironmentFiles creates the Vite .env.<mode> files read through import.meta.env.
+func (g *VueGenerator) generateEnvironmentFiles(p *plan, appDir, deploymentTarget string, apiURLs map[string]string) error {
+	envFiles := map[string]string{
+		".env.development": apiURLs["local"],
+		".env.dev":         apiURLs["dev"],
+		".env.production":  apiURLs["prod"],
+	}
+
+	for filename, apiURL := range envFiles {
+		content := fmt.Sprintf("VITE_API_URL=%s\nVITE_DEPLOYMENT=%s\n", apiURL, deploymentTarget)
+		if err := p.writeFile(filepath.Join(appDir, filename), []byte(content)); err != nil {
+			return err
+		}
+	}
+
+	p.info("  ✓ Generated environment files")
+	return nil
+}
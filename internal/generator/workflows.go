@@ -4,12 +4,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
+// deployerWorkflows maps each deployer to the workflow file deploying with it
+var deployerWorkflows = map[string]string{
+	"helm":     "deploy-gke.yml",
+	"firebase": "deploy-firebase.yml",
+	"cloudrun": "deploy-cloudrun.yml",
+}
+
+// workflowProject is a workspace project as the workflow templates see it.
+type workflowProject struct {
+	Name     string
+	Root     string
+	Language string
+	Type     string
+	// Target is the Bazel pattern covering the project, e.g. //backend/services/users/...
+	Target string
+}
+
 // WorkflowGenerator generates and updates GitHub Actions workflows
 type WorkflowGenerator struct {
 	config        *workspace.Config
@@ -37,34 +56,28 @@ func (g *WorkflowGenerator) UpdateWorkflows() error {
 	}
 
 	// Always generate ci.yml
-	if err := g.generateWorkflow("ci.yml", "github/workflows/ci.yml.tmpl", nil); err != nil {
+	if err := g.generateWorkflow("ci.yml", "github/workflows/ci.yml.tmpl", g.workflowData("")); err != nil {
 		return err
 	}
 
 	// Generate deployer-specific workflows only if they're used
-	deployerWorkflows := map[string]string{
-		"helm":     "deploy-gke.yml",
-		"firebase": "deploy-firebase.yml",
-		"cloudrun": "deploy-cloudrun.yml",
-	}
-
 	for deployer, workflowFile := range deployerWorkflows {
 		workflowPath := filepath.Join(workflowsDir, workflowFile)
 
 		if activeDeployers[deployer] {
 			// Generate workflow if deployer is active
 			templatePath := fmt.Sprintf("github/workflows/%s.tmpl", workflowFile)
-			if err := g.generateWorkflow(workflowFile, templatePath, nil); err != nil {
+			if err := g.generateWorkflow(workflowFile, templatePath, g.workflowData(deployer)); err != nil {
 				return err
 			}
-			fmt.Printf("  ✓ Generated %s (deployer in use)\n", workflowFile)
+			log.Info("  ✓ Generated %s (deployer in use)", workflowFile)
 		} else {
 			// Remove workflow if deployer is not used
 			if _, err := os.Stat(workflowPath); err == nil {
 				if err := os.Remove(workflowPath); err != nil {
 					return fmt.Errorf("failed to remove %s: %w", workflowFile, err)
 				}
-				fmt.Printf("  ✓ Removed %s (deployer not in use)\n", workflowFile)
+				log.Info("  ✓ Removed %s (deployer not in use)", workflowFile)
 			}
 		}
 	}
@@ -72,6 +85,38 @@ func (g *WorkflowGenerator) UpdateWorkflows() error {
 	return nil
 }
 
+// GenerateMissingWorkflows writes the workflows UpdateWorkflows would generate,
+// skipping files that already exist and never removing any.
+func (g *WorkflowGenerator) GenerateMissingWorkflows() error {
+	workflowsDir := filepath.Join(g.workspaceRoot, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create workflows directory: %w", err)
+	}
+
+	// Workflow files mapped to the deployer they deploy with, "" for ci.yml
+	workflowFiles := map[string]string{"ci.yml": ""}
+	activeDeployers := g.collectActiveDeployers()
+	for deployer, workflowFile := range deployerWorkflows {
+		if activeDeployers[deployer] {
+			workflowFiles[workflowFile] = deployer
+		}
+	}
+
+	for workflowFile, deployer := range workflowFiles {
+		workflowPath := filepath.Join(workflowsDir, workflowFile)
+		if _, err := os.Stat(workflowPath); err == nil {
+			continue
+		}
+		templatePath := fmt.Sprintf("github/workflows/%s.tmpl", workflowFile)
+		if err := g.generateWorkflow(workflowFile, templatePath, g.workflowData(deployer)); err != nil {
+			return err
+		}
+		log.Info("CREATE %s", workflowPath)
+	}
+
+	return nil
+}
+
 // collectActiveDeployers scans all projects and returns a set of active deployers
 func (g *WorkflowGenerator) collectActiveDeployers() map[string]bool {
 	deployers := make(map[string]bool)
@@ -88,17 +133,49 @@ func (g *WorkflowGenerator) collectActiveDeployers() map[string]bool {
 	return deployers
 }
 
-// generateWorkflow generates a single workflow file
-func (g *WorkflowGenerator) generateWorkflow(filename, templatePath string, data map[string]interface{}) error {
-	if data == nil {
-		data = make(map[string]interface{})
+// workflowData returns the template data of a workflow. Projects lists every
+// workspace project for the CI matrix; DeployProjects lists the projects
+// deployed with deployer, which is empty for workflows that deploy nothing.
+func (g *WorkflowGenerator) workflowData(deployer string) map[string]interface{} {
+	data := map[string]interface{}{
+		"WorkspaceName": g.config.Workspace.Name,
+		"GitHubOrg":     "",
 	}
-
-	// Add workspace-level data
 	if g.config.Workspace.GitHub != nil {
 		data["GitHubOrg"] = g.config.Workspace.GitHub.Org
 	}
 
+	names := make([]string, 0, len(g.config.Projects))
+	for name := range g.config.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var projects, deployProjects []workflowProject
+	for _, name := range names {
+		project := g.config.Projects[name]
+		wp := workflowProject{
+			Name:     name,
+			Root:     project.Root,
+			Language: project.Language,
+			Type:     project.ProjectType,
+			Target:   "//" + strings.Trim(filepath.ToSlash(project.Root), "/") + "/...",
+		}
+		projects = append(projects, wp)
+
+		if deployer != "" && project.Architect != nil && project.Architect.Deploy != nil &&
+			extractDeployerName(project.Architect.Deploy.Deployer) == deployer {
+			deployProjects = append(deployProjects, wp)
+		}
+	}
+	data["Projects"] = projects
+	data["DeployProjects"] = deployProjects
+
+	return data
+}
+
+// generateWorkflow generates a single workflow file
+func (g *WorkflowGenerator) generateWorkflow(filename, templatePath string, data map[string]interface{}) error {
 	content, err := g.engine.RenderTemplate(templatePath, data)
 	if err != nil {
 		return fmt.Errorf("failed to render %s: %w", filename, err)
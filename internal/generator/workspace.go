@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
@@ -13,6 +16,14 @@ import (
 // WorkspaceGenerator generates a new Forge workspace.
 type WorkspaceGenerator struct {
 	engine *template.Engine
+
+	// onlyMissing is set while re-running into an existing workspace: files
+	// that already exist are left untouched so user changes are preserved.
+	onlyMissing bool
+
+	// overwrite is set while scaffolding with --overwrite: existing files are
+	// replaced with freshly rendered ones.
+	overwrite bool
 }
 
 // NewWorkspaceGenerator creates a new workspace generator.
@@ -32,7 +43,8 @@ func (g *WorkspaceGenerator) Description() string {
 	return "Generate a new Forge workspace with initial structure"
 }
 
-// Generate creates a new workspace.
+// Generate creates a new workspace. With opts.Data["force"] set and an existing
+// forge workspace in place, it only adds the scaffolding files that are missing.
 func (g *WorkspaceGenerator) Generate(ctx context.Context, opts GeneratorOptions) error {
 	workspaceName := opts.Name
 	if workspaceName == "" {
@@ -46,47 +58,65 @@ func (g *WorkspaceGenerator) Generate(ctx context.Context, opts GeneratorOptions
 
 	workspaceDir := filepath.Join(opts.OutputDir, workspaceName)
 
-	// Check if directory already exists
+	// Check if directory already exists. With force, an existing forge workspace
+	// is completed instead; any other directory is never touched.
+	var existing *workspace.Config
 	if _, err := os.Stat(workspaceDir); err == nil {
-		return fmt.Errorf("directory %s already exists", workspaceDir)
+		if force, _ := opts.Data["force"].(bool); !force {
+			return fmt.Errorf("directory %s already exists (use --force to add missing files to an existing workspace)", workspaceDir)
+		}
+		existing, err = workspace.LoadConfigWithoutProjectValidation(workspaceDir)
+		if err != nil {
+			return fmt.Errorf("directory %s already exists and is not a forge workspace: %w", workspaceDir, err)
+		}
 	}
 
 	if opts.DryRun {
-		fmt.Printf("Would create workspace: %s\n", workspaceDir)
+		if existing != nil {
+			log.Info("Would add missing files to workspace: %s", workspaceDir)
+		} else {
+			log.Info("Would create workspace: %s", workspaceDir)
+		}
 		return nil
 	}
 
-	// Create workspace directory
-	if err := os.MkdirAll(workspaceDir, 0755); err != nil {
-		return fmt.Errorf("failed to create workspace directory: %w", err)
-	}
+	g.onlyMissing = existing != nil
+	defer func() { g.onlyMissing = false }()
 
-	// Create workspace configuration
-	config := workspace.NewConfig(workspaceName)
-	config.Schema = "https://raw.githubusercontent.com/dosanma1/forge-cli/main/schemas/forge-config.v1.schema.json"
-	config.NewProjectRoot = "."
+	config := existing
+	if config == nil {
+		// Create workspace directory
+		if err := os.MkdirAll(workspaceDir, 0755); err != nil {
+			return fmt.Errorf("failed to create workspace directory: %w", err)
+		}
 
-	// Initialize workspace paths (kept for internal structure, not exposed in config)
-	// Frontend apps are in frontend/apps/<workspace>/projects/<app>/
-	// Backend services are in backend/services/<service>/
-	config.Workspace.ToolVersions = &workspace.ToolVersions{
-		Angular: "21.0.2",
-		Go:      "1.24.0",
-		NestJS:  "10.4.9",
-		Node:    "24.11.1",
-		Bazel:   "7.4.1",
-	}
+		// Create workspace configuration
+		config = workspace.NewConfig(workspaceName)
+		config.Schema = "https://raw.githubusercontent.com/dosanma1/forge-cli/main/schemas/forge-config.v1.schema.json"
+		config.NewProjectRoot = "."
+
+		// Initialize workspace paths (kept for internal structure, not exposed in config)
+		// Frontend apps are in frontend/apps/<app>/, each its own Angular workspace
+		// Backend services are in backend/services/<service>/
+		defaults := workspace.DefaultToolVersions()
+		config.Workspace.ToolVersions = &defaults
+
+		// Store GitHub org if provided
+		if opts.Data != nil {
+			if githubOrg, ok := opts.Data["github_org"].(string); ok && githubOrg != "" {
+				config.Workspace.GitHub = &workspace.GitHubConfig{Org: githubOrg}
+			}
+		}
 
-	// Store GitHub org if provided
-	if opts.Data != nil {
-		if githubOrg, ok := opts.Data["github_org"].(string); ok && githubOrg != "" {
-			config.Workspace.GitHub = &workspace.GitHubConfig{Org: githubOrg}
+		// Save forge.json
+		if err := config.SaveToDir(workspaceDir); err != nil {
+			return fmt.Errorf("failed to save workspace config: %w", err)
 		}
 	}
 
-	// Save forge.json
-	if err := config.SaveToDir(workspaceDir); err != nil {
-		return fmt.Errorf("failed to save workspace config: %w", err)
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
 	}
 
 	// Create directory structure
@@ -153,7 +183,7 @@ See [docs/](./docs/) for detailed documentation.
 `, workspaceName, workspaceName)
 
 	readmePath := filepath.Join(workspaceDir, "README.md")
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
+	if err := g.writeFile(readmePath, []byte(readmeContent)); err != nil {
 		return fmt.Errorf("failed to create README: %w", err)
 	}
 
@@ -197,7 +227,7 @@ Thumbs.db
 `
 
 	gitignorePath := filepath.Join(workspaceDir, ".gitignore")
-	if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
+	if err := g.writeFile(gitignorePath, []byte(gitignoreContent)); err != nil {
 		return fmt.Errorf("failed to create .gitignore: %w", err)
 	}
 
@@ -209,6 +239,9 @@ Thumbs.db
 	// Track created services and frontend for Bazel config
 	var createdServices []string
 	hasFrontend := false
+	if existing != nil {
+		createdServices, hasFrontend = existingBazelProjects(existing)
+	}
 
 	// Initial Bazel configuration (will be updated after services are created)
 	// Pass the github org from the config we just created
@@ -216,19 +249,13 @@ Thumbs.db
 	if config.Workspace.GitHub != nil {
 		githubOrg = config.Workspace.GitHub.Org
 	}
-	if err := g.generateBazelFilesWithOrg(workspaceDir, workspaceName, hasFrontend, createdServices, githubOrg); err != nil {
+	if err := g.generateBazelFilesWithOrg(workspaceDir, workspaceName, hasFrontend, createdServices, githubOrg, toolVersions); err != nil {
 		return fmt.Errorf("failed to generate Bazel files: %w", err)
 	}
 
 	// Note: forge.json is now the single source of truth (already created above)
 	// No need for separate .forge.yaml file
 
-	// Generate GitHub Actions workflows using the new workflow generator
-	workflowGen := NewWorkflowGenerator(config, workspaceDir)
-	if err := workflowGen.UpdateWorkflows(); err != nil {
-		return fmt.Errorf("failed to generate GitHub workflows: %w", err)
-	}
-
 	// Generate backend services if requested
 	if opts.Data != nil {
 		if servicesData, ok := opts.Data["services"].([]interface{}); ok {
@@ -238,7 +265,12 @@ Thumbs.db
 				serviceType := svc["Type"].(string)
 				deployer := svc["Deployer"].(string)
 
-				fmt.Printf("\n🚀 Generating %s service: %s (→ %s)\n", serviceType, serviceName, deployer)
+				if config.GetProject(serviceName) != nil {
+					log.Info("\n⏭️  Skipping service %s (already in forge.json)", serviceName)
+					continue
+				}
+
+				log.Info("\n🚀 Generating %s service: %s (→ %s)", serviceType, serviceName, deployer)
 
 				var serviceGen Generator
 				if serviceType == "NestJS" {
@@ -259,6 +291,13 @@ Thumbs.db
 					}
 				}
 
+				// Feature options such as grpc, set by workspace presets
+				if options, ok := svc["Options"].(map[string]interface{}); ok {
+					for k, v := range options {
+						data[k] = v
+					}
+				}
+
 				serviceOpts := GeneratorOptions{
 					OutputDir: workspaceDir,
 					Name:      serviceName,
@@ -282,11 +321,11 @@ Thumbs.db
 		if frontendsData, ok := opts.Data["frontends"].([]interface{}); ok {
 			// Check Node.js prerequisites before attempting frontend generation
 			if err := CheckNodeJS(); err != nil {
-				fmt.Printf("\n⚠️  Skipping frontend generation - Node.js not found\n")
-				fmt.Printf("   %s\n", err.Error())
+				log.Warn("\n⚠️  Skipping frontend generation - Node.js not found")
+				log.Warn("   %s", err.Error())
 			} else if err := CheckNPM(); err != nil {
-				fmt.Printf("\n⚠️  Skipping frontend generation - npm/npx not found\n")
-				fmt.Printf("   %s\n", err.Error())
+				log.Warn("\n⚠️  Skipping frontend generation - npm/npx not found")
+				log.Warn("   %s", err.Error())
 			} else {
 				for _, frontendData := range frontendsData {
 					frontend := frontendData.(map[string]interface{})
@@ -294,7 +333,12 @@ Thumbs.db
 					frontendType := frontend["Type"].(string)
 					deployment := frontend["Deployment"].(string)
 
-					fmt.Printf("\n🎨 Generating %s application: %s (→ %s)\n", frontendType, frontendName, deployment)
+					if config.GetProject(frontendName) != nil {
+						log.Info("\n⏭️  Skipping application %s (already in forge.json)", frontendName)
+						continue
+					}
+
+					log.Info("\n🎨 Generating %s application: %s (→ %s)", frontendType, frontendName, deployment)
 
 					hasFrontend = true
 
@@ -310,7 +354,10 @@ Thumbs.db
 						}
 					}
 
-					frontendGen := NewFrontendGenerator()
+					var frontendGen Generator = NewFrontendGenerator()
+					if frontendType == "Vue" {
+						frontendGen = NewVueGenerator()
+					}
 					frontendOpts := GeneratorOptions{
 						OutputDir: workspaceDir,
 						Name:      frontendName,
@@ -326,6 +373,28 @@ Thumbs.db
 		}
 	}
 
+	// Generate GitHub Actions workflows once the requested projects are in
+	// forge.json, so the CI matrix and deploy workflows cover them
+	workflowConfig, err := workspace.LoadConfig(workspaceDir)
+	if err != nil {
+		return fmt.Errorf("failed to reload workspace config: %w", err)
+	}
+	workflowGen := NewWorkflowGenerator(workflowConfig, workspaceDir)
+	if g.onlyMissing {
+		if err := workflowGen.GenerateMissingWorkflows(); err != nil {
+			return fmt.Errorf("failed to generate GitHub workflows: %w", err)
+		}
+	} else if err := workflowGen.UpdateWorkflows(); err != nil {
+		return fmt.Errorf("failed to generate GitHub workflows: %w", err)
+	}
+
+	// The service generators already registered new services in the existing
+	// go.work and MODULE.bazel, so there is nothing left to regenerate
+	if existing != nil {
+		log.Info("\n✓ Workspace at %s is up to date with the Forge scaffolding", workspaceDir)
+		return nil
+	}
+
 	// Update go.work to include generated services
 	if len(createdServices) > 0 {
 		if err := g.updateGoWork(workspaceDir, createdServices, config); err != nil {
@@ -335,21 +404,21 @@ Thumbs.db
 
 	// Regenerate MODULE.bazel with services and frontend info
 	if len(createdServices) > 0 || hasFrontend {
-		if err := g.generateBazelFilesWithOrg(workspaceDir, workspaceName, hasFrontend, createdServices, githubOrg); err != nil {
+		if err := g.generateBazelFilesWithOrg(workspaceDir, workspaceName, hasFrontend, createdServices, githubOrg, toolVersions); err != nil {
 			return fmt.Errorf("failed to regenerate Bazel files: %w", err)
 		}
 	}
 
-	fmt.Printf("\n✓ Workspace created successfully at: %s\n", workspaceDir)
-	fmt.Printf("✓ Run 'cd %s' to enter the workspace\n", workspaceName)
-	fmt.Printf("✓ Run 'forge setup' to install Bazel\n")
-	fmt.Printf("✓ Run 'forge setup-hooks' to configure git hooks\n")
+	log.Info("\n✓ Workspace created successfully at: %s", workspaceDir)
+	log.Info("✓ Run 'cd %s' to enter the workspace", workspaceName)
+	log.Info("✓ Run 'forge setup' to install Bazel")
+	log.Info("✓ Run 'forge setup-hooks' to configure git hooks")
 
 	return nil
 }
 
 // generateBazelFiles creates Bazel configuration files
-func (g *WorkspaceGenerator) generateBazelFilesWithOrg(workspaceDir, workspaceName string, hasFrontend bool, services []string, githubOrg string) error {
+func (g *WorkspaceGenerator) generateBazelFilesWithOrg(workspaceDir, workspaceName string, hasFrontend bool, services []string, githubOrg string, toolVersions workspace.ToolVersions) error {
 	files := map[string]string{
 		"MODULE.bazel":  "bazel/MODULE.bazel.tmpl",
 		"BUILD.bazel":   "bazel/BUILD.bazel.tmpl",
@@ -365,17 +434,18 @@ func (g *WorkspaceGenerator) generateBazelFilesWithOrg(workspaceDir, workspaceNa
 	for _, name := range services {
 		servicesData = append(servicesData, map[string]interface{}{
 			"Name": name,
+			"Root": workspace.DefaultServicesPath + "/" + name,
 		})
 	}
 
 	data := map[string]interface{}{
 		"ProjectName":    workspaceName,
 		"Version":        "0.1.0",
-		"GoVersion":      "1.24.0",
-		"NodeVersion":    "24.11.1",
-		"AngularVersion": "21.0.2",
-		"NestJSVersion":  "11.1.9",
-		"BazelVersion":   "7.4.1",
+		"GoVersion":      toolVersions.Go,
+		"NodeVersion":    toolVersions.Node,
+		"AngularVersion": toolVersions.Angular,
+		"NestJSVersion":  toolVersions.NestJS,
+		"BazelVersion":   toolVersions.Bazel,
 		"HasFrontend":    hasFrontend,
 		"Services":       servicesData,
 		"GitHubOrg":      githubOrg,
@@ -388,7 +458,7 @@ func (g *WorkspaceGenerator) generateBazelFilesWithOrg(workspaceDir, workspaceNa
 		}
 
 		filePath := filepath.Join(workspaceDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := g.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -396,36 +466,104 @@ func (g *WorkspaceGenerator) generateBazelFilesWithOrg(workspaceDir, workspaceNa
 	return nil
 }
 
-// generateGitHubWorkflows creates GitHub Actions workflow files
-func (g *WorkspaceGenerator) generateGitHubWorkflows(workspaceDir string) error {
-	workflowsDir := filepath.Join(workspaceDir, ".github", "workflows")
-	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create workflows directory: %w", err)
+// writeFile writes a generated file. When completing an existing workspace,
+// files that are already present are kept as they are.
+func (g *WorkspaceGenerator) writeFile(path string, content []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		if g.onlyMissing {
+			return nil
+		}
+		if g.overwrite {
+			log.Info("UPDATE %s", path)
+		}
+	} else if g.onlyMissing || g.overwrite {
+		log.Info("CREATE %s", path)
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// existingBazelProjects returns the Go services and whether there is a
+// frontend in an existing workspace, as needed to render the Bazel files.
+func existingBazelProjects(config *workspace.Config) ([]string, bool) {
+	var services []string
+	hasFrontend := false
+	for name, project := range config.Projects {
+		switch workspace.LanguageType(project.Language) {
+		case workspace.LanguageGo:
+			if project.ProjectType == string(workspace.ProjectKindService) {
+				services = append(services, name)
+			}
+		case workspace.LanguageAngular, workspace.LanguageVue:
+			hasFrontend = true
+		}
 	}
+	sort.Strings(services)
+	return services, hasFrontend
+}
+
+// ScaffoldInfrastructure writes the infrastructure files of an existing
+// workspace: the root skaffold.yaml, kind config, generic Helm service chart,
+// Cloud Run README and API gateway chart. Projects are not touched. Files that
+// already exist are kept unless overwrite is set.
+func (g *WorkspaceGenerator) ScaffoldInfrastructure(workspaceDir string, overwrite bool) error {
+	g.onlyMissing = !overwrite
+	g.overwrite = overwrite
+	defer func() {
+		g.onlyMissing = false
+		g.overwrite = false
+	}()
+
+	if err := os.MkdirAll(filepath.Join(workspaceDir, "infra"), 0755); err != nil {
+		return fmt.Errorf("failed to create infra directory: %w", err)
+	}
+	if err := g.generateRootSkaffold(workspaceDir); err != nil {
+		return err
+	}
+	return g.generateInfrastructure(workspaceDir)
+}
 
-	workflows := map[string]string{
-		"ci.yml":              "github/workflows/ci.yml.tmpl",
-		"deploy-gke.yml":      "github/workflows/deploy-gke.yml.tmpl",
-		"deploy-cloudrun.yml": "github/workflows/deploy-cloudrun.yml.tmpl",
-		"deploy-firebase.yml": "github/workflows/deploy-firebase.yml.tmpl",
+// generateRootSkaffold creates the root skaffold.yaml that requires the
+// skaffold.yaml of every service and the API gateway.
+func (g *WorkspaceGenerator) generateRootSkaffold(workspaceDir string) error {
+	config, err := workspace.LoadConfig(workspaceDir)
+	if err != nil {
+		return fmt.Errorf("failed to load workspace config: %w", err)
 	}
 
-	data := map[string]interface{}{
-		"GitHubOrg": "myorg", // Default, user can update manually
+	names := make([]string, 0, len(config.Projects))
+	for name := range config.Projects {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	for filename, templatePath := range workflows {
-		content, err := g.engine.RenderTemplate(templatePath, data)
-		if err != nil {
-			return fmt.Errorf("failed to render %s: %w", filename, err)
+	var services []map[string]interface{}
+	for _, name := range names {
+		project := config.Projects[name]
+		if project.ProjectType != string(workspace.ProjectKindService) {
+			continue
 		}
-
-		filePath := filepath.Join(workflowsDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", filename, err)
+		if _, err := os.Stat(filepath.Join(workspaceDir, project.Root, "skaffold.yaml")); err != nil {
+			continue
 		}
+		services = append(services, map[string]interface{}{
+			"Name": name,
+			"Root": filepath.ToSlash(project.Root),
+		})
+	}
+
+	data := map[string]interface{}{
+		"ProjectName":   config.Workspace.Name,
+		"Services":      services,
+		"HasAPIGateway": true,
+	}
+	content, err := g.engine.RenderTemplate("skaffold.yaml.tmpl", data)
+	if err != nil {
+		return fmt.Errorf("failed to render skaffold.yaml: %w", err)
 	}
 
+	if err := g.writeFile(filepath.Join(workspaceDir, "skaffold.yaml"), []byte(content)); err != nil {
+		return fmt.Errorf("failed to write skaffold.yaml: %w", err)
+	}
 	return nil
 }
 
@@ -451,13 +589,10 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 	}
 
 	kindPath := filepath.Join(infraDir, "kind-config.yaml")
-	if err := os.WriteFile(kindPath, []byte(kindContent), 0644); err != nil {
+	if err := g.writeFile(kindPath, []byte(kindContent)); err != nil {
 		return fmt.Errorf("failed to write kind-config.yaml: %w", err)
 	}
 
-	// Note: Root skaffold.yaml is created by createRootSkaffold() called earlier in Generate()
-	// No need to create it here again to avoid duplication
-
 	// Create helm directory with README
 	helmDir := filepath.Join(infraDir, "helm")
 	if err := os.MkdirAll(helmDir, 0755); err != nil {
@@ -473,7 +608,7 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 	}
 
 	helmReadmePath := filepath.Join(helmDir, "README.md")
-	if err := os.WriteFile(helmReadmePath, []byte(helmReadmeContent), 0644); err != nil {
+	if err := g.writeFile(helmReadmePath, []byte(helmReadmeContent)); err != nil {
 		return fmt.Errorf("failed to write helm README: %w", err)
 	}
 
@@ -497,7 +632,7 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 		return fmt.Errorf("failed to render Chart.yaml: %w", err)
 	}
 	chartPath := filepath.Join(helmServiceDir, "Chart.yaml")
-	if err := os.WriteFile(chartPath, []byte(chartContent), 0644); err != nil {
+	if err := g.writeFile(chartPath, []byte(chartContent)); err != nil {
 		return fmt.Errorf("failed to write Chart.yaml: %w", err)
 	}
 
@@ -507,7 +642,7 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 		return fmt.Errorf("failed to render values.yaml: %w", err)
 	}
 	valuesPath := filepath.Join(helmServiceDir, "values.yaml")
-	if err := os.WriteFile(valuesPath, []byte(valuesContent), 0644); err != nil {
+	if err := g.writeFile(valuesPath, []byte(valuesContent)); err != nil {
 		return fmt.Errorf("failed to write values.yaml: %w", err)
 	}
 
@@ -532,7 +667,7 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 			return fmt.Errorf("failed to read %s: %w", filename, err)
 		}
 		filePath := filepath.Join(helmTemplatesDir, filename)
-		if err := os.WriteFile(filePath, content, 0644); err != nil {
+		if err := g.writeFile(filePath, content); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -552,20 +687,67 @@ func (g *WorkspaceGenerator) generateInfrastructure(workspaceDir string) error {
 	}
 
 	cloudrunReadmePath := filepath.Join(cloudrunDir, "README.md")
-	if err := os.WriteFile(cloudrunReadmePath, []byte(cloudrunReadmeContent), 0644); err != nil {
+	if err := g.writeFile(cloudrunReadmePath, []byte(cloudrunReadmeContent)); err != nil {
 		return fmt.Errorf("failed to write cloudrun README: %w", err)
 	}
 
 	// Create api-gateway Helm chart
-	if err := g.generateAPIGateway(workspaceDir, projectName); err != nil {
+	if err := g.generateAPIGateway(workspaceDir, config); err != nil {
 		return fmt.Errorf("failed to generate API gateway: %w", err)
 	}
 
 	return nil
 }
 
+// defaultGatewayAnnotations are the ingress annotations of the API gateway,
+// enabling CORS for browser clients.
+var defaultGatewayAnnotations = map[string]string{
+	"nginx.ingress.kubernetes.io/enable-cors":            "true",
+	"nginx.ingress.kubernetes.io/cors-allow-methods":     "GET, PUT, POST, DELETE, PATCH, OPTIONS",
+	"nginx.ingress.kubernetes.io/cors-allow-headers":     "Authorization, Request-Context, Content-Type, traceparent, Origin, X-Requested-With",
+	"nginx.ingress.kubernetes.io/cors-allow-credentials": "true",
+	"nginx.ingress.kubernetes.io/cors-max-age":           "86400",
+	"nginx.ingress.kubernetes.io/use-regex":              "true",
+}
+
+// apiGatewayData returns the template data of the API gateway chart from the
+// workspace's apiGateway settings. The domain falls back to kubernetes.domain
+// and the ingress class to nginx.
+func apiGatewayData(config *workspace.Config, now time.Time) map[string]interface{} {
+	settings := config.Workspace.APIGateway
+	if settings == nil {
+		settings = &workspace.APIGatewayConfig{}
+	}
+
+	domain := settings.Domain
+	if domain == "" && config.Workspace.Kubernetes != nil {
+		domain = config.Workspace.Kubernetes.Domain
+	}
+	ingressClass := settings.IngressClass
+	if ingressClass == "" {
+		ingressClass = "nginx"
+	}
+
+	annotations := make(map[string]string, len(defaultGatewayAnnotations)+len(settings.Annotations))
+	for key, value := range defaultGatewayAnnotations {
+		annotations[key] = value
+	}
+	for key, value := range settings.Annotations {
+		annotations[key] = value
+	}
+
+	return map[string]interface{}{
+		"WorkspaceName": config.Workspace.Name,
+		"Domain":        domain,
+		"TLSIssuer":     settings.TLSIssuer,
+		"IngressClass":  ingressClass,
+		"Annotations":   annotations,
+		"Timestamp":     now.UTC().Format(time.RFC3339),
+	}
+}
+
 // generateAPIGateway creates the API gateway Helm chart infrastructure
-func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir, projectName string) error {
+func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir string, config *workspace.Config) error {
 	apiGatewayDir := filepath.Join(workspaceDir, "infra", "api-gateway")
 
 	// Create directory structure
@@ -581,10 +763,7 @@ func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir, projectName string
 		}
 	}
 
-	data := map[string]interface{}{
-		"WorkspaceName": projectName,
-		"Timestamp":     "2025-01-01T00:00:00Z", // Use current timestamp in production
-	}
+	data := apiGatewayData(config, time.Now())
 
 	// Generate root files
 	rootFiles := map[string]string{
@@ -602,7 +781,7 @@ func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir, projectName string
 		}
 
 		filePath := filepath.Join(apiGatewayDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := g.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -622,7 +801,7 @@ func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir, projectName string
 		}
 
 		filePath := filepath.Join(apiGatewayDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := g.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -639,7 +818,7 @@ func (g *WorkspaceGenerator) generateAPIGateway(workspaceDir, projectName string
 		}
 
 		filePath := filepath.Join(apiGatewayDir, filename)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := g.writeFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filename, err)
 		}
 	}
@@ -654,11 +833,17 @@ func (g *WorkspaceGenerator) updateGoWork(workspaceDir string, serviceNames []st
 	for _, name := range serviceNames {
 		services = append(services, map[string]interface{}{
 			"Name": name,
+			"Root": filepath.ToSlash(filepath.Join(config.ServicesPath(), name)),
 		})
 	}
 
+	toolVersions, err := config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
-		"GoVersion": config.Workspace.ToolVersions.Go,
+		"GoVersion": toolVersions.Go,
 		"Services":  services,
 	}
 
@@ -688,7 +873,7 @@ func (g *WorkspaceGenerator) createDependabotConfig(workspaceDir string) error {
 	}
 
 	dependabotPath := filepath.Join(githubDir, "dependabot.yml")
-	if err := os.WriteFile(dependabotPath, []byte(content), 0644); err != nil {
+	if err := g.writeFile(dependabotPath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to create dependabot.yml: %w", err)
 	}
 
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAPIGatewayValues(t *testing.T) {
+	type gatewayValues struct {
+		APIGateway struct {
+			Domain           string            `yaml:"domain"`
+			IngressClassName string            `yaml:"ingressClassName"`
+			Annotations      map[string]string `yaml:"annotations"`
+			TLS              struct {
+				Enabled    bool   `yaml:"enabled"`
+				SecretName string `yaml:"secretName"`
+				Issuer     string `yaml:"issuer"`
+			} `yaml:"tls"`
+		} `yaml:"apiGateway"`
+		CertManager struct {
+			ACME struct {
+				Enabled      bool   `yaml:"enabled"`
+				IngressClass string `yaml:"ingressClass"`
+			} `yaml:"acme"`
+		} `yaml:"certManager"`
+	}
+
+	tests := []struct {
+		name      string
+		workspace workspace.WorkspaceMetadata
+		check     func(t *testing.T, values gatewayValues)
+	}{
+		{
+			name:      "defaults",
+			workspace: workspace.WorkspaceMetadata{Name: "shop"},
+			check: func(t *testing.T, values gatewayValues) {
+				if values.APIGateway.Domain != "" || values.APIGateway.TLS.Enabled {
+					t.Errorf("domain = %q, tls = %v, want no domain and no TLS", values.APIGateway.Domain, values.APIGateway.TLS.Enabled)
+				}
+				if values.APIGateway.IngressClassName != "nginx" || values.CertManager.ACME.IngressClass != "nginx" {
+					t.Errorf("ingress class = %q, want nginx", values.APIGateway.IngressClassName)
+				}
+				if got := values.APIGateway.Annotations["nginx.ingress.kubernetes.io/enable-cors"]; got != "true" {
+					t.Errorf("enable-cors annotation = %q, want true", got)
+				}
+				if !values.CertManager.ACME.Enabled {
+					t.Error("the ACME issuer of the chart is disabled")
+				}
+			},
+		},
+		{
+			name: "kubernetes domain",
+			workspace: workspace.WorkspaceMetadata{
+				Name:       "shop",
+				Kubernetes: &workspace.KubernetesConfig{Namespace: "shop", Domain: "shop.example.com"},
+			},
+			check: func(t *testing.T, values gatewayValues) {
+				if values.APIGateway.Domain != "shop.example.com" {
+					t.Errorf("domain = %q, want shop.example.com", values.APIGateway.Domain)
+				}
+			},
+		},
+		{
+			name: "api gateway settings",
+			workspace: workspace.WorkspaceMetadata{
+				Name:       "shop",
+				Kubernetes: &workspace.KubernetesConfig{Namespace: "shop", Domain: "shop.example.com"},
+				APIGateway: &workspace.APIGatewayConfig{
+					Domain:       "api.example.com",
+					TLSIssuer:    "letsencrypt-prod",
+					IngressClass: "traefik",
+					Annotations: map[string]string{
+						"nginx.ingress.kubernetes.io/cors-max-age": "600",
+						"example.com/owner":                        "platform: team",
+					},
+				},
+			},
+			check: func(t *testing.T, values gatewayValues) {
+				gateway := values.APIGateway
+				if gateway.Domain != "api.example.com" || gateway.IngressClassName != "traefik" {
+					t.Errorf("domain = %q, ingress class = %q, want api.example.com and traefik", gateway.Domain, gateway.IngressClassName)
+				}
+				if !gateway.TLS.Enabled || gateway.TLS.Issuer != "letsencrypt-prod" || gateway.TLS.SecretName == "" {
+					t.Errorf("tls = %+v, want enabled with issuer letsencrypt-prod and a secret", gateway.TLS)
+				}
+				if values.CertManager.ACME.Enabled {
+					t.Error("the ACME issuer of the chart is enabled alongside tlsIssuer")
+				}
+				if got := gateway.Annotations["nginx.ingress.kubernetes.io/cors-max-age"]; got != "600" {
+					t.Errorf("cors-max-age annotation = %q, want 600", got)
+				}
+				if got := gateway.Annotations["example.com/owner"]; got != "platform: team" {
+					t.Errorf("owner annotation = %q, want %q", got, "platform: team")
+				}
+			},
+		},
+	}
+
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := apiGatewayData(&workspace.Config{Workspace: tt.workspace}, now)
+			if data["Timestamp"] != "2026-03-04T05:06:07Z" {
+				t.Errorf("Timestamp = %v, want 2026-03-04T05:06:07Z", data["Timestamp"])
+			}
+
+			content, err := template.NewEngine().RenderTemplate("infra/api-gateway/values.yaml.tmpl", data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var values gatewayValues
+			if err := yaml.Unmarshal([]byte(content), &values); err != nil {
+				t.Fatalf("values.yaml is invalid: %v\n%s", err, content)
+			}
+			tt.check(t, values)
+		})
+	}
+}
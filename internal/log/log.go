@@ -0,0 +1,164 @@
+// Package log provides leveled output for forge commands. The default text
+// format prints messages unchanged so the CLI keeps its friendly emoji output;
+// the JSON format writes one structured event per line for CI systems.
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the lowercase level name used in JSON events.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// Format is the output format of log messages.
+type Format string
+
+const (
+	// FormatText prints messages as-is (default)
+	FormatText Format = "text"
+
+	// FormatJSON prints one JSON event per line
+	FormatJSON Format = "json"
+)
+
+// ParseFormat validates a --log-format value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown log format %q (supported: text, json)", s)
+	}
+}
+
+// event is a single line in JSON mode.
+type event struct {
+	Time  string `json:"time"`
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+var (
+	mu     sync.Mutex
+	level            = LevelInfo
+	format           = FormatText
+	out    io.Writer = os.Stdout
+)
+
+// Configure sets the minimum level and the output format.
+func Configure(l Level, f Format) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+	format = f
+}
+
+// SetOutput sets the destination of log messages.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+}
+
+// Enabled reports whether messages at level l are printed.
+func Enabled(l Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return l >= level
+}
+
+// JSON reports whether output is structured, so callers can skip decorative
+// output such as separators and blank lines.
+func JSON() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return format == FormatJSON
+}
+
+// Debug logs details only shown with --verbose.
+func Debug(msg string, args ...interface{}) { logf(LevelDebug, msg, args...) }
+
+// Info logs regular progress output, hidden with --quiet.
+func Info(msg string, args ...interface{}) { logf(LevelInfo, msg, args...) }
+
+// Warn logs a problem that does not stop the command.
+func Warn(msg string, args ...interface{}) { logf(LevelWarn, msg, args...) }
+
+// Error logs a failure.
+func Error(msg string, args ...interface{}) { logf(LevelError, msg, args...) }
+
+func logf(l Level, msg string, args ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if l < level {
+		return
+	}
+
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+
+	if format == FormatJSON {
+		msg = plain(msg)
+		if msg == "" {
+			return
+		}
+		line, err := json.Marshal(event{
+			Time:  time.Now().UTC().Format(time.RFC3339),
+			Level: l.String(),
+			Msg:   msg,
+		})
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(out, string(line))
+		return
+	}
+
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	fmt.Fprint(out, msg)
+}
+
+// plain strips the decoration meant for terminals (surrounding blank lines,
+// indentation, leading emoji and bullets) from a message.
+func plain(msg string) string {
+	msg = strings.TrimSpace(msg)
+	return strings.TrimLeftFunc(msg, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.Is(unicode.So, r) || unicode.Is(unicode.Mn, r) || r == '•'
+	})
+}
@@ -8,7 +8,9 @@ import (
 )
 
 // CreateBazelArtifact creates a Skaffold Bazel artifact configuration for a project.
-func CreateBazelArtifact(projectName string, project workspace.Project, registry string, bazelArgs []string) *latest.Artifact {
+// When more than one platform is given, the artifact is built once per platform and
+// Skaffold assembles a manifest list when pushing.
+func CreateBazelArtifact(projectName string, project workspace.Project, registry string, bazelArgs []string, platforms []string) *latest.Artifact {
 	target := GenerateBazelTarget(project.Root, project.Language)
 	
 	// If registry is empty, use just the project name (for local development)
@@ -31,6 +33,11 @@ func CreateBazelArtifact(projectName string, project workspace.Project, registry
 		},
 	}
 
+	if len(platforms) > 1 {
+		artifact.Platforms = platforms
+		artifact.BazelArtifact.PlatformMappings = GetBazelPlatformMappings(platforms)
+	}
+
 	return artifact
 }
 
@@ -40,6 +47,7 @@ func CreateBazelArtifacts(projects map[string]workspace.Project, projectNames []
 
 	// Get platform-specific Bazel args
 	bazelArgs := GetBazelPlatformArgs(platform)
+	platforms := ParsePlatforms(platform)
 
 	for _, projectName := range projectNames {
 		project, exists := projects[projectName]
@@ -47,13 +55,23 @@ func CreateBazelArtifacts(projects map[string]workspace.Project, projectNames []
 			continue
 		}
 
-		artifact := CreateBazelArtifact(projectName, project, registry, bazelArgs)
+		artifact := CreateBazelArtifact(projectName, project, registry, bazelArgs, platforms)
 		artifacts = append(artifacts, artifact)
 	}
 
 	return artifacts
 }
 
+// ResolveRegistry returns the registry the images of a build target are pushed
+// to in configuration, preferring registries set for that environment over the
+// project and workspace defaults (see workspace.Config.Registry).
+func ResolveRegistry(config *workspace.Config, target *workspace.ArchitectTarget, configuration string) string {
+	if registry := config.Registry(target, configuration); registry != "" {
+		return registry
+	}
+	return "gcr.io/default-project"
+}
+
 // GetRegistryFromOptions extracts the registry from build options.
 // Falls back to the provided default if not specified in options.
 func GetRegistryFromOptions(options map[string]interface{}, defaultRegistry string) string {
@@ -3,6 +3,8 @@ package skaffold
 import (
 	"fmt"
 	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
 )
 
 // GenerateBazelTarget generates a Bazel target path from a project root and language.
@@ -25,7 +27,7 @@ func GenerateBazelTarget(projectRoot, language string) string {
 		// Go services have the image target in cmd/server
 		// Use image_tarball.tar which outputs the actual tarball file (not directory)
 		target = fmt.Sprintf("%s/cmd/server:image_tarball.tar", root)
-	case "typescript", "angular", "nestjs":
+	case "typescript", "angular", "vue", "nestjs":
 		// TypeScript/Angular/NestJS projects have different structure
 		// For now, assume they follow the apps/{project-name} pattern
 		// Extract project name from root (e.g., "frontend/apps/web-app" -> "web-app")
@@ -42,20 +44,72 @@ func GenerateBazelTarget(projectRoot, language string) string {
 	return target
 }
 
+// bazelPlatformTargets maps target platforms to rules_go toolchain platforms (bzlmod naming).
+var bazelPlatformTargets = map[string]string{
+	"linux/amd64":  "@rules_go//go/toolchain:linux_amd64",
+	"linux/arm64":  "@rules_go//go/toolchain:linux_arm64",
+	"darwin/amd64": "@rules_go//go/toolchain:darwin_amd64",
+	"darwin/arm64": "@rules_go//go/toolchain:darwin_arm64",
+}
+
+// BazelPlatformTarget returns the Bazel platform target of a platform like
+// "linux/arm64", and whether Bazel can build for it.
+func BazelPlatformTarget(platform string) (string, bool) {
+	target, ok := bazelPlatformTargets[strings.TrimSpace(platform)]
+	return target, ok
+}
+
+// ParsePlatforms splits a comma-separated platform list (e.g. "linux/amd64,linux/arm64").
+// Empty entries are dropped and duplicates are removed, keeping the original order.
+func ParsePlatforms(platform string) []string {
+	var platforms []string
+	seen := make(map[string]bool)
+	for _, p := range strings.Split(platform, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		platforms = append(platforms, p)
+	}
+	return platforms
+}
+
+// ValidatePlatforms returns an error if any platform has no Bazel toolchain mapping.
+func ValidatePlatforms(platforms []string) error {
+	for _, p := range platforms {
+		if _, ok := bazelPlatformTargets[p]; !ok {
+			return fmt.Errorf("unsupported platform %q (supported: linux/amd64, linux/arm64, darwin/amd64, darwin/arm64)", p)
+		}
+	}
+	return nil
+}
+
 // GetBazelPlatformArgs returns platform-specific Bazel arguments for builds.
-// Uses bzlmod naming convention (@rules_go) instead of WORKSPACE (@io_bazel_rules_go)
+// Uses bzlmod naming convention (@rules_go) instead of WORKSPACE (@io_bazel_rules_go).
+// For a comma-separated list no --platforms flag is returned; Skaffold selects the
+// platform per build through the artifact's platform mappings instead.
 func GetBazelPlatformArgs(platform string) []string {
-	switch platform {
-	case "linux/amd64":
-		return []string{"--platforms=@rules_go//go/toolchain:linux_amd64"}
-	case "linux/arm64":
-		return []string{"--platforms=@rules_go//go/toolchain:linux_arm64"}
-	case "darwin/amd64":
-		return []string{"--platforms=@rules_go//go/toolchain:darwin_amd64"}
-	case "darwin/arm64":
-		return []string{"--platforms=@rules_go//go/toolchain:darwin_arm64"}
-	default:
-		// Default to linux/amd64
-		return []string{"--platforms=@rules_go//go/toolchain:linux_amd64"}
+	if len(ParsePlatforms(platform)) > 1 {
+		return nil
+	}
+	if target, ok := bazelPlatformTargets[strings.TrimSpace(platform)]; ok {
+		return []string{"--platforms=" + target}
+	}
+	// Default to linux/amd64
+	return []string{"--platforms=" + bazelPlatformTargets["linux/amd64"]}
+}
+
+// GetBazelPlatformMappings returns Skaffold platform mappings for a multi-arch build.
+func GetBazelPlatformMappings(platforms []string) []latest.BazelPlatformMapping {
+	mappings := make([]latest.BazelPlatformMapping, 0, len(platforms))
+	for _, p := range platforms {
+		if target, ok := bazelPlatformTargets[p]; ok {
+			mappings = append(mappings, latest.BazelPlatformMapping{
+				Platform:            p,
+				BazelPlatformTarget: target,
+			})
+		}
 	}
+	return mappings
 }
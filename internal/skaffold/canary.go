@@ -0,0 +1,172 @@
+package skaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+	"gopkg.in/yaml.v3"
+)
+
+// EnableCanary turns every Helm release into a "<name>-canary" release whose
+// ingress receives weight percent of the traffic through ingress-nginx canary
+// annotations, leaving the stable release untouched. It returns a cleanup
+// function removing the generated values file once the deploy has finished.
+func (e *Executor) EnableCanary(weight int) (func(), error) {
+	// Annotations go through a values file: --set would turn "true" into a bool
+	valuesFile, err := os.CreateTemp("", "forge-canary-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary values file: %w", err)
+	}
+	cleanup := func() { os.Remove(valuesFile.Name()) }
+
+	values := fmt.Sprintf(`ingress:
+  annotations:
+    nginx.ingress.kubernetes.io/canary: "true"
+    nginx.ingress.kubernetes.io/canary-weight: "%d"
+`, weight)
+	if _, err := valuesFile.WriteString(values); err != nil {
+		valuesFile.Close()
+		cleanup()
+		return nil, fmt.Errorf("failed to write canary values file: %w", err)
+	}
+	if err := valuesFile.Close(); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to write canary values file: %w", err)
+	}
+
+	canaryReleases(e.config.Deploy.LegacyHelmDeploy, valuesFile.Name())
+	for i := range e.config.Profiles {
+		canaryReleases(e.config.Profiles[i].Deploy.LegacyHelmDeploy, valuesFile.Name())
+	}
+
+	return cleanup, nil
+}
+
+func canaryReleases(helm *latest.LegacyHelmDeploy, valuesFile string) {
+	if helm == nil {
+		return
+	}
+
+	for i := range helm.Releases {
+		release := &helm.Releases[i]
+		if strings.HasSuffix(release.Name, "-canary") {
+			continue
+		}
+		release.Name += "-canary"
+		release.ValuesFiles = append(append([]string{}, release.ValuesFiles...), valuesFile)
+
+		values := make(map[string]string, len(release.SetValueTemplates)+1)
+		for k, v := range release.SetValueTemplates {
+			values[k] = v
+		}
+		values["fullnameOverride"] = release.Name
+		release.SetValueTemplates = values
+	}
+}
+
+// PinCloudRunTraffic deploys Cloud Run canaries without traffic. Each service
+// manifest, keyed by path, is rewritten to keep all traffic on its stable
+// revision, so the new revision only receives traffic once it is split
+// explicitly. It returns a cleanup function removing the rewritten manifests.
+func (e *Executor) PinCloudRunTraffic(stableRevisions map[string]string) (func(), error) {
+	var written []string
+	cleanup := func() {
+		for _, path := range written {
+			os.Remove(path)
+		}
+	}
+
+	for manifest, revision := range stableRevisions {
+		data, err := os.ReadFile(manifest)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to read cloud run manifest: %w", err)
+		}
+
+		pinned, err := pinTraffic(data, revision)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to pin traffic in %s: %w", manifest, err)
+		}
+
+		pinnedFile, err := os.CreateTemp("", "forge-canary-*.yaml")
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to create canary manifest: %w", err)
+		}
+		written = append(written, pinnedFile.Name())
+		if _, err := pinnedFile.Write(pinned); err != nil {
+			pinnedFile.Close()
+			cleanup()
+			return nil, fmt.Errorf("failed to write canary manifest: %w", err)
+		}
+		if err := pinnedFile.Close(); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to write canary manifest: %w", err)
+		}
+
+		e.replaceManifest(manifest, pinnedFile.Name())
+	}
+
+	return cleanup, nil
+}
+
+// replaceManifest swaps a raw manifest for another, adding it when the config
+// does not list it yet.
+func (e *Executor) replaceManifest(original, replacement string) {
+	rawK8s := e.config.Render.RawK8s
+	for i, path := range rawK8s {
+		if path == original || filepath.Join(e.workspaceRoot, path) == original {
+			rawK8s[i] = replacement
+			return
+		}
+	}
+	e.config.Render.RawK8s = append(rawK8s, replacement)
+}
+
+// pinTraffic replaces the traffic block of a Knative service manifest with a
+// single target sending all traffic to revision.
+func pinTraffic(data []byte, revision string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("not a cloud run service manifest")
+	}
+
+	spec := mappingValue(doc.Content[0], "spec")
+	if spec == nil || spec.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("manifest has no spec")
+	}
+
+	traffic := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "revisionName"},
+			{Kind: yaml.ScalarNode, Value: revision},
+			{Kind: yaml.ScalarNode, Value: "percent"},
+			{Kind: yaml.ScalarNode, Tag: "!!int", Value: "100"},
+		},
+	}}}
+	if existing := mappingValue(spec, "traffic"); existing != nil {
+		*existing = *traffic
+	} else {
+		spec.Content = append(spec.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "traffic"}, traffic)
+	}
+
+	return yaml.Marshal(&doc)
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
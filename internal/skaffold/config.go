@@ -20,6 +20,12 @@ func GenerateConfig(config *workspace.Config, projectNames []string, workspaceRo
 		return nil, fmt.Errorf("at least one project name is required")
 	}
 
+	if platform != "" {
+		if err := ValidatePlatforms(ParsePlatforms(platform)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Start with default config
 	skaffoldConfig := GetDefaultConfig()
 
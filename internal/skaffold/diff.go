@@ -0,0 +1,219 @@
+package skaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"gopkg.in/yaml.v3"
+)
+
+// Diff renders the manifests for the given profile and compares them with the
+// live state. Helm and kubectl manifests are compared with `kubectl diff`;
+// Cloud Run services are compared with the exported service definition.
+func (e *Executor) Diff(ctx context.Context, opts DiffOptions) (*DiffResult, error) {
+	if opts.Profile == "" {
+		return nil, fmt.Errorf("profile is required for diff")
+	}
+
+	rendered, err := e.Render(ctx, RenderOptions{Profile: opts.Profile, Verbose: opts.Verbose})
+	if err != nil {
+		return nil, err
+	}
+
+	kubeDocs, cloudRunDocs, err := splitManifests(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{CloudRun: make(map[string]string)}
+
+	if len(kubeDocs) > 0 {
+		out, err := e.kubectlDiff(ctx, kubeDocs)
+		if err != nil {
+			return nil, err
+		}
+		result.Kubernetes = out
+	}
+
+	profiled := e.applyProfile(opts.Profile)
+	var region, projectID string
+	if cr := profiled.Pipeline.Deploy.CloudRunDeploy; cr != nil {
+		region = cr.Region
+		projectID = cr.ProjectID
+	}
+
+	for name, doc := range cloudRunDocs {
+		out, err := e.cloudRunDiff(ctx, name, doc, region, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if out != "" {
+			result.CloudRun[name] = out
+		}
+	}
+
+	return result, nil
+}
+
+// Render returns the manifests Skaffold would deploy for the given profile.
+// Nothing is built or pushed: image tags are resolved from the local images
+// if present.
+func (e *Executor) Render(ctx context.Context, opts RenderOptions) ([]byte, error) {
+	if opts.Profile == "" {
+		return nil, fmt.Errorf("profile is required for render")
+	}
+
+	tmpFile, _, err := e.writeProfileConfig(opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	args := []string{"render", "-f", tmpFile.Name(), "--profile", opts.Profile, "--digest-source=tag"}
+	if opts.Verbose {
+		args = append(args, "-v", "info")
+	}
+
+	var rendered, stderr bytes.Buffer
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "skaffold",
+		Args:   args,
+		Dir:    e.workspaceRoot,
+		Env:    []string{"SKAFFOLD_UPDATE_CHECK=false"},
+		Stdout: &rendered,
+		Stderr: &stderr,
+	}); err != nil {
+		return nil, fmt.Errorf("skaffold render failed: %w\n%s", err, stderr.String())
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// splitManifests separates rendered documents into Kubernetes manifests and
+// Knative services (Cloud Run), keyed by service name.
+func splitManifests(rendered []byte) ([][]byte, map[string][]byte, error) {
+	var kubeDocs [][]byte
+	cloudRunDocs := make(map[string][]byte)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(rendered))
+	for {
+		var doc map[string]interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, fmt.Errorf("failed to parse rendered manifests: %w", err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+
+		content, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+
+		apiVersion, _ := doc["apiVersion"].(string)
+		if strings.HasPrefix(apiVersion, "serving.knative.dev/") {
+			metadata, _ := doc["metadata"].(map[string]interface{})
+			name, _ := metadata["name"].(string)
+			if name == "" {
+				return nil, nil, fmt.Errorf("cloud run manifest is missing metadata.name")
+			}
+			cloudRunDocs[name] = content
+			continue
+		}
+
+		kubeDocs = append(kubeDocs, content)
+	}
+
+	return kubeDocs, cloudRunDocs, nil
+}
+
+// kubectlDiff pipes manifests to `kubectl diff`. Exit code 1 means differences were found.
+func (e *Executor) kubectlDiff(ctx context.Context, docs [][]byte) (string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "kubectl",
+		Args:   []string{"diff", "-f", "-"},
+		Dir:    e.workspaceRoot,
+		Stdin:  bytes.NewReader(bytes.Join(docs, []byte("---\n"))),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		if exec.ExitCode(err) == 1 {
+			return stdout.String(), nil
+		}
+		return "", fmt.Errorf("kubectl diff failed: %w\n%s", err, stderr.String())
+	}
+
+	return "", nil
+}
+
+// cloudRunDiff compares a rendered Knative service with the live Cloud Run service.
+// A service that does not exist yet is diffed against an empty definition.
+func (e *Executor) cloudRunDiff(ctx context.Context, name string, rendered []byte, region, projectID string) (string, error) {
+	args := []string{"run", "services", "describe", name, "--format", "export"}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	if projectID != "" {
+		args = append(args, "--project", projectID)
+	}
+
+	var live, stderr bytes.Buffer
+	if err := exec.Run(ctx, exec.Options{Name: "gcloud", Args: args, Stdout: &live, Stderr: &stderr}); err != nil {
+		if !strings.Contains(stderr.String(), "could not be found") {
+			return "", fmt.Errorf("failed to describe cloud run service %s: %w\n%s", name, err, stderr.String())
+		}
+		live.Reset()
+	}
+
+	liveFile, err := writeDiffFile("live", live.Bytes())
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(liveFile)
+
+	renderedFile, err := writeDiffFile("rendered", rendered)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(renderedFile)
+
+	var out bytes.Buffer
+	if err := exec.Run(ctx, exec.Options{
+		Name:   "diff",
+		Args:   []string{"-u", "--label", name + " (live)", "--label", name + " (rendered)", liveFile, renderedFile},
+		Stdout: &out,
+		Stderr: io.Discard,
+	}); err != nil {
+		if exec.ExitCode(err) == 1 {
+			return out.String(), nil
+		}
+		return "", fmt.Errorf("diff failed for cloud run service %s: %w", name, err)
+	}
+
+	return "", nil
+}
+
+// writeDiffFile writes content to a temp file and returns its path.
+func writeDiffFile(prefix string, content []byte) (string, error) {
+	f, err := os.CreateTemp("", "forge-diff-"+prefix+"-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(content); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return f.Name(), nil
+}
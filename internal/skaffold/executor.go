@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 
 	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/runner"
 	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/runner/runcontext"
 	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+	"github.com/dosanma1/forge-cli/internal/exec"
 	"gopkg.in/yaml.v3"
 )
 
@@ -70,28 +70,13 @@ func (e *Executor) Deploy(ctx context.Context, opts DeployOptions) error {
 		return fmt.Errorf("profile is required for deploy")
 	}
 
-	// Apply profile before handing off to CLI so rendered config matches intent
-	profiledCfg := e.applyProfile(opts.Profile)
-
-	configYAML, err := yaml.Marshal(profiledCfg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal skaffold config: %w", err)
-	}
-
 	// Always persist to a temp file; also mirror to the old debug path for discoverability
-	tmpFile, err := os.CreateTemp("", "forge-skaffold-*.yaml")
+	tmpFile, configYAML, err := e.writeProfileConfig(opts.Profile)
 	if err != nil {
-		return fmt.Errorf("failed to create temp skaffold config: %w", err)
+		return err
 	}
 	defer os.Remove(tmpFile.Name())
 
-	if _, err := tmpFile.Write(configYAML); err != nil {
-		return fmt.Errorf("failed to write temp skaffold config: %w", err)
-	}
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("failed to close temp skaffold config: %w", err)
-	}
-
 	if opts.Debug {
 		fmt.Println("\n=== DEBUG: Skaffold Configuration ===")
 		fmt.Println(string(configYAML))
@@ -99,7 +84,7 @@ func (e *Executor) Deploy(ctx context.Context, opts DeployOptions) error {
 			fmt.Println("✓ Written Skaffold config to /tmp/skaffold-debug.yaml")
 		}
 		fmt.Printf("Temp config: %s\n", tmpFile.Name())
-		fmt.Println("=== END DEBUG ===\n")
+		fmt.Print("=== END DEBUG ===\n\n")
 	}
 
 	args := []string{"run", "-f", tmpFile.Name(), "--profile", opts.Profile}
@@ -107,19 +92,46 @@ func (e *Executor) Deploy(ctx context.Context, opts DeployOptions) error {
 		args = append(args, "-v", "debug")
 	}
 
-	cmd := exec.CommandContext(ctx, "skaffold", args...)
-	cmd.Dir = e.workspaceRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Env = append(os.Environ(), "SKAFFOLD_UPDATE_CHECK=false")
-
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, exec.Options{
+		Name: "skaffold",
+		Args: args,
+		Dir:  e.workspaceRoot,
+		Env:  []string{"SKAFFOLD_UPDATE_CHECK=false"},
+	}); err != nil {
 		return fmt.Errorf("skaffold cli deploy failed: %w", err)
 	}
 
 	return nil
 }
 
+// writeProfileConfig applies the profile and writes the resulting config to a temp file
+// so it can be handed to the Skaffold CLI. The caller must remove the file.
+func (e *Executor) writeProfileConfig(profile string) (*os.File, []byte, error) {
+	// Apply profile before handing off to CLI so rendered config matches intent
+	profiledCfg := e.applyProfile(profile)
+
+	configYAML, err := yaml.Marshal(profiledCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal skaffold config: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "forge-skaffold-*.yaml")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temp skaffold config: %w", err)
+	}
+
+	if _, err := tmpFile.Write(configYAML); err != nil {
+		os.Remove(tmpFile.Name())
+		return nil, nil, fmt.Errorf("failed to write temp skaffold config: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return nil, nil, fmt.Errorf("failed to close temp skaffold config: %w", err)
+	}
+
+	return tmpFile, configYAML, nil
+}
+
 // Run executes a Skaffold dev/run operation with the specified profile.
 func (e *Executor) Run(ctx context.Context, opts RunOptions) error {
 	if opts.Profile == "" {
@@ -191,7 +203,7 @@ func (e *Executor) applyProfile(profileName string) *latest.SkaffoldConfig {
 			}
 
 			// Merge build config
-			if profile.Build.TagPolicy.GitTagger != nil {
+			if profile.Build.TagPolicy != (latest.TagPolicy{}) {
 				config.Pipeline.Build.TagPolicy = profile.Build.TagPolicy
 			}
 
@@ -21,7 +21,10 @@ func GenerateProfiles(config *workspace.Config, projectNames []string, workspace
 
 	// Create a profile for each configuration
 	for _, configKey := range configKeys {
-		profile := createProfile(config, projectNames, configKey, workspaceRoot, platform)
+		profile, err := createProfile(config, projectNames, configKey, workspaceRoot, platform)
+		if err != nil {
+			return nil, err
+		}
 		// Skip profiles with no artifacts (e.g., when all projects use @forge/angular:build)
 		if len(profile.Pipeline.Build.Artifacts) > 0 {
 			profiles = append(profiles, profile)
@@ -32,7 +35,8 @@ func GenerateProfiles(config *workspace.Config, projectNames []string, workspace
 }
 
 // collectConfigurationKeys collects all unique configuration keys from the selected projects.
-// Since we validate that build and deploy configs match, we only need to check build configs.
+// forge.json validation rejects services whose build and deploy configuration keys differ,
+// and forge deploy checks the configuration it uses, so only build configs are read.
 func collectConfigurationKeys(config *workspace.Config, projectNames []string) []string {
 	keysMap := make(map[string]bool)
 
@@ -63,12 +67,19 @@ func collectConfigurationKeys(config *workspace.Config, projectNames []string) [
 }
 
 // createProfile creates a single Skaffold profile for a configuration key.
-func createProfile(config *workspace.Config, projectNames []string, configKey string, workspaceRoot string, platform string) latest.Profile {
+// Images are tagged following the tag strategy of the configuration.
+func createProfile(config *workspace.Config, projectNames []string, configKey string, workspaceRoot string, platform string) (latest.Profile, error) {
+	strategy, err := profileTagStrategy(config, projectNames, configKey)
+	if err != nil {
+		return latest.Profile{}, err
+	}
+
 	profile := latest.Profile{
 		Name: configKey,
 		Pipeline: latest.Pipeline{
 			Build: latest.BuildConfig{
 				Artifacts: []*latest.Artifact{},
+				TagPolicy: TagPolicy(strategy),
 			},
 			Deploy: latest.DeployConfig{
 				DeployType: latest.DeployType{
@@ -97,25 +108,8 @@ func createProfile(config *workspace.Config, projectNames []string, configKey st
 			continue
 		}
 
-		// Get base options
-		baseOptions := project.Architect.Build.Options
-		if baseOptions == nil {
-			baseOptions = make(map[string]interface{})
-		}
-
-		// Get configuration-specific options
-		configOptions := make(map[string]interface{})
-		if project.Architect.Build.Configurations != nil {
-			if cfg, ok := project.Architect.Build.Configurations[configKey].(map[string]interface{}); ok {
-				configOptions = cfg
-			}
-		}
-
-		// Merge options: config-specific overrides base
-		mergedOptions := mergeOptions(baseOptions, configOptions)
-
-		// Get registry (config-specific or base)
-		registry := GetRegistryFromOptions(mergedOptions, "gcr.io/default-project")
+		// Get registry (environment-specific or base)
+		registry := ResolveRegistry(config, project.Architect.Build, configKey)
 
 		// Note: We don't add --config=race or --config=debug here
 		// Environment-specific profiles (local, development, production) should
@@ -125,7 +119,7 @@ func createProfile(config *workspace.Config, projectNames []string, configKey st
 		// Skip @forge/angular:build as it produces static files, not container images
 		builder := project.Architect.Build.Builder
 		if builder == "@forge/bazel:build" {
-			artifact := CreateBazelArtifact(projectName, project, registry, bazelArgs)
+			artifact := CreateBazelArtifact(projectName, project, registry, bazelArgs, ParsePlatforms(platform))
 			profile.Pipeline.Build.Artifacts = append(profile.Pipeline.Build.Artifacts, artifact)
 		}
 
@@ -234,7 +228,7 @@ func createProfile(config *workspace.Config, projectNames []string, configKey st
 		}
 	}
 
-	return profile
+	return profile, nil
 }
 
 // mergeOptions merges two option maps, with override values taking precedence.
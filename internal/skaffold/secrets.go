@@ -0,0 +1,40 @@
+package skaffold
+
+import (
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+)
+
+// ReferenceSecret makes the given Helm releases load their environment from the
+// Kubernetes Secret secretName through the chart's envFrom value. Only the
+// secret name ends up in the release values, never its data.
+func (e *Executor) ReferenceSecret(releases []string, secretName string) {
+	names := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		names[release] = true
+	}
+
+	referenceSecret(e.config.Deploy.LegacyHelmDeploy, names, secretName)
+	for i := range e.config.Profiles {
+		referenceSecret(e.config.Profiles[i].Deploy.LegacyHelmDeploy, names, secretName)
+	}
+}
+
+func referenceSecret(helm *latest.LegacyHelmDeploy, releases map[string]bool, secretName string) {
+	if helm == nil {
+		return
+	}
+
+	for i := range helm.Releases {
+		release := &helm.Releases[i]
+		if !releases[release.Name] {
+			continue
+		}
+
+		values := make(map[string]string, len(release.SetValueTemplates)+1)
+		for k, v := range release.SetValueTemplates {
+			values[k] = v
+		}
+		values["envFrom[0].secretRef.name"] = secretName
+		release.SetValueTemplates = values
+	}
+}
@@ -0,0 +1,54 @@
+package skaffold
+
+import (
+	"fmt"
+
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// TagPolicy returns the Skaffold tag policy of a tag strategy. The tags match
+// the ones builder.ResolveImageTag computes for direct builds.
+func TagPolicy(strategy workspace.TagStrategy) latest.TagPolicy {
+	switch strategy {
+	case workspace.TagStrategySemver:
+		return latest.TagPolicy{GitTagger: &latest.GitTagger{Variant: "Tags"}}
+	case workspace.TagStrategyTimestamp:
+		return latest.TagPolicy{DateTimeTagger: &latest.DateTimeTagger{
+			Format:   workspace.TimestampTagFormat,
+			TimeZone: "UTC",
+		}}
+	case workspace.TagStrategyLatest:
+		return latest.TagPolicy{EnvTemplateTagger: &latest.EnvTemplateTagger{Template: "latest"}}
+	default:
+		return latest.TagPolicy{GitTagger: &latest.GitTagger{Variant: "AbbrevCommitSha"}}
+	}
+}
+
+// profileTagStrategy returns the tag strategy of the Bazel-built projects in
+// configKey. Skaffold tags all the artifacts of a profile alike, so the
+// projects must agree on it.
+func profileTagStrategy(config *workspace.Config, projectNames []string, configKey string) (workspace.TagStrategy, error) {
+	var strategy workspace.TagStrategy
+	var owner string
+	for _, projectName := range projectNames {
+		project, exists := config.Projects[projectName]
+		if !exists || project.Architect == nil || project.Architect.Build == nil {
+			continue
+		}
+		if project.Architect.Build.Builder != "@forge/bazel:build" {
+			continue
+		}
+
+		projectStrategy, err := workspace.ImageTagStrategy(project.Architect.Build, configKey)
+		if err != nil {
+			return "", fmt.Errorf("project %s: %w", projectName, err)
+		}
+		if strategy != "" && projectStrategy != strategy {
+			return "", fmt.Errorf("projects %s and %s use different tag strategies for %s (%s and %s); Skaffold tags the images of a configuration alike",
+				owner, projectName, configKey, strategy, projectStrategy)
+		}
+		strategy, owner = projectStrategy, projectName
+	}
+	return strategy, nil
+}
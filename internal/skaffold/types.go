@@ -34,6 +34,38 @@ type DeployOptions struct {
 	PortForward bool
 }
 
+// RenderOptions contains options for rendering manifests without deploying them.
+type RenderOptions struct {
+	// Profile is the Skaffold profile to use
+	Profile string
+
+	// Verbose enables verbose output
+	Verbose bool
+}
+
+// DiffOptions contains options for comparing rendered manifests with live state.
+type DiffOptions struct {
+	// Profile is the Skaffold profile to use
+	Profile string
+
+	// Verbose enables verbose output
+	Verbose bool
+}
+
+// DiffResult contains the differences between rendered manifests and live state.
+type DiffResult struct {
+	// Kubernetes is the kubectl diff output for Helm/kubectl manifests
+	Kubernetes string
+
+	// CloudRun maps Cloud Run service names to unified diffs against the live service
+	CloudRun map[string]string
+}
+
+// HasChanges reports whether any difference was found.
+func (r *DiffResult) HasChanges() bool {
+	return r.Kubernetes != "" || len(r.CloudRun) > 0
+}
+
 // RunOptions contains options for Skaffold dev/run operations.
 type RunOptions struct {
 	// Profile is the Skaffold profile to use
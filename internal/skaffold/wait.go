@@ -0,0 +1,36 @@
+package skaffold
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/v2/pkg/skaffold/schema/latest"
+)
+
+// WaitForRollout makes the deploy succeed only once the deployed resources are
+// ready: Helm releases are installed with --wait and --timeout, and Skaffold's
+// status check, which waits for Kubernetes deployments to be Available and
+// Cloud Run revisions to be Ready, fails the deploy after timeout.
+func (e *Executor) WaitForRollout(timeout time.Duration) {
+	e.config.Deploy.StatusCheck = boolPtr(true)
+	e.config.Deploy.StatusCheckDeadlineSeconds = int(math.Ceil(timeout.Seconds()))
+
+	waitReleases(e.config.Deploy.LegacyHelmDeploy, timeout)
+	for i := range e.config.Profiles {
+		waitReleases(e.config.Profiles[i].Deploy.LegacyHelmDeploy, timeout)
+	}
+}
+
+func waitReleases(helm *latest.LegacyHelmDeploy, timeout time.Duration) {
+	if helm == nil {
+		return
+	}
+
+	for i := range helm.Releases {
+		helm.Releases[i].Wait = true
+	}
+	flag := fmt.Sprintf("--timeout=%s", timeout)
+	helm.Flags.Install = append(append([]string{}, helm.Flags.Install...), flag)
+	helm.Flags.Upgrade = append(append([]string{}, helm.Flags.Upgrade...), flag)
+}
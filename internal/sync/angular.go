@@ -0,0 +1,223 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// AngularProject is a project declared in an angular.json file.
+type AngularProject struct {
+	Name        string
+	Root        string // Relative path from workspace root
+	ProjectType string // "application" or "library"
+}
+
+// angularJSON is the subset of angular.json read by sync.
+type angularJSON struct {
+	Projects map[string]struct {
+		ProjectType string `json:"projectType"`
+		Root        string `json:"root"`
+	} `json:"projects"`
+}
+
+// DiscoverAngularProjects finds all projects declared in angular.json files in the workspace.
+func (s *Syncer) DiscoverAngularProjects() ([]AngularProject, error) {
+	var projects []AngularProject
+
+	err := filepath.WalkDir(s.workspaceRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if name == "node_modules" || name == "dist" || name == "vendor" ||
+				strings.HasPrefix(name, "bazel-") {
+				return filepath.SkipDir
+			}
+			if path != s.workspaceRoot && len(name) > 0 && name[0] == '.' {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Name() != "angular.json" {
+			return nil
+		}
+
+		found, err := s.readAngularJSON(path)
+		if err != nil {
+			log.Warn("⚠️  Warning: failed to read %s: %v", path, err)
+			return nil
+		}
+		projects = append(projects, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	return projects, nil
+}
+
+// readAngularJSON parses the projects of a single angular.json file.
+func (s *Syncer) readAngularJSON(path string) ([]AngularProject, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc angularJSON
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	dir, err := filepath.Rel(s.workspaceRoot, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	projects := make([]AngularProject, 0, len(doc.Projects))
+	for name, project := range doc.Projects {
+		projectType := project.ProjectType
+		if projectType == "" {
+			projectType = "application"
+		}
+		projects = append(projects, AngularProject{
+			Name:        name,
+			Root:        filepath.ToSlash(filepath.Join(dir, project.Root)),
+			ProjectType: projectType,
+		})
+	}
+	return projects, nil
+}
+
+// RegisterAngularProjects adds Angular projects found in angular.json files but
+// missing from forge.json, generating a BUILD.bazel for each new application.
+// A project counts as registered when forge.json has a project with the same
+// name or root. Returns the names of the newly registered projects.
+func (s *Syncer) RegisterAngularProjects(report *SyncReport) ([]string, error) {
+	discovered, err := s.DiscoverAngularProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	knownRoots := make(map[string]bool, len(s.config.Projects))
+	for _, project := range s.config.Projects {
+		knownRoots[filepath.ToSlash(filepath.Clean(project.Root))] = true
+	}
+
+	var registered []string
+	for _, ng := range discovered {
+		if s.config.GetProject(ng.Name) != nil || knownRoots[ng.Root] {
+			continue
+		}
+		if err := workspace.ValidateName(ng.Name); err != nil {
+			log.Warn("⚠️  Warning: skipping Angular project %q: %v", ng.Name, err)
+			continue
+		}
+
+		if s.dryRun {
+			log.Info("Would register Angular %s %s (%s)", ng.ProjectType, ng.Name, ng.Root)
+			registered = append(registered, ng.Name)
+			continue
+		}
+
+		if err := s.config.AddProject(ng.Name, newAngularProject(ng)); err != nil {
+			return registered, fmt.Errorf("failed to register %s: %w", ng.Name, err)
+		}
+		knownRoots[ng.Root] = true
+		registered = append(registered, ng.Name)
+
+		if ng.ProjectType == "application" {
+			if err := s.generateAngularBuild(ng.Name, ng.Root, report); err != nil {
+				return registered, fmt.Errorf("failed to generate Angular BUILD for %s: %w", ng.Name, err)
+			}
+		}
+	}
+
+	if len(registered) > 0 && !s.dryRun {
+		// Register on the latest forge.json so concurrent changes are kept
+		projects := make(map[string]workspace.Project, len(registered))
+		for _, name := range registered {
+			projects[name] = s.config.Projects[name]
+		}
+		err := s.config.Update(s.workspaceRoot, func(config *workspace.Config) error {
+			for name, project := range projects {
+				if config.GetProject(name) == nil {
+					config.Projects[name] = project
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return registered, fmt.Errorf("failed to save workspace config: %w", err)
+		}
+		report.CreatedFiles = append(report.CreatedFiles, filepath.Join(s.workspaceRoot, workspace.ConfigFileName))
+	}
+
+	return registered, nil
+}
+
+// newAngularProject builds the forge.json entry for a discovered Angular project.
+// Applications get the same targets as 'forge generate application' with the
+// default Firebase deployer.
+func newAngularProject(ng AngularProject) *workspace.Project {
+	configurations := func() map[string]interface{} {
+		return map[string]interface{}{
+			"production":  map[string]interface{}{},
+			"development": map[string]interface{}{},
+			"local":       map[string]interface{}{},
+		}
+	}
+
+	project := &workspace.Project{
+		ProjectType: ng.ProjectType,
+		Language:    string(workspace.LanguageAngular),
+		Root:        ng.Root,
+		Tags:        []string{"frontend", "angular"},
+		Architect: &workspace.Architect{
+			Build: &workspace.ArchitectTarget{
+				Builder: "@forge/bazel:build",
+				Options: map[string]interface{}{
+					"target":     ":build",
+					"outputPath": fmt.Sprintf("dist/%s", ng.Name),
+				},
+				Configurations:       configurations(),
+				DefaultConfiguration: "production",
+			},
+		},
+	}
+
+	if ng.ProjectType != "application" {
+		return project
+	}
+
+	project.Tags = append(project.Tags, "firebase")
+	project.Architect.Serve = &workspace.ArchitectTarget{
+		Builder: "@forge/angular:serve",
+		Options: map[string]interface{}{
+			"port": 4200,
+			"host": "localhost",
+		},
+	}
+	project.Architect.Deploy = &workspace.ArchitectTarget{
+		Deployer: "@forge/firebase:deploy",
+		Options: map[string]interface{}{
+			"configPath": "deploy/firebase",
+		},
+		Configurations:       configurations(),
+		DefaultConfiguration: "production",
+	}
+	return project
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
 )
 
 // GoBuildData contains template data for Go BUILD generation.
@@ -41,16 +43,23 @@ func (s *Syncer) GenerateGoBuild(pkg *GoPackage) (string, error) {
 		// Parse go.work to get workspace modules
 		modules, err := s.getWorkspaceModules()
 		if err != nil {
-			fmt.Printf("⚠️  Failed to parse workspace modules: %v\n", err)
+			log.Warn("⚠️  Failed to parse workspace modules: %v", err)
 			modules = []WorkspaceModule{} // Continue without modules
 		}
 
+		directives, err := s.projectGazelleDirectives(pkg.Path)
+		if err != nil {
+			return "", err
+		}
+
 		data := struct {
 			ImportPath string
 			Modules    []WorkspaceModule
+			Directives []string
 		}{
 			ImportPath: pkg.ImportPath,
 			Modules:    modules,
+			Directives: directives,
 		}
 
 		content, err := s.engine.RenderTemplate("bazel/go-root.BUILD.bazel.tmpl", data)
@@ -116,7 +125,9 @@ func (s *Syncer) generateGoBinaryBuild(pkg *GoPackage) (string, error) {
 		binaryName = filepath.Base(s.workspaceRoot)
 	}
 
-	// Image tag uses workspace name and binary name
+	// Image tag uses workspace name and binary name. It only names the image
+	// bazel run loads locally: deploys retag it following the tag strategy of
+	// the build configuration (see skaffold.TagPolicy).
 	imageTag := fmt.Sprintf("%s/%s:latest", s.config.Workspace.Name, binaryName)
 
 	// Check if this package has migrations folder (e.g., cmd/migrator)
@@ -149,7 +160,7 @@ func (s *Syncer) WriteGoBuild(pkg *GoPackage, content string, report *SyncReport
 	buildPath := filepath.Join(s.workspaceRoot, pkg.Path, "BUILD.bazel")
 
 	if s.dryRun {
-		fmt.Printf("Would write: %s\n", buildPath)
+		log.Info("Would write: %s", buildPath)
 		return nil
 	}
 
@@ -174,7 +185,7 @@ func (s *Syncer) syncGoBuildFiles(report *SyncReport) error {
 		return fmt.Errorf("failed to discover Go packages: %w", err)
 	}
 
-	fmt.Printf("📦 Found %d Go packages\n", len(packages))
+	log.Info("📦 Found %d Go packages", len(packages))
 
 	for _, pkg := range packages {
 		relPath := pkg.Path
@@ -183,11 +194,11 @@ func (s *Syncer) syncGoBuildFiles(report *SyncReport) error {
 		}
 
 		if pkg.IsMain {
-			fmt.Printf("   🔹 %s (binary)\n", relPath)
+			log.Debug("   🔹 %s (binary)", relPath)
 		} else if strings.Contains(pkg.Path, string(filepath.Separator)) {
-			fmt.Printf("   🔸 %s (library)\n", relPath)
+			log.Debug("   🔸 %s (library)", relPath)
 		} else {
-			fmt.Printf("   🔸 %s (service root)\n", relPath)
+			log.Debug("   🔸 %s (service root)", relPath)
 		}
 
 		content, err := s.GenerateGoBuild(pkg)
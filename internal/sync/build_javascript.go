@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
 
 // JSBuildData contains template data for JavaScript BUILD generation.
@@ -12,9 +15,12 @@ type JSBuildData struct {
 	ServiceName   string
 	AppName       string
 	PackagePath   string
+	SSR           bool   // Angular only: the image runs the SSR server
+	NestRoot      string // NestJS monorepo apps only: the monorepo directory
+	AppPath       string // NestJS monorepo apps only: the app directory in the monorepo
 }
 
-// syncJSBuildFiles regenerates BUILD.bazel for NestJS and Angular projects.
+// syncJSBuildFiles regenerates BUILD.bazel for NestJS, Angular and Vue projects.
 func (s *Syncer) syncJSBuildFiles(report *SyncReport) error {
 	for name, project := range s.config.Projects {
 		switch project.Language {
@@ -26,28 +32,64 @@ func (s *Syncer) syncJSBuildFiles(report *SyncReport) error {
 			if err := s.generateAngularBuild(name, project.Root, report); err != nil {
 				return fmt.Errorf("failed to generate Angular BUILD for %s: %w", name, err)
 			}
+		case "vue":
+			if err := s.generateVueBuild(name, project.Root, report); err != nil {
+				return fmt.Errorf("failed to generate Vue BUILD for %s: %w", name, err)
+			}
 		}
 	}
 	return nil
 }
 
-// generateNestJSBuild creates BUILD.bazel for a NestJS service.
+// generateNestJSBuild creates BUILD.bazel for a NestJS service. Apps of a
+// Nest monorepo build against the monorepo's package.json and node_modules,
+// exported by the BUILD.bazel written at its root.
 func (s *Syncer) generateNestJSBuild(serviceName, serviceRoot string, report *SyncReport) error {
 	data := JSBuildData{
 		WorkspaceName: s.config.Workspace.Name,
 		ServiceName:   serviceName,
 		PackagePath:   serviceRoot,
 	}
+	templatePath := "bazel/nestjs.BUILD.bazel.tmpl"
+
+	nestDir, app := workspace.FindNestMonorepo(s.workspaceRoot, filepath.Join(s.workspaceRoot, serviceRoot))
+	if nestDir != "" {
+		nestRoot, err := filepath.Rel(s.workspaceRoot, nestDir)
+		if err != nil {
+			return err
+		}
+		appPath, err := filepath.Rel(nestDir, filepath.Join(s.workspaceRoot, serviceRoot))
+		if err != nil {
+			return err
+		}
+		data.AppName = app
+		data.NestRoot = filepath.ToSlash(nestRoot)
+		data.AppPath = filepath.ToSlash(appPath)
+		templatePath = "bazel/nestjs-app.BUILD.bazel.tmpl"
+
+		// Written once for all the apps of the monorepo
+		if !contains(report.CreatedFiles, filepath.Join(nestDir, "BUILD.bazel")) {
+			if err := s.writeJSBuild("bazel/nestjs-monorepo.BUILD.bazel.tmpl", nestRoot, data, report); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.writeJSBuild(templatePath, serviceRoot, data, report)
+}
 
-	content, err := s.engine.RenderTemplate("bazel/nestjs.BUILD.bazel.tmpl", data)
+// writeJSBuild renders a BUILD.bazel template into dir, relative to the
+// workspace root.
+func (s *Syncer) writeJSBuild(templatePath, dir string, data JSBuildData, report *SyncReport) error {
+	content, err := s.engine.RenderTemplate(templatePath, data)
 	if err != nil {
 		return fmt.Errorf("failed to render template: %w", err)
 	}
 
-	buildPath := filepath.Join(s.workspaceRoot, serviceRoot, "BUILD.bazel")
+	buildPath := filepath.Join(s.workspaceRoot, dir, "BUILD.bazel")
 
 	if s.dryRun {
-		fmt.Printf("Would write: %s\n", buildPath)
+		log.Info("Would write: %s", buildPath)
 		return nil
 	}
 
@@ -69,6 +111,7 @@ func (s *Syncer) generateAngularBuild(appName, appRoot string, report *SyncRepor
 		WorkspaceName: s.config.Workspace.Name,
 		AppName:       appName,
 		PackagePath:   appRoot,
+		SSR:           angularSSR(s.config.GetProject(appName)),
 	}
 
 	content, err := s.engine.RenderTemplate("bazel/angular.BUILD.bazel.tmpl", data)
@@ -79,7 +122,7 @@ func (s *Syncer) generateAngularBuild(appName, appRoot string, report *SyncRepor
 	buildPath := filepath.Join(s.workspaceRoot, appRoot, "BUILD.bazel")
 
 	if s.dryRun {
-		fmt.Printf("Would write: %s\n", buildPath)
+		log.Info("Would write: %s", buildPath)
 		return nil
 	}
 
@@ -94,3 +137,41 @@ func (s *Syncer) generateAngularBuild(appName, appRoot string, report *SyncRepor
 	report.CreatedFiles = append(report.CreatedFiles, buildPath)
 	return nil
 }
+
+// angularSSR reports whether an Angular project is server-side rendered, set
+// by the ssr build option.
+func angularSSR(project *workspace.Project) bool {
+	if project == nil || project.Architect == nil || project.Architect.Build == nil {
+		return false
+	}
+	ssr, _ := project.Architect.Build.Options["ssr"].(bool)
+	return ssr
+}
+
+// generateVueBuild creates BUILD.bazel for a Vue application.
+func (s *Syncer) generateVueBuild(appName, appRoot string, report *SyncReport) error {
+	data := JSBuildData{
+		WorkspaceName: s.config.Workspace.Name,
+		AppName:       appName,
+		PackagePath:   appRoot,
+	}
+
+	content, err := s.engine.RenderTemplate("bazel/vue.BUILD.bazel.tmpl", data)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	buildPath := filepath.Join(s.workspaceRoot, appRoot, "BUILD.bazel")
+
+	if s.dryRun {
+		log.Info("Would write: %s", buildPath)
+		return nil
+	}
+
+	if err := os.WriteFile(buildPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write BUILD.bazel: %w", err)
+	}
+
+	report.CreatedFiles = append(report.CreatedFiles, buildPath)
+	return nil
+}
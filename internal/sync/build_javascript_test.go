@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestGenerateAngularBuildSSR(t *testing.T) {
+	root := t.TempDir()
+	config := &workspace.Config{
+		Workspace: workspace.WorkspaceMetadata{Name: "shop"},
+		Projects: map[string]workspace.Project{
+			"web": {Language: "angular", Root: "frontend/apps/web", Architect: &workspace.Architect{
+				Build: &workspace.ArchitectTarget{Builder: "@forge/bazel:build"},
+			}},
+			"marketing": {Language: "angular", Root: "frontend/apps/marketing", Architect: &workspace.Architect{
+				Build: &workspace.ArchitectTarget{Builder: "@forge/bazel:build", Options: map[string]interface{}{"ssr": true}},
+			}},
+		},
+	}
+	s := &Syncer{workspaceRoot: root, config: config, engine: template.NewEngine()}
+
+	for name, project := range config.Projects {
+		if err := s.generateAngularBuild(name, project.Root, &SyncReport{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(root, config.Projects[name].Root, "BUILD.bazel"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	if web := read("web"); !strings.Contains(web, "/usr/share/nginx/html") || strings.Contains(web, "server.mjs") {
+		t.Errorf("static app BUILD.bazel should package the browser bundle for nginx:\n%s", web)
+	}
+	if marketing := read("marketing"); !strings.Contains(marketing, `"/app/dist/server/server.mjs"`) || strings.Contains(marketing, "nginx") {
+		t.Errorf("SSR app BUILD.bazel should run the Node server:\n%s", marketing)
+	}
+}
+
+func TestRegisterAngularProjects(t *testing.T) {
+	root := t.TempDir()
+	config := workspace.NewConfig("shop")
+	config.Projects["web"] = workspace.Project{ProjectType: "application", Language: "angular", Root: "frontend/apps/web"}
+	if err := config.Save(root); err != nil {
+		t.Fatal(err)
+	}
+
+	// 'ng generate' added an application and a library next to the registered app
+	writeFiles(t, root, map[string]string{
+		"frontend/apps/web/angular.json": `{
+  "version": 1,
+  "projects": {
+    "web": {"projectType": "application", "root": ""},
+    "admin": {"projectType": "application", "root": "projects/admin"},
+    "ui": {"projectType": "library", "root": "projects/ui"}
+  }
+}`,
+	})
+
+	s := &Syncer{workspaceRoot: root, config: config, engine: template.NewEngine()}
+	registered, err := s.RegisterAngularProjects(&SyncReport{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(registered, ",") != "admin,ui" {
+		t.Fatalf("registered %v, want admin and ui", registered)
+	}
+
+	saved, err := workspace.LoadConfigWithoutProjectValidation(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := saved.GetProject("admin")
+	if admin == nil || admin.Root != "frontend/apps/web/projects/admin" || admin.Architect.Serve == nil {
+		t.Fatalf("forge.json admin = %+v, want an application at frontend/apps/web/projects/admin", admin)
+	}
+	if ui := saved.GetProject("ui"); ui == nil || ui.ProjectType != "library" {
+		t.Errorf("forge.json ui = %+v, want a library", ui)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "frontend/apps/web/projects/admin/BUILD.bazel")); err != nil {
+		t.Errorf("no BUILD.bazel for the new application: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "frontend/apps/web/projects/ui/BUILD.bazel")); !os.IsNotExist(err) {
+		t.Error("a BUILD.bazel was generated for the library")
+	}
+
+	// A second sync finds nothing new
+	registered, err = s.RegisterAngularProjects(&SyncReport{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registered) != 0 {
+		t.Errorf("registered %v again", registered)
+	}
+}
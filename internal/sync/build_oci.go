@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
 )
 
 // ensureOciSupport guarantees MODULE.bazel has rules_oci and a distroless base image.
@@ -173,7 +175,7 @@ filegroup(
 			return fmt.Errorf("failed to update %s: %w", buildFile, err)
 		}
 
-		fmt.Printf("   Added container image targets to %s\n", filepath.Join(project.Root, "cmd", "server"))
+		log.Info("   Added container image targets to %s", filepath.Join(project.Root, "cmd", "server"))
 	}
 
 	return nil
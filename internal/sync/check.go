@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/bazel"
+)
+
+// Check runs a full sync on a copy of the workspace and reports the managed
+// files it would create, update or delete, with a unified diff of each. The
+// workspace itself is left untouched, so CI can fail when the committed Bazel
+// files are out of date.
+func (s *Syncer) Check(ctx context.Context) (*SyncReport, error) {
+	// Without gazelle the check would report every generated BUILD file
+	if !bazel.Available() {
+		return nil, fmt.Errorf("bazel is not installed: checking BUILD files needs gazelle")
+	}
+
+	overlay, err := os.MkdirTemp("", "forge-sync-check-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create check directory: %w", err)
+	}
+	defer os.RemoveAll(overlay)
+
+	if err := copyWorkspace(s.workspaceRoot, overlay); err != nil {
+		return nil, fmt.Errorf("failed to copy workspace: %w", err)
+	}
+
+	checker, err := NewSyncer(overlay, false)
+	if err != nil {
+		return nil, err
+	}
+	checker.diffAll = true
+	checker.jobs = s.jobs
+
+	return checker.Sync(ctx)
+}
+
+// copyWorkspace copies the workspace sources into dir, skipping the
+// directories sync never reads such as bazel outputs, node_modules and hidden
+// directories. Symlinks are copied as links.
+func copyWorkspace(root, dir string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+
+		switch {
+		case d.IsDir():
+			if path != root && skipSyncDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		case d.Type()&os.ModeSymlink != 0:
+			// Convenience symlinks such as bazel-<workspace> point at the
+			// output base of the real workspace
+			if strings.HasPrefix(d.Name(), "bazel-") {
+				return nil
+			}
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		default:
+			return nil
+		}
+	})
+}
+
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCopyWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".bazelrc":                   "common --enable_bzlmod\n",
+		"MODULE.bazel":               "module(name = \"shop\")\n",
+		"backend/orders/main.go":     "package main\n",
+		".git/HEAD":                  "ref: refs/heads/main\n",
+		"frontend/node_modules/x.js": "",
+	})
+	if err := os.Symlink(t.TempDir(), filepath.Join(root, "bazel-shop")); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := copyWorkspace(root, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{".bazelrc", "MODULE.bazel", "backend/orders/main.go"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was not copied: %v", name, err)
+		}
+	}
+	for _, name := range []string{".git", "frontend/node_modules", "bazel-shop"} {
+		if _, err := os.Lstat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s was copied", name)
+		}
+	}
+}
+
+func TestRecordChangesDiffAll(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"MODULE.bazel":     "module(name = \"shop\")\n",
+		"api/BUILD.bazel":  "# new\n",
+		"docs/BUILD.bazel": "",
+	})
+	before := map[string][]byte{
+		"MODULE.bazel":     []byte("module(name = \"old\")\n"),
+		"docs/BUILD.bazel": {},
+		"web/BUILD.bazel":  []byte("# stale\n"),
+	}
+
+	s := &Syncer{workspaceRoot: root, diffAll: true}
+	report := &SyncReport{}
+	if err := s.recordChanges(report, before); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(report.CreatedFiles, ",") != "api/BUILD.bazel" ||
+		strings.Join(report.UpdatedFiles, ",") != "MODULE.bazel" ||
+		strings.Join(report.DeletedFiles, ",") != "web/BUILD.bazel" {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, want := range []string{"+++ b/api/BUILD.bazel", "+++ b/MODULE.bazel", "--- a/web/BUILD.bazel", "+# new", "-# stale"} {
+		if !strings.Contains(report.Diff, want) {
+			t.Errorf("diff does not contain %q:\n%s", want, report.Diff)
+		}
+	}
+	if strings.Contains(report.Diff, "docs/BUILD.bazel") {
+		t.Errorf("diff contains the unchanged file:\n%s", report.Diff)
+	}
+}
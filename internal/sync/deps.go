@@ -0,0 +1,137 @@
+package sync
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// GoProjectDependencies returns, for each Go project of the workspace, the
+// other projects its go.mod requires or its packages import. Projects are
+// identified by their import path, derived from the nearest go.mod, so
+// libraries that are packages of a larger module are told apart from it.
+func GoProjectDependencies(workspaceRoot string, config *workspace.Config) (map[string][]string, error) {
+	importPaths := make(map[string]string) // import path -> project
+	roots := make(map[string]string)       // absolute root -> project
+	for name, project := range config.Projects {
+		if project.Language != string(workspace.LanguageGo) {
+			continue
+		}
+		root := filepath.Join(workspaceRoot, project.Root)
+		importPath := goImportPath(workspaceRoot, root)
+		if importPath == "" {
+			log.Debug("Skipping %s: not in a Go module", name)
+			continue
+		}
+		importPaths[importPath] = name
+		roots[root] = name
+	}
+
+	prefixes := make([]string, 0, len(importPaths))
+	for importPath := range importPaths {
+		prefixes = append(prefixes, importPath)
+	}
+
+	deps := make(map[string][]string, len(roots))
+	for root, name := range roots {
+		imports, err := projectImports(root, roots)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the imports of %s: %w", name, err)
+		}
+
+		// A required workspace module is a dependency even before it is imported
+		if content, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			imports = append(imports, parseGoModRequires(string(content))...)
+		}
+
+		found := make(map[string]bool)
+		for _, imp := range imports {
+			if dep := importPaths[owningModule(prefixes, imp)]; dep != "" && dep != name {
+				found[dep] = true
+			}
+		}
+		deps[name] = sortedKeys(found)
+	}
+	return deps, nil
+}
+
+// projectImports returns the import paths of the Go files under root, test
+// files included. Directories holding another project or module are skipped.
+func projectImports(root string, projectRoots map[string]string) ([]string, error) {
+	var imports []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if skipSyncDir(d.Name()) || d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			if _, ok := projectRoots[path]; ok {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			log.Debug("Skipping %s: %v", path, err)
+			return nil
+		}
+		for _, imp := range file.Imports {
+			imports = append(imports, strings.Trim(imp.Path.Value, `"`))
+		}
+		return nil
+	})
+	sort.Strings(imports)
+	return imports, err
+}
+
+// goImportPath returns the import path of dir from the module declared by the
+// nearest go.mod up to the workspace root, or "" outside a Go module.
+func goImportPath(workspaceRoot, dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if content, err := os.ReadFile(filepath.Join(current, "go.mod")); err == nil {
+			module := goModModulePath(string(content))
+			if module == "" {
+				return ""
+			}
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return ""
+			}
+			return path.Join(module, filepath.ToSlash(rel))
+		}
+		if current == workspaceRoot || filepath.Dir(current) == current {
+			return ""
+		}
+	}
+}
+
+// goModModulePath returns the module path declared by a go.mod file.
+func goModModulePath(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestGoProjectDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"backend/services/orders/go.mod": "module github.com/acme/shop/backend/services/orders\n\ngo 1.24\n\nrequire github.com/acme/shop/shared v0.0.0\n",
+		"backend/services/orders/cmd/main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/acme/shop/backend/services/billing/client"
+)
+`,
+		"backend/services/billing/go.mod":           "module github.com/acme/shop/backend/services/billing\n\ngo 1.24\n",
+		"backend/services/billing/client/client.go": "package client\n",
+		"shared/go.mod":                  "module github.com/acme/shop/shared\n\ngo 1.24\n",
+		"shared/auth/auth.go":            "package auth\n\nimport \"github.com/acme/shop/shared/db\"\n",
+		"shared/db/db.go":                "package db\n",
+		"frontend/apps/web/package.json": "{}",
+	})
+
+	config := &workspace.Config{Projects: map[string]workspace.Project{
+		"orders":  {Language: "go", Root: "backend/services/orders"},
+		"billing": {Language: "go", Root: "backend/services/billing"},
+		"auth":    {Language: "go", Root: "shared/auth"},
+		"db":      {Language: "go", Root: "shared/db"},
+		"shared":  {Language: "go", Root: "shared"},
+		"web":     {Language: "angular", Root: "frontend/apps/web"},
+	}}
+
+	deps, err := GoProjectDependencies(root, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"orders":  {"billing", "shared"},
+		"billing": {},
+		"auth":    {"db"},
+		"db":      {},
+		"shared":  {},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("GoProjectDependencies() = %v, want %v", deps, want)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
 )
 
 // GoPackage represents a discovered Go package.
@@ -91,7 +93,7 @@ func (s *Syncer) DiscoverGoPackages() ([]*GoPackage, error) {
 		// Discover package
 		pkg, err := s.discoverGoPackage(pkgDir, modulePath)
 		if err != nil {
-			fmt.Printf("⚠️  Warning: failed to process package at %s: %v\n", pkgDir, err)
+			log.Warn("⚠️  Warning: failed to process package at %s: %v", pkgDir, err)
 			return nil
 		}
 
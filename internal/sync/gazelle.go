@@ -0,0 +1,238 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+)
+
+// GazelleConfig is the canonical set of gazelle directives for the workspace.
+type GazelleConfig struct {
+	Prefix     string
+	Directives []string
+}
+
+// BuildGazelleConfig computes the canonical gazelle directives for the workspace:
+// the prefix from the workspace module path, one resolve rule per go.work module,
+// and any custom directives from forge.json. The output is sorted and deduplicated
+// so repeated runs produce identical files.
+func (s *Syncer) BuildGazelleConfig() (*GazelleConfig, error) {
+	goProjects := s.getGoProjects()
+	if len(goProjects) == 0 {
+		return nil, fmt.Errorf("no Go projects found in forge.json")
+	}
+
+	modules, err := s.getWorkspaceModules()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var resolves []string
+	for _, mod := range modules {
+		label := "//" + filepath.ToSlash(filepath.Clean(mod.Path)) + ":go_default_library"
+		directive := fmt.Sprintf("gazelle:resolve go %s %s", mod.ImportPath, label)
+		if !seen[directive] {
+			seen[directive] = true
+			resolves = append(resolves, directive)
+		}
+	}
+	sort.Strings(resolves)
+
+	// Custom directives are kept after the generated ones, except for prefix
+	// overrides which would make gazelle's behaviour depend on ordering.
+	var custom []string
+	for _, directive := range s.config.Workspace.GazelleDirectives {
+		directive = strings.TrimSpace(strings.TrimPrefix(directive, "#"))
+		if directive == "" || strings.HasPrefix(directive, "gazelle:prefix") || seen[directive] {
+			continue
+		}
+		seen[directive] = true
+		custom = append(custom, directive)
+	}
+	sort.Strings(custom)
+
+	return &GazelleConfig{
+		Prefix:     s.detectModulePrefix(goProjects),
+		Directives: append(resolves, custom...),
+	}, nil
+}
+
+// EmitGazelleConfig writes the root BUILD.bazel with the canonical gazelle directives.
+// In dry-run mode the file is rendered but not written.
+func (s *Syncer) EmitGazelleConfig(report *SyncReport) (*GazelleConfig, error) {
+	gazelleConfig, err := s.BuildGazelleConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	content, _, err := s.renderRootBuildFile(s.getGoProjects(), gazelleConfig.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.dryRun {
+		return gazelleConfig, nil
+	}
+
+	buildFile := filepath.Join(s.workspaceRoot, "BUILD.bazel")
+	if err := os.WriteFile(buildFile, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write BUILD.bazel: %w", err)
+	}
+	report.CreatedFiles = append(report.CreatedFiles, "BUILD.bazel")
+
+	return gazelleConfig, nil
+}
+
+// renderRootBuildFile renders the root BUILD.bazel: the gazelle target with the
+// workspace prefix, the given directives, and a resolve directive for every Go
+// project and proto directory that the directives don't already contain. It
+// returns the content and the number of resolve directives.
+func (s *Syncer) renderRootBuildFile(goProjects []GoProject, directives []string) (string, int, error) {
+	modulePrefix := s.detectModulePrefix(goProjects)
+
+	// Resolve directives are listed together, whether configured or generated
+	seen := make(map[string]bool, len(directives))
+	var custom, resolves []string
+	for _, directive := range directives {
+		directive = strings.TrimSpace(strings.TrimPrefix(directive, "#"))
+		if directive == "" || seen[directive] {
+			continue
+		}
+		seen[directive] = true
+		if strings.HasPrefix(directive, "gazelle:resolve ") {
+			resolves = append(resolves, directive)
+		} else {
+			custom = append(custom, directive)
+		}
+	}
+	for _, proj := range append(goProjects, s.protoPackages(goProjects)...) {
+		root := filepath.ToSlash(filepath.Clean(proj.Root))
+		directive := fmt.Sprintf("gazelle:resolve go %s/%s //%s:go_default_library", modulePrefix, root, root)
+		if !seen[directive] {
+			seen[directive] = true
+			resolves = append(resolves, directive)
+		}
+	}
+	sort.Strings(resolves)
+
+	data := struct {
+		ModulePrefix      string
+		GazelleDirectives []string
+		Resolves          []string
+	}{
+		ModulePrefix:      modulePrefix,
+		GazelleDirectives: custom,
+		Resolves:          resolves,
+	}
+
+	content, err := s.engine.RenderTemplate("bazel/root-build.tmpl", data)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to render BUILD.bazel template: %w", err)
+	}
+	return content, len(resolves), nil
+}
+
+// protoPackages returns the directories of the Go projects that contain
+// .proto files, as projects of their own.
+func (s *Syncer) protoPackages(goProjects []GoProject) []GoProject {
+	var protoProjects []GoProject
+	for _, proj := range goProjects {
+		projPath := filepath.Join(s.workspaceRoot, proj.Root)
+		filepath.Walk(projPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return nil
+			}
+			// Check if directory contains .proto files
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil
+			}
+			for _, entry := range entries {
+				if strings.HasSuffix(entry.Name(), ".proto") {
+					relPath, _ := filepath.Rel(s.workspaceRoot, path)
+					protoProjects = append(protoProjects, GoProject{
+						Name: filepath.Base(path),
+						Root: relPath,
+					})
+					break
+				}
+			}
+			return nil
+		})
+	}
+	return protoProjects
+}
+
+// projectGazelleDirectives returns the gazelle directives configured in the
+// metadata of the project rooted at root, if any.
+func (s *Syncer) projectGazelleDirectives(root string) ([]string, error) {
+	root = filepath.Clean(root)
+	for name, project := range s.config.Projects {
+		if filepath.Clean(project.Root) != root {
+			continue
+		}
+		directives, err := project.GazelleDirectives()
+		if err != nil {
+			return nil, fmt.Errorf("project %q: %w", name, err)
+		}
+		return directives, nil
+	}
+	return nil, nil
+}
+
+// ensureProjectGazelleDirectives adds the project's configured gazelle
+// directives that are missing from its root BUILD.bazel. Gazelle keeps these
+// comments when it regenerates the file and applies them to the whole module.
+func (s *Syncer) ensureProjectGazelleDirectives(proj GoProject) error {
+	directives, err := s.projectGazelleDirectives(proj.Root)
+	if err != nil || len(directives) == 0 {
+		return err
+	}
+
+	buildPath := filepath.Join(s.workspaceRoot, proj.Root, "BUILD.bazel")
+	content, err := os.ReadFile(buildPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", buildPath, err)
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))] = true
+	}
+
+	var missing strings.Builder
+	for _, directive := range directives {
+		if !existing[directive] {
+			existing[directive] = true
+			fmt.Fprintf(&missing, "# %s\n", directive)
+		}
+	}
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	// Keep a module docstring first, as buildifier expects
+	text := string(content)
+	head, tail := "", text
+	if strings.HasPrefix(text, `"""`) {
+		if end := strings.Index(text[3:], `"""`); end != -1 {
+			head = text[:end+6] + "\n\n"
+			tail = strings.TrimLeft(text[end+6:], "\n")
+		}
+	}
+	if tail != "" {
+		missing.WriteString("\n")
+	}
+	updated := head + missing.String() + tail
+
+	if err := os.WriteFile(buildPath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", buildPath, err)
+	}
+	log.Info("   Added gazelle directives to %s/BUILD.bazel", proj.Root)
+	return nil
+}
@@ -0,0 +1,74 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+func TestRootBuildFileGazelleDirectives(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.work":                                        "go 1.24\n\nuse (\n\t./backend/services/orders\n\t./shared\n)\n",
+		"backend/services/orders/go.mod":                 "module github.com/acme/shop/backend/services/orders\n\ngo 1.24\n",
+		"backend/services/orders/main.go":                "package main\n",
+		"backend/services/orders/api/proto/orders.proto": "syntax = \"proto3\";\n",
+		"shared/go.mod":                                  "module github.com/acme/shop/shared\n\ngo 1.24\n",
+	})
+
+	s := &Syncer{
+		workspaceRoot: root,
+		engine:        template.NewEngine(),
+		config: &workspace.Config{
+			Workspace: workspace.WorkspaceMetadata{
+				Name:              "shop",
+				GazelleDirectives: []string{"gazelle:exclude vendor"},
+			},
+			Projects: map[string]workspace.Project{
+				"orders": {Language: "go", Root: "backend/services/orders"},
+				"shared": {Language: "go", Root: "shared"},
+			},
+		},
+	}
+
+	if err := s.generateRootBuildFile(s.getGoProjects()); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(root, "BUILD.bazel"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.EmitGazelleConfig(&SyncReport{}); err != nil {
+		t.Fatal(err)
+	}
+	emitted, err := os.ReadFile(filepath.Join(root, "BUILD.bazel"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string]string{"sync": string(generated), "--emit-gazelle-config": string(emitted)} {
+		for _, want := range []string{
+			"# gazelle:prefix github.com/acme/shop\n",
+			"# gazelle:exclude vendor\n",
+			"# gazelle:resolve go github.com/acme/shop/backend/services/orders //backend/services/orders:go_default_library\n",
+			"# gazelle:resolve go github.com/acme/shop/shared //shared:go_default_library\n",
+			"# gazelle:resolve go github.com/acme/shop/backend/services/orders/api/proto //backend/services/orders/api/proto:go_default_library\n",
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s: root BUILD.bazel has no %q:\n%s", name, strings.TrimSpace(want), content)
+			}
+		}
+		if strings.Count(content, "gazelle:resolve go github.com/acme/shop/shared ") != 1 {
+			t.Errorf("%s: the shared resolve directive is repeated:\n%s", name, content)
+		}
+	}
+
+	if string(emitted) != string(generated) {
+		t.Errorf("--emit-gazelle-config differs from sync:\n%s\nwant:\n%s", emitted, generated)
+	}
+}
@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/log"
+)
+
+// SyncPaths re-syncs only what is affected by the given changed files, so it can
+// run after every save. Changes to go.mod or go.work re-sync go.work and the Bazel
+// module dependencies; Go file changes re-run gazelle on their package directory.
+// Packages left without Go files have their BUILD.bazel removed.
+func (s *Syncer) SyncPaths(ctx context.Context, paths []string) (*SyncReport, error) {
+	report := &SyncReport{}
+
+	dirs := make(map[string]bool)
+	modulesChanged := false
+	for _, path := range paths {
+		rel, err := filepath.Rel(s.workspaceRoot, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		switch filepath.Base(rel) {
+		case "go.work":
+			modulesChanged = true
+		case "go.mod":
+			modulesChanged = true
+			dirs[filepath.Dir(rel)] = true
+		default:
+			if filepath.Ext(rel) == ".go" {
+				dirs[filepath.Dir(rel)] = true
+			}
+		}
+	}
+
+	if s.dryRun {
+		if modulesChanged {
+			log.Info("Would sync go.work and run bazel mod tidy")
+		}
+		for _, dir := range sortedKeys(dirs) {
+			log.Info("Would regenerate %s", filepath.Join(dir, "BUILD.bazel"))
+		}
+		return report, nil
+	}
+
+	if modulesChanged {
+		if err := s.syncGoWork(ctx, s.getGoProjects()); err != nil {
+			return report, err
+		}
+		if err := s.runBazelModTidy(ctx); err != nil {
+			return report, err
+		}
+		if err := s.fixModuleBazelDependencies(); err != nil {
+			return report, err
+		}
+	}
+
+	// Snapshot BUILD files so the report can tell created from updated ones
+	before := make(map[string][]byte)
+	var gazelleDirs []string
+	for _, dir := range sortedKeys(dirs) {
+		buildPath := filepath.Join(s.workspaceRoot, dir, "BUILD.bazel")
+		content, err := os.ReadFile(buildPath)
+		if err == nil {
+			before[dir] = content
+		}
+
+		if hasGoFiles(filepath.Join(s.workspaceRoot, dir)) {
+			gazelleDirs = append(gazelleDirs, dir)
+			continue
+		}
+
+		if err == nil {
+			if err := os.Remove(buildPath); err != nil {
+				return report, fmt.Errorf("failed to delete %s: %w", buildPath, err)
+			}
+			report.DeletedFiles = append(report.DeletedFiles, filepath.Join(dir, "BUILD.bazel"))
+		}
+	}
+
+	if len(gazelleDirs) == 0 {
+		return report, nil
+	}
+
+	if err := s.runGazelle(ctx, gazelleDirs...); err != nil {
+		return report, err
+	}
+
+	for _, dir := range gazelleDirs {
+		buildFile := filepath.Join(dir, "BUILD.bazel")
+		content, err := os.ReadFile(filepath.Join(s.workspaceRoot, buildFile))
+		if err != nil {
+			continue
+		}
+		previous, existed := before[dir]
+		switch {
+		case !existed:
+			report.CreatedFiles = append(report.CreatedFiles, buildFile)
+		case !bytes.Equal(previous, content):
+			report.UpdatedFiles = append(report.UpdatedFiles, buildFile)
+		}
+	}
+
+	return report, nil
+}
+
+// hasGoFiles reports whether dir directly contains any .go files.
+func hasGoFiles(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,34 +1,42 @@
 package sync
 
 import (
+	"context"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 )
 
 // Sync uses the same template data structure as the generator
 
 // GenerateModuleBazel creates MODULE.bazel based on detected languages.
-func (s *Syncer) GenerateModuleBazel(languages []string) (string, error) {
+func (s *Syncer) GenerateModuleBazel(ctx context.Context, languages []string) (string, error) {
 	// Detect which language rules are needed
 	repoName := ""
 	if s.config.Workspace.GitHub != nil && s.config.Workspace.GitHub.Org != "" {
 		repoName = fmt.Sprintf("github.com/%s/%s", s.config.Workspace.GitHub.Org, s.config.Workspace.Name)
 	}
 
-	// Get Go version from config
-	goVersion := "1.24.0" // Default
-	if s.config.Workspace.ToolVersions != nil && s.config.Workspace.ToolVersions.Go != "" {
-		goVersion = s.config.Workspace.ToolVersions.Go
+	// Get tool versions locked in forge.json
+	toolVersions, err := s.config.GetToolVersions()
+	if err != nil {
+		return "", err
 	}
+	goVersion := toolVersions.Go
 
 	// Parse go.work to find all modules
 	var goModules []string
 	var useRootGoMod bool
+	var goPrivateModules []GoPrivateModule
+	var goPrivate string
 	if contains(languages, "go") {
 		modules, err := s.parseGoWorkModules()
 		if err != nil {
@@ -58,9 +66,12 @@ func (s *Syncer) GenerateModuleBazel(languages []string) (string, error) {
 		// Bazel only supports single go_deps.from_file
 		// Create root go.mod aggregator that merges deps from all library modules
 		if len(goModules) > 0 {
-			if err := s.createAggregatorGoMod(goModules); err != nil {
+			overrides, err := s.createAggregatorGoMod(ctx, goModules)
+			if err != nil {
 				return "", fmt.Errorf("failed to create aggregator go.mod: %w", err)
 			}
+			goPrivateModules = overrides.PrivateModules
+			goPrivate = strings.Join(overrides.PrivatePatterns, ",")
 			useRootGoMod = true
 			goModules = nil // Clear so template uses root
 		} else {
@@ -80,7 +91,7 @@ func (s *Syncer) GenerateModuleBazel(languages []string) (string, error) {
 	}
 
 	// Determine if there are frontend projects
-	hasFrontend := contains(languages, "nestjs") || contains(languages, "angular") || contains(languages, "react")
+	hasFrontend := contains(languages, "nestjs") || contains(languages, "angular") || contains(languages, "react") || contains(languages, "vue")
 
 	data := struct {
 		ProjectName    string
@@ -88,22 +99,30 @@ func (s *Syncer) GenerateModuleBazel(languages []string) (string, error) {
 		HasGo          bool
 		HasJS          bool
 		HasFrontend    bool
+		HasProto       bool
 		WorkspaceRepo  string
 		GoVersion      string
+		NodeVersion    string
 		GoModules      []string
 		UseRootGoMod   bool
 		GoDependencies []string
+		GoPrivate      string
+		GoPrivateMods  []GoPrivateModule
 	}{
 		ProjectName:    s.config.Workspace.Name,
 		Version:        "0.1.0",
 		HasGo:          contains(languages, "go"),
 		HasJS:          hasFrontend,
 		HasFrontend:    hasFrontend,
+		HasProto:       s.config.HasProto(),
 		WorkspaceRepo:  repoName,
 		GoVersion:      goVersion,
+		NodeVersion:    toolVersions.Node,
 		GoModules:      goModules,
 		UseRootGoMod:   useRootGoMod,
 		GoDependencies: goDependencies,
+		GoPrivate:      goPrivate,
+		GoPrivateMods:  goPrivateModules,
 	}
 
 	// Use the same template file that forge new uses
@@ -121,7 +140,7 @@ func (s *Syncer) WriteModuleBazel(content string, report *SyncReport) error {
 	modulePath := filepath.Join(s.workspaceRoot, "MODULE.bazel")
 
 	if s.dryRun {
-		fmt.Printf("Would write: %s\n", modulePath)
+		log.Info("Would write: %s", modulePath)
 		return nil
 	}
 
@@ -134,10 +153,10 @@ func (s *Syncer) WriteModuleBazel(content string, report *SyncReport) error {
 }
 
 // syncModuleBazel regenerates MODULE.bazel based on detected languages.
-func (s *Syncer) syncModuleBazel(languages []string, report *SyncReport) error {
-	fmt.Println("📝 Regenerating MODULE.bazel...")
+func (s *Syncer) syncModuleBazel(ctx context.Context, languages []string, report *SyncReport) error {
+	log.Info("📝 Regenerating MODULE.bazel...")
 
-	content, err := s.GenerateModuleBazel(languages)
+	content, err := s.GenerateModuleBazel(ctx, languages)
 	if err != nil {
 		return err
 	}
@@ -146,23 +165,32 @@ func (s *Syncer) syncModuleBazel(languages []string, report *SyncReport) error {
 }
 
 // runBazelModTidy runs bazel mod tidy to populate use_repo() declarations.
-func (s *Syncer) runBazelModTidy() error {
-	fmt.Println("🔧 Running bazel mod tidy...")
-	cmd := exec.Command("bazel", "mod", "tidy")
-	cmd.Dir = s.workspaceRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+func (s *Syncer) runBazelModTidy(ctx context.Context) error {
+	log.Info("🔧 Running bazel mod tidy...")
+	opts := exec.Options{Name: "bazel", Args: []string{"mod", "tidy"}, Dir: s.workspaceRoot}
+	if err := exec.Run(ctx, opts); err != nil {
 		return fmt.Errorf("failed to run bazel mod tidy: %w", err)
 	}
 	return nil
 }
 
-// fixModuleBazelDependencies adds missing indirect dependencies to MODULE.bazel.
-// This is needed because bazel mod tidy only includes direct dependencies by default,
-// but misses blank imports like database drivers.
+// fixModuleBazelDependencies adds the repositories of blank-imported packages to
+// the use_repo(go_deps, ...) call in MODULE.bazel. bazel mod tidy only keeps
+// repositories it sees referenced from BUILD files, so blank imports such as
+// database drivers (_ "github.com/go-sql-driver/mysql") get dropped.
 func (s *Syncer) fixModuleBazelDependencies() error {
-	fmt.Println("🔧 Adding missing indirect dependencies...")
+	modules, err := s.parseGoWorkModules()
+	if err != nil {
+		return fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	required, err := s.blankImportRepos(modules)
+	if err != nil {
+		return err
+	}
+	if len(required) == 0 {
+		return nil
+	}
 
 	modulePath := filepath.Join(s.workspaceRoot, "MODULE.bazel")
 	content, err := os.ReadFile(modulePath)
@@ -170,110 +198,189 @@ func (s *Syncer) fixModuleBazelDependencies() error {
 		return fmt.Errorf("failed to read MODULE.bazel: %w", err)
 	}
 
-	// Extract current use_repo dependencies
-	contentStr := string(content)
-	lines := strings.Split(contentStr, "\n")
-
-	// Find the use_repo line for go_deps
-	var useRepoLineIndex = -1
-	var currentDeps []string
-
-	for i, line := range lines {
-		if strings.Contains(line, "use_repo(go_deps,") {
-			useRepoLineIndex = i
-			// Extract current dependencies
-			// Handle both single line and multi-line use_repo
-			useRepoContent := line
-
-			// If it doesn't end with ), it might be multi-line
-			if !strings.Contains(line, ")") {
-				for j := i + 1; j < len(lines); j++ {
-					useRepoContent += " " + strings.TrimSpace(lines[j])
-					if strings.Contains(lines[j], ")") {
-						break
-					}
-				}
-			}
-
-			// Parse dependencies from use_repo line
-			start := strings.Index(useRepoContent, "(go_deps,")
-			end := strings.LastIndex(useRepoContent, ")")
-			if start != -1 && end != -1 {
-				depsStr := useRepoContent[start+len("(go_deps,") : end]
-				depsStr = strings.TrimSpace(depsStr)
-				if depsStr != "" {
-					// Split by comma and clean up
-					for _, dep := range strings.Split(depsStr, ",") {
-						dep = strings.TrimSpace(dep)
-						dep = strings.Trim(dep, `"`)
-						if dep != "" {
-							currentDeps = append(currentDeps, dep)
-						}
-					}
-				}
-			}
-			break
-		}
-	}
-
-	// Essential dependencies that should always be included (common blank imports)
-	essentialDeps := []string{
-		"com_github_lib_pq", // PostgreSQL driver
-	}
+	lines := strings.Split(string(content), "\n")
+	start, end, currentDeps := findGoDepsUseRepo(lines)
 
-	// Add missing essential dependencies
 	depsMap := make(map[string]bool)
 	for _, dep := range currentDeps {
 		depsMap[dep] = true
 	}
 
-	needsUpdate := false
-	for _, dep := range essentialDeps {
+	var added []string
+	for _, dep := range required {
 		if !depsMap[dep] {
 			currentDeps = append(currentDeps, dep)
 			depsMap[dep] = true
-			needsUpdate = true
+			added = append(added, dep)
 		}
 	}
+	if len(added) == 0 {
+		return nil
+	}
 
-	if needsUpdate && useRepoLineIndex != -1 {
-		// Sort dependencies for consistency
-		// (keep existing order, just add new ones at the end)
+	// Keep the existing order, new repositories go at the end
+	newUseRepoLine := `use_repo(go_deps`
+	for _, dep := range currentDeps {
+		newUseRepoLine += `, "` + dep + `"`
+	}
+	newUseRepoLine += `)`
+
+	var newLines []string
+	if start == -1 {
+		newLines = append(lines, newUseRepoLine)
+	} else {
+		newLines = append(newLines, lines[:start]...)
+		newLines = append(newLines, newUseRepoLine)
+		newLines = append(newLines, lines[end+1:]...)
+	}
 
-		// Rebuild use_repo line
-		newUseRepoLine := `use_repo(go_deps`
-		for _, dep := range currentDeps {
-			newUseRepoLine += `, "` + dep + `"`
+	if err := os.WriteFile(modulePath, []byte(strings.Join(newLines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write MODULE.bazel: %w", err)
+	}
+
+	log.Info("✅ Added blank-imported dependencies: %v", added)
+	return nil
+}
+
+// findGoDepsUseRepo locates the use_repo(go_deps, ...) call in MODULE.bazel,
+// which may span several lines, and returns its first and last line along with
+// the repositories it lists. start is -1 when there is no such call.
+func findGoDepsUseRepo(lines []string) (start, end int, deps []string) {
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "use_repo(") {
+			continue
+		}
+
+		call := strings.TrimSpace(line)
+		j := i
+		for !strings.Contains(lines[j], ")") && j+1 < len(lines) {
+			j++
+			call += " " + strings.TrimSpace(lines[j])
+		}
+
+		open := strings.Index(call, "(")
+		closing := strings.LastIndex(call, ")")
+		if closing < open {
+			continue
+		}
+
+		args := strings.Split(call[open+1:closing], ",")
+		if strings.TrimSpace(args[0]) != "go_deps" {
+			continue
+		}
+		for _, arg := range args[1:] {
+			if dep := strings.Trim(strings.TrimSpace(arg), `"`); dep != "" {
+				deps = append(deps, dep)
+			}
+		}
+		return i, j, deps
+	}
+	return -1, -1, nil
+}
+
+// blankImportRepos scans the Go sources of the given workspace modules for
+// blank imports and returns the Bazel repositories of the required modules
+// that provide them, sorted.
+func (s *Syncer) blankImportRepos(modules []string) ([]string, error) {
+	repos := make(map[string]bool)
+
+	for _, module := range modules {
+		moduleDir := filepath.Join(s.workspaceRoot, module)
+		content, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+		if err != nil {
+			continue
+		}
+
+		var requires []string
+		for _, req := range parseGoModRequires(string(content)) {
+			// Workspace modules are resolved through go.work, not go_deps
+			if !strings.Contains(req, s.config.Workspace.Name) {
+				requires = append(requires, req)
+			}
+		}
+		if len(requires) == 0 {
+			continue
 		}
-		newUseRepoLine += `)`
-
-		// Replace the line(s)
-		newLines := make([]string, 0, len(lines))
-		i := 0
-		for i < len(lines) {
-			if i == useRepoLineIndex {
-				newLines = append(newLines, newUseRepoLine)
-				// Skip any continuation lines
-				for i < len(lines) && !strings.Contains(lines[i], ")") {
-					i++
+
+		err = filepath.WalkDir(moduleDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != moduleDir && (name == "vendor" || name == "testdata" || name == "node_modules" ||
+					strings.HasPrefix(name, "bazel-") || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
 				}
-				i++ // Skip the closing line
-			} else {
-				newLines = append(newLines, lines[i])
-				i++
+				return nil
+			}
+			if filepath.Ext(path) != ".go" {
+				return nil
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+			if err != nil {
+				log.Debug("Skipping %s: %v", path, err)
+				return nil
 			}
+			for _, imp := range file.Imports {
+				if imp.Name == nil || imp.Name.Name != "_" {
+					continue
+				}
+				importPath := strings.Trim(imp.Path.Value, `"`)
+				if mod := owningModule(requires, importPath); mod != "" {
+					repos[goModuleToRepoName(mod)] = true
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s for blank imports: %w", module, err)
 		}
+	}
 
-		// Write back to file
-		newContent := strings.Join(newLines, "\n")
-		if err := os.WriteFile(modulePath, []byte(newContent), 0644); err != nil {
-			return fmt.Errorf("failed to write MODULE.bazel: %w", err)
+	return sortedKeys(repos), nil
+}
+
+// owningModule returns the module providing importPath, the longest of the
+// given module paths that is a prefix of it, or "" for none (e.g. the
+// standard library).
+func owningModule(modules []string, importPath string) string {
+	owner := ""
+	for _, mod := range modules {
+		if (importPath == mod || strings.HasPrefix(importPath, mod+"/")) && len(mod) > len(owner) {
+			owner = mod
 		}
+	}
+	return owner
+}
 
-		fmt.Printf("✅ Added missing dependencies: %v\n", essentialDeps)
+// parseGoModRequires returns the module paths required by a go.mod file,
+// including indirect requirements.
+func parseGoModRequires(content string) []string {
+	var requires []string
+	inRequireBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "require ("):
+			inRequireBlock = true
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+		case inRequireBlock && line != "" && !strings.HasPrefix(line, "//"):
+			if parts := strings.Fields(line); len(parts) >= 2 {
+				requires = append(requires, parts[0])
+			}
+		case strings.HasPrefix(line, "require "):
+			if parts := strings.Fields(strings.TrimPrefix(line, "require ")); len(parts) >= 2 {
+				requires = append(requires, parts[0])
+			}
+		}
 	}
 
-	return nil
+	return requires
 }
 
 // contains checks if a slice contains a string.
@@ -350,51 +457,24 @@ func (s *Syncer) extractGoModDependencies(modules []string) ([]string, error) {
 	return deps, nil
 }
 
-// goModuleToRepoName converts a Go module path to a Bazel repository name.
-// e.g., "github.com/lib/pq" -> "com_github_lib_pq"
+// goModuleToRepoName converts a Go module path to the Bazel repository name
+// gazelle gives it, e.g. "github.com/lib/pq" -> "com_github_lib_pq" and
+// "github.com/redis/go-redis/v9" -> "com_github_redis_go_redis_v9".
 func goModuleToRepoName(modulePath string) string {
-	// Remove version suffixes like /v2, /v3
-	if idx := strings.LastIndex(modulePath, "/v"); idx != -1 {
-		if len(modulePath) > idx+2 {
-			// Check if it's a version suffix
-			rest := modulePath[idx+2:]
-			isVersion := true
-			for _, ch := range rest {
-				if ch < '0' || ch > '9' {
-					isVersion = false
-					break
-				}
-			}
-			if isVersion {
-				modulePath = modulePath[:idx]
-			}
-		}
-	}
-
-	// Replace dots and slashes with underscores
-	name := strings.ReplaceAll(modulePath, ".", "_")
-	name = strings.ReplaceAll(name, "/", "_")
-	name = strings.ReplaceAll(name, "-", "_")
+	modulePath = strings.ToLower(modulePath)
 
 	// Reverse domain notation (github.com -> com_github)
 	parts := strings.Split(modulePath, "/")
-	if len(parts) >= 2 {
-		domain := parts[0]
-		domainParts := strings.Split(domain, ".")
-		// Reverse domain parts
-		for i, j := 0, len(domainParts)-1; i < j; i, j = i+1, j-1 {
-			domainParts[i], domainParts[j] = domainParts[j], domainParts[i]
-		}
-		reversedDomain := strings.Join(domainParts, "_")
-
-		// Reconstruct with reversed domain
-		result := reversedDomain
-		for i := 1; i < len(parts); i++ {
-			result += "_" + strings.ReplaceAll(strings.ReplaceAll(parts[i], ".", "_"), "-", "_")
-		}
-		return result
+	domainParts := strings.Split(parts[0], ".")
+	for i, j := 0, len(domainParts)-1; i < j; i, j = i+1, j-1 {
+		domainParts[i], domainParts[j] = domainParts[j], domainParts[i]
 	}
+	parts[0] = strings.Join(domainParts, "_")
 
+	// Replace dots, dashes and slashes with underscores
+	name := strings.Join(parts, "_")
+	name = strings.ReplaceAll(name, ".", "_")
+	name = strings.ReplaceAll(name, "-", "_")
 	return name
 }
 
@@ -448,11 +528,15 @@ func (s *Syncer) parseGoWorkModules() ([]string, error) {
 }
 
 // createAggregatorGoMod creates or updates a root go.mod that aggregates dependencies from all modules.
-func (s *Syncer) createAggregatorGoMod(modules []string) error {
+// Replace directives pointing at other modules are carried over so go_deps.from_file honours them,
+// and modules matching GOPRIVATE are returned separately to be pinned with go_deps.module.
+func (s *Syncer) createAggregatorGoMod(ctx context.Context, modules []string) (*GoModOverrides, error) {
 	goModPath := filepath.Join(s.workspaceRoot, "go.mod")
 
 	// Collect all unique dependencies from all modules
 	depMap := make(map[string]string) // module path -> version
+	sums := make(map[string]string)   // "path@version" -> go.sum hash
+	replaces := make(map[string]goModReplace)
 	var goVersion string
 
 	for _, module := range modules {
@@ -462,6 +546,22 @@ func (s *Syncer) createAggregatorGoMod(modules []string) error {
 			continue
 		}
 
+		for key, sum := range readGoSums(filepath.Join(s.workspaceRoot, module, "go.sum")) {
+			sums[key] = sum
+		}
+
+		for _, r := range parseGoModReplaces(string(content)) {
+			if r.isLocal() {
+				// Workspace modules are resolved through go.work; anything else can't be seen by Bazel
+				target := filepath.Clean(filepath.Join(module, r.New))
+				if !contains(modules, filepath.ToSlash(target)) {
+					log.Warn("⚠️  Warning: %s replaces %s with local path %s, which Bazel cannot fetch", module, r.Old, r.New)
+				}
+				continue
+			}
+			replaces[r.Old] = r
+		}
+
 		lines := strings.Split(string(content), "\n")
 		inRequireBlock := false
 
@@ -513,9 +613,42 @@ func (s *Syncer) createAggregatorGoMod(modules []string) error {
 	}
 
 	if goVersion == "" {
-		goVersion = "1.24.0"
+		toolVersions, err := s.config.GetToolVersions()
+		if err != nil {
+			return nil, err
+		}
+		goVersion = toolVersions.Go
+	}
+
+	// Private modules are pinned with go_deps.module; modules already fetched
+	// through the proxy are left to go_deps.from_file and bazel mod tidy
+	overrides := &GoModOverrides{PrivatePatterns: goPrivatePatterns(ctx)}
+	if len(overrides.PrivatePatterns) > 0 {
+		for mod, ver := range depMap {
+			if !matchesGoPrivate(overrides.PrivatePatterns, mod) {
+				continue
+			}
+			if _, replaced := replaces[mod]; replaced {
+				continue
+			}
+			sum, ok := sums[mod+"@"+ver]
+			if !ok {
+				log.Warn("⚠️  Warning: no go.sum entry for private module %s@%s, run 'go mod download' first", mod, ver)
+				continue
+			}
+			overrides.PrivateModules = append(overrides.PrivateModules, GoPrivateModule{Path: mod, Version: ver, Sum: sum})
+			delete(depMap, mod)
+		}
+		sort.Slice(overrides.PrivateModules, func(i, j int) bool {
+			return overrides.PrivateModules[i].Path < overrides.PrivateModules[j].Path
+		})
 	}
 
+	for _, r := range replaces {
+		overrides.Replaces = append(overrides.Replaces, r.String())
+	}
+	sort.Strings(overrides.Replaces)
+
 	// Build go.mod content
 	var content strings.Builder
 	moduleName := s.config.Workspace.Name
@@ -534,10 +667,21 @@ func (s *Syncer) createAggregatorGoMod(modules []string) error {
 		content.WriteString(")\n")
 	}
 
+	if len(overrides.Replaces) > 0 {
+		content.WriteString("\nreplace (\n")
+		for _, r := range overrides.Replaces {
+			content.WriteString(fmt.Sprintf("\t%s\n", r))
+		}
+		content.WriteString(")\n")
+	}
+
 	if s.dryRun {
-		fmt.Printf("Would create/update: %s\n", goModPath)
-		return nil
+		log.Info("Would create/update: %s", goModPath)
+		return overrides, nil
 	}
 
-	return os.WriteFile(goModPath, []byte(content.String()), 0644)
+	if err := os.WriteFile(goModPath, []byte(content.String()), 0644); err != nil {
+		return nil, err
+	}
+	return overrides, nil
 }
@@ -0,0 +1,225 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// writeFiles creates the given files, relative to root.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFixModuleBazelDependenciesKeepsBlankImports(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.work": "go 1.24\n\nuse (\n\t./backend/services/orders\n)\n",
+		"backend/services/orders/go.mod": `module github.com/acme/shop/backend/services/orders
+
+go 1.24
+
+require (
+	github.com/acme/shop/shared v0.0.0
+	github.com/go-sql-driver/mysql v1.8.1
+	github.com/google/uuid v1.6.0
+	github.com/redis/go-redis/v9 v9.7.0
+)
+`,
+		"backend/services/orders/cmd/main.go": `package main
+
+import (
+	"database/sql"
+	_ "embed"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+func main() {
+	_, _ = sql.Open("mysql", uuid.NewString())
+}
+`,
+		// Not a blank import: bazel mod tidy keeps it through BUILD files
+		"backend/services/orders/internal/cache/cache.go": `package cache
+
+import "github.com/redis/go-redis/v9"
+
+var _ = redis.NewClient
+`,
+		// Vendored code is not part of the module's imports
+		"backend/services/orders/vendor/example.com/x/x.go": "package x\n\nimport _ \"github.com/lib/pq\"\n",
+		"MODULE.bazel": `module(name = "shop")
+
+go_deps = use_extension("@gazelle//:extensions.bzl", "go_deps")
+go_deps.from_file(go_mod = "//:go.mod")
+use_repo(
+    go_deps,
+    "com_github_google_uuid",
+    "com_github_redis_go_redis_v9",
+)
+`,
+	})
+
+	s := &Syncer{
+		workspaceRoot: root,
+		config:        &workspace.Config{Workspace: workspace.WorkspaceMetadata{Name: "shop"}},
+	}
+	if err := s.fixModuleBazelDependencies(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, "MODULE.bazel"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	start, end, deps := findGoDepsUseRepo(lines)
+	if start == -1 {
+		t.Fatalf("no use_repo(go_deps, ...) in regenerated MODULE.bazel:\n%s", content)
+	}
+	if start != end {
+		t.Errorf("use_repo spans lines %d-%d, want it rewritten on one line", start, end)
+	}
+
+	want := []string{"com_github_google_uuid", "com_github_redis_go_redis_v9", "com_github_go_sql_driver_mysql"}
+	if strings.Join(deps, ",") != strings.Join(want, ",") {
+		t.Errorf("use_repo(go_deps, ...) lists %v, want %v", deps, want)
+	}
+	if strings.Count(string(content), "use_repo(") != 1 {
+		t.Errorf("MODULE.bazel has more than one use_repo call:\n%s", content)
+	}
+	if !strings.Contains(string(content), `go_deps.from_file(go_mod = "//:go.mod")`) {
+		t.Errorf("lines around use_repo were not preserved:\n%s", content)
+	}
+}
+
+func TestFixModuleBazelDependenciesNoChange(t *testing.T) {
+	root := t.TempDir()
+	module := `use_repo(go_deps, "com_github_go_sql_driver_mysql")
+`
+	writeFiles(t, root, map[string]string{
+		"go.work":          "go 1.24\n\nuse ./api\n",
+		"api/go.mod":       "module github.com/acme/shop/api\n\ngo 1.24\n\nrequire github.com/go-sql-driver/mysql v1.8.1\n",
+		"api/db/driver.go": "package db\n\nimport _ \"github.com/go-sql-driver/mysql\"\n",
+		"MODULE.bazel":     module,
+	})
+
+	s := &Syncer{
+		workspaceRoot: root,
+		config:        &workspace.Config{Workspace: workspace.WorkspaceMetadata{Name: "shop"}},
+	}
+	if err := s.fixModuleBazelDependencies(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, "MODULE.bazel"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != module {
+		t.Errorf("MODULE.bazel was rewritten although nothing was missing:\n%s", content)
+	}
+}
+
+func TestFindGoDepsUseRepo(t *testing.T) {
+	tests := []struct {
+		name      string
+		module    string
+		wantStart int
+		wantEnd   int
+		wantDeps  []string
+	}{
+		{
+			name:      "single line",
+			module:    "bazel_dep(name = \"gazelle\")\nuse_repo(go_deps, \"com_github_lib_pq\", \"org_golang_x_sync\")\n",
+			wantStart: 1,
+			wantEnd:   1,
+			wantDeps:  []string{"com_github_lib_pq", "org_golang_x_sync"},
+		},
+		{
+			name: "multi line",
+			module: `go_deps = use_extension("@gazelle//:extensions.bzl", "go_deps")
+use_repo(
+    go_deps,
+    "com_github_google_uuid",
+    "com_github_lib_pq",
+)
+`,
+			wantStart: 1,
+			wantEnd:   5,
+			wantDeps:  []string{"com_github_google_uuid", "com_github_lib_pq"},
+		},
+		{
+			name: "other extension first",
+			module: `use_repo(node, "nodejs")
+use_repo(go_deps,
+    "com_github_lib_pq")
+`,
+			wantStart: 1,
+			wantEnd:   2,
+			wantDeps:  []string{"com_github_lib_pq"},
+		},
+		{
+			name:      "missing",
+			module:    "use_repo(node, \"nodejs\")\n",
+			wantStart: -1,
+			wantEnd:   -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, deps := findGoDepsUseRepo(strings.Split(tt.module, "\n"))
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("lines = %d-%d, want %d-%d", start, end, tt.wantStart, tt.wantEnd)
+			}
+			if strings.Join(deps, ",") != strings.Join(tt.wantDeps, ",") {
+				t.Errorf("deps = %v, want %v", deps, tt.wantDeps)
+			}
+		})
+	}
+}
+
+func TestGoPrivatePatterns(t *testing.T) {
+	var ran []string
+	defer exec.SetRunner(exec.RunnerFunc(func(ctx context.Context, opts exec.Options) error {
+		ran = append(ran, opts.String())
+		fmt.Fprintln(opts.Stdout, "gitlab.example.com/*, github.com/acme")
+		return nil
+	}))()
+
+	// The environment wins over 'go env -w'
+	t.Setenv("GOPRIVATE", "gitlab.internal")
+	if got := goPrivatePatterns(context.Background()); strings.Join(got, ",") != "gitlab.internal" || len(ran) != 0 {
+		t.Errorf("goPrivatePatterns() = %v after running %v, want the GOPRIVATE variable", got, ran)
+	}
+
+	t.Setenv("GOPRIVATE", "")
+	got := goPrivatePatterns(context.Background())
+	if strings.Join(got, ",") != "gitlab.example.com/*,github.com/acme" {
+		t.Errorf("goPrivatePatterns() = %v, want the patterns from go env", got)
+	}
+	if len(ran) != 1 || ran[0] != "go env GOPRIVATE" {
+		t.Errorf("ran %v, want go env GOPRIVATE", ran)
+	}
+	if !matchesGoPrivate(got, "gitlab.example.com/team/lib") || matchesGoPrivate(got, "github.com/other/lib") {
+		t.Errorf("matchesGoPrivate(%v) matched the wrong modules", got)
+	}
+}
@@ -0,0 +1,160 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+// GoPrivateModule is a module matching GOPRIVATE. It is declared explicitly with
+// go_deps.module so Bazel pins the version and checksum from go.sum instead of
+// resolving it through the public Go proxy.
+type GoPrivateModule struct {
+	Path    string
+	Version string
+	Sum     string
+}
+
+// GoModOverrides holds the go.mod settings that go_deps.from_file cannot pick up
+// from the aggregated root go.mod on its own.
+type GoModOverrides struct {
+	PrivatePatterns []string
+	PrivateModules  []GoPrivateModule
+	Replaces        []string // replace directives carried into the root go.mod
+}
+
+// goModReplace is a replace directive from a go.mod file.
+type goModReplace struct {
+	Old        string
+	OldVersion string
+	New        string
+	NewVersion string
+}
+
+// isLocal reports whether the replacement points at a directory rather than a module.
+func (r goModReplace) isLocal() bool {
+	return strings.HasPrefix(r.New, "./") || strings.HasPrefix(r.New, "../") || filepath.IsAbs(r.New)
+}
+
+// String formats the directive as a go.mod replace line.
+func (r goModReplace) String() string {
+	old := r.Old
+	if r.OldVersion != "" {
+		old += " " + r.OldVersion
+	}
+	replacement := r.New
+	if r.NewVersion != "" {
+		replacement += " " + r.NewVersion
+	}
+	return old + " => " + replacement
+}
+
+// parseGoModReplaces extracts replace directives from go.mod content,
+// handling both single-line and block forms.
+func parseGoModReplaces(content string) []goModReplace {
+	var replaces []goModReplace
+	inReplaceBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, "//"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		if strings.HasPrefix(line, "replace (") {
+			inReplaceBlock = true
+			continue
+		}
+
+		if inReplaceBlock {
+			if line == ")" {
+				inReplaceBlock = false
+				continue
+			}
+		} else if strings.HasPrefix(line, "replace ") {
+			line = strings.TrimPrefix(line, "replace ")
+		} else {
+			continue
+		}
+
+		old, replacement, ok := strings.Cut(line, "=>")
+		if !ok {
+			continue
+		}
+		oldParts := strings.Fields(old)
+		newParts := strings.Fields(replacement)
+		if len(oldParts) == 0 || len(newParts) == 0 {
+			continue
+		}
+
+		r := goModReplace{Old: oldParts[0], New: newParts[0]}
+		if len(oldParts) > 1 {
+			r.OldVersion = oldParts[1]
+		}
+		if len(newParts) > 1 {
+			r.NewVersion = newParts[1]
+		}
+		replaces = append(replaces, r)
+	}
+
+	return replaces
+}
+
+// readGoSums returns the module checksums from a go.sum file keyed by "path@version".
+func readGoSums(goSumPath string) map[string]string {
+	sums := make(map[string]string)
+	content, err := os.ReadFile(goSumPath)
+	if err != nil {
+		return sums
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		parts := strings.Fields(line)
+		// Skip the /go.mod lines, we want the module itself
+		if len(parts) >= 3 && !strings.HasSuffix(parts[1], "/go.mod") {
+			sums[parts[0]+"@"+parts[1]] = parts[2]
+		}
+	}
+	return sums
+}
+
+// goPrivatePatterns returns the GOPRIVATE patterns from the environment,
+// falling back to the value set with 'go env -w'.
+func goPrivatePatterns(ctx context.Context) []string {
+	value := os.Getenv("GOPRIVATE")
+	if value == "" {
+		if output, err := exec.Output(ctx, exec.Options{Name: "go", Args: []string{"env", "GOPRIVATE"}}); err == nil {
+			value = strings.TrimSpace(string(output))
+		}
+	}
+
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// matchesGoPrivate reports whether a module path matches any GOPRIVATE pattern.
+// Like the go command, a pattern matches a path prefix with the same number of
+// elements, so "gitlab.example.com" matches "gitlab.example.com/team/lib".
+func matchesGoPrivate(patterns []string, modulePath string) bool {
+	elements := strings.Split(modulePath, "/")
+	for _, pattern := range patterns {
+		n := strings.Count(pattern, "/") + 1
+		if n > len(elements) {
+			continue
+		}
+		prefix := strings.Join(elements[:n], "/")
+		if matched, _ := path.Match(pattern, prefix); matched {
+			return true
+		}
+	}
+	return false
+}
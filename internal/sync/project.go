@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SyncProject generates the Bazel files of a single project, for a project
+// just added to forge.json. Go projects are synced like their go.mod and Go
+// files changed: go.work and the module dependencies are updated and gazelle
+// runs on their packages. JavaScript projects get their BUILD.bazel rendered.
+func (s *Syncer) SyncProject(ctx context.Context, name string) (*SyncReport, error) {
+	project := s.config.GetProject(name)
+	if project == nil {
+		return nil, fmt.Errorf("project %q not found in forge.json", name)
+	}
+
+	report := &SyncReport{}
+	switch project.Language {
+	case "go":
+		paths, err := projectGoPaths(filepath.Join(s.workspaceRoot, project.Root))
+		if err != nil {
+			return nil, err
+		}
+		return s.SyncPaths(ctx, paths)
+	case "nestjs":
+		return report, s.generateNestJSBuild(name, project.Root, report)
+	case "angular", "react":
+		if project.ProjectType != "application" {
+			return report, nil
+		}
+		return report, s.generateAngularBuild(name, project.Root, report)
+	case "vue":
+		return report, s.generateVueBuild(name, project.Root, report)
+	}
+	return report, nil
+}
+
+// projectGoPaths returns the go.mod and Go files of the module at root,
+// skipping vendored, generated and hidden directories.
+func projectGoPaths(root string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (skipSyncDir(name) || name == "testdata") {
+				return filepath.SkipDir
+			}
+			// Nested modules are projects of their own
+			if path != root {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if d.Name() == "go.mod" || filepath.Ext(path) == ".go" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
@@ -0,0 +1,212 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/pkg/workspace"
+)
+
+// managedFiles are the files a full sync may create, rewrite or delete.
+var managedFiles = map[string]bool{
+	"BUILD.bazel":            true,
+	"BUILD":                  true,
+	"MODULE.bazel":           true,
+	"go.work":                true,
+	workspace.ConfigFileName: true,
+}
+
+// Changed reports whether the sync created, updated or deleted any file.
+func (r *SyncReport) Changed() bool {
+	return len(r.CreatedFiles)+len(r.UpdatedFiles)+len(r.DeletedFiles) > 0
+}
+
+// MarshalJSON encodes the report with errors as strings and empty lists
+// instead of null, so CI can check e.g. `.changed == false`.
+func (r *SyncReport) MarshalJSON() ([]byte, error) {
+	errs := make([]string, 0, len(r.Errors))
+	for _, err := range r.Errors {
+		errs = append(errs, err.Error())
+	}
+	orEmpty := func(files []string) []string {
+		if files == nil {
+			return []string{}
+		}
+		return files
+	}
+
+	return json.Marshal(struct {
+		Changed bool     `json:"changed"`
+		Created []string `json:"created"`
+		Updated []string `json:"updated"`
+		Deleted []string `json:"deleted"`
+		Errors  []string `json:"errors"`
+		Diff    string   `json:"diff,omitempty"`
+	}{
+		Changed: r.Changed(),
+		Created: orEmpty(r.CreatedFiles),
+		Updated: orEmpty(r.UpdatedFiles),
+		Deleted: orEmpty(r.DeletedFiles),
+		Errors:  errs,
+		Diff:    r.Diff,
+	})
+}
+
+// snapshotManagedFiles reads the managed files of the workspace, keyed by
+// their path relative to the workspace root.
+func (s *Syncer) snapshotManagedFiles() (map[string][]byte, error) {
+	snapshot := make(map[string][]byte)
+
+	err := filepath.WalkDir(s.workspaceRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != s.workspaceRoot && skipSyncDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !managedFiles[d.Name()] {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.workspaceRoot, path)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace files: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// recordChanges compares the managed files with a snapshot taken before the
+// sync and records the files created, updated and deleted since, along with a
+// unified diff of the updated ones, or of every changed file when the syncer
+// checks for drift. Files rewritten with the same content are not changes.
+func (s *Syncer) recordChanges(report *SyncReport, before map[string][]byte) error {
+	after, err := s.snapshotManagedFiles()
+	if err != nil {
+		return err
+	}
+
+	report.CreatedFiles = []string{}
+	report.UpdatedFiles = []string{}
+	report.DeletedFiles = []string{}
+
+	var diff strings.Builder
+	writeDiff := func(path string, previous, current []byte) error {
+		fileDiff, err := unifiedDiff(path, previous, current)
+		if err != nil {
+			return err
+		}
+		diff.WriteString(fileDiff)
+		return nil
+	}
+
+	for _, path := range sortedPaths(after) {
+		previous, existed := before[path]
+		switch {
+		case !existed:
+			report.CreatedFiles = append(report.CreatedFiles, path)
+			if s.diffAll {
+				if err := writeDiff(path, nil, after[path]); err != nil {
+					return err
+				}
+			}
+		case !bytes.Equal(previous, after[path]):
+			report.UpdatedFiles = append(report.UpdatedFiles, path)
+			if err := writeDiff(path, previous, after[path]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, path := range sortedPaths(before) {
+		if _, exists := after[path]; !exists {
+			report.DeletedFiles = append(report.DeletedFiles, path)
+			if s.diffAll {
+				if err := writeDiff(path, before[path], nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	report.Diff = diff.String()
+	return nil
+}
+
+// unifiedDiff returns `diff -u` output between two versions of a file. A nil
+// version stands for a file that does not exist.
+func unifiedDiff(path string, previous, current []byte) (string, error) {
+	dir, err := os.MkdirTemp("", "forge-sync-diff-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create diff directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	previousFile := filepath.Join(dir, "previous")
+	currentFile := filepath.Join(dir, "current")
+	if err := os.WriteFile(previousFile, previous, 0644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(currentFile, current, 0644); err != nil {
+		return "", err
+	}
+
+	previousLabel, currentLabel := "a/"+path, "b/"+path
+	if previous == nil {
+		previousLabel = "/dev/null"
+	}
+	if current == nil {
+		currentLabel = "/dev/null"
+	}
+
+	out, err := exec.Output(context.Background(), exec.Options{
+		Name:   "diff",
+		Args:   []string{"-u", "--label", previousLabel, "--label", currentLabel, previousFile, currentFile},
+		Stderr: &bytes.Buffer{},
+	})
+	// diff exits with 1 when the files differ
+	var exitErr *osexec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return "", fmt.Errorf("diff failed for %s: %w", path, err)
+	}
+	return string(out), nil
+}
+
+// skipSyncDir reports whether a directory holds no files managed by sync.
+func skipSyncDir(name string) bool {
+	switch name {
+	case "bazel-bin", "bazel-out", "bazel-testlogs", "node_modules", "vendor":
+		return true
+	}
+	return strings.HasPrefix(name, ".")
+}
+
+func sortedPaths(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
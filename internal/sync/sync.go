@@ -1,13 +1,16 @@
 package sync
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/dosanma1/forge-cli/internal/bazel"
+	"github.com/dosanma1/forge-cli/internal/exec"
+	"github.com/dosanma1/forge-cli/internal/log"
 	"github.com/dosanma1/forge-cli/internal/template"
 	"github.com/dosanma1/forge-cli/pkg/workspace"
 )
@@ -16,7 +19,12 @@ import (
 type SyncReport struct {
 	DeletedFiles []string
 	CreatedFiles []string
+	UpdatedFiles []string
 	Errors       []error
+
+	// Diff is a unified diff of the updated files (full sync only), or of
+	// every changed file when checking for drift
+	Diff string
 }
 
 // Syncer handles workspace synchronization operations.
@@ -25,6 +33,8 @@ type Syncer struct {
 	config        *workspace.Config
 	engine        *template.Engine
 	dryRun        bool
+	diffAll       bool
+	jobs          int
 }
 
 // NewSyncer creates a new Syncer instance.
@@ -39,120 +49,182 @@ func NewSyncer(workspaceRoot string, dryRun bool) (*Syncer, error) {
 		config:        config,
 		engine:        template.NewEngine(),
 		dryRun:        dryRun,
+		jobs:          config.ParallelJobs(0),
 	}, nil
 }
 
+// SetJobs overrides the number of parallel jobs Bazel runs with (the --jobs
+// flag). Zero keeps workspace.build.parallel.workers or the number of CPUs.
+func (s *Syncer) SetJobs(jobs int) {
+	s.jobs = s.config.ParallelJobs(jobs)
+}
+
+// bazelRunArgs returns the arguments of 'bazel run' for target.
+func (s *Syncer) bazelRunArgs(target string) []string {
+	args := []string{"run"}
+	if s.jobs > 0 {
+		args = append(args, fmt.Sprintf("--jobs=%d", s.jobs))
+	}
+	return append(args, target)
+}
+
 // Sync performs a full workspace synchronization following the Bazel bzlmod workflow.
-func (s *Syncer) Sync() (*SyncReport, error) {
+func (s *Syncer) Sync(ctx context.Context) (*SyncReport, error) {
 	report := &SyncReport{
 		DeletedFiles: []string{},
 		CreatedFiles: []string{},
 		Errors:       []error{},
 	}
 
-	fmt.Println("🚀 Starting Bazel workspace sync...")
-	fmt.Println()
+	log.Info("🚀 Starting Bazel workspace sync...")
+	log.Info("")
+
+	// Report what actually changed on disk rather than every file rewritten
+	if !s.dryRun {
+		before, err := s.snapshotManagedFiles()
+		if err != nil {
+			return report, err
+		}
+		defer func() {
+			if err := s.recordChanges(report, before); err != nil {
+				report.Errors = append(report.Errors, err)
+			}
+		}()
+	}
+
+	// Register Angular projects added outside of forge (e.g. with 'ng generate application')
+	registered, err := s.RegisterAngularProjects(report)
+	if err != nil {
+		return report, fmt.Errorf("failed to register Angular projects: %w", err)
+	}
+	if len(registered) > 0 && !s.dryRun {
+		log.Info("📦 Registered %d Angular project(s) from angular.json:", len(registered))
+		for _, name := range registered {
+			log.Info("   - %s", name)
+		}
+		log.Info("")
+	}
 
 	// Detect Go projects from forge.json
 	goProjects := s.getGoProjects()
 
 	if len(goProjects) == 0 {
-		fmt.Println("⚠️  No Go projects found in forge.json")
+		log.Warn("⚠️  No Go projects found in forge.json")
 		return report, nil
 	}
 
-	fmt.Printf("🔍 Found %d Go project(s):\n", len(goProjects))
+	log.Info("🔍 Found %d Go project(s):", len(goProjects))
 	for _, proj := range goProjects {
-		fmt.Printf("   - %s (%s)\n", proj.Name, proj.Root)
+		log.Info("   - %s (%s)", proj.Name, proj.Root)
 	}
-	fmt.Println()
+	log.Info("")
 
 	if s.dryRun {
-		fmt.Println("🏃 DRY RUN - No changes will be made")
+		log.Info("🏃 DRY RUN - No changes will be made")
 		return report, nil
 	}
 
+	// The Go steps still run without bazel; the ones that run it are skipped
+	hasBazel := bazel.Available()
+	if !hasBazel {
+		err := fmt.Errorf("bazel is not installed: skipped gazelle, bazel mod tidy and workspace validation")
+		log.Warn("⚠️  %v", err)
+		log.Warn("   BUILD.bazel files for Go packages were not generated. Install Bazelisk and run 'forge sync' again.")
+		log.Info("")
+		report.Errors = append(report.Errors, err)
+	}
+
 	// Step 1: Generate root BUILD.bazel with gazelle target
-	fmt.Println("📝 Step 1: Generating root BUILD.bazel...")
+	log.Info("📝 Step 1: Generating root BUILD.bazel...")
 	if err := s.generateRootBuildFile(goProjects); err != nil {
 		return report, fmt.Errorf("failed to generate root BUILD.bazel: %w", err)
 	}
-	fmt.Println("✅ Root BUILD.bazel generated")
-	fmt.Println()
+	log.Info("✅ Root BUILD.bazel generated")
+	log.Info("")
 
 	// Step 2: Generate go.work and run go work sync
-	fmt.Println("📝 Step 2: Syncing go.work...")
-	if err := s.syncGoWork(goProjects); err != nil {
+	log.Info("📝 Step 2: Syncing go.work...")
+	if err := s.syncGoWork(ctx, goProjects); err != nil {
 		return report, fmt.Errorf("failed to sync go.work: %w", err)
 	}
-	fmt.Println("✅ go.work synced")
-	fmt.Println()
+	log.Info("✅ go.work synced")
+	log.Info("")
 
 	// Step 2b: Ensure MODULE.bazel has OCI support
-	fmt.Println("📝 Step 2b: Ensuring OCI support in MODULE.bazel...")
+	log.Info("📝 Step 2b: Ensuring OCI support in MODULE.bazel...")
 	if err := s.ensureOciSupport(); err != nil {
 		return report, fmt.Errorf("failed to ensure OCI support: %w", err)
 	}
-	fmt.Println("✅ OCI support ensured")
-	fmt.Println()
+	log.Info("✅ OCI support ensured")
+	log.Info("")
 
 	// Step 3: Create empty BUILD files in service directories
 	// (Required for bzlmod to evaluate go.work references)
-	fmt.Println("📝 Step 3: Creating BUILD files in service directories...")
+	log.Info("📝 Step 3: Creating BUILD files in service directories...")
 	for _, proj := range goProjects {
 		buildPath := filepath.Join(s.workspaceRoot, proj.Root, "BUILD.bazel")
 		if _, err := os.Stat(buildPath); os.IsNotExist(err) {
 			if err := os.WriteFile(buildPath, []byte("# Managed by gazelle\n"), 0644); err != nil {
 				return report, fmt.Errorf("failed to create BUILD file for %s: %w", proj.Name, err)
 			}
-			fmt.Printf("   Created %s/BUILD.bazel\n", proj.Root)
+			log.Info("   Created %s/BUILD.bazel", proj.Root)
+		}
+		if err := s.ensureProjectGazelleDirectives(proj); err != nil {
+			return report, fmt.Errorf("failed to add gazelle directives for %s: %w", proj.Name, err)
 		}
 	}
-	fmt.Println("✅ BUILD files created")
-	fmt.Println()
+	log.Info("✅ BUILD files created")
+	log.Info("")
 
 	// Step 4: Run gazelle to populate BUILD.bazel files
-	fmt.Println("📝 Step 4: Generating BUILD.bazel files...")
-	if err := s.runGazelle(); err != nil {
-		return report, fmt.Errorf("failed to run gazelle: %w", err)
+	if hasBazel {
+		log.Info("📝 Step 4: Generating BUILD.bazel files...")
+		if err := s.runGazelle(ctx); err != nil {
+			return report, fmt.Errorf("failed to run gazelle: %w", err)
+		}
+		log.Info("✅ BUILD.bazel files generated")
+		log.Info("")
 	}
-	fmt.Println("✅ BUILD.bazel files generated")
-	fmt.Println()
 
 	// Step 4b: Add container image targets for services
-	fmt.Println("📝 Step 4b: Adding container image targets for services...")
+	log.Info("📝 Step 4b: Adding container image targets for services...")
 	if err := s.ensureServiceImageTargets(); err != nil {
 		return report, fmt.Errorf("failed to add container image targets: %w", err)
 	}
-	fmt.Println("✅ Container image targets ready")
-	fmt.Println()
+	log.Info("✅ Container image targets ready")
+	log.Info("")
 
 	// Step 5: Run bazel mod tidy (reads go.work via go_deps.from_file)
-	fmt.Println("📝 Step 5: Running bazel mod tidy...")
-	if err := s.runBazelModTidy(); err != nil {
-		return report, fmt.Errorf("failed to run bazel mod tidy: %w", err)
-	}
-	fmt.Println("✅ Dependencies resolved from go.work")
-	fmt.Println()
-
-	// Step 6: Validate workspace
-	fmt.Println("🔍 Step 6: Validating workspace...")
-	if err := s.validateWorkspace(); err != nil {
-		fmt.Printf("⚠️  Warning: %v\n", err)
-		report.Errors = append(report.Errors, err)
-	} else {
-		fmt.Println("✅ Workspace validated")
+	if hasBazel {
+		log.Info("📝 Step 5: Running bazel mod tidy...")
+		if err := s.runBazelModTidy(ctx); err != nil {
+			return report, fmt.Errorf("failed to run bazel mod tidy: %w", err)
+		}
+		if err := s.fixModuleBazelDependencies(); err != nil {
+			return report, err
+		}
+		log.Info("✅ Dependencies resolved from go.work")
+		log.Info("")
+
+		// Step 6: Validate workspace
+		log.Info("🔍 Step 6: Validating workspace...")
+		if err := s.validateWorkspace(ctx); err != nil {
+			log.Warn("⚠️  Warning: %v", err)
+			report.Errors = append(report.Errors, err)
+		} else {
+			log.Info("✅ Workspace validated")
+		}
+		log.Info("")
 	}
-	fmt.Println()
 
 	// Final summary
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("✅ Sync complete!")
+	log.Info("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Info("✅ Sync complete!")
 	if len(report.Errors) > 0 {
-		fmt.Printf("⚠️  Completed with %d warning(s)\n", len(report.Errors))
+		log.Warn("⚠️  Completed with %d warning(s)", len(report.Errors))
 	}
-	fmt.Println("Ready for: forge build, forge test, forge deploy")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Info("Ready for: forge build, forge test, forge deploy")
+	log.Info("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	return report, nil
 }
@@ -206,15 +278,8 @@ func (s *Syncer) deleteAllBuildFiles(report *SyncReport) error {
 		}
 
 		// Skip bazel output directories and hidden directories
-		if d.IsDir() {
-			name := d.Name()
-			if name == "bazel-bin" || name == "bazel-out" || name == "bazel-testlogs" ||
-				name == ".git" || name == "node_modules" || name == "vendor" {
-				return filepath.SkipDir
-			}
-			if len(name) > 0 && name[0] == '.' && name != "." {
-				return filepath.SkipDir
-			}
+		if d.IsDir() && path != s.workspaceRoot && skipSyncDir(d.Name()) {
+			return filepath.SkipDir
 		}
 
 		// Collect BUILD.bazel files
@@ -254,15 +319,15 @@ func (s *Syncer) syncBuildFiles(report *SyncReport) error {
 
 	// Regenerate Go BUILD files
 	if contains(languages, "go") {
-		fmt.Println("🔧 Regenerating Go BUILD files...")
+		log.Info("🔧 Regenerating Go BUILD files...")
 		if err := s.syncGoBuildFiles(report); err != nil {
 			return err
 		}
 	}
 
-	// Regenerate NestJS/Angular BUILD files
-	if contains(languages, "nestjs") || contains(languages, "angular") || contains(languages, "react") {
-		fmt.Println("🔧 Regenerating JavaScript BUILD files...")
+	// Regenerate NestJS/Angular/Vue BUILD files
+	if contains(languages, "nestjs") || contains(languages, "angular") || contains(languages, "react") || contains(languages, "vue") {
+		log.Info("🔧 Regenerating JavaScript BUILD files...")
 		if err := s.syncJSBuildFiles(report); err != nil {
 			return err
 		}
@@ -299,20 +364,22 @@ func (s *Syncer) getGoProjects() []GoProject {
 	return projects
 }
 
-// runGazelle executes bazel run //:gazelle to generate BUILD.bazel files
-func (s *Syncer) runGazelle() error {
-	cmd := exec.Command("bazel", "run", "//:gazelle")
-	cmd.Dir = s.workspaceRoot
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// runGazelle executes bazel run //:gazelle to generate BUILD.bazel files.
+// When dirs are given, gazelle only visits those directories.
+func (s *Syncer) runGazelle(ctx context.Context, dirs ...string) error {
+	args := s.bazelRunArgs("//:gazelle")
+	if len(dirs) > 0 {
+		args = append(append(args, "--"), dirs...)
+	}
+	opts := exec.Options{Name: "bazel", Args: args, Dir: s.workspaceRoot}
 
 	// Use go.work if it exists for proper module resolution
 	goWorkPath := filepath.Join(s.workspaceRoot, "go.work")
 	if _, err := os.Stat(goWorkPath); err == nil {
-		cmd.Env = append(os.Environ(), fmt.Sprintf("GOWORK=%s", goWorkPath))
+		opts.Env = []string{fmt.Sprintf("GOWORK=%s", goWorkPath)}
 	}
 
-	if err := cmd.Run(); err != nil {
+	if err := exec.Run(ctx, opts); err != nil {
 		return fmt.Errorf("gazelle execution failed: %w", err)
 	}
 
@@ -320,11 +387,10 @@ func (s *Syncer) runGazelle() error {
 }
 
 // validateWorkspace runs quick validation checks on the workspace
-func (s *Syncer) validateWorkspace() error {
+func (s *Syncer) validateWorkspace(ctx context.Context) error {
 	// Check if we can query the workspace
-	cmd := exec.Command("bazel", "query", "//...", "--noshow_progress")
-	cmd.Dir = s.workspaceRoot
-	output, err := cmd.CombinedOutput()
+	opts := exec.Options{Name: "bazel", Args: []string{"query", "//...", "--noshow_progress"}, Dir: s.workspaceRoot}
+	output, err := exec.CombinedOutput(ctx, opts)
 
 	if err != nil {
 		return fmt.Errorf("bazel query failed: %w\nOutput: %s", err, string(output))
@@ -332,7 +398,7 @@ func (s *Syncer) validateWorkspace() error {
 
 	// Count targets
 	targets := strings.Split(strings.TrimSpace(string(output)), "\n")
-	fmt.Printf("   Found %d Bazel target(s)\n", len(targets))
+	log.Debug("   Found %d Bazel target(s)", len(targets))
 
 	return nil
 }
@@ -394,8 +460,23 @@ func (s *Syncer) getWorkspaceModulesForGazelle() ([]string, error) {
 func (s *Syncer) generateRootBuildFile(goProjects []GoProject) error {
 	buildFile := filepath.Join(s.workspaceRoot, "BUILD.bazel")
 
-	// Detect the Go module prefix from the first service's go.mod
-	// This handles cases like "github.com/owner/repo" vs just "repo-name"
+	content, resolves, err := s.renderRootBuildFile(goProjects, s.config.Workspace.GazelleDirectives)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(buildFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write BUILD.bazel: %w", err)
+	}
+
+	log.Info("   Added gazelle target with prefix %s and %d resolve directives", s.detectModulePrefix(goProjects), resolves)
+	return nil
+}
+
+// detectModulePrefix returns the base Go module path of the workspace.
+// It is detected from the first service's go.mod, which handles cases like
+// "github.com/owner/repo" vs just "repo-name".
+func (s *Syncer) detectModulePrefix(goProjects []GoProject) string {
 	modulePrefix := s.config.Workspace.Name // fallback
 	if len(goProjects) > 0 {
 		goModPath := filepath.Join(s.workspaceRoot, goProjects[0].Root, "go.mod")
@@ -418,95 +499,37 @@ func (s *Syncer) generateRootBuildFile(goProjects []GoProject) error {
 		}
 	}
 
-	// Scan for proto directories and add them as additional projects
-	protoProjects := []GoProject{}
-	for _, proj := range goProjects {
-		projPath := filepath.Join(s.workspaceRoot, proj.Root)
-		filepath.Walk(projPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil || !info.IsDir() {
-				return nil
-			}
-			// Check if directory contains .proto files
-			entries, err := os.ReadDir(path)
-			if err != nil {
-				return nil
-			}
-			hasProto := false
-			for _, entry := range entries {
-				if strings.HasSuffix(entry.Name(), ".proto") {
-					hasProto = true
-					break
-				}
-			}
-			if hasProto {
-				relPath, _ := filepath.Rel(s.workspaceRoot, path)
-				protoProjects = append(protoProjects, GoProject{
-					Name: filepath.Base(path),
-					Root: relPath,
-				})
-			}
-			return nil
-		})
-	}
-
-	// Combine base projects and proto projects
-	allProjects := append(goProjects, protoProjects...)
-
-	// Template data
-	data := struct {
-		ModulePrefix      string
-		Projects          []GoProject
-		GazelleDirectives []string
-	}{
-		ModulePrefix:      modulePrefix,
-		Projects:          allProjects,
-		GazelleDirectives: s.config.Workspace.GazelleDirectives,
-	}
-
-	// Render template
-	content, err := s.engine.RenderTemplate("bazel/root-build.tmpl", data)
-	if err != nil {
-		return fmt.Errorf("failed to render BUILD.bazel template: %w", err)
-	}
-
-	if err := os.WriteFile(buildFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write BUILD.bazel: %w", err)
-	}
-
-	fmt.Printf("   Added gazelle target with prefix %s and %d resolve directives\n", modulePrefix, len(allProjects))
-	return nil
+	return modulePrefix
 }
 
 // updateGoDeps runs gazelle update-repos for each Go project to populate MODULE.bazel.
 func (s *Syncer) updateGoDeps(goProjects []GoProject) error {
 	// First, clean up old use_repo to avoid conflicts
 	if err := s.cleanUseRepo(); err != nil {
-		fmt.Printf("⚠️  Warning: failed to clean use_repo: %v\n", err)
+		log.Warn("⚠️  Warning: failed to clean use_repo: %v", err)
 	}
 
 	for i, proj := range goProjects {
 		goModPath := filepath.Join(proj.Root, "go.mod")
-		fmt.Printf("   [%d/%d] Updating from %s...\n", i+1, len(goProjects), goModPath)
+		log.Debug("   [%d/%d] Updating from %s...", i+1, len(goProjects), goModPath)
 
-		cmd := exec.Command("bazel", "run", "//:gazelle", "--",
-			"update-repos",
-			"-from_file="+goModPath,
-			"-prune",
-		)
-		cmd.Dir = s.workspaceRoot
-		cmd.Env = append(os.Environ(), "GOWORK="+filepath.Join(s.workspaceRoot, "go.work"))
-
-		output, err := cmd.CombinedOutput()
+		opts := exec.Options{
+			Name: "bazel",
+			Args: append(s.bazelRunArgs("//:gazelle"), "--", "update-repos", "-from_file="+goModPath, "-prune"),
+			Dir:  s.workspaceRoot,
+			Env:  []string{"GOWORK=" + filepath.Join(s.workspaceRoot, "go.work")},
+		}
+		output, err := exec.CombinedOutput(context.Background(), opts)
 		if err != nil {
-			fmt.Printf("⚠️  Warning: gazelle update-repos failed for %s: %v\n", proj.Name, err)
+			log.Warn("⚠️  Warning: gazelle update-repos failed for %s: %v", proj.Name, err)
 			if len(output) > 0 {
-				fmt.Printf("   Output: %s\n", string(output))
+				log.Debug("   Output: %s", string(output))
 			}
 			// Continue with other projects
 			continue
 		}
 
-		fmt.Printf("      ✓ %s\n", proj.Name)
+		log.Debug("      ✓ %s", proj.Name)
 	}
 
 	return nil
@@ -552,11 +575,16 @@ func (s *Syncer) cleanUseRepo() error {
 }
 
 // syncGoWork creates go.work and runs go work sync
-func (s *Syncer) syncGoWork(goProjects []GoProject) error {
+func (s *Syncer) syncGoWork(ctx context.Context, goProjects []GoProject) error {
 	goWorkPath := filepath.Join(s.workspaceRoot, "go.work")
 
+	toolVersions, err := s.config.GetToolVersions()
+	if err != nil {
+		return err
+	}
+
 	// Create go.work content
-	content := "go 1.24.0\n\n"
+	content := fmt.Sprintf("go %s\n\n", toolVersions.Go)
 	for _, proj := range goProjects {
 		content += fmt.Sprintf("use ./%s\n", proj.Root)
 	}
@@ -565,16 +593,15 @@ func (s *Syncer) syncGoWork(goProjects []GoProject) error {
 		return fmt.Errorf("failed to write go.work: %w", err)
 	}
 
-	fmt.Printf("   Created go.work with %d modules\n", len(goProjects))
+	log.Info("   Created go.work with %d modules", len(goProjects))
 
 	// Run go work sync to update go.mod files
-	cmd := exec.Command("go", "work", "sync")
-	cmd.Dir = s.workspaceRoot
-	if output, err := cmd.CombinedOutput(); err != nil {
+	opts := exec.Options{Name: "go", Args: []string{"work", "sync"}, Dir: s.workspaceRoot}
+	if output, err := exec.CombinedOutput(ctx, opts); err != nil {
 		return fmt.Errorf("failed to run go work sync: %w\nOutput: %s", err, string(output))
 	}
 
-	fmt.Println("   Ran go work sync")
+	log.Debug("   Ran go work sync")
 	return nil
 }
 
@@ -583,11 +610,13 @@ func (s *Syncer) updateModuleDeps() error {
 	goWorkPath := filepath.Join(s.workspaceRoot, "go.work")
 
 	// Get all modules and their dependencies using go list
-	cmd := exec.Command("go", "list", "-m", "-json", "all")
-	cmd.Dir = s.workspaceRoot
-	cmd.Env = append(os.Environ(), "GOWORK="+goWorkPath)
-
-	output, err := cmd.CombinedOutput()
+	opts := exec.Options{
+		Name: "go",
+		Args: []string{"list", "-m", "-json", "all"},
+		Dir:  s.workspaceRoot,
+		Env:  []string{"GOWORK=" + goWorkPath},
+	}
+	output, err := exec.CombinedOutput(context.Background(), opts)
 	if err != nil {
 		return fmt.Errorf("failed to list Go modules: %w\nOutput: %s", err, string(output))
 	}
@@ -630,7 +659,7 @@ func (s *Syncer) updateModuleDeps() error {
 		modules = append(modules, mod)
 	}
 
-	fmt.Printf("   Found %d external dependencies\n", len(modules))
+	log.Debug("   Found %d external dependencies", len(modules))
 
 	// Collect sums from all go.sum files in workspace
 	sums := make(map[string]string) // "path@version" -> sum
@@ -656,7 +685,7 @@ func (s *Syncer) updateModuleDeps() error {
 		}
 	}
 
-	fmt.Printf("   Collected %d sums from go.sum files\n", len(sums))
+	log.Debug("   Collected %d sums from go.sum files", len(sums))
 
 	// Read current MODULE.bazel
 	modulePath := filepath.Join(s.workspaceRoot, "MODULE.bazel")
@@ -707,7 +736,7 @@ func (s *Syncer) updateModuleDeps() error {
 				newLines = append(newLines, ")")
 				addedCount++
 			}
-			fmt.Printf("   Added %d go_deps.module() calls\n", addedCount)
+			log.Debug("   Added %d go_deps.module() calls", addedCount)
 			inserted = true
 		}
 	}
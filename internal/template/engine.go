@@ -6,9 +6,15 @@ import (
 	"embed"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+
+	"github.com/dosanma1/forge-cli/internal/log"
 )
 
 //go:embed all:templates
@@ -17,6 +23,62 @@ var templatesFS embed.FS
 // TemplatesFS exposes the embedded templates filesystem
 var TemplatesFS = templatesFS
 
+// OverrideEnvVar names the environment variable pointing at a directory of
+// template overrides.
+const OverrideEnvVar = "FORGE_TEMPLATES"
+
+var (
+	overrideMu  sync.Mutex
+	overrideDir string
+)
+
+// SetOverrideDir sets the template override directory given with
+// --templates-dir. It takes precedence over $FORGE_TEMPLATES and
+// ~/.forge/templates.
+func SetOverrideDir(dir string) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	overrideDir = dir
+}
+
+// OverrideDirs returns the directories searched for template overrides, in
+// order of precedence: --templates-dir, $FORGE_TEMPLATES, ~/.forge/templates.
+// A file at the same relative path as an embedded template, e.g.
+// service/Dockerfile.tmpl, replaces the embedded one.
+func OverrideDirs() []string {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+
+	var dirs []string
+	if overrideDir != "" {
+		dirs = append(dirs, overrideDir)
+	}
+	if dir := os.Getenv(OverrideEnvVar); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".forge", "templates"))
+	}
+	return dirs
+}
+
+// readTemplate returns the content of a template, from the first override
+// directory that has it or else from the embedded templates.
+func readTemplate(templatePath string) ([]byte, error) {
+	for _, dir := range OverrideDirs() {
+		path := filepath.Join(dir, filepath.FromSlash(templatePath))
+		content, err := os.ReadFile(path)
+		if err == nil {
+			log.Debug("Using template override %s", path)
+			return content, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template override %s: %w", path, err)
+		}
+	}
+	return templatesFS.ReadFile("templates/" + templatePath)
+}
+
 // Engine provides template rendering capabilities.
 type Engine struct {
 	funcMap template.FuncMap
@@ -38,6 +100,13 @@ func NewEngine() *Engine {
 			"title":      strings.Title,
 			"replace":    strings.ReplaceAll,
 			"raw":        func(s string) string { return s }, // Return raw string without escaping
+			"add":        func(a, b int) int { return a + b },
+			"default":    Default,
+			"quote":      Quote,
+			"indent":     Indent,
+			"nindent":    Nindent,
+			"trim":       strings.TrimSpace,
+			"env":        os.Getenv,
 		},
 	}
 }
@@ -67,10 +136,10 @@ func (e *Engine) RenderFile(path string, data interface{}) (string, error) {
 	return e.Render(string(content), data)
 }
 
-// RenderTemplate renders an embedded template file with the given data.
+// RenderTemplate renders an embedded template file with the given data. An
+// override of the template (see OverrideDirs) is rendered instead when present.
 func (e *Engine) RenderTemplate(templatePath string, data interface{}) (string, error) {
-	// Read from embedded filesystem
-	content, err := templatesFS.ReadFile("templates/" + templatePath)
+	content, err := readTemplate(templatePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read embedded template %s: %w", templatePath, err)
 	}
@@ -78,10 +147,10 @@ func (e *Engine) RenderTemplate(templatePath string, data interface{}) (string,
 	return e.Render(string(content), data)
 }
 
-// ReadEmbeddedFile reads an embedded file without template rendering
+// ReadEmbeddedFile reads an embedded file without template rendering, or its
+// override when present (see OverrideDirs).
 func (e *Engine) ReadEmbeddedFile(templatePath string) ([]byte, error) {
-	// Read from embedded filesystem
-	content, err := templatesFS.ReadFile("templates/" + templatePath)
+	content, err := readTemplate(templatePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read embedded file %s: %w", templatePath, err)
 	}
@@ -191,6 +260,52 @@ func Pluralize(s string) string {
 	return s + "s"
 }
 
+// Default returns value, or defaultValue when value is empty: nil, false, 0,
+// or an empty string, slice or map. Like Sprig's default, it takes the value
+// last so it can be piped: {{.Port | default 8080}}.
+func Default(defaultValue, value interface{}) interface{} {
+	if value == nil {
+		return defaultValue
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+		if v.Len() == 0 {
+			return defaultValue
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return defaultValue
+		}
+	default:
+		if v.IsZero() {
+			return defaultValue
+		}
+	}
+	return value
+}
+
+// Quote returns value as a double-quoted string with Go escapes, which is
+// also a valid YAML and JSON string for printable text.
+func Quote(value interface{}) string {
+	if value == nil {
+		return `""`
+	}
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+// Indent indents every line of s by the given number of spaces.
+func Indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// Nindent is Indent preceded by a newline, to start an indented block after a
+// YAML key: {{.Labels | nindent 4}}.
+func Nindent(spaces int, s string) string {
+	return "\n" + Indent(spaces, s)
+}
+
 // splitWords splits a string into words for transformation.
 func splitWords(s string) []string {
 	// Handle kebab-case and snake_case
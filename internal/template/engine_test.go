@@ -0,0 +1,104 @@
+package template
+
+import "testing"
+
+func TestRenderHelpers(t *testing.T) {
+	t.Setenv("FORGE_TEST_REGION", "europe-west1")
+
+	tests := []struct {
+		name     string
+		template string
+		data     interface{}
+		want     string
+	}{
+		{
+			name:     "default on empty string",
+			template: `{{.Name | default "api"}}`,
+			data:     map[string]interface{}{"Name": ""},
+			want:     "api",
+		},
+		{
+			name:     "default on missing key",
+			template: `{{.Port | default 8080}}`,
+			data:     map[string]interface{}{},
+			want:     "8080",
+		},
+		{
+			name:     "default keeps a value",
+			template: `{{.Port | default 8080}}`,
+			data:     map[string]interface{}{"Port": 9090},
+			want:     "9090",
+		},
+		{
+			name:     "default on zero",
+			template: `{{.Replicas | default 2}}`,
+			data:     map[string]interface{}{"Replicas": 0},
+			want:     "2",
+		},
+		{
+			name:     "default on empty list",
+			template: `{{.Hosts | default "none"}}`,
+			data:     map[string]interface{}{"Hosts": []string{}},
+			want:     "none",
+		},
+		{
+			name:     "quote",
+			template: `{{.Value | quote}}`,
+			data:     map[string]interface{}{"Value": `say "hi"`},
+			want:     `"say \"hi\""`,
+		},
+		{
+			name:     "quote number",
+			template: `{{.Port | quote}}`,
+			data:     map[string]interface{}{"Port": 8080},
+			want:     `"8080"`,
+		},
+		{
+			name:     "quote missing",
+			template: `{{.Missing | quote}}`,
+			data:     map[string]interface{}{},
+			want:     `""`,
+		},
+		{
+			name:     "indent",
+			template: `{{.Block | indent 2}}`,
+			data:     map[string]interface{}{"Block": "a: 1\nb: 2"},
+			want:     "  a: 1\n  b: 2",
+		},
+		{
+			name:     "nindent",
+			template: `labels:{{.Block | nindent 2}}`,
+			data:     map[string]interface{}{"Block": "app: api\ntier: backend"},
+			want:     "labels:\n  app: api\n  tier: backend",
+		},
+		{
+			name:     "trim",
+			template: `[{{.Value | trim}}]`,
+			data:     map[string]interface{}{"Value": "  api \n"},
+			want:     "[api]",
+		},
+		{
+			name:     "env",
+			template: `region: {{env "FORGE_TEST_REGION"}}`,
+			want:     "region: europe-west1",
+		},
+		{
+			name:     "env unset with default",
+			template: `{{env "FORGE_TEST_UNSET" | default "local"}}`,
+			want:     "local",
+		},
+	}
+
+	engine := NewEngine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := engine.Render(tt.template, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
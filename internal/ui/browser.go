@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/dosanma1/forge-cli/internal/exec"
+)
+
+// OpenBrowser opens url with the default application of the OS: open on
+// macOS, the URL protocol handler on Windows and xdg-open elsewhere.
+func OpenBrowser(ctx context.Context, url string) error {
+	opts := exec.Options{Name: "xdg-open", Args: []string{url}}
+	switch runtime.GOOS {
+	case "darwin":
+		opts = exec.Options{Name: "open", Args: []string{url}}
+	case "windows":
+		// Unlike "cmd /c start", rundll32 does not split URLs on "&"
+		opts = exec.Options{Name: "rundll32", Args: []string{"url.dll,FileProtocolHandler", url}}
+	}
+	_, err := exec.Capture(ctx, opts)
+	return err
+}
@@ -1,19 +1,85 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
 	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
 )
 
+var nonInteractive atomic.Bool
+
+// SetNonInteractive makes every prompt fail immediately instead of waiting
+// for input, for --no-interactive.
+func SetNonInteractive(enabled bool) {
+	nonInteractive.Store(enabled)
+}
+
+// Interactive reports whether prompts may wait for input. They may not after
+// SetNonInteractive(true) or when the CI environment variable is true, as set
+// by most CI systems.
+func Interactive() bool {
+	if nonInteractive.Load() {
+		return false
+	}
+	ci, _ := strconv.ParseBool(os.Getenv("CI"))
+	return !ci
+}
+
+// NotInteractiveError is returned by prompts when prompting is disabled.
+type NotInteractiveError struct {
+	Label string // The prompt that could not be shown
+	Flag  string // How to answer it without a prompt, e.g. "--yes"
+}
+
+func (e *NotInteractiveError) Error() string {
+	msg := fmt.Sprintf("cannot ask %q: prompts are disabled by --no-interactive or CI", strings.TrimSuffix(e.Label, ":"))
+	if e.Flag != "" {
+		msg += "; provide " + e.Flag + " instead"
+	}
+	return msg
+}
+
 // Prompter wraps promptui for consistent UI interactions
-type Prompter struct{}
+type Prompter struct {
+	flag string
+}
 
 // NewPrompter creates a new Prompter instance
 func NewPrompter() (*Prompter, error) {
 	return &Prompter{}, nil
 }
 
+// WithFlag returns a prompter whose prompts name flag, the flag or argument
+// that answers them, when they fail because prompting is disabled.
+func (p *Prompter) WithFlag(flag string) *Prompter {
+	return &Prompter{flag: flag}
+}
+
+// WithFlag returns a prompter that names flag when prompting is disabled
+// (convenience function).
+func WithFlag(flag string) *Prompter {
+	return defaultPrompter.WithFlag(flag)
+}
+
+// check fails when prompting is disabled.
+func (p *Prompter) check(label string) error {
+	if Interactive() {
+		return nil
+	}
+	return &NotInteractiveError{Label: label, Flag: p.flag}
+}
+
 // AskText prompts for text input
 func (p *Prompter) AskText(label string, defaultValue string) (string, error) {
+	if err := p.check(label); err != nil {
+		return "", err
+	}
+
 	prompt := promptui.Prompt{
 		Label:   label,
 		Default: defaultValue,
@@ -24,6 +90,10 @@ func (p *Prompter) AskText(label string, defaultValue string) (string, error) {
 
 // AskConfirm prompts for yes/no confirmation
 func (p *Prompter) AskConfirm(label string, defaultValue bool) (bool, error) {
+	if err := p.check(label); err != nil {
+		return false, err
+	}
+
 	defaultText := "N"
 	if defaultValue {
 		defaultText = "Y"
@@ -48,23 +118,75 @@ func (p *Prompter) AskConfirm(label string, defaultValue bool) (bool, error) {
 
 // AskSelect prompts for selection from a list
 func (p *Prompter) AskSelect(label string, items []string) (string, error) {
+	_, result, err := p.AskSelectIndex(label, items)
+	return result, err
+}
+
+// AskSelectIndex prompts for selection from a list and also returns the index
+// of the selected item
+func (p *Prompter) AskSelectIndex(label string, items []string) (int, string, error) {
+	if err := p.check(label); err != nil {
+		return 0, "", err
+	}
+
 	prompt := promptui.Select{
 		Label: label,
 		Items: items,
 	}
 
-	_, result, err := prompt.Run()
-	return result, err
+	return prompt.Run()
 }
 
-// AskMultiSelect prompts for multiple selections (not implemented in promptui, returns single select)
+// AskMultiSelect prompts for any number of items from a list, toggled one at
+// a time until "Done" is chosen, and returns the chosen items in list order
 func (p *Prompter) AskMultiSelect(label string, items []string) ([]string, error) {
-	// promptui doesn't support multi-select, so we'll do single select for now
-	result, err := p.AskSelect(label, items)
-	if err != nil {
+	if err := p.check(label); err != nil {
 		return nil, err
 	}
-	return []string{result}, nil
+
+	const size = 10
+	selected := make([]bool, len(items))
+	cursor := 0
+	for {
+		options := make([]string, 0, len(items)+1)
+		options = append(options, "Done")
+		for i, item := range items {
+			mark := "[ ]"
+			if selected[i] {
+				mark = "[x]"
+			}
+			options = append(options, mark+" "+item)
+		}
+
+		prompt := promptui.Select{
+			Label:        label,
+			Items:        options,
+			Size:         size,
+			HideSelected: true,
+		}
+		index, _, err := prompt.RunCursorAt(cursor, max(cursor-size+1, 0))
+		if err != nil {
+			return nil, err
+		}
+		if index == 0 {
+			break
+		}
+		selected[index-1] = !selected[index-1]
+		cursor = index
+	}
+
+	var result []string
+	for i, item := range items {
+		if selected[i] {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// IsTerminal reports whether stdin is a terminal a user can answer prompts on
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
 }
 
 // Package-level convenience functions
@@ -82,10 +204,10 @@ func AskConfirm(label string, defaultValue bool) (bool, error) {
 
 // AskSelect prompts for selection from a list (convenience function)
 func AskSelect(label string, items []string) (int, string, error) {
-	prompt := promptui.Select{
-		Label: label,
-		Items: items,
-	}
+	return defaultPrompter.AskSelectIndex(label, items)
+}
 
-	return prompt.Run()
+// AskMultiSelect prompts for any number of items from a list (convenience function)
+func AskMultiSelect(label string, items []string) ([]string, error) {
+	return defaultPrompter.AskMultiSelect(label, items)
 }
@@ -0,0 +1,231 @@
+// Package update checks GitHub for newer forge releases. The latest release is
+// cached in ~/.forge/version-check.json so the API is queried at most once per
+// TTL, and every failure is swallowed: an update check never affects the
+// command that triggered it.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DisableEnv disables the update check when set to any non-empty value
+	DisableEnv = "FORGE_NO_UPDATE_CHECK"
+
+	// TTL is how long a cached release lookup is trusted
+	TTL = 24 * time.Hour
+
+	releasesURL = "https://api.github.com/repos/dosanma1/forge-cli/releases/latest"
+
+	// fetchTimeout bounds both the GitHub request and how long Notice waits
+	// for it once the command has finished
+	fetchTimeout = 1500 * time.Millisecond
+)
+
+// cache is the content of version-check.json.
+type cache struct {
+	CheckedAt     time.Time `json:"checkedAt"`
+	LatestVersion string    `json:"latestVersion"`
+}
+
+// Check looks up the latest release for the running version.
+type Check struct {
+	current string
+	latest  string
+	done    chan struct{}
+}
+
+// Disabled reports whether the update check is turned off through the
+// environment.
+func Disabled() bool {
+	return os.Getenv(DisableEnv) != ""
+}
+
+// Start begins checking for a release newer than current. A fresh cache is
+// used as-is; otherwise GitHub is queried in the background. Development
+// builds whose version is not semantic are never checked.
+func Start(current string) *Check {
+	c := &Check{current: current, done: make(chan struct{})}
+	if _, ok := parseVersion(current); !ok {
+		close(c.done)
+		return c
+	}
+
+	path := cachePath()
+	cached, err := readCache(path)
+	if err == nil && time.Since(cached.CheckedAt) < TTL {
+		c.latest = cached.LatestVersion
+		close(c.done)
+		return c
+	}
+
+	go func() {
+		defer close(c.done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+
+		// A failed lookup is cached too, keeping the last known release, so
+		// an unreachable GitHub delays at most one command per TTL.
+		latest, err := fetchLatest(ctx)
+		if err != nil && cached != nil {
+			latest = cached.LatestVersion
+		}
+		c.latest = latest
+		writeCache(path, cache{CheckedAt: time.Now().UTC(), LatestVersion: latest})
+	}()
+
+	return c
+}
+
+// Notice returns the one-line update notice, or "" when the running version
+// is current. It waits for an unfinished lookup so the cache is written before
+// the process exits, but never longer than fetchTimeout.
+func (c *Check) Notice() string {
+	if c == nil {
+		return ""
+	}
+
+	select {
+	case <-c.done:
+	case <-time.After(fetchTimeout):
+		return ""
+	}
+
+	if !Newer(c.latest, c.current) {
+		return ""
+	}
+	return fmt.Sprintf("💡 forge %s is available (you have %s): https://github.com/dosanma1/forge-cli/releases/latest",
+		strings.TrimPrefix(c.latest, "v"), strings.TrimPrefix(c.current, "v"))
+}
+
+// Newer reports whether version a is newer than version b. Unparseable
+// versions are never newer.
+func Newer(a, b string) bool {
+	va, ok := parseVersion(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseVersion(b)
+	if !ok {
+		return false
+	}
+
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses "v1.2.3" or "1.2.3" into its numeric parts, ignoring
+// any pre-release or build suffix.
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// fetchLatest returns the tag of the latest GitHub release.
+func fetchLatest(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("github returned %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", err
+	}
+	if _, ok := parseVersion(release.TagName); !ok {
+		return "", fmt.Errorf("unexpected release tag %q", release.TagName)
+	}
+	return release.TagName, nil
+}
+
+func cachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".forge", "version-check.json")
+}
+
+func readCache(path string) (*cache, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no home directory")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c cache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// writeCache stores the lookup result, ignoring errors so a read-only home
+// directory only costs a lookup per command.
+func writeCache(path string, c cache) {
+	if path == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+	}
+}
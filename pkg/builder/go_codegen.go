@@ -0,0 +1,375 @@
+package builder
+
+import (
+	"bufio"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/template"
+)
+
+// goGraph is the code generation view of a Go service graph: entities with
+// their Go fields and the transports connected to them through edges.
+type goGraph struct {
+	Package     string
+	ProjectName string
+	Entities    []*goEntity
+	REST        []*goRESTTransport
+	GRPC        []*goGRPCService
+	HasREST     bool
+	HasGRPC     bool
+	HasTime     bool
+
+	// ProtoImportPath is the Go import path of the stubs generated from
+	// proto/, set when the graph has gRPC services.
+	ProtoImportPath string
+}
+
+type goEntity struct {
+	ID      string
+	Name    string // Go type name, e.g. "OrderItem"
+	VarName string // unexported prefix, e.g. "orderItem"
+	Label   string // human-readable name used in error messages
+	Fields  []goField
+	HasTime bool
+}
+
+type goField struct {
+	Name     string
+	JSONName string
+	Type     string
+}
+
+type goRESTTransport struct {
+	ID       string
+	Name     string
+	BasePath string
+	Entity   *goEntity
+}
+
+type goGRPCService struct {
+	ID           string
+	Name         string // proto service name, e.g. "OrdersService"
+	ServerName   string // Go type implementing the service, e.g. "OrdersServiceGRPCServer"
+	ProtoFile    string // file name under proto/, e.g. "orders.proto"
+	ProtoPackage string
+	Entities     []*goEntity
+	HasTime      bool
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// newGoGraph resolves the nodes and edges of result into Go identifiers.
+func newGoGraph(result *ParseResult) (*goGraph, error) {
+	graph := &goGraph{
+		Package:     goPackageName(result),
+		ProjectName: result.ProjectName,
+	}
+
+	entitiesByID := make(map[string]*goEntity)
+	for _, node := range result.Nodes {
+		if node.Type != "entity" {
+			continue
+		}
+		entity, err := newGoEntity(node)
+		if err != nil {
+			return nil, err
+		}
+		entitiesByID[node.ID] = entity
+		graph.Entities = append(graph.Entities, entity)
+		graph.HasTime = graph.HasTime || entity.HasTime
+	}
+
+	for _, node := range result.Nodes {
+		if node.Type != "rest-endpoint" {
+			continue
+		}
+		entity := connectedEntity(node.ID, result.Edges, entitiesByID)
+		if entity == nil {
+			return nil, fmt.Errorf("REST endpoint %s is not connected to an entity", node.ID)
+		}
+
+		basePath := "/" + strings.Trim(fmt.Sprint(node.Data["basePath"]), "/")
+		graph.REST = append(graph.REST, &goRESTTransport{
+			ID:       node.ID,
+			Name:     template.Pascalize(nonIdentifierChars.ReplaceAllString(basePath, " ")) + "RESTTransport",
+			BasePath: basePath,
+			Entity:   entity,
+		})
+	}
+	graph.HasREST = len(graph.REST) > 0
+
+	// An entity's messages are declared in the proto file of its service, so
+	// it can only be exposed by one of them.
+	grpcServiceOf := make(map[string]string)
+	for _, node := range result.Nodes {
+		if node.Type != "grpc-service" {
+			continue
+		}
+		name, _ := node.Data["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("gRPC service %s has no name", node.ID)
+		}
+		entities := connectedEntities(node.ID, result.Edges, entitiesByID)
+		if len(entities) == 0 {
+			return nil, fmt.Errorf("gRPC service %s is not connected to an entity", name)
+		}
+
+		serviceName := template.Pascalize(nonIdentifierChars.ReplaceAllString(name, " "))
+		if !strings.HasSuffix(serviceName, "Service") {
+			serviceName += "Service"
+		}
+		service := &goGRPCService{
+			ID:           node.ID,
+			Name:         serviceName,
+			ServerName:   serviceName + "GRPCServer",
+			ProtoFile:    template.SnakeCase(nonIdentifierChars.ReplaceAllString(name, " ")) + ".proto",
+			ProtoPackage: graph.Package + ".v1",
+			Entities:     entities,
+		}
+		for _, entity := range entities {
+			if other, ok := grpcServiceOf[entity.ID]; ok {
+				return nil, fmt.Errorf("entity %s is exposed by gRPC services %s and %s; connect it to only one", entity.Name, other, serviceName)
+			}
+			grpcServiceOf[entity.ID] = serviceName
+			service.HasTime = service.HasTime || entity.HasTime
+		}
+		graph.GRPC = append(graph.GRPC, service)
+	}
+	graph.HasGRPC = len(graph.GRPC) > 0
+
+	sort.Slice(graph.Entities, func(i, j int) bool { return graph.Entities[i].Name < graph.Entities[j].Name })
+	sort.Slice(graph.REST, func(i, j int) bool { return graph.REST[i].Name < graph.REST[j].Name })
+	sort.Slice(graph.GRPC, func(i, j int) bool { return graph.GRPC[i].Name < graph.GRPC[j].Name })
+
+	return graph, nil
+}
+
+func newGoEntity(node Node) (*goEntity, error) {
+	name, _ := node.Data["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("entity %s has no name", node.ID)
+	}
+
+	entity := &goEntity{
+		ID:      node.ID,
+		Name:    template.Pascalize(name),
+		VarName: template.Camelize(name),
+		Label:   strings.ToLower(template.KebabCase(name)),
+	}
+
+	fields, _ := node.Data["fields"].([]interface{})
+	for _, raw := range fields {
+		field, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entity %s: fields must be objects with a name and a type", name)
+		}
+		fieldName, _ := field["name"].(string)
+		if fieldName == "" {
+			return nil, fmt.Errorf("entity %s: field without a name", name)
+		}
+
+		goName := template.Pascalize(fieldName)
+		if goName == "Id" {
+			// Every entity already has an ID
+			continue
+		}
+		fieldType, _ := field["type"].(string)
+		goType := goFieldType(fieldType)
+
+		entity.Fields = append(entity.Fields, goField{
+			Name:     goName,
+			JSONName: template.Camelize(fieldName),
+			Type:     goType,
+		})
+		entity.HasTime = entity.HasTime || goType == "time.Time"
+	}
+
+	return entity, nil
+}
+
+// connectedEntity returns the entity linked to nodeID by an edge, in either direction.
+func connectedEntity(nodeID string, edges []Edge, entities map[string]*goEntity) *goEntity {
+	for _, edge := range edges {
+		if edge.Target == nodeID {
+			if entity, ok := entities[edge.Source]; ok {
+				return entity
+			}
+		}
+		if edge.Source == nodeID {
+			if entity, ok := entities[edge.Target]; ok {
+				return entity
+			}
+		}
+	}
+	return nil
+}
+
+// connectedEntities returns every entity linked to nodeID by an edge, sorted by name.
+func connectedEntities(nodeID string, edges []Edge, entities map[string]*goEntity) []*goEntity {
+	seen := make(map[string]bool)
+	var connected []*goEntity
+	for _, edge := range edges {
+		var other string
+		switch nodeID {
+		case edge.Target:
+			other = edge.Source
+		case edge.Source:
+			other = edge.Target
+		default:
+			continue
+		}
+		if entity, ok := entities[other]; ok && !seen[entity.ID] {
+			seen[entity.ID] = true
+			connected = append(connected, entity)
+		}
+	}
+	sort.Slice(connected, func(i, j int) bool { return connected[i].Name < connected[j].Name })
+	return connected
+}
+
+// goFieldType maps a forge.json field type to a Go type.
+func goFieldType(fieldType string) string {
+	switch strings.ToLower(fieldType) {
+	case "int", "integer":
+		return "int"
+	case "int64", "long":
+		return "int64"
+	case "float", "float64", "number", "decimal":
+		return "float64"
+	case "bool", "boolean":
+		return "bool"
+	case "time", "date", "datetime", "timestamp":
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// ProtoName returns the field name in proto messages. protoc-gen-go turns it
+// back into Name.
+func (f goField) ProtoName() string {
+	return template.SnakeCase(f.Name)
+}
+
+// ProtoType returns the protobuf type of the field.
+func (f goField) ProtoType() string {
+	switch f.Type {
+	case "int", "int64":
+		return "int64"
+	case "float64":
+		return "double"
+	case "bool":
+		return "bool"
+	case "time.Time":
+		return "google.protobuf.Timestamp"
+	default:
+		return "string"
+	}
+}
+
+// ToProto converts the Go expression v of the field's type to its protobuf type.
+func (f goField) ToProto(v string) string {
+	switch f.Type {
+	case "int":
+		return "int64(" + v + ")"
+	case "time.Time":
+		return "timestamppb.New(" + v + ")"
+	default:
+		return v
+	}
+}
+
+// FromProto converts the protobuf expression v back to the field's Go type.
+func (f goField) FromProto(v string) string {
+	switch f.Type {
+	case "int":
+		return "int(" + v + ")"
+	case "time.Time":
+		return v + ".AsTime()"
+	default:
+		return v
+	}
+}
+
+// Plural returns the plural of the entity name, used by list RPCs.
+func (e *goEntity) Plural() string {
+	return template.Pluralize(e.Name)
+}
+
+// goImportPath returns the Go import path of dir, derived from the module
+// declared by the nearest go.mod. It returns "" outside a Go module.
+func goImportPath(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for current := dir; ; current = filepath.Dir(current) {
+		if module := goModulePath(filepath.Join(current, "go.mod")); module != "" {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return ""
+			}
+			return path.Join(module, filepath.ToSlash(rel))
+		}
+		if filepath.Dir(current) == current {
+			return ""
+		}
+	}
+}
+
+// goModulePath reads the module path from a go.mod file.
+func goModulePath(goModPath string) string {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+		}
+	}
+	return ""
+}
+
+// goPackageName returns the package of the generated code: metadata.package,
+// or the project name without separators.
+func goPackageName(result *ParseResult) string {
+	if pkg, ok := result.Metadata["package"].(string); ok && pkg != "" {
+		return pkg
+	}
+	name := strings.ToLower(nonIdentifierChars.ReplaceAllString(result.ProjectName, ""))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "service"
+	}
+	return name
+}
+
+// renderGoFile renders a builder/go template and writes it gofmt-ed to path.
+func renderGoFile(path, templatePath string, data interface{}) error {
+	content, err := template.NewEngine().RenderTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := format.Source([]byte(content))
+	if err != nil {
+		return fmt.Errorf("generated invalid Go code for %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
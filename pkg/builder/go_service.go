@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/internal/template"
 )
 
 // GoServiceBuilder generates Go microservice code from forge.json
@@ -81,6 +85,17 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 
 	progress(0, "Starting code generation...")
 
+	graph, err := newGoGraph(opts.ParseResult)
+	if err != nil {
+		return err
+	}
+	if graph.HasGRPC {
+		graph.ProtoImportPath = goImportPath(filepath.Join(outputDir, "proto"))
+		if graph.ProtoImportPath == "" {
+			return fmt.Errorf("gRPC services require %s to be inside a Go module (no go.mod found)", outputDir)
+		}
+	}
+
 	// Group nodes by type
 	entities := make([]Node, 0)
 	restEndpoints := make([]Node, 0)
@@ -112,7 +127,7 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 		progress(currentStep*100/totalSteps, fmt.Sprintf("Generating entity: %s", entity.Data["name"]))
 
 		if !opts.DryRun {
-			if err := b.generateEntity(ctx, outputDir, entity, opts.ParseResult.Edges); err != nil {
+			if err := b.generateEntity(ctx, outputDir, graph, entity); err != nil {
 				return fmt.Errorf("failed to generate entity %s: %w", entity.Data["name"], err)
 			}
 		}
@@ -124,7 +139,7 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 		progress(currentStep*100/totalSteps, fmt.Sprintf("Generating REST endpoint: %s", endpoint.Data["basePath"]))
 
 		if !opts.DryRun {
-			if err := b.generateRESTTransport(ctx, outputDir, endpoint, entities, opts.ParseResult.Edges); err != nil {
+			if err := b.generateRESTTransport(ctx, outputDir, graph, endpoint); err != nil {
 				return fmt.Errorf("failed to generate REST endpoint: %w", err)
 			}
 		}
@@ -136,12 +151,24 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 		progress(currentStep*100/totalSteps, fmt.Sprintf("Generating gRPC service: %s", service.Data["name"]))
 
 		if !opts.DryRun {
-			if err := b.generateGRPCService(ctx, outputDir, service, entities, opts.ParseResult.Edges); err != nil {
+			if err := b.generateGRPCService(ctx, outputDir, graph, service); err != nil {
 				return fmt.Errorf("failed to generate gRPC service: %w", err)
 			}
 		}
 	}
 
+	// Compile the generated protos when a compiler is available; otherwise
+	// they are picked up by the next 'forge proto'
+	if graph.HasGRPC && !opts.DryRun {
+		compiled, err := CompileGoProtos(ctx, filepath.Join(outputDir, "proto"))
+		if err != nil {
+			return fmt.Errorf("failed to compile protos: %w", err)
+		}
+		if !compiled {
+			progress(currentStep*100/totalSteps, "Protobuf compiler not found, run 'forge proto' to generate the gRPC stubs")
+		}
+	}
+
 	// Generate NATS producer files
 	for _, producer := range natsProducers {
 		currentStep++
@@ -170,7 +197,7 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 	currentStep++
 	progress(currentStep*100/totalSteps, "Generating module.go")
 	if !opts.DryRun {
-		if err := b.generateModule(ctx, outputDir, opts.ParseResult); err != nil {
+		if err := b.generateModule(ctx, outputDir, graph); err != nil {
 			return fmt.Errorf("failed to generate module.go: %w", err)
 		}
 	}
@@ -179,7 +206,7 @@ func (b *GoServiceBuilder) Generate(ctx context.Context, opts GenerateOptions) e
 	currentStep++
 	progress(currentStep*100/totalSteps, "Generating types.go")
 	if !opts.DryRun {
-		if err := b.generateTypes(ctx, outputDir, opts.ParseResult); err != nil {
+		if err := b.generateTypes(ctx, outputDir, graph); err != nil {
 			return fmt.Errorf("failed to generate types.go: %w", err)
 		}
 	}
@@ -249,6 +276,17 @@ func (b *GoServiceBuilder) Validate(ctx context.Context, opts ValidateOptions) e
 				})
 			}
 		}
+
+		if node.Type == "grpc-service" {
+			if node.Data["name"] == nil || node.Data["name"] == "" {
+				errors = append(errors, ValidationError{
+					NodeID:  node.ID,
+					Field:   "name",
+					Message: "gRPC service name is required",
+					Severe:  true,
+				})
+			}
+		}
 	}
 
 	if len(errors) > 0 {
@@ -269,22 +307,102 @@ func (v *ValidationResult) Error() string {
 	return fmt.Sprintf("validation failed: %d errors", len(v.Errors))
 }
 
-// Placeholder implementations for code generation
-// These will be expanded with actual template-based generation
+// generateEntity writes the entity struct, its repository and its service.
+func (b *GoServiceBuilder) generateEntity(ctx context.Context, outputDir string, graph *goGraph, entity Node) error {
+	for _, e := range graph.Entities {
+		if e.ID == entity.ID {
+			return renderGoFile(filepath.Join(outputDir, template.SnakeCase(e.Name)+".go"), "builder/go/entity.go.tmpl", map[string]interface{}{
+				"Package": graph.Package,
+				"Entity":  e,
+			})
+		}
+	}
+	return fmt.Errorf("entity %s not found", entity.ID)
+}
 
-func (b *GoServiceBuilder) generateEntity(ctx context.Context, outputDir string, entity Node, edges []Edge) error {
-	// TODO: Implement entity code generation using templates
-	return nil
+// generateRESTTransport writes the HTTP handlers of a REST endpoint for the
+// entity it is connected to.
+func (b *GoServiceBuilder) generateRESTTransport(ctx context.Context, outputDir string, graph *goGraph, endpoint Node) error {
+	for _, t := range graph.REST {
+		if t.ID == endpoint.ID {
+			fileName := template.SnakeCase(strings.TrimSuffix(t.Name, "RESTTransport")) + "_rest.go"
+			return renderGoFile(filepath.Join(outputDir, fileName), "builder/go/rest.go.tmpl", map[string]interface{}{
+				"Package":   graph.Package,
+				"Transport": t,
+			})
+		}
+	}
+	return fmt.Errorf("REST endpoint %s not found", endpoint.ID)
 }
 
-func (b *GoServiceBuilder) generateRESTTransport(ctx context.Context, outputDir string, endpoint Node, entities []Node, edges []Edge) error {
-	// TODO: Implement REST transport code generation using templates
-	return nil
+// generateGRPCService writes proto/<service>.proto with a message per
+// connected entity and CRUD RPCs, and the Go server implementing it on top of
+// the entity services.
+func (b *GoServiceBuilder) generateGRPCService(ctx context.Context, outputDir string, graph *goGraph, service Node) error {
+	for _, s := range graph.GRPC {
+		if s.ID != service.ID {
+			continue
+		}
+
+		proto, err := template.NewEngine().RenderTemplate("builder/go/service.proto.tmpl", map[string]interface{}{
+			"Service":   s,
+			"GoPackage": graph.ProtoImportPath + ";" + graph.Package + "pb",
+		})
+		if err != nil {
+			return err
+		}
+		protoDir := filepath.Join(outputDir, "proto")
+		if err := os.MkdirAll(protoDir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(protoDir, s.ProtoFile), []byte(proto), 0644); err != nil {
+			return err
+		}
+
+		fileName := strings.TrimSuffix(s.ProtoFile, ".proto") + "_grpc.go"
+		return renderGoFile(filepath.Join(outputDir, fileName), "builder/go/grpc_server.go.tmpl", map[string]interface{}{
+			"Package":         graph.Package,
+			"ProtoImportPath": graph.ProtoImportPath,
+			"Service":         s,
+		})
+	}
+	return fmt.Errorf("gRPC service %s not found", service.ID)
 }
 
-func (b *GoServiceBuilder) generateGRPCService(ctx context.Context, outputDir string, service Node, entities []Node, edges []Edge) error {
-	// TODO: Implement gRPC service code generation using templates
-	return nil
+// CompileGoProtos generates the Go stubs of the protos in protoDir next to
+// them, with buf when the directory has a buf.yaml and protoc otherwise. It
+// reports false when the required tools are not installed.
+func CompileGoProtos(ctx context.Context, protoDir string) (bool, error) {
+	var cmd *exec.Cmd
+	if _, err := os.Stat(filepath.Join(protoDir, "buf.yaml")); err == nil {
+		if _, err := exec.LookPath("buf"); err == nil {
+			cmd = exec.CommandContext(ctx, "buf", "generate")
+		}
+	}
+
+	if cmd == nil {
+		for _, tool := range []string{"protoc", "protoc-gen-go", "protoc-gen-go-grpc"} {
+			if _, err := exec.LookPath(tool); err != nil {
+				return false, nil
+			}
+		}
+
+		protoFiles, err := filepath.Glob(filepath.Join(protoDir, "*.proto"))
+		if err != nil {
+			return false, err
+		}
+		args := []string{"--proto_path=.", "--go_out=paths=source_relative:.", "--go-grpc_out=paths=source_relative:."}
+		for _, file := range protoFiles {
+			args = append(args, filepath.Base(file))
+		}
+		cmd = exec.CommandContext(ctx, "protoc", args...)
+	}
+
+	cmd.Dir = protoDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("%s: %w\n%s", cmd.Args[0], err, output)
+	}
+	return true, nil
 }
 
 func (b *GoServiceBuilder) generateNATSProducer(ctx context.Context, outputDir string, producer Node) error {
@@ -297,14 +415,16 @@ func (b *GoServiceBuilder) generateNATSConsumer(ctx context.Context, outputDir s
 	return nil
 }
 
-func (b *GoServiceBuilder) generateModule(ctx context.Context, outputDir string, result *ParseResult) error {
-	// TODO: Implement module.go generation using templates
-	return nil
+// generateModule writes module.go, which constructs a repository and a service
+// per entity and hands each service to the transports connected to its entity.
+func (b *GoServiceBuilder) generateModule(ctx context.Context, outputDir string, graph *goGraph) error {
+	return renderGoFile(filepath.Join(outputDir, "module.go"), "builder/go/module.go.tmpl", graph)
 }
 
-func (b *GoServiceBuilder) generateTypes(ctx context.Context, outputDir string, result *ParseResult) error {
-	// TODO: Implement types.go generation using templates
-	return nil
+// generateTypes writes types.go with the entity payloads shared by services and
+// transports, and the helpers used by several generated files.
+func (b *GoServiceBuilder) generateTypes(ctx context.Context, outputDir string, graph *goGraph) error {
+	return renderGoFile(filepath.Join(outputDir, "types.go"), "builder/go/types.go.tmpl", graph)
 }
 
 func init() {
@@ -6,6 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/dosanma1/forge-cli/pkg/xos"
 )
 
 const ConfigFileName = "forge.json"
@@ -16,7 +21,25 @@ type Config struct {
 	Version        string             `json:"version"`
 	Workspace      WorkspaceMetadata  `json:"workspace"`
 	NewProjectRoot string             `json:"newProjectRoot,omitempty"`
+	CLI            *CLIConfig         `json:"cli,omitempty"`
+	Watch          *WatchConfig       `json:"watch,omitempty"`
 	Projects       map[string]Project `json:"projects"`
+
+	// Constraints restrict which projects may depend on which, by tag
+	Constraints []DependencyConstraint `json:"constraints,omitempty"`
+}
+
+// WatchConfig adds file patterns to the daemon watcher. Entries are merged with
+// the built-in defaults. Ignore patterns match a path component, or a path
+// relative to the workspace (or project) root when they contain a slash.
+type WatchConfig struct {
+	Patterns []string `json:"patterns,omitempty"` // Extra files to watch, e.g. "*.proto"
+	Ignore   []string `json:"ignore,omitempty"`   // Extra files and directories to skip, e.g. "gen"
+}
+
+// CLIConfig contains settings for the forge CLI itself.
+type CLIConfig struct {
+	DefaultBuildEnvironment string `json:"defaultBuildEnvironment,omitempty"` // Used by build/deploy when --env is omitted
 }
 
 // Architect contains build, serve, deploy, and test targets
@@ -34,20 +57,45 @@ type ArchitectTarget struct {
 	Options              map[string]interface{} `json:"options,omitempty"`
 	Configurations       map[string]interface{} `json:"configurations,omitempty"`
 	DefaultConfiguration string                 `json:"defaultConfiguration,omitempty"`
+	Overrides            ValuesOverrides        `json:"overrides,omitempty"`
 }
 
+// ValuesOverrides are Helm values deep-merged into the values files generated
+// for a deployer, keyed by environment ("default", "dev" or "prod").
+type ValuesOverrides map[string]map[string]interface{}
+
 // WorkspaceMetadata contains workspace-level metadata.
 type WorkspaceMetadata struct {
-	Name         string             `json:"name"`
-	ForgeVersion string             `json:"forgeVersion"`
-	ToolVersions *ToolVersions      `json:"toolVersions,omitempty"`
-	Paths        *WorkspacePaths    `json:"paths,omitempty"`
-	Defaults     *WorkspaceDefaults `json:"defaults,omitempty"`
-	GitHub       *GitHubConfig      `json:"github,omitempty"`
-	Docker       *DockerConfig      `json:"docker,omitempty"`
-	GCP          *GCPConfig         `json:"gcp,omitempty"`
-	Kubernetes   *KubernetesConfig  `json:"kubernetes,omitempty"`
-	GazelleDirectives []string      `json:"gazelleDirectives,omitempty"`
+	Name              string             `json:"name"`
+	ForgeVersion      string             `json:"forgeVersion"`
+	ToolVersions      *ToolVersions      `json:"toolVersions,omitempty"`
+	Paths             *WorkspacePaths    `json:"paths,omitempty"`
+	Defaults          *WorkspaceDefaults `json:"defaults,omitempty"`
+	GitHub            *GitHubConfig      `json:"github,omitempty"`
+	Docker            *DockerConfig      `json:"docker,omitempty"`
+	GCP               *GCPConfig         `json:"gcp,omitempty"`
+	Kubernetes        *KubernetesConfig  `json:"kubernetes,omitempty"`
+	APIGateway        *APIGatewayConfig  `json:"apiGateway,omitempty"`
+	Build             *BuildConfig       `json:"build,omitempty"`
+	GazelleDirectives []string           `json:"gazelleDirectives,omitempty"`
+
+	// Environments holds settings shared by all projects, keyed by configuration name
+	Environments map[string]*EnvironmentConfig `json:"environments,omitempty"`
+}
+
+// EnvironmentConfig contains workspace-level settings of one environment.
+type EnvironmentConfig struct {
+	Registry string `json:"registry,omitempty"` // Container registry for the images of this environment
+}
+
+// BuildConfig contains workspace-level build settings.
+type BuildConfig struct {
+	Parallel *ParallelConfig `json:"parallel,omitempty"`
+}
+
+// ParallelConfig controls how many projects are built at the same time.
+type ParallelConfig struct {
+	Workers int `json:"workers,omitempty"` // 0 = number of CPUs
 }
 
 // WorkspaceDefaults contains workspace-level defaults for projects
@@ -65,6 +113,17 @@ type ToolVersions struct {
 	Bazel   string `json:"bazel,omitempty"`   // Bazel build tool version
 }
 
+// DefaultToolVersions returns the tool versions used for tools a workspace has not locked.
+func DefaultToolVersions() ToolVersions {
+	return ToolVersions{
+		Angular: "21.0.2",
+		Go:      "1.24.0",
+		NestJS:  "11.1.9",
+		Node:    "24.11.1",
+		Bazel:   "7.4.1",
+	}
+}
+
 // WorkspacePaths contains workspace directory structure configuration.
 type WorkspacePaths struct {
 	Services       string `json:"services,omitempty"`
@@ -94,6 +153,16 @@ type GCPConfig struct {
 type KubernetesConfig struct {
 	Namespace string `json:"namespace"`
 	Context   string `json:"context,omitempty"`
+	Domain    string `json:"domain,omitempty"` // Base domain for ingress hosts
+}
+
+// APIGatewayConfig contains the ingress settings of the API gateway chart in
+// infra/api-gateway.
+type APIGatewayConfig struct {
+	Domain       string            `json:"domain,omitempty"`       // Default: kubernetes.domain
+	TLSIssuer    string            `json:"tlsIssuer,omitempty"`    // cert-manager ClusterIssuer; enables TLS when set
+	IngressClass string            `json:"ingressClass,omitempty"` // Default: "nginx"
+	Annotations  map[string]string `json:"annotations,omitempty"`  // Added to, or overriding, the default CORS annotations
 }
 
 // Project represents a project in the workspace.
@@ -103,6 +172,7 @@ type Project struct {
 	Root        string                 `json:"root"`
 	Tags        []string               `json:"tags,omitempty"`
 	Architect   *Architect             `json:"architect,omitempty"`
+	Watch       *WatchConfig           `json:"watch,omitempty"` // Watch settings applying below Root only
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -197,20 +267,50 @@ func (c *Config) SaveToDir(dir string) error {
 	return c.SaveTo(configPath)
 }
 
-// SaveTo saves the configuration to the specified file.
+// SaveTo saves the configuration to the specified file. The file is replaced
+// atomically, so readers never see a partially written forge.json.
 func (c *Config) SaveTo(path string) error {
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := xos.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// Update applies fn to the forge.json in dir while holding the workspace lock:
+// the file is reloaded, changed by fn and saved, and c is replaced with the
+// result. Writers that go through Update never lose each other's changes, for
+// example two generators registering projects at the same time.
+func (c *Config) Update(dir string, fn func(*Config) error) error {
+	lock, err := xos.LockFile(filepath.Join(dir, ".forge", ConfigFileName+".lock"))
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", ConfigFileName, err)
+	}
+	defer lock.Unlock()
+
+	current, err := LoadConfigWithoutProjectValidation(dir)
+	if err != nil {
+		return err
+	}
+	if current.Projects == nil {
+		current.Projects = make(map[string]Project)
+	}
+	if err := fn(current); err != nil {
+		return err
+	}
+	if err := current.SaveToDir(dir); err != nil {
+		return err
+	}
+
+	*c = *current
+	return nil
+}
+
 // AddProject adds a project to the workspace.
 func (c *Config) AddProject(name string, project *Project) error {
 	if _, exists := c.Projects[name]; exists {
@@ -239,6 +339,151 @@ func (c *Config) GetProject(name string) *Project {
 	return nil
 }
 
+// GetToolVersions returns the tool versions locked in forge.json. It fails
+// when a tool has not been locked, so generators never fall back to floating
+// versions; 'forge workspace lock' pins them.
+func (c *Config) GetToolVersions() (ToolVersions, error) {
+	var versions ToolVersions
+	if c.Workspace.ToolVersions != nil {
+		versions = *c.Workspace.ToolVersions
+	}
+
+	var missing []string
+	for _, tool := range []struct{ name, version string }{
+		{"go", versions.Go},
+		{"node", versions.Node},
+		{"bazel", versions.Bazel},
+		{"angular", versions.Angular},
+		{"nestjs", versions.NestJS},
+	} {
+		if tool.version == "" {
+			missing = append(missing, tool.name)
+		}
+	}
+	if len(missing) > 0 {
+		return versions, fmt.Errorf("no version is locked for %s in forge.json, run 'forge workspace lock'", strings.Join(missing, ", "))
+	}
+	return versions, nil
+}
+
+// Environments returns the configuration names defined by the build and deploy
+// targets of all projects, sorted.
+func (c *Config) Environments() []string {
+	seen := make(map[string]bool)
+	for _, project := range c.Projects {
+		if project.Architect == nil {
+			continue
+		}
+		for _, target := range []*ArchitectTarget{project.Architect.Build, project.Architect.Deploy} {
+			if target == nil {
+				continue
+			}
+			for name := range target.Configurations {
+				seen[name] = true
+			}
+		}
+	}
+
+	environments := make([]string, 0, len(seen))
+	for name := range seen {
+		environments = append(environments, name)
+	}
+	sort.Strings(environments)
+	return environments
+}
+
+// HasEnvironment reports whether any project defines the named configuration.
+func (c *Config) HasEnvironment(name string) bool {
+	for _, env := range c.Environments() {
+		if env == name {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownEnvironments returns the environments that may be selected with --env:
+// the configurations of Environments plus the environments of
+// workspace.environments, sorted.
+func (c *Config) KnownEnvironments() []string {
+	environments := c.Environments()
+	seen := make(map[string]bool, len(environments))
+	for _, name := range environments {
+		seen[name] = true
+	}
+	for name := range c.Workspace.Environments {
+		if !seen[name] {
+			environments = append(environments, name)
+		}
+	}
+	sort.Strings(environments)
+	return environments
+}
+
+// DefaultEnvironment returns the environment used when --env is omitted: the
+// one selected with 'forge env use', then workspace.defaults.buildEnvironment.
+// It returns "" when neither is set.
+func (c *Config) DefaultEnvironment() string {
+	if c.CLI != nil && c.CLI.DefaultBuildEnvironment != "" {
+		return c.CLI.DefaultBuildEnvironment
+	}
+	if c.Workspace.Defaults != nil {
+		return c.Workspace.Defaults.BuildEnvironment
+	}
+	return ""
+}
+
+// BuildWorkers returns the configured number of parallel build workers, or 0
+// when the number of CPUs should be used.
+func (c *Config) BuildWorkers() int {
+	if c.Workspace.Build == nil || c.Workspace.Build.Parallel == nil {
+		return 0
+	}
+	return c.Workspace.Build.Parallel.Workers
+}
+
+// MaxParallelJobs caps the number of parallel jobs, so a large machine or a
+// typo in forge.json does not start hundreds of builds at once.
+const MaxParallelJobs = 64
+
+// ParallelJobs returns the number of parallel jobs: jobs when positive (the
+// --jobs flag), then workspace.build.parallel.workers, then the number of
+// CPUs, never more than MaxParallelJobs.
+func (c *Config) ParallelJobs(jobs int) int {
+	if jobs <= 0 {
+		jobs = c.BuildWorkers()
+	}
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	return min(jobs, MaxParallelJobs)
+}
+
+// Default project directories, relative to the workspace root.
+const (
+	DefaultServicesPath     = "backend/services"
+	DefaultFrontendAppsPath = "frontend/apps"
+)
+
+// ServicesPath returns the directory of backend services relative to the
+// workspace root (workspace.paths.services).
+func (c *Config) ServicesPath() string {
+	if c.Workspace.Paths != nil && c.Workspace.Paths.Services != "" {
+		return c.Workspace.Paths.Services
+	}
+	return DefaultServicesPath
+}
+
+// FrontendAppsPath returns the directory of frontend applications relative to
+// the workspace root (workspace.paths.frontendApps), or defaultPath when the
+// workspace does not configure one.
+func (c *Config) FrontendAppsPath(defaultPath string) string {
+	if c.Workspace.Paths != nil && c.Workspace.Paths.FrontendApps != "" {
+		return c.Workspace.Paths.FrontendApps
+	}
+	return defaultPath
+}
+
 // ListProjects returns all projects.
 func (c *Config) ListProjects() []Project {
 	projects := make([]Project, 0, len(c.Projects))
@@ -0,0 +1,78 @@
+package workspace
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParallelJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		jobs    int
+		want    int
+	}{
+		{name: "flag wins", workers: 2, jobs: 3, want: 3},
+		{name: "configured workers", workers: 2, want: 2},
+		{name: "number of CPUs", want: min(runtime.NumCPU(), MaxParallelJobs)},
+		{name: "flag clamped", jobs: 1000, want: MaxParallelJobs},
+		{name: "workers clamped", workers: 1000, want: MaxParallelJobs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{}
+			if tt.workers != 0 {
+				config.Workspace.Build = &BuildConfig{Parallel: &ParallelConfig{Workers: tt.workers}}
+			}
+			if got := config.ParallelJobs(tt.jobs); got != tt.want {
+				t.Errorf("ParallelJobs(%d) = %d, want %d", tt.jobs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKnownEnvironments(t *testing.T) {
+	config := &Config{
+		Workspace: WorkspaceMetadata{Environments: map[string]*EnvironmentConfig{
+			"production": {Registry: "gcr.io/acme"},
+			"qa":         {Registry: "gcr.io/acme-qa"},
+		}},
+		Projects: map[string]Project{
+			"api": {Architect: &Architect{
+				Build:  &ArchitectTarget{Configurations: map[string]interface{}{"production": nil, "local": nil}},
+				Deploy: &ArchitectTarget{Configurations: map[string]interface{}{"staging": nil}},
+			}},
+		},
+	}
+
+	got := config.KnownEnvironments()
+	want := []string{"local", "production", "qa", "staging"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KnownEnvironments() = %q, want %q", got, want)
+	}
+}
+
+func TestGetToolVersions(t *testing.T) {
+	config := NewConfig("shop")
+	if _, err := config.GetToolVersions(); err == nil {
+		t.Error("GetToolVersions() succeeded without locked versions")
+	}
+
+	config.Workspace.ToolVersions = &ToolVersions{Go: "1.24.3", Node: "22.12.0", Bazel: "7.4.1", Angular: "21.0.2"}
+	_, err := config.GetToolVersions()
+	if err == nil || !strings.Contains(err.Error(), "nestjs") {
+		t.Errorf("GetToolVersions() error = %v, want one naming nestjs", err)
+	}
+
+	config.Workspace.ToolVersions.NestJS = "11.0.7"
+	versions, err := config.GetToolVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if versions != *config.Workspace.ToolVersions {
+		t.Errorf("GetToolVersions() = %+v, want the locked versions", versions)
+	}
+}
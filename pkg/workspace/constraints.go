@@ -0,0 +1,114 @@
+package workspace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AnyTag matches every project in dependency constraints.
+const AnyTag = "*"
+
+// DependencyConstraint restricts the dependencies of the projects having
+// SourceTag, like Nx's depConstraints. A project matching several constraints
+// must satisfy all of them.
+type DependencyConstraint struct {
+	SourceTag        string   `json:"sourceTag"`                  // Tag of the constrained projects, or "*" for all
+	OnlyDependOnTags []string `json:"onlyDependOnTags,omitempty"` // Dependencies must have one of these tags
+	NotDependOnTags  []string `json:"notDependOnTags,omitempty"`  // Dependencies must have none of these tags
+}
+
+// DependencyViolation is a dependency a constraint does not allow.
+type DependencyViolation struct {
+	Project        string
+	Dependency     string
+	DependencyTags []string
+	Constraint     DependencyConstraint
+}
+
+// Error describes the violation.
+func (v DependencyViolation) Error() string {
+	c := v.Constraint
+	if len(c.OnlyDependOnTags) > 0 && !hasAnyTag(v.DependencyTags, c.OnlyDependOnTags) {
+		return fmt.Sprintf("%s (%s) depends on %s, but %q projects may only depend on projects tagged %s",
+			v.Project, c.SourceTag, v.Dependency, c.SourceTag, strings.Join(c.OnlyDependOnTags, ", "))
+	}
+	return fmt.Sprintf("%s (%s) depends on %s, but %q projects must not depend on projects tagged %s",
+		v.Project, c.SourceTag, v.Dependency, c.SourceTag, strings.Join(c.NotDependOnTags, ", "))
+}
+
+// ValidateConstraints checks that every constraint has a source tag and at
+// least one rule.
+func (c *Config) ValidateConstraints() error {
+	for i, constraint := range c.Constraints {
+		if constraint.SourceTag == "" {
+			return fmt.Errorf("constraints[%d]: sourceTag is required", i)
+		}
+		if len(constraint.OnlyDependOnTags) == 0 && len(constraint.NotDependOnTags) == 0 {
+			return fmt.Errorf("constraints[%d] (%s): set onlyDependOnTags or notDependOnTags", i, constraint.SourceTag)
+		}
+	}
+	return nil
+}
+
+// CheckDependencyConstraints returns the dependencies that break the
+// workspace constraints, sorted by project and dependency. deps maps each
+// project to the projects it depends on.
+func (c *Config) CheckDependencyConstraints(deps map[string][]string) []DependencyViolation {
+	var violations []DependencyViolation
+	for project, dependencies := range deps {
+		source, ok := c.Projects[project]
+		if !ok {
+			continue
+		}
+		for _, constraint := range c.Constraints {
+			if !hasAnyTag(source.Tags, []string{constraint.SourceTag}) {
+				continue
+			}
+			for _, dependency := range dependencies {
+				target := c.Projects[dependency]
+				if allowsDependency(constraint, target.Tags) {
+					continue
+				}
+				violations = append(violations, DependencyViolation{
+					Project:        project,
+					Dependency:     dependency,
+					DependencyTags: target.Tags,
+					Constraint:     constraint,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Project != violations[j].Project {
+			return violations[i].Project < violations[j].Project
+		}
+		return violations[i].Dependency < violations[j].Dependency
+	})
+	return violations
+}
+
+// allowsDependency reports whether a constraint allows depending on a project
+// with the given tags.
+func allowsDependency(constraint DependencyConstraint, tags []string) bool {
+	if len(constraint.OnlyDependOnTags) > 0 && !hasAnyTag(tags, constraint.OnlyDependOnTags) {
+		return false
+	}
+	return !hasAnyTag(tags, constraint.NotDependOnTags)
+}
+
+// hasAnyTag reports whether tags contain one of wanted, "*" matching any project.
+func hasAnyTag(tags, wanted []string) bool {
+	for _, want := range wanted {
+		if want == AnyTag {
+			return true
+		}
+		for _, tag := range tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
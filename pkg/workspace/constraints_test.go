@@ -0,0 +1,58 @@
+package workspace
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDependencyConstraints(t *testing.T) {
+	config := &Config{
+		Projects: map[string]Project{
+			"orders":  {Tags: []string{"service"}},
+			"billing": {Tags: []string{"service"}},
+			"auth":    {Tags: []string{"shared"}},
+			"db":      {Tags: []string{"shared", "infra"}},
+			"tools":   {},
+		},
+		Constraints: []DependencyConstraint{
+			{SourceTag: "service", OnlyDependOnTags: []string{"shared"}},
+			{SourceTag: "shared", NotDependOnTags: []string{"service"}},
+			{SourceTag: "*", NotDependOnTags: []string{"infra"}},
+		},
+	}
+
+	violations := config.CheckDependencyConstraints(map[string][]string{
+		"orders":  {"auth", "billing", "db"},
+		"billing": {"auth"},
+		"auth":    {"orders"},
+		"tools":   {"orders"},
+	})
+
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.Project+" -> "+v.Dependency)
+	}
+	want := []string{"auth -> orders", "orders -> billing", "orders -> db"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+
+	if msg := violations[1].Error(); !strings.Contains(msg, "may only depend on projects tagged shared") {
+		t.Errorf("orders -> billing: %q", msg)
+	}
+	if msg := violations[2].Error(); !strings.Contains(msg, "must not depend on projects tagged infra") {
+		t.Errorf("orders -> db: %q", msg)
+	}
+}
+
+func TestValidateConstraints(t *testing.T) {
+	config := &Config{Constraints: []DependencyConstraint{{SourceTag: "service"}}}
+	if err := config.ValidateConstraints(); err == nil {
+		t.Error("ValidateConstraints() = nil for a constraint without rules")
+	}
+
+	config.Constraints = []DependencyConstraint{{OnlyDependOnTags: []string{"shared"}}}
+	if err := config.ValidateConstraints(); err == nil {
+		t.Error("ValidateConstraints() = nil for a constraint without sourceTag")
+	}
+}
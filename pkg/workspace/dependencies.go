@@ -0,0 +1,149 @@
+package workspace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DependsOn returns the projects a project must be deployed after, read from
+// its optional metadata.dependsOn list.
+func (p *Project) DependsOn() ([]string, error) {
+	return p.metadataStrings("dependsOn", "project names")
+}
+
+// GazelleDirectives returns the gazelle directives of a project, read from its
+// optional metadata.gazelle list. Entries may be written with or without the
+// leading "# gazelle:" and are returned as "gazelle:<directive>".
+func (p *Project) GazelleDirectives() ([]string, error) {
+	entries, err := p.metadataStrings("gazelle", "directives")
+	if err != nil {
+		return nil, err
+	}
+
+	directives := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		directive := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(entry), "#"))
+		directive = strings.TrimSpace(strings.TrimPrefix(directive, "gazelle:"))
+		if directive == "" {
+			continue
+		}
+		directives = append(directives, "gazelle:"+directive)
+	}
+	return directives, nil
+}
+
+// metadataStrings reads an optional list of strings from the project metadata.
+func (p *Project) metadataStrings(key, what string) ([]string, error) {
+	raw, ok := p.Metadata[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	switch values := raw.(type) {
+	case []string:
+		return values, nil
+	case []interface{}:
+		strs := make([]string, 0, len(values))
+		for _, value := range values {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("metadata.%s must be a list of %s, got %v", key, what, value)
+			}
+			strs = append(strs, str)
+		}
+		return strs, nil
+	default:
+		return nil, fmt.Errorf("metadata.%s must be a list of %s", key, what)
+	}
+}
+
+// Dependencies returns, for each of projectNames, the projects of projectNames
+// it depends on. Dependencies outside projectNames must exist in the workspace
+// but are otherwise ignored, since they are not part of this run.
+func (c *Config) Dependencies(projectNames []string) (map[string][]string, error) {
+	selected := make(map[string]bool, len(projectNames))
+	for _, name := range projectNames {
+		selected[name] = true
+	}
+
+	deps := make(map[string][]string, len(projectNames))
+	for _, name := range projectNames {
+		project, exists := c.Projects[name]
+		if !exists {
+			return nil, fmt.Errorf("project %q not found", name)
+		}
+
+		dependsOn, err := project.DependsOn()
+		if err != nil {
+			return nil, fmt.Errorf("project %q: %w", name, err)
+		}
+		for _, dep := range dependsOn {
+			if _, exists := c.Projects[dep]; !exists {
+				return nil, fmt.Errorf("project %q depends on unknown project %q", name, dep)
+			}
+			if selected[dep] {
+				deps[name] = append(deps[name], dep)
+			}
+		}
+		sort.Strings(deps[name])
+	}
+
+	return deps, nil
+}
+
+// SortByDependencies orders projectNames so every project comes after the
+// projects it depends on, failing on cycles. Independent projects are ordered
+// by name so the result is stable.
+func (c *Config) SortByDependencies(projectNames []string) ([]string, error) {
+	deps, err := c.Dependencies(projectNames)
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string(nil), projectNames...)
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(names))
+	ordered := make([]string, 0, len(names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(path, name)
+			for i, n := range path {
+				if n == name {
+					cycle = cycle[i:]
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,34 @@
+package workspace
+
+// ProtoDir returns the proto directory of a gRPC project or proto contract
+// library relative to its root, as registered in its grpc metadata, or "" when
+// the project has none.
+func (p Project) ProtoDir() string {
+	grpc, ok := p.Metadata["grpc"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	dir, _ := grpc["protoDir"].(string)
+	return dir
+}
+
+// GRPCGateway reports whether a gRPC project also serves its API over HTTP
+// through a gRPC-gateway.
+func (p Project) GRPCGateway() bool {
+	grpc, ok := p.Metadata["grpc"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	gateway, _ := grpc["gateway"].(bool)
+	return gateway
+}
+
+// HasProto reports whether any project of the workspace registers a proto directory.
+func (c *Config) HasProto() bool {
+	for _, project := range c.Projects {
+		if project.ProtoDir() != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Get returns the value at a dotted key path such as "workspace.gcp.projectId"
+// or "projects.api.root". Keys follow the JSON names of forge.json; map
+// entries such as projects and environments are addressed by name. Unset
+// values are returned as nil.
+func (c *Config) Get(key string) (interface{}, error) {
+	parts, err := splitKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	for i, part := range parts {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := lookupJSONField(jsonFields(v.Type()), part)
+			if !ok {
+				return nil, unknownKeyError(parts[:i+1], v.Type())
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			entry := v.MapIndex(reflect.ValueOf(part))
+			if !entry.IsValid() {
+				return nil, nil
+			}
+			v = entry
+		default:
+			return nil, fmt.Errorf("key %q: %s is not an object", key, strings.Join(parts[:i], "."))
+		}
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), nil
+}
+
+// Set parses value as the type of the field at a dotted key path and stores it,
+// creating intermediate objects as needed. Strings are taken as-is, numbers and
+// booleans must parse, lists accept a JSON array or comma-separated items and
+// objects a JSON object. Free-form objects such as metadata and architect
+// options take JSON values, falling back to a plain string.
+func (c *Config) Set(key, value string) error {
+	parts, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+	return setPath(reflect.ValueOf(c).Elem(), parts, 0, value)
+}
+
+func setPath(v reflect.Value, parts []string, i int, value string) error {
+	if i == len(parts) {
+		parsed, err := parseValue(v.Type(), value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", strings.Join(parts, "."), err)
+		}
+		v.Set(parsed)
+		return nil
+	}
+	part := parts[i]
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), parts, i, value)
+
+	case reflect.Interface:
+		// Free-form JSON: descend into objects, creating them when missing
+		object, ok := v.Interface().(map[string]interface{})
+		if !ok {
+			if !v.IsNil() {
+				return fmt.Errorf("key %q: %s is not an object", strings.Join(parts, "."), strings.Join(parts[:i], "."))
+			}
+			object = make(map[string]interface{})
+			v.Set(reflect.ValueOf(object))
+		}
+		return setPath(reflect.ValueOf(object), parts, i, value)
+
+	case reflect.Struct:
+		field, ok := lookupJSONField(jsonFields(v.Type()), part)
+		if !ok {
+			return unknownKeyError(parts[:i+1], v.Type())
+		}
+		return setPath(v.FieldByIndex(field.Index), parts, i+1, value)
+
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		// Map entries are not addressable: update a copy and store it back
+		entry := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(reflect.ValueOf(part)); existing.IsValid() {
+			entry.Set(existing)
+		} else if isStruct(v.Type().Elem()) {
+			// Projects and environments are created by their commands, not by a typo
+			return unknownEntryError(parts[:i+1], v)
+		}
+		if err := setPath(entry, parts, i+1, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(part), entry)
+		return nil
+	}
+
+	return fmt.Errorf("key %q: %s is not an object", strings.Join(parts, "."), strings.Join(parts[:i], "."))
+}
+
+// parseValue converts a command-line value to type t.
+func parseValue(t reflect.Type, value string) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(value).Convert(t), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("expected an integer, got %q", value)
+		}
+		return reflect.ValueOf(n).Convert(t), nil
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("expected true or false, got %q", value)
+		}
+		return reflect.ValueOf(b).Convert(t), nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+			items := reflect.MakeSlice(t, 0, 0)
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = reflect.Append(items, reflect.ValueOf(item).Convert(t.Elem()))
+				}
+			}
+			return items, nil
+		}
+
+	case reflect.Interface:
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			parsed = value
+		}
+		if parsed == nil {
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(parsed), nil
+	}
+
+	// Objects, lists and anything else are given as JSON
+	parsed := reflect.New(t)
+	if err := json.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("expected a JSON %s: %w", jsonKind(t), err)
+	}
+	return parsed.Elem(), nil
+}
+
+func jsonKind(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return "value"
+}
+
+func splitKey(key string) ([]string, error) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q", key)
+		}
+	}
+	return parts, nil
+}
+
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// unknownEntryError reports a missing entry of a map such as projects along
+// with the entries it has.
+func unknownEntryError(path []string, m reflect.Value) error {
+	var names []string
+	for _, name := range m.MapKeys() {
+		names = append(names, name.String())
+	}
+	sort.Strings(names)
+
+	parent := strings.Join(path[:len(path)-1], ".")
+	if len(names) == 0 {
+		return fmt.Errorf("unknown key %q: %s has no entries", strings.Join(path, "."), parent)
+	}
+	return fmt.Errorf("unknown key %q (%s: %s)", strings.Join(path, "."), parent, strings.Join(names, ", "))
+}
+
+// unknownKeyError reports a key missing from a struct along with the keys it
+// does define.
+func unknownKeyError(path []string, t reflect.Type) error {
+	var keys []string
+	for name := range jsonFields(t) {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("unknown key %q (valid keys: %s)", strings.Join(path, "."), strings.Join(keys, ", "))
+}
@@ -0,0 +1,64 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// NestCLIFileName is the configuration file of a Nest CLI project.
+const NestCLIFileName = "nest-cli.json"
+
+// nestCLIConfig is the part of nest-cli.json describing a monorepo.
+type nestCLIConfig struct {
+	Monorepo bool `json:"monorepo"`
+	Projects map[string]struct {
+		Root string `json:"root"`
+	} `json:"projects"`
+}
+
+// readNestCLIConfig reads the nest-cli.json of dir. It returns nil when there
+// is none or it cannot be parsed.
+func readNestCLIConfig(dir string) *nestCLIConfig {
+	content, err := os.ReadFile(filepath.Join(dir, NestCLIFileName))
+	if err != nil {
+		return nil
+	}
+	var config nestCLIConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil
+	}
+	return &config
+}
+
+// IsNestMonorepo reports whether dir holds a Nest workspace in monorepo mode,
+// whose apps share the root package.json and node_modules.
+func IsNestMonorepo(dir string) bool {
+	config := readNestCLIConfig(dir)
+	return config != nil && config.Monorepo
+}
+
+// FindNestMonorepo returns the directory of the Nest monorepo declaring a
+// project as one of its apps, searched from the project root up to the
+// workspace root, and the name of the app. It returns "" for standalone
+// NestJS projects.
+func FindNestMonorepo(workspaceRoot, projectRoot string) (dir, app string) {
+	dir = projectRoot
+	for {
+		if config := readNestCLIConfig(dir); config != nil {
+			if !config.Monorepo {
+				return "", ""
+			}
+			for name, project := range config.Projects {
+				if filepath.Join(dir, project.Root) == filepath.Clean(projectRoot) {
+					return dir, name
+				}
+			}
+			return "", ""
+		}
+		if dir == workspaceRoot || dir == filepath.Dir(dir) {
+			return "", ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
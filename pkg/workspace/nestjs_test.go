@@ -0,0 +1,62 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindNestMonorepo(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("services/nest/nest-cli.json", `{
+  "monorepo": true,
+  "root": "apps/orders",
+  "projects": {
+    "orders": {"type": "application", "root": "apps/orders"},
+    "billing": {"type": "application", "root": "apps/billing"}
+  }
+}`)
+	write("services/nest/apps/orders/src/main.ts", "")
+	write("services/nest/apps/billing/src/main.ts", "")
+	write("services/nest/apps/stray/src/main.ts", "")
+	write("services/users/nest-cli.json", `{"collection": "@nestjs/schematics", "sourceRoot": "src"}`)
+
+	tests := []struct {
+		name    string
+		project string
+		wantDir string
+		wantApp string
+	}{
+		{name: "app", project: "services/nest/apps/billing", wantDir: "services/nest", wantApp: "billing"},
+		{name: "directory that is no app", project: "services/nest/apps/stray"},
+		{name: "standalone project", project: "services/users"},
+		{name: "no nest-cli.json", project: "services/go-api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, app := FindNestMonorepo(root, filepath.Join(root, tt.project))
+			wantDir := ""
+			if tt.wantDir != "" {
+				wantDir = filepath.Join(root, tt.wantDir)
+			}
+			if dir != wantDir || app != tt.wantApp {
+				t.Errorf("FindNestMonorepo() = %q, %q, want %q, %q", dir, app, wantDir, tt.wantApp)
+			}
+		})
+	}
+
+	if !IsNestMonorepo(filepath.Join(root, "services/nest")) || IsNestMonorepo(filepath.Join(root, "services/users")) {
+		t.Error("IsNestMonorepo() does not match the monorepo flag of nest-cli.json")
+	}
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ServePort returns the port in the serve options of a project, or 0 when it
+// does not configure one.
+func (p Project) ServePort() int {
+	if p.Architect == nil || p.Architect.Serve == nil {
+		return 0
+	}
+	return PortValue(p.Architect.Serve.Options["port"])
+}
+
+// SetServePort stores port in the serve options of a project, creating the
+// serve target when the project has none.
+func (p *Project) SetServePort(port int) {
+	if p.Architect == nil {
+		p.Architect = &Architect{}
+	}
+	if p.Architect.Serve == nil {
+		p.Architect.Serve = &ArchitectTarget{}
+	}
+	if p.Architect.Serve.Options == nil {
+		p.Architect.Serve.Options = make(map[string]interface{})
+	}
+	p.Architect.Serve.Options["port"] = port
+}
+
+// UsedPorts returns the serve ports configured by the projects of the workspace.
+func (c *Config) UsedPorts() map[int]bool {
+	used := make(map[int]bool)
+	for _, project := range c.Projects {
+		if port := project.ServePort(); port != 0 {
+			used[port] = true
+		}
+	}
+	return used
+}
+
+// NextFreePort returns the first port from base upward that is not in used.
+func NextFreePort(base int, used map[int]bool) int {
+	port := base
+	for used[port] {
+		port++
+	}
+	return port
+}
+
+// PortValue converts a port option decoded from forge.json, a number or a
+// numeric string, to an int. It returns 0 for anything else.
+func PortValue(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return 0
+		}
+		return port
+	}
+	return 0
+}
+
+// ParsePort validates a port given on the command line or in a prompt.
+func ParsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q: must be a number between 1 and 65535", value)
+	}
+	return port, nil
+}
@@ -0,0 +1,132 @@
+package workspace
+
+import "fmt"
+
+// ConfigurationOptions returns the options of the given configuration, or nil
+// when the target does not define it.
+func (t *ArchitectTarget) ConfigurationOptions(configuration string) map[string]interface{} {
+	if t == nil || t.Configurations == nil {
+		return nil
+	}
+	options, _ := t.Configurations[configuration].(map[string]interface{})
+	return options
+}
+
+// HasConfiguration reports whether the target defines configuration.
+func (t *ArchitectTarget) HasConfiguration(configuration string) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.Configurations[configuration]
+	return ok
+}
+
+// ConfigurationMismatches describes, one line per project, the projects whose
+// build and deploy targets do not both define configuration. Such projects
+// would be built or deployed with their base options alone.
+func (c *Config) ConfigurationMismatches(projectNames []string, configuration string) []string {
+	var mismatches []string
+	for _, name := range projectNames {
+		project, ok := c.Projects[name]
+		if !ok || project.Architect == nil {
+			continue
+		}
+
+		inBuild := project.Architect.Build.HasConfiguration(configuration)
+		inDeploy := project.Architect.Deploy.HasConfiguration(configuration)
+		switch {
+		case !inBuild && !inDeploy:
+			mismatches = append(mismatches, fmt.Sprintf("%s: neither build nor deploy defines configuration %q", name, configuration))
+		case !inBuild:
+			mismatches = append(mismatches, fmt.Sprintf("%s: deploy defines configuration %q but build does not", name, configuration))
+		case !inDeploy:
+			mismatches = append(mismatches, fmt.Sprintf("%s: build defines configuration %q but deploy does not", name, configuration))
+		}
+	}
+	return mismatches
+}
+
+// ResolveOptions returns the target options overridden by the options of the
+// given configuration. The target itself is left untouched.
+func (t *ArchitectTarget) ResolveOptions(configuration string) map[string]interface{} {
+	resolved := make(map[string]interface{})
+	if t == nil {
+		return resolved
+	}
+	for k, v := range t.Options {
+		resolved[k] = v
+	}
+	for k, v := range t.ConfigurationOptions(configuration) {
+		resolved[k] = v
+	}
+	return resolved
+}
+
+// MapEnvironment returns the framework configuration that a forge
+// configuration maps to through the environmentMapper option of a build
+// target, as generated for Angular and Vue apps (e.g. local -> development).
+// Unmapped configurations are returned unchanged.
+func MapEnvironment(options map[string]interface{}, configuration string) string {
+	switch mapper := options["environmentMapper"].(type) {
+	case map[string]interface{}:
+		if mapped, ok := mapper[configuration].(string); ok && mapped != "" {
+			return mapped
+		}
+	case map[string]string:
+		if mapped := mapper[configuration]; mapped != "" {
+			return mapped
+		}
+	}
+	return configuration
+}
+
+// BuildConfiguration returns the configuration forge build uses for project:
+// env when set, then the workspace default environment, the build target's
+// defaultConfiguration and finally "production".
+func (c *Config) BuildConfiguration(project *Project, env string) string {
+	if env != "" {
+		return env
+	}
+	if env := c.DefaultEnvironment(); env != "" {
+		return env
+	}
+	if project.Architect != nil && project.Architect.Build != nil && project.Architect.Build.DefaultConfiguration != "" {
+		return project.Architect.Build.DefaultConfiguration
+	}
+	return "production"
+}
+
+// DeployConfiguration returns the configuration forge deploy uses: env when
+// set, then the workspace default environment and finally "production".
+func (c *Config) DeployConfiguration(env string) string {
+	if env != "" {
+		return env
+	}
+	if env := c.DefaultEnvironment(); env != "" {
+		return env
+	}
+	return "production"
+}
+
+// Registry returns the container registry the images of a build target are
+// pushed to in configuration. The registry of the configuration itself wins,
+// then workspace.environments[configuration].registry, the registry of the
+// target's base options and finally workspace.docker.registry. It returns ""
+// when none is set.
+func (c *Config) Registry(target *ArchitectTarget, configuration string) string {
+	if registry, ok := target.ConfigurationOptions(configuration)["registry"].(string); ok && registry != "" {
+		return registry
+	}
+	if env := c.Workspace.Environments[configuration]; env != nil && env.Registry != "" {
+		return env.Registry
+	}
+	if target != nil {
+		if registry, ok := target.Options["registry"].(string); ok && registry != "" {
+			return registry
+		}
+	}
+	if c.Workspace.Docker != nil {
+		return c.Workspace.Docker.Registry
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigurationMismatches(t *testing.T) {
+	target := func(configurations ...string) *ArchitectTarget {
+		target := &ArchitectTarget{Configurations: map[string]interface{}{}}
+		for _, configuration := range configurations {
+			target.Configurations[configuration] = map[string]interface{}{}
+		}
+		return target
+	}
+	config := &Config{Projects: map[string]Project{
+		"api":    {Architect: &Architect{Build: target("production", "staging"), Deploy: target("production", "staging")}},
+		"web":    {Architect: &Architect{Build: target("production", "staging"), Deploy: target("production")}},
+		"worker": {Architect: &Architect{Build: target("production"), Deploy: target("production", "staging")}},
+		"cron":   {Architect: &Architect{Build: target("production"), Deploy: target("production")}},
+	}}
+
+	got := config.ConfigurationMismatches([]string{"api", "cron", "web", "worker"}, "staging")
+	want := []string{
+		`cron: neither build nor deploy defines configuration "staging"`,
+		`web: build defines configuration "staging" but deploy does not`,
+		`worker: deploy defines configuration "staging" but build does not`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigurationMismatches() = %q, want %q", got, want)
+	}
+
+	if got := config.ConfigurationMismatches([]string{"api", "cron", "web", "worker"}, "production"); len(got) != 0 {
+		t.Errorf("ConfigurationMismatches(production) = %q, want none", got)
+	}
+}
+
+func TestMapEnvironment(t *testing.T) {
+	// forge.json decodes the mapper as map[string]interface{}, generators set map[string]string
+	decoded := map[string]interface{}{"environmentMapper": map[string]interface{}{"local": "development", "prod": "production"}}
+	generated := map[string]interface{}{"environmentMapper": map[string]string{"local": "development"}}
+
+	tests := []struct {
+		options       map[string]interface{}
+		configuration string
+		want          string
+	}{
+		{decoded, "local", "development"},
+		{decoded, "prod", "production"},
+		{decoded, "staging", "staging"},
+		{generated, "local", "development"},
+		{nil, "local", "local"},
+	}
+	for _, tt := range tests {
+		if got := MapEnvironment(tt.options, tt.configuration); got != tt.want {
+			t.Errorf("MapEnvironment(%v, %q) = %q, want %q", tt.options, tt.configuration, got, tt.want)
+		}
+	}
+}
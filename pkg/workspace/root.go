@@ -0,0 +1,40 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotInWorkspace is returned by FindRoot outside a Forge workspace.
+var ErrNotInWorkspace = errors.New("not inside a Forge workspace")
+
+// FindRoot returns the root of the workspace containing the current
+// directory, so commands work from any of its subdirectories.
+func FindRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return FindRootFrom(dir)
+}
+
+// FindRootFrom returns the root of the workspace containing dir: the closest
+// directory, dir included, holding a forge.json. The error wraps
+// ErrNotInWorkspace when there is none.
+func FindRootFrom(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(filepath.Join(current, ConfigFileName)); err == nil {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("%w: no %s in %s or any parent directory", ErrNotInWorkspace, ConfigFileName, dir)
+		}
+	}
+}
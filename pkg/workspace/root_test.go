@@ -0,0 +1,33 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRootFrom(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ConfigFileName), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "backend", "services", "api")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range []string{root, nested} {
+		got, err := FindRootFrom(dir)
+		if err != nil {
+			t.Fatalf("FindRootFrom(%s): %v", dir, err)
+		}
+		if got != root {
+			t.Errorf("FindRootFrom(%s) = %s, want %s", dir, got, root)
+		}
+	}
+
+	if _, err := FindRootFrom(t.TempDir()); !errors.Is(err, ErrNotInWorkspace) {
+		t.Errorf("FindRootFrom() outside a workspace = %v, want ErrNotInWorkspace", err)
+	}
+}
@@ -0,0 +1,46 @@
+package workspace
+
+import "fmt"
+
+// TagStrategy is how the container images of a build configuration are tagged.
+type TagStrategy string
+
+const (
+	// TagStrategyGitSha tags images with the abbreviated commit SHA.
+	TagStrategyGitSha TagStrategy = "gitSha"
+	// TagStrategySemver tags images with the closest git tag (git describe --tags).
+	TagStrategySemver TagStrategy = "semver"
+	// TagStrategyTimestamp tags images with the UTC build time.
+	TagStrategyTimestamp TagStrategy = "timestamp"
+	// TagStrategyLatest tags images with "latest". Deploys cannot be rolled back.
+	TagStrategyLatest TagStrategy = "latest"
+)
+
+// TimestampTagFormat is the time layout of timestamp tags, in UTC.
+const TimestampTagFormat = "20060102-150405"
+
+// TagStrategies lists the valid tag strategies.
+var TagStrategies = []TagStrategy{TagStrategyGitSha, TagStrategySemver, TagStrategyTimestamp, TagStrategyLatest}
+
+// ImageTagStrategy returns the tag strategy of a build target in
+// configuration, read from its "tagStrategy" option. Production
+// configurations default to gitSha so every deploy can be rolled back, the
+// others to latest.
+func ImageTagStrategy(target *ArchitectTarget, configuration string) (TagStrategy, error) {
+	value, ok := target.ResolveOptions(configuration)["tagStrategy"]
+	if !ok || value == "" {
+		if configuration == "production" || configuration == "prod" {
+			return TagStrategyGitSha, nil
+		}
+		return TagStrategyLatest, nil
+	}
+
+	if s, ok := value.(string); ok {
+		for _, strategy := range TagStrategies {
+			if TagStrategy(s) == strategy {
+				return strategy, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("invalid tagStrategy %v for configuration %s (must be one of %v)", value, configuration, TagStrategies)
+}
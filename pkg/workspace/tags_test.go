@@ -0,0 +1,44 @@
+package workspace
+
+import "testing"
+
+func TestImageTagStrategy(t *testing.T) {
+	target := &ArchitectTarget{
+		Options: map[string]interface{}{"registry": "gcr.io/acme"},
+		Configurations: map[string]interface{}{
+			"production":  map[string]interface{}{},
+			"staging":     map[string]interface{}{"tagStrategy": "timestamp"},
+			"development": map[string]interface{}{},
+			"broken":      map[string]interface{}{"tagStrategy": "sha"},
+		},
+	}
+
+	tests := []struct {
+		configuration string
+		want          TagStrategy
+		wantErr       bool
+	}{
+		{configuration: "production", want: TagStrategyGitSha},
+		{configuration: "prod", want: TagStrategyGitSha},
+		{configuration: "staging", want: TagStrategyTimestamp},
+		{configuration: "development", want: TagStrategyLatest},
+		{configuration: "broken", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.configuration, func(t *testing.T) {
+			got, err := ImageTagStrategy(target, tt.configuration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImageTagStrategy(%q) error = %v, wantErr %v", tt.configuration, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ImageTagStrategy(%q) = %q, want %q", tt.configuration, got, tt.want)
+			}
+		})
+	}
+
+	target.Options["tagStrategy"] = "semver"
+	if got, _ := ImageTagStrategy(target, "production"); got != TagStrategySemver {
+		t.Errorf("base tagStrategy option = %q, want %q", got, TagStrategySemver)
+	}
+}
@@ -3,6 +3,8 @@ package workspace
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
 )
 
 var (
@@ -126,12 +128,107 @@ func (v *Validator) validateAngularKubernetesDeployment(name string, project *Pr
 	)
 }
 
-// ValidateName validates a name follows kebab-case convention.
+// MaxNameLength is the longest workspace or project name. Names end up in
+// Kubernetes resource names, which are DNS labels.
+const MaxNameLength = 63
+
+// reservedNames clash with directories Go, Bazel or forge treat specially.
+var reservedNames = map[string]bool{
+	"forge":    true,
+	"vendor":   true,
+	"external": true,
+	"testdata": true,
+}
+
+// NameError explains why a workspace or project name is invalid and, when
+// possible, suggests a valid alternative.
+type NameError struct {
+	Name       string
+	Reason     string
+	Suggestion string // A valid name close to Name, or ""
+}
+
+func (e *NameError) Error() string {
+	msg := fmt.Sprintf("%q %s", e.Name, e.Reason)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (try %q)", e.Suggestion)
+	}
+	return msg
+}
+
+// ValidateName validates a name follows kebab-case convention. The error is a
+// *NameError naming the rule that is broken.
 func ValidateName(name string) error {
-	if !namePattern.MatchString(name) {
-		return fmt.Errorf("name must be kebab-case (lowercase letters, numbers, and hyphens only, starting with a letter)")
+	var reason string
+	switch {
+	case name == "":
+		return &NameError{Name: name, Reason: "is empty; names must be kebab-case, e.g. my-service"}
+	case len(name) > MaxNameLength:
+		reason = fmt.Sprintf("is longer than %d characters", MaxNameLength)
+	case strings.ToLower(name) != name:
+		reason = "contains uppercase letters"
+	case name[0] < 'a' || name[0] > 'z':
+		reason = "does not start with a letter"
+	case strings.HasPrefix(name, "bazel-"):
+		reason = "starts with bazel-, which Bazel uses for its output directories"
+	case reservedNames[name]:
+		reason = "is reserved"
+	case !namePattern.MatchString(name):
+		if strings.Trim(name, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+			reason = "contains characters other than lowercase letters, numbers and hyphens"
+		} else {
+			reason = "has a hyphen at the end or two hyphens in a row"
+		}
+	default:
+		return nil
 	}
-	return nil
+
+	return &NameError{Name: name, Reason: reason, Suggestion: SanitizeName(name)}
+}
+
+// SanitizeName turns name into a valid kebab-case name, e.g. "My Service" and
+// "myService" into "my-service". It returns "" when nothing usable is left.
+func SanitizeName(name string) string {
+	var b strings.Builder
+	hyphen := false
+	var prev rune
+	for _, r := range name {
+		original := r
+		switch {
+		case unicode.IsUpper(r):
+			// Split camelCase words
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				hyphen = true
+			}
+			r = unicode.ToLower(r)
+			fallthrough
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if r > unicode.MaxASCII {
+				hyphen = true
+				break
+			}
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		default:
+			hyphen = true
+		}
+		prev = original
+	}
+
+	sanitized := strings.TrimLeft(b.String(), "0123456789-")
+	if len(sanitized) > MaxNameLength {
+		sanitized = strings.TrimRight(sanitized[:MaxNameLength], "-")
+	}
+	for strings.HasPrefix(sanitized, "bazel-") {
+		sanitized = strings.TrimPrefix(sanitized, "bazel-")
+	}
+	if reservedNames[sanitized] {
+		sanitized += "-app"
+	}
+	return sanitized
 }
 
 // isValidProjectType checks if a project type is valid.
@@ -0,0 +1,64 @@
+package workspace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantReason     string
+		wantSuggestion string
+	}{
+		{name: "api-server"},
+		{name: "web2"},
+		{name: "", wantReason: "is empty"},
+		{name: "My Service", wantReason: "uppercase", wantSuggestion: "my-service"},
+		{name: "userService", wantReason: "uppercase", wantSuggestion: "user-service"},
+		{name: "api_server", wantReason: "characters", wantSuggestion: "api-server"},
+		{name: "2fa", wantReason: "start with a letter", wantSuggestion: "fa"},
+		{name: "api--server", wantReason: "hyphens in a row", wantSuggestion: "api-server"},
+		{name: "api-", wantReason: "hyphen at the end", wantSuggestion: "api"},
+		{name: "vendor", wantReason: "reserved", wantSuggestion: "vendor-app"},
+		{name: "bazel-out", wantReason: "bazel-", wantSuggestion: "out"},
+		{name: strings.Repeat("a", MaxNameLength+1), wantReason: "longer than", wantSuggestion: strings.Repeat("a", MaxNameLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.name)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("ValidateName(%q) = %v, want nil", tt.name, err)
+				}
+				return
+			}
+
+			var nameErr *NameError
+			if !errors.As(err, &nameErr) {
+				t.Fatalf("ValidateName(%q) = %v, want a *NameError", tt.name, err)
+			}
+			if !strings.Contains(nameErr.Reason, tt.wantReason) {
+				t.Errorf("reason = %q, want it to mention %q", nameErr.Reason, tt.wantReason)
+			}
+			if nameErr.Suggestion != tt.wantSuggestion {
+				t.Errorf("suggestion = %q, want %q", nameErr.Suggestion, tt.wantSuggestion)
+			}
+			if nameErr.Suggestion != "" {
+				if err := ValidateName(nameErr.Suggestion); err != nil {
+					t.Errorf("suggestion %q is invalid: %v", nameErr.Suggestion, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeNameNothingLeft(t *testing.T) {
+	for _, name := range []string{"", "---", "123", "日本"} {
+		if got := SanitizeName(name); got != "" {
+			t.Errorf("SanitizeName(%q) = %q, want \"\"", name, got)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package xos
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileLock is an advisory lock held on a file. It coordinates processes (and
+// goroutines) that take the same lock; it does not stop other writers.
+type FileLock struct {
+	file *os.File
+}
+
+// LockFile takes an exclusive lock on the named file, creating it and its
+// parent directories if needed. It blocks until the lock is available.
+func LockFile(filename string) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &FileLock{file: f}, nil
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if err := unlockFile(l.file); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,262 @@
+package xos
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+// tempDir returns an empty directory that also serves as TMPDIR, so temp
+// files land in it whichever directory the platform implementation picks.
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	t.Setenv("TMP", dir)
+	t.Setenv("TEMP", dir)
+	return dir
+}
+
+// assertEntries fails unless dir holds exactly the named entries, catching
+// temp files left behind.
+func assertEntries(t *testing.T, dir string, want ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if len(got) != len(want) {
+		t.Fatalf("entries in %s = %v, want %v", dir, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("entries in %s = %v, want %v", dir, got, want)
+		}
+	}
+}
+
+func assertFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), data, content)
+	}
+
+	// Windows only tracks the read-only bit
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != perm {
+		t.Errorf("%s has mode %v, want %v", filepath.Base(path), info.Mode().Perm(), perm)
+	}
+}
+
+// blockWith makes path a non-empty directory, so renaming a file over it fails
+// on every platform.
+func blockWith(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(path, "child"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+
+	if err := WriteFile(path, []byte("first"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "first", 0644)
+
+	// Like os.WriteFile, perm only applies to new files
+	if err := WriteFile(path, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "second", 0644)
+	assertEntries(t, dir, "forge.json")
+}
+
+func TestWriteFileFailureLeavesNoTempFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+	blockWith(t, path)
+
+	if err := WriteFile(path, []byte("data"), 0644); err == nil {
+		t.Fatal("WriteFile over a non-empty directory succeeded")
+	}
+	assertEntries(t, dir, "forge.json")
+}
+
+func TestWriteFileMissingDirectory(t *testing.T) {
+	dir := tempDir(t)
+
+	if err := WriteFile(filepath.Join(dir, "missing", "forge.json"), []byte("data"), 0644); err == nil {
+		t.Fatal("WriteFile into a missing directory succeeded")
+	}
+	assertEntries(t, dir)
+}
+
+func TestWriteFileWithBackup(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+
+	// No backup without an original
+	if err := WriteFileWithBackup(path, []byte("first"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, dir, "forge.json")
+
+	if err := WriteFileWithBackup(path, []byte("second"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "second", 0644)
+	assertFile(t, path+".bak", "first", 0644)
+
+	// The backup always holds the previous content
+	if err := WriteFileWithBackup(path, []byte("third"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "third", 0644)
+	assertFile(t, path+".bak", "second", 0644)
+	assertEntries(t, dir, "forge.json", "forge.json.bak")
+}
+
+func TestWriteFileWithBackupFailureLeavesNoTempFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+	if err := WriteFile(path, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	blockWith(t, path+".bak")
+
+	if err := WriteFileWithBackup(path, []byte("new"), 0644); err == nil {
+		t.Fatal("WriteFileWithBackup succeeded without writing its backup")
+	}
+	assertFile(t, path, "original", 0644)
+	assertEntries(t, dir, "forge.json", "forge.json.bak")
+}
+
+func TestPendingFileCloseAtomically(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+	if err := WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := NewPendingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Path() != path {
+		t.Errorf("Path() = %q, want %q", pending.Path(), path)
+	}
+	if _, err := pending.WriteString("new "); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pending.Write([]byte("content")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pending.Chmod(0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing changes until the write completes
+	assertFile(t, path, "old", 0644)
+
+	if err := pending.CloseAtomically(); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "new content", 0600)
+	assertEntries(t, dir, "forge.json")
+}
+
+func TestPendingFileCleanup(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+
+	pending, err := NewPendingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pending.WriteString("discarded"); err != nil {
+		t.Fatal(err)
+	}
+	pending.Cleanup()
+
+	assertEntries(t, dir)
+}
+
+func TestPendingFileCloseAtomicallyFailureLeavesNoTempFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "forge.json")
+
+	pending, err := NewPendingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pending.WriteString("data"); err != nil {
+		t.Fatal(err)
+	}
+	blockWith(t, path)
+
+	if err := pending.CloseAtomically(); err == nil {
+		t.Fatal("CloseAtomically over a non-empty directory succeeded")
+	}
+	pending.Cleanup()
+	assertEntries(t, dir, "forge.json")
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := tempDir(t)
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	if err := WriteFile(src, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyFile(src, dst, 0600); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, dst, "content", 0600)
+	assertFile(t, src, "content", 0644)
+	assertEntries(t, dir, "dst.txt", "src.txt")
+}
+
+func TestCopyFileFailureLeavesNoTempFile(t *testing.T) {
+	dir := tempDir(t)
+
+	if err := CopyFile(filepath.Join(dir, "missing.txt"), filepath.Join(dir, "dst.txt"), 0644); err == nil {
+		t.Fatal("CopyFile from a missing file succeeded")
+	}
+	assertEntries(t, dir)
+
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	if err := WriteFile(src, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	blockWith(t, dst)
+
+	if err := CopyFile(src, dst, 0644); err == nil {
+		t.Fatal("CopyFile over a non-empty directory succeeded")
+	}
+	assertEntries(t, dir, "dst.txt", "src.txt")
+}
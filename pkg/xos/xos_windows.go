@@ -47,7 +47,10 @@ func WriteFile(filename string, data []byte, perm os.FileMode) error {
 		return err
 	}
 
-	// Set permissions
+	// Set permissions, keeping those of an existing file like os.WriteFile
+	if info, err := os.Stat(filename); err == nil && info.Mode().IsRegular() {
+		perm = info.Mode().Perm()
+	}
 	if err := os.Chmod(tempName, perm); err != nil {
 		return err
 	}
@@ -100,6 +103,9 @@ func WriteFileTemp(filename string, data []byte, perm os.FileMode, tempDir strin
 		return err
 	}
 
+	if info, err := os.Stat(filename); err == nil && info.Mode().IsRegular() {
+		perm = info.Mode().Perm()
+	}
 	if err := os.Chmod(tempName, perm); err != nil {
 		return err
 	}
@@ -213,7 +219,11 @@ func (p *PendingFile) CloseAtomically() error {
 		}
 	}
 
-	return os.Rename(p.tempName, p.path)
+	if err := os.Rename(p.tempName, p.path); err != nil {
+		os.Remove(p.tempName)
+		return err
+	}
+	return nil
 }
 
 // Cleanup discards the pending file without writing.